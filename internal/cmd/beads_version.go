@@ -12,6 +12,8 @@ import (
 	"strings"
 	"sync"
 	"time"
+
+	"github.com/cursorworkshop/cursor-gastown/internal/deps"
 )
 
 // MinBeadsVersion is the minimum required beads version for Gas Town.
@@ -22,10 +24,6 @@ const MinBeadsVersion = "0.47.0"
 // If bd hangs, we don't want to block the entire gt command.
 const beadsVersionCheckTimeout = 3 * time.Second
 
-// beadsVersionCacheFile stores the cached version check result.
-// This avoids running bd --version on every gt command.
-const beadsVersionCacheFile = ".gt-beads-version-cache"
-
 // beadsVersionCacheTTL is how long we trust the cached version.
 const beadsVersionCacheTTL = 1 * time.Hour
 
@@ -109,16 +107,7 @@ func (v beadsVersion) compare(other beadsVersion) int {
 
 // getCacheFilePath returns the path to the version cache file.
 func getCacheFilePath() string {
-	// Use XDG cache or fallback to home directory
-	cacheDir := os.Getenv("XDG_CACHE_HOME")
-	if cacheDir == "" {
-		home, err := os.UserHomeDir()
-		if err != nil {
-			return ""
-		}
-		cacheDir = filepath.Join(home, ".cache")
-	}
-	return filepath.Join(cacheDir, "gastown", beadsVersionCacheFile)
+	return deps.BeadsVersionCachePath()
 }
 
 // readCachedVersion reads the cached version if still valid.
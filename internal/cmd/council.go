@@ -2,7 +2,8 @@
 package cmd
 
 import (
-	"encoding/json"
+	"bufio"
+	"errors"
 	"fmt"
 	"os"
 	"sort"
@@ -11,6 +12,7 @@ import (
 
 	"github.com/spf13/cobra"
 	"github.com/cursorworkshop/cursor-gastown/internal/council"
+	"github.com/cursorworkshop/cursor-gastown/internal/cursor"
 	"github.com/cursorworkshop/cursor-gastown/internal/style"
 	"github.com/cursorworkshop/cursor-gastown/internal/templates"
 	"github.com/cursorworkshop/cursor-gastown/internal/workspace"
@@ -67,6 +69,64 @@ Examples:
 	RunE: runCouncilRole,
 }
 
+var councilWhyCmd = &cobra.Command{
+	Use:   "why [role]",
+	Short: "Explain why a role uses its model",
+	Long: `Explain the full rationale behind a role's model selection.
+
+Shows the model and its rationale, where the setting comes from, the
+fallback chain with each fallback's provider and priority, complexity
+routing, force-mode policy, and predefined profiles that make the same
+choice.
+
+Examples:
+  gt council why polecat
+  gt council why --all
+  gt council why refinery --json`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runCouncilWhy,
+}
+
+var councilSetupCmd = &cobra.Command{
+	Use:   "setup",
+	Short: "Interactive council configuration wizard",
+	Long: `Walk through a few questions and write a council config.
+
+Asks whether you favor cost or quality, which providers you have access
+to (pre-filled from ANTHROPIC_API_KEY, OPENAI_API_KEY, GEMINI_API_KEY),
+and your monthly budget. Picks the best-fitting predefined profile,
+removes providers you don't have, and shows the changes before saving.
+
+Requires an interactive terminal. For scripts, use:
+  gt council init --profile <name>
+
+Examples:
+  gt council setup`,
+	RunE: runCouncilSetup,
+}
+
+var councilValidateCmd = &cobra.Command{
+	Use:   "validate [file]",
+	Short: "Validate council configuration",
+	Long: `Validate the council configuration for CI and pre-merge checks.
+
+Checks the town's council config (or the given file) for errors that
+break routing, and lint findings that are likely mistakes.
+
+Exit codes:
+  0  no errors (and no warnings with --strict)
+  1  errors found, or warnings found with --strict
+
+With --format json, prints {"ok": bool, "issues": [...], "warnings": [...]}.
+
+Examples:
+  gt council validate
+  gt council validate --strict
+  gt council validate .beads/council.toml --format json`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runCouncilValidate,
+}
+
 var councilSetCmd = &cobra.Command{
 	Use:   "set <role> <model>",
 	Short: "Set model for a role",
@@ -81,6 +141,8 @@ Available models:
   - gemini-3-pro, gemini-3-flash (Google)
   - auto (use Cursor's default)
 
+Models cursor-agent doesn't support are saved with a warning.
+
 Examples:
   gt council set mayor opus-4.5-thinking
   gt council set polecat sonnet-4.5
@@ -114,10 +176,156 @@ and any rate limiting or availability issues.
 
 Examples:
   gt council providers
-  gt council providers --json`,
+  gt council providers --json
+  gt council providers refresh-models`,
 	RunE: runCouncilProviders,
 }
 
+var councilProvidersRefreshCmd = &cobra.Command{
+	Use:   "refresh-models [provider...]",
+	Short: "Fetch live model lists from providers",
+	Long: `Fetch the current model list from each provider's API and save it
+to the council config.
+
+Only providers with a models-list endpoint (openai, google) are queried.
+The API key is read from the provider's api_key_env (default OPENAI_API_KEY,
+GEMINI_API_KEY); providers without a key are skipped.
+
+Examples:
+  gt council providers refresh-models
+  gt council providers refresh-models openai`,
+	RunE: runCouncilProvidersRefresh,
+}
+
+var councilModelsCmd = &cobra.Command{
+	Use:   "models",
+	Short: "List known models",
+	Long: `List every model Gas Town knows about, with its provider,
+capabilities, observed average cost, and whether the current council
+config references it.
+
+Examples:
+  gt council models
+  gt council models --provider openai
+  gt council models --json`,
+	RunE: runCouncilModels,
+}
+
+var councilTuneCmd = &cobra.Command{
+	Use:   "tune",
+	Short: "Suggest config changes from metrics",
+	Long: `Analyze recorded council metrics and suggest model changes.
+
+A model is flagged when it fails often for a role and another model does
+clearly better, or when it costs far more than an equally successful
+alternative. Only role/model pairs with enough recorded tasks are
+considered. Nothing changes unless --apply is given.
+
+Examples:
+  gt council tune
+  gt council tune --json
+  gt council tune --apply`,
+	RunE: runCouncilTune,
+}
+
+var councilSuggestCmd = &cobra.Command{
+	Use:   "suggest",
+	Short: "Suggest a profile from metrics",
+	Long: `Build a profile from recorded council metrics and offer to apply it.
+
+For each role, the model with the best success rate (among models with
+enough recorded tasks for that role) becomes the primary and the runner-up
+the first fallback. The changes against the current config are shown
+before asking for confirmation (skip with --yes). Only the roles covered
+by the metrics are changed.
+
+Examples:
+  gt council suggest
+  gt council suggest --yes
+  gt council suggest --json`,
+	RunE: runCouncilSuggest,
+}
+
+var councilFailuresCmd = &cobra.Command{
+	Use:   "failures",
+	Short: "Show the most common task failures",
+	Long: `Group recent failed tasks by error message and show the most frequent.
+
+Each group lists how often the error occurred, which models and providers
+hit it, and when it was last seen. Only tasks still in the metrics
+history are considered.
+
+Examples:
+  gt council failures
+  gt council failures --top 5
+  gt council failures --json`,
+	RunE: runCouncilFailures,
+}
+
+var councilSchemaCmd = &cobra.Command{
+	Use:   "schema",
+	Short: "Print a JSON Schema for the council config",
+	Long: `Print a JSON Schema describing the council config file.
+
+Point your editor at the schema to get validation and autocompletion while
+editing council.json (or council.toml, for editors that apply JSON Schema
+to TOML). The schema is generated from the config types, so it always
+matches this version of gt.
+
+Examples:
+  gt council schema
+  gt council schema --out .vscode/council.schema.json`,
+	Args: cobra.NoArgs,
+	RunE: runCouncilSchema,
+}
+
+var councilDoctorCmd = &cobra.Command{
+	Use:   "doctor",
+	Short: "Check for common council misconfigurations",
+	Long: `Check the council setup for common misconfigurations.
+
+Checks:
+  - council-config-exists    Check the council config file exists (fixable)
+  - council-providers        Check referenced models have provider entries (fixable)
+  - beads-version-cache      Check the cached bd version is current (fixable)
+  - workspace-hooks          Check this workspace has hooks and rules (fixable)
+  - provider-auth            Probe provider credentials (with --network)
+
+With --fix, each fixable problem is fixed after confirmation (or without
+asking, with --yes) and every fix is logged. Missing credentials and
+unreachable providers are only reported.
+
+Examples:
+  gt council doctor
+  gt council doctor --fix
+  gt council doctor --fix --yes --network`,
+	Args: cobra.NoArgs,
+	RunE: runCouncilDoctor,
+}
+
+var councilCircuitsCmd = &cobra.Command{
+	Use:   "circuits [provider]",
+	Short: "Show provider circuit breaker state",
+	Long: `Show each provider's circuit breaker state.
+
+An open circuit means the provider failed repeatedly (or returned a fatal
+error) and is skipped by routing until its reset timeout passes. State is
+read from .beads/council-circuits.json, which council processes update
+whenever a circuit opens, closes, or is probed for recovery; providers
+with no saved state show as closed.
+
+Use --reset to close a stuck circuit: for the named provider, or for every
+provider when none is given.
+
+Examples:
+  gt council circuits
+  gt council circuits --json
+  gt council circuits --reset anthropic
+  gt council circuits --reset`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runCouncilCircuits,
+}
+
 var councilRouteCmd = &cobra.Command{
 	Use:   "route <role>",
 	Short: "Test routing decision",
@@ -129,6 +337,7 @@ including any fallback decisions and the rationale.
 Examples:
   gt council route mayor
   gt council route polecat --complexity high
+  gt council route polecat --complexity high --narrate
   gt council route refinery`,
 	Args: cobra.ExactArgs(1),
 	RunE: runCouncilRoute,
@@ -142,8 +351,11 @@ var councilInitCmd = &cobra.Command{
 Creates the council.toml configuration file with the recommended
 role-model matrix for Gas Town multi-model orchestration.
 
+Use --profile to start from a predefined profile instead of the defaults.
+
 Examples:
   gt council init
+  gt council init --profile cost-optimized
   gt council init --force  # Overwrite existing config`,
 	RunE: runCouncilInit,
 }
@@ -170,12 +382,66 @@ var councilStatsCmd = &cobra.Command{
 Displays metrics including task counts, success rates, costs,
 and model comparisons.
 
+Use --since-last to see only the tasks recorded since the previous
+'gt council stats --since-last' run. The first run shows everything.
+
+Use --since to see only the tasks recorded in a recent window, given as a
+duration (7d, 12h) or a date (2006-01-02). Windows are rebuilt from task
+history, which keeps the last 1000 tasks, so older tasks are not counted.
+
+Use --by complexity to compare tasks, success rate, duration, and cost
+across complexity levels.
+
+Use --format csv for a per-model spreadsheet, or --format prometheus for
+the Prometheus text format (e.g., for a node_exporter textfile collector).
+
 Examples:
   gt council stats
-  gt council stats --json`,
+  gt council stats --since-last
+  gt council stats --since 7d
+  gt council stats --by complexity
+  gt council stats --format csv > models.csv
+  gt council stats --format prometheus
+  gt council stats --json
+  gt council stats diff before.json`,
 	RunE: runCouncilStats,
 }
 
+var councilStatsDiffCmd = &cobra.Command{
+	Use:   "diff <snapshot.json>",
+	Short: "Compare current metrics against a snapshot",
+	Long: `Compare current metrics against a previously saved snapshot.
+
+Take a snapshot with 'gt council stats --json > before.json' (or copy
+.beads/council-metrics.json), change the config, run workloads, then
+diff to see how tasks, success rate, and cost moved per role and model.
+
+Examples:
+  gt council stats --json > before.json
+  gt council stats diff before.json
+  gt council stats diff before.json --json`,
+	Args: cobra.ExactArgs(1),
+	RunE: runCouncilStatsDiff,
+}
+
+var councilPruneCmd = &cobra.Command{
+	Use:   "prune",
+	Short: "Clear collected council metrics",
+	Long: `Clear collected council metrics.
+
+By default all metrics are reset. With --history-only, only the per-task
+history is cleared; role, model, and provider aggregates are kept so
+'gt council stats' summaries still work.
+
+The daemon also prunes task history automatically when the metrics file
+grows large.
+
+Examples:
+  gt council prune --history-only
+  gt council prune`,
+	RunE: runCouncilPrune,
+}
+
 var councilCompareCmd = &cobra.Command{
 	Use:   "compare <model1> <model2>",
 	Short: "Compare two models",
@@ -194,12 +460,15 @@ Examples:
 var councilChainsCmd = &cobra.Command{
 	Use:   "chains",
 	Short: "List available chain patterns",
-	Long: `Show predefined chain-of-models patterns.
+	Long: `Show predefined and custom chain-of-models patterns.
 
 Chains pass output through a sequence of models, where each model
 refines or transforms the previous output. This is useful for
 complex tasks that benefit from multiple perspectives.
 
+Custom chains are read from .beads/council-patterns.toml and override
+predefined chains of the same name.
+
 Examples:
   gt council chains
   gt council chains --json`,
@@ -209,11 +478,14 @@ Examples:
 var councilEnsemblesCmd = &cobra.Command{
 	Use:   "ensembles",
 	Short: "List available ensemble patterns",
-	Long: `Show predefined ensemble voting patterns.
+	Long: `Show predefined and custom ensemble voting patterns.
 
 Ensembles run multiple models in parallel and combine their outputs
 through voting. This provides higher confidence for critical decisions.
 
+Custom ensembles are read from .beads/council-patterns.toml and override
+predefined ensembles of the same name.
+
 Examples:
   gt council ensembles
   gt council ensembles --json`,
@@ -223,18 +495,21 @@ Examples:
 var councilPatternCmd = &cobra.Command{
 	Use:   "pattern <name>",
 	Short: "Show details of a specific pattern",
-	Long: `Show detailed configuration for a chain or ensemble pattern.
+	Long: `Show detailed configuration for a chain, ensemble, or debate pattern.
 
 Examples:
   gt council pattern code-review
-  gt council pattern critical-decision`,
+  gt council pattern critical-decision
+  gt council pattern design-tradeoff
+  gt council pattern critical-decision --json`,
 	Args: cobra.ExactArgs(1),
 	RunE: runCouncilPattern,
 }
 
 var councilProfilesCmd = &cobra.Command{
-	Use:   "profiles",
-	Short: "List available configuration profiles",
+	Use:     "profiles",
+	Aliases: []string{"profile"},
+	Short:   "List available configuration profiles",
 	Long: `Show predefined configuration profiles.
 
 Profiles are pre-built configurations optimized for different use cases:
@@ -251,40 +526,93 @@ Examples:
 	RunE: runCouncilProfiles,
 }
 
+var councilProfilesDiffCmd = &cobra.Command{
+	Use:   "diff <file>",
+	Short: "Show what importing a profile would change",
+	Long: `Compare a profile file (or https:// URL) with the current council config.
+
+Lists every role model, fallback chain, and complexity model the profile
+would add (+), remove (-), or change (~), followed by the defaults.
+Nothing is written.
+
+Examples:
+  gt council profile diff shared-config.json
+  gt council profile diff https://example.com/profile.json --json`,
+	Args: cobra.ExactArgs(1),
+	RunE: runCouncilProfilesDiff,
+}
+
 var councilUseCmd = &cobra.Command{
-	Use:   "use <profile>",
-	Short: "Apply a configuration profile",
+	Use:     "use <profile>",
+	Aliases: []string{"apply-profile"},
+	Short:   "Apply a configuration profile",
 	Long: `Apply a predefined profile to your council configuration.
 
 This will overwrite your current council.toml with the profile settings.
+Use --backup to save the current config first; undo with 'gt council restore'.
 
 Examples:
   gt council use balanced
-  gt council use cost-optimized`,
+  gt council use cost-optimized --backup`,
 	Args: cobra.ExactArgs(1),
 	RunE: runCouncilUse,
 }
 
+var councilRestoreCmd = &cobra.Command{
+	Use:   "restore [backup]",
+	Short: "Restore council config from a backup",
+	Long: `Restore council.toml from a backup made by 'gt council use --backup'.
+
+Without an argument, the most recent backup is restored. A backup may be
+given as a path or as a filename in .beads/.
+
+Examples:
+  gt council restore
+  gt council restore council.toml.bak-20260101-120000.000`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runCouncilRestore,
+}
+
 var councilExportCmd = &cobra.Command{
 	Use:   "export <file>",
 	Short: "Export current configuration as a profile",
 	Long: `Export the current council configuration as a shareable profile.
 
+The profile is written as TOML when the file ends in .toml, JSON otherwise.
+
 Examples:
   gt council export my-team-config.json
+  gt council export my-team-config.toml
   gt council export my-team-config.json --name "My Team" --author "Jane Doe"`,
 	Args: cobra.ExactArgs(1),
 	RunE: runCouncilExport,
 }
 
 var councilImportCmd = &cobra.Command{
-	Use:   "import <file>",
-	Short: "Import a profile from a file",
-	Long: `Import a council configuration profile from a JSON file.
+	Use:     "import <file>",
+	Aliases: []string{"import-profile"},
+	Short:   "Import a profile from a file",
+	Long: `Import a council configuration profile from a JSON or TOML file.
+
+The profile is validated and the changes it would make to the current
+config are shown before asking for confirmation (skip with --yes). A
+profile with validation issues is never applied.
+
+With --roles, only the named roles are taken from the profile; the other
+roles, defaults, and providers stay as they are unless --include-defaults
+is given.
+
+Use --validate-only to inspect an untrusted profile first: it is fetched
+and validated, every model is checked against the supported models, and
+the role-model matrix it would set is shown. Nothing is written.
 
 Examples:
   gt council import shared-config.json
-  gt council import https://example.com/profile.json`,
+  gt council import shared-config.json --yes
+  gt council import shared-config.json --roles refinery
+  gt council import shared-config.json --roles mayor,refinery --include-defaults
+  gt council import https://example.com/profile.json
+  gt council import https://example.com/profile.json --validate-only`,
 	Args: cobra.ExactArgs(1),
 	RunE: runCouncilImport,
 }
@@ -292,12 +620,38 @@ Examples:
 // Flags
 var (
 	councilShowJSON     bool
+	councilWhyAll       bool
+	councilValidStrict  bool
+	councilValidFormat  string
 	councilRouteComplex string
+	councilModelsFilter string
 	councilInitForce    bool
+	councilInitProfile  string
 	councilStatsJSON    bool
+	councilStatsSince   bool
+	councilPruneHistory bool
 	councilExportName   string
 	councilExportAuthor string
 	councilExportDesc   string
+	councilUseBackup    bool
+	councilTuneApply    bool
+	councilSuggestYes   bool
+	councilValidateOnly bool
+	councilImportYes    bool
+	councilImportRoles  []string
+	councilImportDefs   bool
+	councilFailuresTop  int
+	councilCircuitReset bool
+	councilRouteMaxCost float64
+	councilStatsBy      string
+	councilCompact      bool
+	councilSchemaOut    string
+	councilRouteNarrate bool
+	councilDoctorFix    bool
+	councilDoctorYes    bool
+	councilDoctorNet    bool
+	councilStatsFormat  string
+	councilStatsWindow  string
 )
 
 func runCouncilShow(cmd *cobra.Command, args []string) error {
@@ -312,9 +666,7 @@ func runCouncilShow(cmd *cobra.Command, args []string) error {
 	}
 
 	if councilShowJSON {
-		enc := json.NewEncoder(os.Stdout)
-		enc.SetIndent("", "  ")
-		return enc.Encode(config)
+		return renderOutput(config)
 	}
 
 	// Text output
@@ -385,6 +737,9 @@ func runCouncilRole(cmd *cobra.Command, args []string) error {
 
 	fmt.Printf("%s\n\n", style.Bold.Render("Role: "+role))
 	fmt.Printf("Model:     %s\n", rc.Model)
+	if rc.Provider != "" {
+		fmt.Printf("Provider:  %s %s\n", rc.Provider, style.Dim.Render("(pinned)"))
+	}
 
 	if len(rc.Fallback) > 0 {
 		fmt.Printf("Fallback:  %s\n", strings.Join(rc.Fallback, " -> "))
@@ -394,6 +749,23 @@ func runCouncilRole(cmd *cobra.Command, args []string) error {
 		fmt.Printf("Rationale: %s\n", rc.Rationale)
 	}
 
+	safeMode := cursor.SafeModeEnabled(townRoot)
+	forceMode := "enabled"
+	if safeMode || !config.ForceModeFor(role) {
+		forceMode = "disabled"
+	}
+	switch {
+	case safeMode:
+		forceMode += style.Dim.Render(" (safe mode)")
+	case cursor.ForceModeDisabled():
+		forceMode += style.Dim.Render(" (" + cursor.DisableForceModeEnv + ")")
+	case rc.ForceMode != nil:
+		forceMode += style.Dim.Render(" (role config)")
+	default:
+		forceMode += style.Dim.Render(" (default)")
+	}
+	fmt.Printf("Force:     %s\n", forceMode)
+
 	if rc.ComplexityRouting && rc.Complexity != nil {
 		fmt.Printf("\n%s\n", style.Bold.Render("Complexity Routing:"))
 		fmt.Printf("  High:   %s\n", rc.Complexity.High)
@@ -428,6 +800,7 @@ func runCouncilSet(cmd *cobra.Command, args []string) error {
 
 	// Set model
 	config.Roles[role].Model = model
+	added := config.EnsureProvidersForModels()
 
 	// Save config
 	configPath := council.ConfigPath(townRoot)
@@ -436,6 +809,11 @@ func runCouncilSet(cmd *cobra.Command, args []string) error {
 	}
 
 	fmt.Printf("Set %s model to %s\n", style.Bold.Render(role), style.Bold.Render(model))
+	printAddedProviders(added)
+	if !cursor.IsValidModel(model) {
+		fmt.Printf("%s %q is not a supported cursor-agent model; run 'gt council validate' to check the config\n",
+			style.Warning.Render("⚠"), model)
+	}
 	return nil
 }
 
@@ -463,6 +841,7 @@ func runCouncilFallback(cmd *cobra.Command, args []string) error {
 
 	// Set fallbacks
 	config.Roles[role].Fallback = fallbacks
+	added := config.EnsureProvidersForModels()
 
 	// Save config
 	configPath := council.ConfigPath(townRoot)
@@ -471,9 +850,17 @@ func runCouncilFallback(cmd *cobra.Command, args []string) error {
 	}
 
 	fmt.Printf("Set %s fallback chain: %s\n", style.Bold.Render(role), strings.Join(fallbacks, " -> "))
+	printAddedProviders(added)
 	return nil
 }
 
+// printAddedProviders reports providers added by EnsureProvidersForModels.
+func printAddedProviders(added []string) {
+	if len(added) > 0 {
+		fmt.Printf("%s\n", style.Dim.Render("Added provider(s): "+strings.Join(added, ", ")))
+	}
+}
+
 func runCouncilProviders(cmd *cobra.Command, args []string) error {
 	townRoot, err := workspace.FindFromCwd()
 	if err != nil {
@@ -486,9 +873,7 @@ func runCouncilProviders(cmd *cobra.Command, args []string) error {
 	}
 
 	if councilShowJSON {
-		enc := json.NewEncoder(os.Stdout)
-		enc.SetIndent("", "  ")
-		return enc.Encode(config.Providers)
+		return renderOutput(config.Providers)
 	}
 
 	fmt.Printf("%s\n\n", style.Bold.Render("Model Providers"))
@@ -523,6 +908,57 @@ func runCouncilProviders(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+func runCouncilProvidersRefresh(cmd *cobra.Command, args []string) error {
+	townRoot, err := workspace.FindFromCwd()
+	if err != nil {
+		return fmt.Errorf("finding town root: %w", err)
+	}
+
+	config, err := council.LoadOrCreate(townRoot)
+	if err != nil {
+		return fmt.Errorf("loading council config: %w", err)
+	}
+
+	providers := args
+	if len(providers) == 0 {
+		for name := range config.Providers {
+			providers = append(providers, name)
+		}
+		sort.Strings(providers)
+	}
+
+	if council.NetworkDisabled() {
+		return council.ErrNetworkDisabled
+	}
+
+	updated := 0
+	for _, provider := range providers {
+		models, err := config.RefreshModels(cmd.Context(), provider)
+		switch {
+		case errors.Is(err, council.ErrModelsListUnsupported):
+			fmt.Printf("  %s %s\n", style.Dim.Render("-"), style.Dim.Render(provider+": no models-list endpoint, skipped"))
+		case errors.Is(err, council.ErrNoCredentials):
+			fmt.Printf("  %s %s\n", style.Dim.Render("-"), style.Dim.Render(err.Error()+", skipped"))
+		case err != nil:
+			fmt.Printf("  %s %s: %v\n", style.Error.Render("✗"), provider, err)
+		default:
+			updated++
+			fmt.Printf("  %s %s: %d models\n", style.Success.Render("✓"), provider, len(models))
+		}
+	}
+
+	if updated == 0 {
+		return nil
+	}
+
+	configPath := council.ConfigPath(townRoot)
+	if err := council.SaveConfig(configPath, config); err != nil {
+		return fmt.Errorf("saving council config: %w", err)
+	}
+	fmt.Printf("\n%s Updated %d provider(s)\n", style.Success.Render("✓"), updated)
+	return nil
+}
+
 func runCouncilRoute(cmd *cobra.Command, args []string) error {
 	role := args[0]
 
@@ -539,7 +975,7 @@ func runCouncilRoute(cmd *cobra.Command, args []string) error {
 	router := council.NewRouter(config)
 
 	// Build request
-	req := &council.RouteRequest{Role: role}
+	req := &council.RouteRequest{Role: role, MaxCostPerTask: councilRouteMaxCost}
 
 	// Add complexity if specified
 	if councilRouteComplex != "" {
@@ -560,6 +996,9 @@ func runCouncilRoute(cmd *cobra.Command, args []string) error {
 	fmt.Printf("Model:      %s\n", style.Bold.Render(result.Model))
 	fmt.Printf("Provider:   %s\n", result.Provider)
 	fmt.Printf("Complexity: %s\n", result.Complexity)
+	if result.EstimatedCost > 0 {
+		fmt.Printf("Est. cost:  $%.4f\n", result.EstimatedCost)
+	}
 
 	if result.Rationale != "" {
 		fmt.Printf("Rationale:  %s\n", result.Rationale)
@@ -569,9 +1008,35 @@ func runCouncilRoute(cmd *cobra.Command, args []string) error {
 		fmt.Printf("\n%s %s\n", style.Warning.Render("Fallback:"), result.FallbackReason)
 	}
 
+	if councilRouteNarrate {
+		narrative, err := router.Explain(req)
+		if err != nil {
+			return fmt.Errorf("explaining route: %w", err)
+		}
+		fmt.Printf("\n%s\n", narrative)
+	}
+
 	return nil
 }
 
+// parseStatsSince parses --since as a duration back from now ("7d", "12h")
+// or as a date ("2006-01-02") or RFC 3339 time.
+func parseStatsSince(value string, now time.Time) (time.Time, error) {
+	if d, err := parseDuration(value); err == nil {
+		if d <= 0 {
+			return time.Time{}, fmt.Errorf("--since %q must be a positive duration", value)
+		}
+		return now.Add(-d), nil
+	}
+	if t, err := time.ParseInLocation("2006-01-02", value, time.Local); err == nil {
+		return t, nil
+	}
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	return time.Time{}, fmt.Errorf("invalid --since %q (want a duration like 7d or 12h, or a date like 2006-01-02)", value)
+}
+
 func runCouncilInit(cmd *cobra.Command, args []string) error {
 	townRoot, err := workspace.FindFromCwd()
 	if err != nil {
@@ -585,8 +1050,19 @@ func runCouncilInit(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("council config already exists at %s (use --force to overwrite)", configPath)
 	}
 
-	// Create default config
+	// Create default config, or start from a profile
 	config := council.DefaultCouncilConfig()
+	if councilInitProfile != "" {
+		profile, ok := council.GetProfile(councilInitProfile)
+		if !ok {
+			return fmt.Errorf("profile %q not found (run 'gt council profiles' to see available)", councilInitProfile)
+		}
+		cloned, err := profile.Config.Clone()
+		if err != nil {
+			return err
+		}
+		config = cloned
+	}
 
 	if err := council.SaveConfig(configPath, config); err != nil {
 		return fmt.Errorf("saving council config: %w", err)
@@ -649,17 +1125,66 @@ func runCouncilStats(cmd *cobra.Command, args []string) error {
 	metrics := store.GetMetrics()
 	summary := store.GetSummary()
 
+	var since time.Time
+	if councilStatsSince && councilStatsWindow != "" {
+		return fmt.Errorf("--since and --since-last cannot be combined")
+	}
+	if councilStatsWindow != "" {
+		since, err = parseStatsSince(councilStatsWindow, time.Now())
+		if err != nil {
+			return err
+		}
+		metrics = store.MetricsBetween(since, time.Time{})
+		summary = metrics.Summary()
+	}
+	if councilStatsSince {
+		metrics, since, err = store.SinceLast(time.Now())
+		if err != nil {
+			return fmt.Errorf("loading incremental metrics: %w", err)
+		}
+		summary = metrics.Summary()
+	}
+
+	switch councilStatsFormat {
+	case "":
+	case council.ExportCSV:
+		return metrics.WriteCSV(os.Stdout)
+	case council.ExportPrometheus:
+		return metrics.WritePrometheus(os.Stdout)
+	default:
+		return fmt.Errorf("unknown --format %q (want %s or %s)", councilStatsFormat, council.ExportCSV, council.ExportPrometheus)
+	}
+
+	switch councilStatsBy {
+	case "":
+	case "complexity":
+		return printCouncilStatsByComplexity(metrics, councilStatsJSON)
+	default:
+		return fmt.Errorf("unknown --by dimension %q (want complexity)", councilStatsBy)
+	}
+
+	forecast := store.ForecastMonthlyCost()
+
 	if councilStatsJSON {
-		enc := json.NewEncoder(os.Stdout)
-		enc.SetIndent("", "  ")
-		return enc.Encode(map[string]interface{}{
-			"summary": summary,
-			"metrics": metrics,
+		return renderOutput(map[string]interface{}{
+			"summary":  summary,
+			"metrics":  metrics,
+			"forecast": forecast,
 		})
 	}
 
 	// Summary
 	fmt.Printf("%s\n\n", style.Bold.Render("Gas Town Council Statistics"))
+	if councilStatsSince {
+		if since.IsZero() {
+			fmt.Printf("%s\n\n", style.Dim.Render("First --since-last run: showing all recorded tasks"))
+		} else {
+			fmt.Printf("%s\n\n", style.Dim.Render("Since last run: "+since.Local().Format("2006-01-02 15:04:05")))
+		}
+	}
+	if councilStatsWindow != "" {
+		fmt.Printf("%s\n\n", style.Dim.Render("Tasks started since "+since.Local().Format("2006-01-02 15:04:05")))
+	}
 
 	fmt.Printf("%s\n", style.Bold.Render("Summary:"))
 	fmt.Printf("  Total Tasks:     %d\n", summary.TotalTasks)
@@ -673,6 +1198,16 @@ func runCouncilStats(cmd *cobra.Command, args []string) error {
 		fmt.Printf("  Top Model:       %s\n", summary.TopModel)
 	}
 
+	// Forecast
+	if forecast.BasedOnDays > 0 {
+		fmt.Printf("\n%s\n", style.Bold.Render("Forecast:"))
+		fmt.Printf("  Daily Average:   $%.2f %s\n", forecast.DailyAvg, style.Dim.Render(fmt.Sprintf("(last %d day(s))", forecast.BasedOnDays)))
+		fmt.Printf("  Projected (%dd): $%.2f\n", council.ForecastMonthDays, forecast.ProjectedMonthly)
+		if forecast.LowConfidence {
+			fmt.Printf("  %s\n", style.Dim.Render("Low confidence: less than 2 days of history"))
+		}
+	}
+
 	// By Role
 	if len(metrics.ByRole) > 0 {
 		fmt.Printf("\n%s\n", style.Bold.Render("By Role:"))
@@ -689,11 +1224,18 @@ func runCouncilStats(cmd *cobra.Command, args []string) error {
 	if len(metrics.ByModel) > 0 {
 		fmt.Printf("\n%s\n", style.Bold.Render("By Model:"))
 		for model, mm := range metrics.ByModel {
-			fmt.Printf("  %s: %d tasks, %.1f%% success, avg %v\n",
+			fmt.Printf("  %s: %d tasks, %.1f%% success, avg %v",
 				style.Bold.Render(model),
 				mm.TotalTasks,
 				mm.SuccessRate*100,
 				mm.AvgDuration.Round(time.Second))
+			if mm.P50Duration > 0 {
+				fmt.Printf(", p50 %v, p95 %v, p99 %v",
+					mm.P50Duration.Round(time.Second),
+					mm.P95Duration.Round(time.Second),
+					mm.P99Duration.Round(time.Second))
+			}
+			fmt.Println()
 		}
 	}
 
@@ -720,6 +1262,97 @@ func runCouncilStats(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+func runCouncilStatsDiff(cmd *cobra.Command, args []string) error {
+	townRoot, err := workspace.FindFromCwd()
+	if err != nil {
+		return fmt.Errorf("finding town root: %w", err)
+	}
+
+	store, err := council.NewMetricsStore(townRoot)
+	if err != nil {
+		return fmt.Errorf("loading metrics: %w", err)
+	}
+
+	snapshot, err := council.LoadMetricsSnapshot(args[0])
+	if err != nil {
+		return err
+	}
+
+	delta := store.GetMetrics().Diff(snapshot)
+
+	if councilStatsJSON {
+		return renderOutput(delta)
+	}
+
+	fmt.Printf("%s\n\n", style.Bold.Render("Council Metrics Diff"))
+	fmt.Printf("%s\n", style.Dim.Render("Baseline: "+args[0]))
+	fmt.Printf("  Tasks:         %+d\n", delta.Tasks)
+	fmt.Printf("  Success Rate:  %+.1f%%\n", delta.SuccessRate*100)
+	fmt.Printf("  Cost:          %+.2f\n", delta.Cost)
+
+	printDeltaEntries("By Role:", delta.ByRole)
+	printDeltaEntries("By Model:", delta.ByModel)
+
+	return nil
+}
+
+// printDeltaEntries prints a sorted section of metrics delta entries.
+func printDeltaEntries(title string, entries map[string]*council.DeltaEntry) {
+	if len(entries) == 0 {
+		return
+	}
+
+	names := make([]string, 0, len(entries))
+	for name := range entries {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	fmt.Printf("\n%s\n", style.Bold.Render(title))
+	for _, name := range names {
+		entry := entries[name]
+		note := ""
+		if entry.Added {
+			note = " " + style.Success.Render("(new)")
+		} else if entry.Removed {
+			note = " " + style.Warning.Render("(gone)")
+		}
+		fmt.Printf("  %s: %+d tasks, %+.1f%% success, %+.2f cost%s\n",
+			style.Bold.Render(name),
+			entry.Tasks,
+			entry.SuccessRate*100,
+			entry.Cost,
+			note)
+	}
+}
+
+func runCouncilPrune(cmd *cobra.Command, args []string) error {
+	townRoot, err := workspace.FindFromCwd()
+	if err != nil {
+		return fmt.Errorf("finding town root: %w", err)
+	}
+
+	store, err := council.NewMetricsStore(townRoot)
+	if err != nil {
+		return fmt.Errorf("loading metrics: %w", err)
+	}
+
+	if councilPruneHistory {
+		n := len(store.GetRecentTasks(council.MaxTaskHistory))
+		if err := store.PruneHistory(); err != nil {
+			return fmt.Errorf("pruning history: %w", err)
+		}
+		fmt.Printf("%s Cleared %d task history entries (aggregates kept)\n", style.Success.Render("✓"), n)
+		return nil
+	}
+
+	if err := store.Reset(); err != nil {
+		return fmt.Errorf("resetting metrics: %w", err)
+	}
+	fmt.Printf("%s Reset all council metrics\n", style.Success.Render("✓"))
+	return nil
+}
+
 func runCouncilCompare(cmd *cobra.Command, args []string) error {
 	model1, model2 := args[0], args[1]
 
@@ -770,18 +1403,40 @@ func runCouncilCompare(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+// loadCouncilPatterns returns predefined patterns merged with the town's
+// .beads/council-patterns.toml. Outside a town only predefined patterns
+// are available.
+func loadCouncilPatterns() (map[string]*council.ChainConfig, map[string]*council.EnsembleConfig, error) {
+	townRoot, err := workspace.FindFromCwd()
+	if err != nil || townRoot == "" {
+		return council.PredefinedChains, council.PredefinedEnsembles, nil
+	}
+	return council.LoadPatterns(townRoot)
+}
+
+// patternLabel marks user-defined patterns in listings.
+func patternLabel(name string, predefined bool) string {
+	if predefined {
+		return style.Bold.Render(name)
+	}
+	return style.Bold.Render(name) + " " + style.Dim.Render("(custom)")
+}
+
 func runCouncilChains(cmd *cobra.Command, args []string) error {
+	chains, _, err := loadCouncilPatterns()
+	if err != nil {
+		return err
+	}
+
 	if councilShowJSON {
-		enc := json.NewEncoder(os.Stdout)
-		enc.SetIndent("", "  ")
-		return enc.Encode(council.PredefinedChains)
+		return renderOutput(chains)
 	}
 
-	fmt.Printf("%s\n\n", style.Bold.Render("Predefined Chain Patterns"))
+	fmt.Printf("%s\n\n", style.Bold.Render("Chain Patterns"))
 	fmt.Printf("%s\n\n", style.Dim.Render("Chains pass output through a sequence of models"))
 
-	for name, chain := range council.PredefinedChains {
-		fmt.Printf("  %s\n", style.Bold.Render(name))
+	for name, chain := range chains {
+		fmt.Printf("  %s\n", patternLabel(name, council.PredefinedChains[name] == chain))
 		fmt.Printf("    Steps: %d\n", len(chain.Steps))
 
 		// Show step models
@@ -811,17 +1466,20 @@ func runCouncilChains(cmd *cobra.Command, args []string) error {
 }
 
 func runCouncilEnsembles(cmd *cobra.Command, args []string) error {
+	_, ensembles, err := loadCouncilPatterns()
+	if err != nil {
+		return err
+	}
+
 	if councilShowJSON {
-		enc := json.NewEncoder(os.Stdout)
-		enc.SetIndent("", "  ")
-		return enc.Encode(council.PredefinedEnsembles)
+		return renderOutput(ensembles)
 	}
 
-	fmt.Printf("%s\n\n", style.Bold.Render("Predefined Ensemble Patterns"))
+	fmt.Printf("%s\n\n", style.Bold.Render("Ensemble Patterns"))
 	fmt.Printf("%s\n\n", style.Dim.Render("Ensembles run models in parallel and vote on output"))
 
-	for name, ensemble := range council.PredefinedEnsembles {
-		fmt.Printf("  %s\n", style.Bold.Render(name))
+	for name, ensemble := range ensembles {
+		fmt.Printf("  %s\n", patternLabel(name, council.PredefinedEnsembles[name] == ensemble))
 		fmt.Printf("    Models:   %s\n", strings.Join(ensemble.Models, ", "))
 		fmt.Printf("    Strategy: %s\n", ensemble.VotingStrategy)
 		if ensemble.Threshold > 0 {
@@ -839,8 +1497,24 @@ func runCouncilEnsembles(cmd *cobra.Command, args []string) error {
 func runCouncilPattern(cmd *cobra.Command, args []string) error {
 	name := args[0]
 
+	chains, ensembles, err := loadCouncilPatterns()
+	if err != nil {
+		return err
+	}
+
+	return printCouncilPattern(name, chains, ensembles, councilShowJSON)
+}
+
+// printCouncilPattern renders a single chain, ensemble, or predefined
+// debate as text or JSON. Chains take precedence over ensembles, and
+// ensembles over debates, with the same name.
+func printCouncilPattern(name string, chains map[string]*council.ChainConfig, ensembles map[string]*council.EnsembleConfig, asJSON bool) error {
 	// Check chains first
-	if chain, ok := council.PredefinedChains[name]; ok {
+	if chain, ok := chains[name]; ok {
+		if asJSON {
+			return renderOutput(chain)
+		}
+
 		fmt.Printf("%s %s\n\n", style.Bold.Render("Chain:"), name)
 		fmt.Printf("Type: Chain-of-Models\n")
 		fmt.Printf("Pass Context: %v\n", chain.PassContext)
@@ -869,7 +1543,11 @@ func runCouncilPattern(cmd *cobra.Command, args []string) error {
 	}
 
 	// Check ensembles
-	if ensemble, ok := council.PredefinedEnsembles[name]; ok {
+	if ensemble, ok := ensembles[name]; ok {
+		if asJSON {
+			return renderOutput(ensemble)
+		}
+
 		fmt.Printf("%s %s\n\n", style.Bold.Render("Ensemble:"), name)
 		fmt.Printf("Type: Ensemble Voting\n")
 		fmt.Printf("Strategy: %s\n", ensemble.VotingStrategy)
@@ -886,28 +1564,46 @@ func runCouncilPattern(cmd *cobra.Command, args []string) error {
 
 		// Explain voting strategy
 		fmt.Printf("\n%s\n", style.Bold.Render("Voting Strategy:"))
-		switch ensemble.VotingStrategy {
-		case council.VoteMajority:
-			fmt.Printf("  Selects the most common response among models.\n")
-		case council.VoteConsensus:
-			fmt.Printf("  Requires all models to agree. Falls back to majority if not.\n")
-		case council.VoteWeighted:
-			fmt.Printf("  Weights votes by model confidence scores.\n")
-		case council.VoteBest:
-			fmt.Printf("  Selects the highest quality response based on metrics.\n")
+		if desc := council.VotingStrategyDescription(ensemble.VotingStrategy); desc != "" {
+			fmt.Printf("  %s\n", desc)
 		}
 
 		return nil
 	}
 
+	// Check debates
+	if debate, ok := council.PredefinedDebates[name]; ok {
+		if asJSON {
+			return renderOutput(debate)
+		}
+
+		rounds := debate.Rounds
+		if rounds <= 0 {
+			rounds = council.DefaultDebateRounds
+		}
+		fmt.Printf("%s %s\n\n", style.Bold.Render("Debate:"), name)
+		fmt.Printf("Type: Debate\n")
+		fmt.Printf("Rounds: %d\n", rounds)
+		fmt.Printf("Timeout: %s\n\n", debate.Timeout)
+
+		fmt.Printf("%s\n", style.Bold.Render("Models:"))
+		fmt.Printf("  For:     %s\n", debate.ProModel)
+		fmt.Printf("  Against: %s\n", debate.ConModel)
+		fmt.Printf("  Judge:   %s\n", debate.JudgeModel)
+
+		fmt.Printf("\n%s\n", style.Bold.Render("How it works:"))
+		fmt.Printf("  Each round the first model argues for the proposition and the second\n")
+		fmt.Printf("  rebuts it. The judge reads the transcript and gives a verdict with a\n")
+		fmt.Printf("  confidence score.\n")
+		return nil
+	}
+
 	return fmt.Errorf("pattern %q not found (try 'gt council chains' or 'gt council ensembles')", name)
 }
 
 func runCouncilProfiles(cmd *cobra.Command, args []string) error {
 	if councilShowJSON {
-		enc := json.NewEncoder(os.Stdout)
-		enc.SetIndent("", "  ")
-		return enc.Encode(council.PredefinedProfiles)
+		return renderOutput(council.PredefinedProfiles)
 	}
 
 	fmt.Printf("%s\n\n", style.Bold.Render("Available Configuration Profiles"))
@@ -925,6 +1621,34 @@ func runCouncilProfiles(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+func runCouncilProfilesDiff(cmd *cobra.Command, args []string) error {
+	townRoot, err := workspace.FindFromCwd()
+	if err != nil {
+		return fmt.Errorf("finding town root: %w", err)
+	}
+
+	profile, err := council.ImportProfileFromFile(args[0])
+	if err != nil {
+		return fmt.Errorf("importing profile: %w", err)
+	}
+	current, err := council.LoadConfig(council.ResolveConfigPath(townRoot))
+	if err != nil {
+		return fmt.Errorf("loading current config: %w", err)
+	}
+
+	changes := council.DiffProfiles(current, profile.Config)
+	if councilShowJSON {
+		if changes == nil {
+			changes = []council.ConfigChange{}
+		}
+		return renderOutput(changes)
+	}
+
+	fmt.Printf("%s %s\n\n", style.Bold.Render("Changes from profile"), profile.Name)
+	printConfigChanges(changes)
+	return nil
+}
+
 func runCouncilUse(cmd *cobra.Command, args []string) error {
 	profileName := args[0]
 
@@ -943,6 +1667,18 @@ func runCouncilUse(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("invalid profile: %s", strings.Join(issues, "; "))
 	}
 
+	if councilUseBackup {
+		backupPath, err := council.BackupConfig(townRoot)
+		switch {
+		case errors.Is(err, os.ErrNotExist):
+			fmt.Printf("%s\n", style.Dim.Render("No existing config to back up"))
+		case err != nil:
+			return fmt.Errorf("backing up config: %w", err)
+		default:
+			fmt.Printf("Backed up config to %s\n", backupPath)
+		}
+	}
+
 	// Apply the profile
 	if err := council.ApplyProfile(profile, townRoot); err != nil {
 		return fmt.Errorf("applying profile: %w", err)
@@ -960,6 +1696,30 @@ func runCouncilUse(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+func runCouncilRestore(cmd *cobra.Command, args []string) error {
+	townRoot, err := workspace.FindFromCwd()
+	if err != nil {
+		return fmt.Errorf("finding town root: %w", err)
+	}
+
+	backupPath := ""
+	if len(args) > 0 {
+		backupPath = args[0]
+	} else {
+		backupPath, err = council.LatestBackup(townRoot)
+		if err != nil {
+			return err
+		}
+	}
+
+	if err := council.RestoreConfig(townRoot, backupPath); err != nil {
+		return err
+	}
+
+	fmt.Printf("%s Restored council config from %s\n", style.Success.Render("✓"), backupPath)
+	return nil
+}
+
 func runCouncilExport(cmd *cobra.Command, args []string) error {
 	outputPath := args[0]
 
@@ -1007,22 +1767,86 @@ func runCouncilImport(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("finding town root: %w", err)
 	}
 
+	opts := profileImportOptions{
+		validateOnly:    councilValidateOnly,
+		roles:           councilImportRoles,
+		includeDefaults: councilImportDefs,
+	}
+	if !councilValidateOnly && !councilImportYes {
+		reader := bufio.NewReader(os.Stdin)
+		opts.confirm = func(question string) bool { return promptConfirm(reader, question) }
+	}
+	return importCouncilProfile(inputPath, townRoot, opts)
+}
+
+// profileImportOptions controls importCouncilProfile.
+type profileImportOptions struct {
+	// validateOnly reports what the profile would set without writing.
+	validateOnly bool
+
+	// roles, when set, limits the import to these roles (see
+	// council.Config.MergeProfileRoles); includeDefaults then also takes
+	// the profile's defaults and providers.
+	roles           []string
+	includeDefaults bool
+
+	// confirm, when non-nil, must agree before anything is written.
+	confirm func(string) bool
+}
+
+// importCouncilProfile loads a profile and applies it to the town. With
+// validateOnly it reports what the profile would set and any issues, and
+// writes nothing; issues then make it return an error. Otherwise a profile
+// with validation issues is refused, and the changes against the current
+// config are shown and applied only if confirm agrees.
+func importCouncilProfile(inputPath, townRoot string, opts profileImportOptions) error {
 	profile, err := council.ImportProfileFromFile(inputPath)
 	if err != nil {
 		return fmt.Errorf("importing profile: %w", err)
 	}
 
-	// Validate
+	if opts.validateOnly {
+		inspection := council.InspectProfile(profile)
+		printProfileInspection(profile, inspection)
+		if len(inspection.Issues) > 0 {
+			return fmt.Errorf("profile %q has %d validation issue(s); nothing was applied", profile.Name, len(inspection.Issues))
+		}
+		return nil
+	}
+
 	if issues := council.ValidateProfile(profile); len(issues) > 0 {
-		fmt.Printf("%s Profile has issues:\n", style.Warning.Render("Warning:"))
+		fmt.Printf("%s Profile has issues:\n", style.Error.Render("Error:"))
 		for _, issue := range issues {
 			fmt.Printf("  - %s\n", issue)
 		}
-		fmt.Println()
+		return fmt.Errorf("profile %q has %d validation issue(s); refusing to apply", profile.Name, len(issues))
 	}
 
-	// Apply
-	if err := council.ApplyProfile(profile, townRoot); err != nil {
+	current, err := council.LoadConfig(council.ResolveConfigPath(townRoot))
+	if err != nil {
+		return fmt.Errorf("loading current config: %w", err)
+	}
+	target := profile.Config
+	if len(opts.roles) > 0 {
+		if target, err = current.Clone(); err != nil {
+			return err
+		}
+		if err := target.MergeProfileRoles(profile, opts.roles, opts.includeDefaults); err != nil {
+			return err
+		}
+	}
+
+	// The profile is saved even without changes, so the file on disk is
+	// exactly what was imported.
+	fmt.Printf("%s\n", style.Bold.Render("Changes:"))
+	printConfigChanges(council.DiffProfiles(current, target))
+	fmt.Println()
+	if opts.confirm != nil && !opts.confirm(fmt.Sprintf("Apply profile %s?", profile.Name)) {
+		fmt.Println("Import cancelled")
+		return nil
+	}
+
+	if err := council.SaveConfig(council.ConfigPath(townRoot), target); err != nil {
 		return fmt.Errorf("applying profile: %w", err)
 	}
 
@@ -1037,36 +1861,145 @@ func runCouncilImport(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+// printConfigChanges prints a DiffProfiles result as a table, or a note
+// when there are no changes.
+func printConfigChanges(changes []council.ConfigChange) {
+	if len(changes) == 0 {
+		fmt.Printf("  %s\n", style.Dim.Render("(no changes)"))
+		return
+	}
+	for _, c := range changes {
+		var mark, value string
+		switch c.Kind {
+		case council.ChangeAdded:
+			mark, value = style.Success.Render("+"), c.New
+		case council.ChangeRemoved:
+			mark, value = style.Error.Render("-"), style.Dim.Render(c.Old)
+		default:
+			mark, value = style.Warning.Render("~"), style.Dim.Render(c.Old)+" → "+c.New
+		}
+		fmt.Printf("  %s %-20s %-18s %s\n", mark, c.Scope(), c.Field, value)
+	}
+}
+
+// printProfileInspection shows the role-model matrix a profile would set,
+// followed by its issues and warnings.
+func printProfileInspection(profile *council.Profile, inspection *council.ProfileInspection) {
+	fmt.Printf("%s %s\n", style.Bold.Render("Profile:"), profile.Name)
+	if profile.Author != "" {
+		fmt.Printf("  Author: %s\n", profile.Author)
+	}
+
+	fmt.Printf("\n%s\n", style.Bold.Render("Would set:"))
+	if len(inspection.Roles) == 0 {
+		fmt.Printf("  %s\n", style.Dim.Render("(no roles)"))
+	}
+	for _, r := range inspection.Roles {
+		fmt.Printf("  %-12s %s", r.Role, r.Model)
+		if len(r.Fallback) > 0 {
+			fmt.Printf(" %s", style.Dim.Render("→ "+strings.Join(r.Fallback, " → ")))
+		}
+		fmt.Println()
+	}
+
+	if len(inspection.Issues) > 0 {
+		fmt.Printf("\n%s\n", style.Bold.Render("Issues:"))
+		for _, issue := range inspection.Issues {
+			fmt.Printf("  %s %s\n", style.Error.Render("✗"), issue)
+		}
+	}
+	if len(inspection.Warnings) > 0 {
+		fmt.Printf("\n%s\n", style.Bold.Render("Warnings:"))
+		for _, w := range inspection.Warnings {
+			fmt.Printf("  %s %s\n", style.Warning.Render("⚠"), w)
+		}
+	}
+
+	fmt.Println()
+	if len(inspection.Issues) == 0 {
+		fmt.Printf("%s Profile is valid (not applied)\n", style.Success.Render("✓"))
+	}
+}
+
 func init() {
 	// Add flags
 	councilShowCmd.Flags().BoolVar(&councilShowJSON, "json", false, "Output as JSON")
 	councilProvidersCmd.Flags().BoolVar(&councilShowJSON, "json", false, "Output as JSON")
+	councilWhyCmd.Flags().BoolVar(&councilWhyAll, "all", false, "Explain every configured role")
+	councilWhyCmd.Flags().BoolVar(&councilShowJSON, "json", false, "Output as JSON")
+	councilValidateCmd.Flags().BoolVar(&councilValidStrict, "strict", false, "Fail on lint warnings as well as errors")
+	councilValidateCmd.Flags().StringVar(&councilValidFormat, "format", "text", "Output format: text or json")
+	councilModelsCmd.Flags().StringVar(&councilModelsFilter, "provider", "", "Only list models from this provider")
+	councilModelsCmd.Flags().BoolVar(&councilShowJSON, "json", false, "Output as JSON")
+	councilTuneCmd.Flags().BoolVar(&councilShowJSON, "json", false, "Output as JSON")
+	councilTuneCmd.Flags().BoolVar(&councilTuneApply, "apply", false, "Write the suggestions to the council config")
+	councilSuggestCmd.Flags().BoolVar(&councilShowJSON, "json", false, "Print the suggested profile as JSON without applying it")
+	councilSuggestCmd.Flags().BoolVarP(&councilSuggestYes, "yes", "y", false, "Apply without asking for confirmation")
+	councilFailuresCmd.Flags().IntVar(&councilFailuresTop, "top", 10, "Number of failure groups to show (0 for all)")
+	councilFailuresCmd.Flags().BoolVar(&councilShowJSON, "json", false, "Output as JSON")
+	councilSchemaCmd.Flags().StringVar(&councilSchemaOut, "out", "", "Write the schema to a file instead of stdout")
+	councilDoctorCmd.Flags().BoolVar(&councilDoctorFix, "fix", false, "Fix problems that can be fixed safely")
+	councilDoctorCmd.Flags().BoolVarP(&councilDoctorYes, "yes", "y", false, "Apply fixes without asking (use with --fix)")
+	councilDoctorCmd.Flags().BoolVar(&councilDoctorNet, "network", false, "Also probe provider credentials")
+	councilCircuitsCmd.Flags().BoolVar(&councilCircuitReset, "reset", false, "Close the circuit for the given provider (or all providers)")
+	councilCircuitsCmd.Flags().BoolVar(&councilShowJSON, "json", false, "Output as JSON")
 	councilRouteCmd.Flags().StringVar(&councilRouteComplex, "complexity", "", "Task complexity (low, medium, high)")
+	councilRouteCmd.Flags().Float64Var(&councilRouteMaxCost, "max-cost", 0, "Maximum estimated cost per task in dollars (0 for no cap)")
+	councilRouteCmd.Flags().BoolVar(&councilRouteNarrate, "narrate", false, "Explain the routing decision in prose")
 	councilInitCmd.Flags().BoolVar(&councilInitForce, "force", false, "Overwrite existing config")
-	councilStatsCmd.Flags().BoolVar(&councilStatsJSON, "json", false, "Output as JSON")
+	councilInitCmd.Flags().StringVar(&councilInitProfile, "profile", "", "Initialize from a predefined profile")
+	councilStatsCmd.PersistentFlags().BoolVar(&councilStatsJSON, "json", false, "Output as JSON")
+	councilCmd.PersistentFlags().BoolVar(&councilCompact, "compact", false, "Emit JSON output on a single line (or set "+councilJSONCompactEnv+"=1)")
+	councilStatsCmd.Flags().BoolVar(&councilStatsSince, "since-last", false, "Only show tasks recorded since the previous --since-last run")
+	councilStatsCmd.Flags().StringVar(&councilStatsBy, "by", "", "Break statistics down by dimension (complexity)")
+	councilStatsCmd.Flags().StringVar(&councilStatsWindow, "since", "", "Only show tasks recorded within this window (e.g. 7d, 12h, 2006-01-02)")
+	councilStatsCmd.Flags().StringVar(&councilStatsFormat, "format", "", "Export per-model metrics as csv or prometheus")
+	councilPruneCmd.Flags().BoolVar(&councilPruneHistory, "history-only", false, "Clear task history but keep aggregates")
 	councilChainsCmd.Flags().BoolVar(&councilShowJSON, "json", false, "Output as JSON")
 	councilEnsemblesCmd.Flags().BoolVar(&councilShowJSON, "json", false, "Output as JSON")
+	councilPatternCmd.Flags().BoolVar(&councilShowJSON, "json", false, "Output as JSON")
 	councilProfilesCmd.Flags().BoolVar(&councilShowJSON, "json", false, "Output as JSON")
+	councilProfilesDiffCmd.Flags().BoolVar(&councilShowJSON, "json", false, "Output as JSON")
+	councilUseCmd.Flags().BoolVar(&councilUseBackup, "backup", false, "Back up the current config before applying")
 	councilExportCmd.Flags().StringVar(&councilExportName, "name", "", "Profile name")
 	councilExportCmd.Flags().StringVar(&councilExportAuthor, "author", "", "Profile author")
 	councilExportCmd.Flags().StringVar(&councilExportDesc, "description", "", "Profile description")
+	councilImportCmd.Flags().BoolVarP(&councilImportYes, "yes", "y", false, "Apply without asking for confirmation")
+	councilImportCmd.Flags().StringSliceVar(&councilImportRoles, "roles", nil, "Only import these roles (comma-separated), keeping the rest of the config")
+	councilImportCmd.Flags().BoolVar(&councilImportDefs, "include-defaults", false, "With --roles, also import the profile's defaults and providers")
+	councilImportCmd.Flags().BoolVar(&councilValidateOnly, "validate-only", false, "Validate the profile and show what it would set without applying it")
 
 	// Add subcommands
 	councilCmd.AddCommand(councilShowCmd)
 	councilCmd.AddCommand(councilRoleCmd)
+	councilCmd.AddCommand(councilWhyCmd)
+	councilCmd.AddCommand(councilValidateCmd)
 	councilCmd.AddCommand(councilSetCmd)
 	councilCmd.AddCommand(councilFallbackCmd)
 	councilCmd.AddCommand(councilProvidersCmd)
 	councilCmd.AddCommand(councilRouteCmd)
 	councilCmd.AddCommand(councilInitCmd)
+	councilCmd.AddCommand(councilSetupCmd)
 	councilCmd.AddCommand(councilTemplatesCmd)
+	councilStatsCmd.AddCommand(councilStatsDiffCmd)
 	councilCmd.AddCommand(councilStatsCmd)
+	councilProvidersCmd.AddCommand(councilProvidersRefreshCmd)
+	councilCmd.AddCommand(councilModelsCmd)
+	councilCmd.AddCommand(councilTuneCmd)
+	councilCmd.AddCommand(councilSuggestCmd)
+	councilCmd.AddCommand(councilFailuresCmd)
+	councilCmd.AddCommand(councilCircuitsCmd)
+	councilCmd.AddCommand(councilSchemaCmd)
+	councilCmd.AddCommand(councilDoctorCmd)
+	councilCmd.AddCommand(councilPruneCmd)
 	councilCmd.AddCommand(councilCompareCmd)
 	councilCmd.AddCommand(councilChainsCmd)
 	councilCmd.AddCommand(councilEnsemblesCmd)
 	councilCmd.AddCommand(councilPatternCmd)
+	councilProfilesCmd.AddCommand(councilProfilesDiffCmd)
 	councilCmd.AddCommand(councilProfilesCmd)
 	councilCmd.AddCommand(councilUseCmd)
+	councilCmd.AddCommand(councilRestoreCmd)
 	councilCmd.AddCommand(councilExportCmd)
 	councilCmd.AddCommand(councilImportCmd)
 
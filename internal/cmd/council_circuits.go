@@ -0,0 +1,114 @@
+package cmd
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/cursorworkshop/cursor-gastown/internal/council"
+	"github.com/cursorworkshop/cursor-gastown/internal/style"
+	"github.com/cursorworkshop/cursor-gastown/internal/workspace"
+)
+
+func runCouncilCircuits(cmd *cobra.Command, args []string) error {
+	townRoot, err := workspace.FindFromCwd()
+	if err != nil {
+		return fmt.Errorf("finding town root: %w", err)
+	}
+
+	config, err := council.LoadOrCreate(townRoot)
+	if err != nil {
+		return fmt.Errorf("loading council config: %w", err)
+	}
+
+	fm := council.NewFallbackManager(council.NewRouter(config))
+	statePath := council.CircuitStatePath(townRoot)
+	if err := fm.LoadCircuits(statePath); err != nil {
+		return err
+	}
+
+	var provider string
+	if len(args) > 0 {
+		provider = args[0]
+	}
+
+	if councilCircuitReset {
+		if provider != "" {
+			err = fm.ResetProvider(provider)
+		} else {
+			fm.Reset()
+		}
+		if err != nil {
+			return err
+		}
+		if err := fm.SaveCircuits(statePath); err != nil {
+			return err
+		}
+		if !councilShowJSON {
+			target := provider
+			if target == "" {
+				target = "all providers"
+			}
+			fmt.Printf("%s Reset circuit for %s\n\n", style.Success.Render("✓"), target)
+		}
+	}
+
+	circuits := fm.Circuits()
+	if provider != "" {
+		var filtered []council.CircuitStatus
+		for _, c := range circuits {
+			if c.Provider == provider {
+				filtered = append(filtered, c)
+			}
+		}
+		if len(filtered) == 0 {
+			return fmt.Errorf("unknown provider: %s", provider)
+		}
+		circuits = filtered
+	}
+
+	return printCouncilCircuits(circuits, councilShowJSON)
+}
+
+// printCouncilCircuits renders circuit state as text or JSON.
+func printCouncilCircuits(circuits []council.CircuitStatus, asJSON bool) error {
+	if asJSON {
+		return renderOutput(circuits)
+	}
+
+	fmt.Printf("%s\n\n", style.Bold.Render("Provider Circuits"))
+	if len(circuits) == 0 {
+		fmt.Printf("  %s\n", style.Dim.Render("No providers configured"))
+		return nil
+	}
+
+	for _, c := range circuits {
+		var icon string
+		switch c.State {
+		case "open":
+			icon = style.Error.Render("✗")
+		case "half-open":
+			icon = style.Warning.Render("⚠")
+		default:
+			icon = style.Success.Render("✓")
+		}
+		fmt.Printf("  %s %-12s %-9s failures %d/%d\n", icon, c.Provider, c.State, c.FailureCount, c.Threshold)
+
+		details := fmt.Sprintf("last failure %s · last success %s", circuitTime(c.LastFailure), circuitTime(c.LastSuccess))
+		if c.State == "open" {
+			details += fmt.Sprintf(" · opened %s · retry after %s", circuitTime(c.OpenedAt), c.ResetTimeout)
+			if retryAt := c.OpenedAt.Add(c.ResetTimeout); retryAt.After(time.Now()) {
+				details += fmt.Sprintf(" (in %s)", time.Until(retryAt).Round(time.Second))
+			}
+		}
+		fmt.Printf("    %s\n", style.Dim.Render(details))
+	}
+	return nil
+}
+
+func circuitTime(t time.Time) string {
+	if t.IsZero() {
+		return "never"
+	}
+	return formatAge(t)
+}
@@ -0,0 +1,51 @@
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/cursorworkshop/cursor-gastown/internal/doctor"
+	"github.com/cursorworkshop/cursor-gastown/internal/workspace"
+)
+
+func runCouncilDoctor(cmd *cobra.Command, args []string) error {
+	townRoot, err := workspace.FindFromCwdOrError()
+	if err != nil {
+		return fmt.Errorf("not in a Gas Town workspace: %w", err)
+	}
+	cwd, err := os.Getwd()
+	if err != nil {
+		return fmt.Errorf("getting current directory: %w", err)
+	}
+	role := detectRole(cwd, townRoot)
+
+	d := doctor.NewDoctor()
+	d.RegisterAll(doctor.CouncilChecks(cwd, string(role.Role))...)
+	if councilDoctorNet {
+		d.Register(doctor.NewProviderAuthCheck())
+	}
+
+	ctx := &doctor.CheckContext{TownRoot: townRoot, Verbose: true}
+	var report *doctor.Report
+	if councilDoctorFix {
+		ctx.FixLog = os.Stdout
+		if !councilDoctorYes {
+			reader := bufio.NewReader(os.Stdin)
+			ctx.Confirm = func(check doctor.Check, result *doctor.CheckResult) bool {
+				return promptConfirm(reader, fmt.Sprintf("Fix %s (%s)?", check.Name(), result.Message))
+			}
+		}
+		report = d.Fix(ctx)
+		fmt.Println()
+	} else {
+		report = d.Run(ctx)
+	}
+
+	report.Print(os.Stdout, true)
+	if report.HasErrors() {
+		return fmt.Errorf("council doctor found %d error(s)", report.Summary.Errors)
+	}
+	return nil
+}
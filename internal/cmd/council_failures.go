@@ -0,0 +1,69 @@
+package cmd
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/cursorworkshop/cursor-gastown/internal/council"
+	"github.com/cursorworkshop/cursor-gastown/internal/style"
+	"github.com/cursorworkshop/cursor-gastown/internal/workspace"
+)
+
+func runCouncilFailures(cmd *cobra.Command, args []string) error {
+	townRoot, err := workspace.FindFromCwd()
+	if err != nil {
+		return fmt.Errorf("finding town root: %w", err)
+	}
+
+	store, err := council.NewMetricsStore(townRoot)
+	if err != nil {
+		return fmt.Errorf("loading metrics: %w", err)
+	}
+
+	return printCouncilFailures(store.TopFailures(councilFailuresTop), councilShowJSON)
+}
+
+// printCouncilFailures renders failure groups as text or JSON.
+func printCouncilFailures(groups []council.FailureGroup, asJSON bool) error {
+	if asJSON {
+		return renderOutput(groups)
+	}
+
+	fmt.Printf("%s\n\n", style.Bold.Render("Council Task Failures"))
+	if len(groups) == 0 {
+		fmt.Printf("  %s\n", style.Dim.Render("No failed tasks in history"))
+		return nil
+	}
+
+	for _, g := range groups {
+		fmt.Printf("  %s %s %s\n", style.Error.Render("✗"), style.Bold.Render(fmt.Sprintf("%d×", g.Count)), g.Signature)
+		fmt.Printf("    %s\n", style.Dim.Render(fmt.Sprintf("models: %s · last seen %s",
+			formatFailureCounts(g.Models), g.LastSeen.Local().Format("2006-01-02 15:04"))))
+	}
+	return nil
+}
+
+// formatFailureCounts renders a count map as "a (3), b (1)", most frequent first.
+func formatFailureCounts(counts map[string]int) string {
+	names := make([]string, 0, len(counts))
+	for name := range counts {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool {
+		if counts[names[i]] != counts[names[j]] {
+			return counts[names[i]] > counts[names[j]]
+		}
+		return names[i] < names[j]
+	})
+
+	parts := make([]string, len(names))
+	for i, name := range names {
+		parts[i] = fmt.Sprintf("%s (%d)", name, counts[name])
+	}
+	if len(parts) == 0 {
+		return "unknown"
+	}
+	return strings.Join(parts, ", ")
+}
@@ -0,0 +1,69 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/cursorworkshop/cursor-gastown/internal/council"
+	"github.com/cursorworkshop/cursor-gastown/internal/cursor"
+	"github.com/cursorworkshop/cursor-gastown/internal/style"
+	"github.com/cursorworkshop/cursor-gastown/internal/workspace"
+)
+
+func runCouncilModels(cmd *cobra.Command, args []string) error {
+	townRoot, err := workspace.FindFromCwd()
+	if err != nil {
+		return fmt.Errorf("finding town root: %w", err)
+	}
+
+	config, err := council.LoadOrCreate(townRoot)
+	if err != nil {
+		return fmt.Errorf("loading council config: %w", err)
+	}
+
+	// Observed costs are optional; a missing or unreadable store just
+	// leaves the cost column empty.
+	var metrics *council.Metrics
+	if store, err := council.NewMetricsStore(townRoot); err == nil {
+		metrics = store.GetMetrics()
+	}
+
+	models := council.ListModels(cursor.SupportedModels, config, metrics, councilModelsFilter)
+	return printCouncilModels(models, councilShowJSON)
+}
+
+// printCouncilModels renders the model listing as text or JSON.
+func printCouncilModels(models []council.ModelInfo, asJSON bool) error {
+	if asJSON {
+		return renderOutput(models)
+	}
+
+	fmt.Printf("%s\n\n", style.Bold.Render("Available Models"))
+	if len(models) == 0 {
+		fmt.Printf("  %s\n", style.Dim.Render("No models match"))
+		return nil
+	}
+
+	for _, m := range models {
+		marker := " "
+		if m.InUse {
+			marker = style.Success.Render("●")
+		}
+		fmt.Printf("%s %-20s %-10s", marker, m.Name, m.Provider)
+		if len(m.Capabilities) > 0 {
+			fmt.Printf(" %s", strings.Join(m.Capabilities, ", "))
+		}
+		fmt.Println()
+
+		if m.AvgCost > 0 {
+			fmt.Printf("    %s\n", style.Dim.Render(fmt.Sprintf("avg cost/task: $%.4f", m.AvgCost)))
+		}
+		if m.InUse {
+			fmt.Printf("    %s\n", style.Dim.Render("used by: "+strings.Join(m.UsedBy, ", ")))
+		}
+	}
+
+	fmt.Printf("\n%s\n", style.Dim.Render("● = referenced by the current council config"))
+	return nil
+}
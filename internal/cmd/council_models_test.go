@@ -0,0 +1,68 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/cursorworkshop/cursor-gastown/internal/council"
+	"github.com/cursorworkshop/cursor-gastown/internal/cursor"
+)
+
+func TestPrintCouncilModels(t *testing.T) {
+	cfg := &council.Config{Roles: map[string]*council.RoleConfig{
+		"mayor":    {Model: "opus-4.5", Fallback: []string{"gpt-5.2"}},
+		"refinery": {Model: "gpt-5.2"},
+	}}
+	models := council.ListModels(cursor.SupportedModels, cfg, nil, "")
+
+	output := captureStdout(t, func() {
+		if err := printCouncilModels(models, false); err != nil {
+			t.Fatalf("printCouncilModels failed: %v", err)
+		}
+	})
+
+	lines := strings.Split(output, "\n")
+	for _, model := range cursor.SupportedModels {
+		provider := council.ModelProvider(model)
+		if model == "auto" {
+			provider = "cursor"
+		}
+		var line string
+		for _, l := range lines {
+			if fields := strings.Fields(l); len(fields) >= 2 && (fields[0] == model || fields[1] == model) {
+				line = l
+				break
+			}
+		}
+		if line == "" {
+			t.Errorf("model %s missing from output", model)
+			continue
+		}
+		if !strings.Contains(line, provider) {
+			t.Errorf("model %s line %q missing provider %s", model, line, provider)
+		}
+		inUse := model == "opus-4.5" || model == "gpt-5.2"
+		if marked := strings.HasPrefix(line, "●"); marked != inUse {
+			t.Errorf("model %s in-use marker = %v, want %v", model, marked, inUse)
+		}
+	}
+
+	if !strings.Contains(output, "used by: mayor, refinery") {
+		t.Errorf("expected gpt-5.2 to list both roles, got:\n%s", output)
+	}
+}
+
+func TestListModels_ProviderFilter(t *testing.T) {
+	models := council.ListModels(cursor.SupportedModels, nil, nil, "google")
+	if len(models) == 0 {
+		t.Fatal("expected google models")
+	}
+	for _, m := range models {
+		if m.Provider != "google" {
+			t.Errorf("model %s has provider %s, want google", m.Name, m.Provider)
+		}
+		if want := cursor.Catalog().Lookup(m.Name).Capabilities; strings.Join(m.Capabilities, ",") != strings.Join(want, ",") {
+			t.Errorf("model %s capabilities = %v, want %v from the catalog", m.Name, m.Capabilities, want)
+		}
+	}
+}
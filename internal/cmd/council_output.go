@@ -0,0 +1,21 @@
+package cmd
+
+import (
+	"encoding/json"
+	"os"
+)
+
+// councilJSONCompactEnv, when set to 1, makes council JSON output compact
+// without passing --compact.
+const councilJSONCompactEnv = "GASTOWN_JSON_COMPACT"
+
+// renderOutput writes v to stdout as JSON for the council --json outputs.
+// Output is indented by default and a single line with --compact or
+// GASTOWN_JSON_COMPACT=1.
+func renderOutput(v any) error {
+	enc := json.NewEncoder(os.Stdout)
+	if !councilCompact && os.Getenv(councilJSONCompactEnv) != "1" {
+		enc.SetIndent("", "  ")
+	}
+	return enc.Encode(v)
+}
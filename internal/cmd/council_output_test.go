@@ -0,0 +1,55 @@
+package cmd
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cursorworkshop/cursor-gastown/internal/council"
+)
+
+func TestRenderOutput_Compact(t *testing.T) {
+	groups := []council.FailureGroup{{
+		Signature: "HTTP 500",
+		Count:     2,
+		Models:    map[string]int{"gpt-5.2": 2},
+		Providers: map[string]int{"openai": 2},
+		LastSeen:  time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC),
+	}}
+	render := func(compact bool) string {
+		old := councilCompact
+		councilCompact = compact
+		defer func() { councilCompact = old }()
+		return captureStdout(t, func() {
+			if err := printCouncilFailures(groups, true); err != nil {
+				t.Fatalf("printCouncilFailures: %v", err)
+			}
+		})
+	}
+
+	pretty, compact := render(false), render(true)
+	if !strings.Contains(pretty, "\n  ") {
+		t.Errorf("default output should be indented:\n%s", pretty)
+	}
+	if body := strings.TrimSuffix(compact, "\n"); strings.Contains(body, "\n") || strings.Contains(body, "  ") {
+		t.Errorf("compact output should be a single unindented line: %q", compact)
+	}
+
+	var a, b any
+	if err := json.Unmarshal([]byte(pretty), &a); err != nil {
+		t.Fatalf("pretty output is not JSON: %v", err)
+	}
+	if err := json.Unmarshal([]byte(compact), &b); err != nil {
+		t.Fatalf("compact output is not JSON: %v", err)
+	}
+	if !reflect.DeepEqual(a, b) {
+		t.Errorf("compact and pretty output differ:\n%s\n%s", pretty, compact)
+	}
+
+	t.Setenv(councilJSONCompactEnv, "1")
+	if got := render(false); got != compact {
+		t.Errorf("%s=1 output = %q, want %q", councilJSONCompactEnv, got, compact)
+	}
+}
@@ -0,0 +1,34 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+	"github.com/cursorworkshop/cursor-gastown/internal/council"
+	"github.com/cursorworkshop/cursor-gastown/internal/style"
+)
+
+func runCouncilSchema(cmd *cobra.Command, args []string) error {
+	schema := council.ConfigSchema()
+	if councilSchemaOut == "" {
+		return renderOutput(schema)
+	}
+
+	data, err := json.MarshalIndent(schema, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encoding schema: %w", err)
+	}
+	if dir := filepath.Dir(councilSchemaOut); dir != "." {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return fmt.Errorf("creating %s: %w", dir, err)
+		}
+	}
+	if err := os.WriteFile(councilSchemaOut, append(data, '\n'), 0644); err != nil {
+		return fmt.Errorf("writing schema: %w", err)
+	}
+	fmt.Printf("%s Wrote council config schema to %s\n", style.SuccessPrefix, councilSchemaOut)
+	return nil
+}
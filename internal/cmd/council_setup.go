@@ -0,0 +1,151 @@
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/cursorworkshop/cursor-gastown/internal/council"
+	"github.com/cursorworkshop/cursor-gastown/internal/style"
+	"github.com/cursorworkshop/cursor-gastown/internal/workspace"
+	"golang.org/x/term"
+)
+
+func runCouncilSetup(cmd *cobra.Command, args []string) error {
+	if !term.IsTerminal(int(os.Stdin.Fd())) || !term.IsTerminal(int(os.Stdout.Fd())) {
+		return fmt.Errorf("gt council setup needs an interactive terminal; use 'gt council init --profile <name>' instead")
+	}
+
+	townRoot, err := workspace.FindFromCwd()
+	if err != nil {
+		return fmt.Errorf("finding town root: %w", err)
+	}
+
+	current, err := council.LoadOrCreate(townRoot)
+	if err != nil {
+		return fmt.Errorf("loading council config: %w", err)
+	}
+
+	reader := bufio.NewReader(os.Stdin)
+	fmt.Printf("%s\n\n", style.Bold.Render("Gas Town Council Setup"))
+
+	var answers council.SetupAnswers
+
+	answers.Priority = promptChoice(reader, "What matters most?", []string{"balanced", "cost", "quality"}, "balanced")
+
+	detected := council.DetectProviders()
+	defaultProviders := strings.Join(detected, ",")
+	if defaultProviders == "" {
+		defaultProviders = "anthropic,openai,google"
+	} else {
+		fmt.Printf("%s\n", style.Dim.Render("Detected credentials for: "+defaultProviders))
+	}
+	providers := promptLine(reader, "Which providers can you use? (comma-separated)", defaultProviders)
+	for _, p := range strings.Split(providers, ",") {
+		if p = strings.TrimSpace(strings.ToLower(p)); p != "" {
+			answers.Providers = append(answers.Providers, p)
+		}
+	}
+
+	budget := promptLine(reader, "Monthly budget in USD (0 for no limit)", "0")
+	if answers.MonthlyBudget, err = strconv.ParseFloat(strings.TrimPrefix(budget, "$"), 64); err != nil {
+		return fmt.Errorf("invalid budget %q: %w", budget, err)
+	}
+
+	profileName := council.RecommendProfile(answers)
+	profile, ok := council.GetProfile(profileName)
+	if !ok {
+		return fmt.Errorf("recommended profile %q not found", profileName)
+	}
+
+	proposed, err := profile.Config.Clone()
+	if err != nil {
+		return err
+	}
+	if err := proposed.PruneProviders(answers.Providers); err != nil {
+		return fmt.Errorf("profile %s doesn't fit those providers: %w", profileName, err)
+	}
+
+	fmt.Printf("\n%s %s\n", style.Bold.Render("Recommended profile:"), profileName)
+	fmt.Printf("%s\n\n", style.Dim.Render(profile.Description))
+	printRoleModelChanges(current, proposed)
+
+	if !promptConfirm(reader, "\nWrite this configuration?") {
+		fmt.Println("Aborted; no changes written.")
+		return nil
+	}
+
+	configPath := council.ConfigPath(townRoot)
+	if err := council.SaveConfig(configPath, proposed); err != nil {
+		return fmt.Errorf("saving council config: %w", err)
+	}
+	fmt.Printf("%s Wrote %s\n", style.Success.Render("✓"), configPath)
+	return nil
+}
+
+// printRoleModelChanges shows per-role model changes between two configs.
+func printRoleModelChanges(current, proposed *council.Config) {
+	roleSet := make(map[string]bool)
+	for role := range current.Roles {
+		roleSet[role] = true
+	}
+	for role := range proposed.Roles {
+		roleSet[role] = true
+	}
+	roles := make([]string, 0, len(roleSet))
+	for role := range roleSet {
+		roles = append(roles, role)
+	}
+	sort.Strings(roles)
+
+	fmt.Printf("%s\n", style.Bold.Render("Changes:"))
+	for _, role := range roles {
+		before, after := "-", "-"
+		if rc := current.Roles[role]; rc != nil {
+			before = rc.Model
+		}
+		if rc := proposed.Roles[role]; rc != nil {
+			after = rc.Model
+		}
+		if before == after {
+			fmt.Printf("  %-10s %s\n", role+":", style.Dim.Render(after+" (unchanged)"))
+			continue
+		}
+		fmt.Printf("  %-10s %s -> %s\n", role+":", before, style.Bold.Render(after))
+	}
+}
+
+// promptLine asks for a line of input, returning def on empty input.
+func promptLine(reader *bufio.Reader, question, def string) string {
+	fmt.Printf("%s [%s]: ", question, def)
+	answer, _ := reader.ReadString('\n')
+	if answer = strings.TrimSpace(answer); answer == "" {
+		return def
+	}
+	return answer
+}
+
+// promptChoice asks until the answer is one of choices.
+func promptChoice(reader *bufio.Reader, question string, choices []string, def string) string {
+	for {
+		answer := strings.ToLower(promptLine(reader, question+" ("+strings.Join(choices, "/")+")", def))
+		for _, c := range choices {
+			if answer == c {
+				return c
+			}
+		}
+		fmt.Printf("Please answer one of: %s\n", strings.Join(choices, ", "))
+	}
+}
+
+// promptConfirm asks a yes/no question, defaulting to no.
+func promptConfirm(reader *bufio.Reader, question string) bool {
+	fmt.Printf("%s [y/N]: ", question)
+	answer, _ := reader.ReadString('\n')
+	answer = strings.TrimSpace(strings.ToLower(answer))
+	return answer == "y" || answer == "yes"
+}
@@ -0,0 +1,45 @@
+package cmd
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/cursorworkshop/cursor-gastown/internal/council"
+	"github.com/cursorworkshop/cursor-gastown/internal/style"
+)
+
+// complexityOrder ranks complexity buckets for display.
+var complexityOrder = map[string]int{"low": 0, "medium": 1, "high": 2, council.ComplexityUnspecified: 3}
+
+// printCouncilStatsByComplexity renders per-complexity aggregates as text
+// or JSON, ordered low, medium, high, unspecified.
+func printCouncilStatsByComplexity(metrics *council.Metrics, asJSON bool) error {
+	levels := make([]*council.ComplexityMetrics, 0, len(metrics.ByComplexity))
+	for _, cm := range metrics.ByComplexity {
+		levels = append(levels, cm)
+	}
+	sort.Slice(levels, func(i, j int) bool {
+		return complexityOrder[levels[i].Complexity] < complexityOrder[levels[j].Complexity]
+	})
+
+	if asJSON {
+		return renderOutput(levels)
+	}
+
+	fmt.Printf("%s\n\n", style.Bold.Render("Council Statistics by Complexity"))
+	if len(levels) == 0 {
+		fmt.Printf("%s\n", style.Dim.Render("No metrics recorded yet. Run tasks to collect data."))
+		return nil
+	}
+	for _, cm := range levels {
+		fmt.Printf("  %s: %d tasks, %.1f%% success, avg %v, avg $%.2f, $%.2f total\n",
+			style.Bold.Render(cm.Complexity),
+			cm.TotalTasks,
+			cm.SuccessRate*100,
+			cm.AvgDuration.Round(time.Second),
+			cm.AvgCost,
+			cm.TotalCost)
+	}
+	return nil
+}
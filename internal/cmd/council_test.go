@@ -0,0 +1,365 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cursorworkshop/cursor-gastown/internal/council"
+)
+
+func TestPrintCouncilPattern_JSON(t *testing.T) {
+	output := captureStdout(t, func() {
+		if err := printCouncilPattern("critical-decision", council.PredefinedChains, council.PredefinedEnsembles, true); err != nil {
+			t.Fatalf("printCouncilPattern failed: %v", err)
+		}
+	})
+
+	var got council.EnsembleConfig
+	if err := json.Unmarshal([]byte(output), &got); err != nil {
+		t.Fatalf("output is not an EnsembleConfig: %v\n%s", err, output)
+	}
+
+	want := council.PredefinedEnsembles["critical-decision"]
+	if len(got.Models) != len(want.Models) {
+		t.Fatalf("models = %v, want %v", got.Models, want.Models)
+	}
+	for i := range want.Models {
+		if got.Models[i] != want.Models[i] {
+			t.Errorf("models[%d] = %s, want %s", i, got.Models[i], want.Models[i])
+		}
+	}
+	if got.VotingStrategy != want.VotingStrategy {
+		t.Errorf("voting_strategy = %s, want %s", got.VotingStrategy, want.VotingStrategy)
+	}
+	if got.Threshold != want.Threshold {
+		t.Errorf("threshold = %v, want %v", got.Threshold, want.Threshold)
+	}
+}
+
+func TestPrintCouncilPattern_Debate(t *testing.T) {
+	output := captureStdout(t, func() {
+		if err := printCouncilPattern("design-tradeoff", council.PredefinedChains, council.PredefinedEnsembles, false); err != nil {
+			t.Fatalf("printCouncilPattern failed: %v", err)
+		}
+	})
+	want := council.PredefinedDebates["design-tradeoff"]
+	for _, s := range []string{"Debate:", want.ProModel, want.ConModel, want.JudgeModel} {
+		if !strings.Contains(output, s) {
+			t.Errorf("output missing %q:\n%s", s, output)
+		}
+	}
+}
+
+func TestPrintCouncilPattern_NotFound(t *testing.T) {
+	captureStdout(t, func() {
+		if err := printCouncilPattern("no-such-pattern", council.PredefinedChains, council.PredefinedEnsembles, true); err == nil {
+			t.Error("expected error for unknown pattern")
+		}
+	})
+}
+
+func TestImportCouncilProfile_ValidateOnly(t *testing.T) {
+	townRoot := t.TempDir()
+	profile := council.ExportProfile(&council.Config{
+		Roles: map[string]*council.RoleConfig{
+			"polecat": {Model: "sonnet-4.5", Fallback: []string{"gpt-9000"}},
+			"witness": {Model: "gemini-3-flash"},
+		},
+		Defaults:  &council.DefaultConfig{Model: "sonnet-4.5"},
+		Providers: map[string]*council.ProviderConfig{"google": {Enabled: false}},
+	}, "community", "", "someone")
+	path := filepath.Join(t.TempDir(), "profile.json")
+	if err := council.ExportProfileToFile(profile, path); err != nil {
+		t.Fatal(err)
+	}
+
+	var err error
+	output := captureStdout(t, func() {
+		err = importCouncilProfile(path, townRoot, profileImportOptions{validateOnly: true})
+	})
+	if err == nil {
+		t.Error("expected an error for a profile with validation issues")
+	}
+	for _, want := range []string{`"gpt-9000" is not a supported model`, `disabled provider "google"`, "polecat", "witness"} {
+		if !strings.Contains(output, want) {
+			t.Errorf("output missing %q:\n%s", want, output)
+		}
+	}
+	if _, statErr := os.Stat(council.ConfigPath(townRoot)); !os.IsNotExist(statErr) {
+		t.Errorf("--validate-only wrote a config (stat err: %v)", statErr)
+	}
+}
+
+func TestImportCouncilProfile_Apply(t *testing.T) {
+	townRoot := t.TempDir()
+	cfg := council.DefaultCouncilConfig()
+	cfg.Roles["refinery"].Model = "gemini-3-pro"
+	path := filepath.Join(t.TempDir(), "profile.json")
+	if err := council.ExportProfileToFile(council.ExportProfile(cfg, "team", "", "someone"), path); err != nil {
+		t.Fatal(err)
+	}
+
+	var asked string
+	output := captureStdout(t, func() {
+		if err := importCouncilProfile(path, townRoot, profileImportOptions{confirm: func(q string) bool { asked = q; return false }}); err != nil {
+			t.Errorf("declined import: %v", err)
+		}
+	})
+	if asked == "" || !strings.Contains(output, "gemini-3-pro") {
+		t.Errorf("expected a confirmation after a diff showing the refinery change, asked %q:\n%s", asked, output)
+	}
+	if _, err := os.Stat(council.ConfigPath(townRoot)); !os.IsNotExist(err) {
+		t.Errorf("declined import wrote a config (stat err: %v)", err)
+	}
+
+	captureStdout(t, func() {
+		if err := importCouncilProfile(path, townRoot, profileImportOptions{}); err != nil {
+			t.Errorf("import: %v", err)
+		}
+	})
+	got, err := council.LoadConfig(council.ConfigPath(townRoot))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Roles["refinery"].Model != "gemini-3-pro" {
+		t.Errorf("refinery model = %s, want gemini-3-pro", got.Roles["refinery"].Model)
+	}
+
+	// A change outside the role models is shown and applied too.
+	cfg.MaxFallbackDepth = 2
+	if err := council.ExportProfileToFile(council.ExportProfile(cfg, "team", "", "someone"), path); err != nil {
+		t.Fatal(err)
+	}
+	output = captureStdout(t, func() {
+		if err := importCouncilProfile(path, townRoot, profileImportOptions{}); err != nil {
+			t.Errorf("import: %v", err)
+		}
+	})
+	if !strings.Contains(output, "max_fallback_depth") {
+		t.Errorf("expected the diff to show max_fallback_depth:\n%s", output)
+	}
+	if got, _ := council.LoadConfig(council.ConfigPath(townRoot)); got.MaxFallbackDepth != 2 {
+		t.Errorf("MaxFallbackDepth = %d, want 2", got.MaxFallbackDepth)
+	}
+
+	invalid := council.ExportProfile(&council.Config{Roles: map[string]*council.RoleConfig{"mayor": {Model: "opus-4.5"}}}, "", "", "")
+	if err := council.ExportProfileToFile(invalid, path); err != nil {
+		t.Fatal(err)
+	}
+	captureStdout(t, func() {
+		if err := importCouncilProfile(path, townRoot, profileImportOptions{}); err == nil {
+			t.Error("expected a profile with validation issues to be refused")
+		}
+	})
+	if got, _ := council.LoadConfig(council.ConfigPath(townRoot)); got.Roles["mayor"].Model == "opus-4.5" && len(got.Roles) == 1 {
+		t.Error("invalid profile overwrote the config")
+	}
+}
+
+func TestApplySuggestedProfile(t *testing.T) {
+	townRoot := t.TempDir()
+	profile := &council.Profile{Config: &council.Config{Roles: map[string]*council.RoleConfig{
+		"refinery": {Model: "gemini-3-pro", Fallback: []string{"gpt-5.2"}},
+	}}}
+
+	captureStdout(t, func() {
+		if err := applySuggestedProfile(townRoot, profile, func(string) bool { return true }); err != nil {
+			t.Fatalf("applySuggestedProfile: %v", err)
+		}
+	})
+
+	got, err := council.LoadConfig(council.ConfigPath(townRoot))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The runner-up is prepended to the existing opus-4.5, sonnet-4.5 chain.
+	if rc := got.Roles["refinery"]; rc.Model != "gemini-3-pro" || strings.Join(rc.Fallback, ",") != "gpt-5.2,opus-4.5,sonnet-4.5" {
+		t.Errorf("refinery = %s %v, want gemini-3-pro [gpt-5.2 opus-4.5 sonnet-4.5]", rc.Model, rc.Fallback)
+	}
+
+	// Re-applying doesn't duplicate fallbacks, and a new primary is
+	// dropped from the chain.
+	profile.Config.Roles["refinery"] = &council.RoleConfig{Model: "opus-4.5", Fallback: []string{"gpt-5.2"}}
+	captureStdout(t, func() {
+		if err := applySuggestedProfile(townRoot, profile, func(string) bool { return true }); err != nil {
+			t.Fatalf("applySuggestedProfile: %v", err)
+		}
+	})
+	got, _ = council.LoadConfig(council.ConfigPath(townRoot))
+	if rc := got.Roles["refinery"]; rc.Model != "opus-4.5" || strings.Join(rc.Fallback, ",") != "gpt-5.2,sonnet-4.5" {
+		t.Errorf("refinery = %s %v, want opus-4.5 [gpt-5.2 sonnet-4.5]", rc.Model, rc.Fallback)
+	}
+	if want := council.DefaultCouncilConfig().Roles["mayor"].Model; got.Roles["mayor"].Model != want {
+		t.Errorf("mayor model = %s, want untouched %s", got.Roles["mayor"].Model, want)
+	}
+}
+
+func TestCouncilConfigEnvOverride(t *testing.T) {
+	townRoot := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(townRoot, "mayor"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	customPath := filepath.Join(t.TempDir(), "team", "council.toml")
+	t.Setenv(council.ConfigPathEnv, customPath)
+
+	originalWd, _ := os.Getwd()
+	defer os.Chdir(originalWd)
+	if err := os.Chdir(townRoot); err != nil {
+		t.Fatalf("chdir: %v", err)
+	}
+
+	captureStdout(t, func() {
+		if err := runCouncilSet(nil, []string{"polecat", "gpt-5.2"}); err != nil {
+			t.Fatalf("council set failed: %v", err)
+		}
+	})
+
+	cfg, err := council.LoadConfig(customPath)
+	if err != nil {
+		t.Fatalf("loading custom config: %v", err)
+	}
+	if got := cfg.Roles["polecat"].Model; got != "gpt-5.2" {
+		t.Errorf("custom config polecat model = %q, want gpt-5.2", got)
+	}
+	if _, err := os.Stat(filepath.Join(townRoot, ".beads", council.ConfigFileName)); !os.IsNotExist(err) {
+		t.Errorf("standard config path was written despite %s (stat err: %v)", council.ConfigPathEnv, err)
+	}
+
+	oldJSON := councilShowJSON
+	councilShowJSON = true
+	defer func() { councilShowJSON = oldJSON }()
+	output := captureStdout(t, func() {
+		if err := runCouncilShow(nil, nil); err != nil {
+			t.Fatalf("council show failed: %v", err)
+		}
+	})
+	var shown council.Config
+	if err := json.Unmarshal([]byte(output), &shown); err != nil {
+		t.Fatalf("council show output is not JSON: %v", err)
+	}
+	if got := shown.Roles["polecat"].Model; got != "gpt-5.2" {
+		t.Errorf("council show polecat model = %q, want gpt-5.2 from the custom config", got)
+	}
+}
+
+func TestCouncilConfigEnvOverride_JSON(t *testing.T) {
+	townRoot := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(townRoot, "mayor"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	customPath := filepath.Join(t.TempDir(), "council.json")
+	t.Setenv(council.ConfigPathEnv, customPath)
+
+	originalWd, _ := os.Getwd()
+	defer os.Chdir(originalWd)
+	if err := os.Chdir(townRoot); err != nil {
+		t.Fatalf("chdir: %v", err)
+	}
+
+	// Two writes: the second loads what the first saved.
+	captureStdout(t, func() {
+		if err := runCouncilSet(nil, []string{"polecat", "gpt-5.2"}); err != nil {
+			t.Fatalf("council set failed: %v", err)
+		}
+		if err := runCouncilSet(nil, []string{"witness", "haiku-3.5"}); err != nil {
+			t.Fatalf("second council set failed: %v", err)
+		}
+	})
+
+	data, err := os.ReadFile(customPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !json.Valid(data) {
+		t.Fatalf("%s is not JSON:\n%s", customPath, data)
+	}
+	cfg, err := council.LoadConfig(customPath)
+	if err != nil {
+		t.Fatalf("loading custom config: %v", err)
+	}
+	if cfg.Roles["polecat"].Model != "gpt-5.2" || cfg.Roles["witness"].Model != "haiku-3.5" {
+		t.Errorf("polecat = %q, witness = %q; want gpt-5.2, haiku-3.5", cfg.Roles["polecat"].Model, cfg.Roles["witness"].Model)
+	}
+}
+
+func TestPrintCouncilCircuits(t *testing.T) {
+	fm := council.NewFallbackManager(council.NewRouter(council.DefaultCouncilConfig()))
+	fm.RecordRequestOutcome("anthropic", "", false, errors.New("401 unauthorized"))
+	fm.RecordRequestOutcome("openai", "", true, nil)
+
+	var circuits []council.CircuitStatus
+	for _, c := range fm.Circuits() {
+		if c.Provider == "anthropic" || c.Provider == "openai" {
+			circuits = append(circuits, c)
+		}
+	}
+
+	output := captureStdout(t, func() {
+		if err := printCouncilCircuits(circuits, false); err != nil {
+			t.Fatalf("printCouncilCircuits failed: %v", err)
+		}
+	})
+	lines := strings.Split(output, "\n")
+	var anthropic, openai string
+	for i, line := range lines {
+		if strings.Contains(line, "anthropic") && i+1 < len(lines) {
+			anthropic = line + "\n" + lines[i+1]
+		}
+		if strings.Contains(line, "openai") && i+1 < len(lines) {
+			openai = line + "\n" + lines[i+1]
+		}
+	}
+	if !strings.Contains(anthropic, "open ") || !strings.Contains(anthropic, "opened") || !strings.Contains(anthropic, "last success never") {
+		t.Errorf("anthropic entry should show an open circuit:\n%s", anthropic)
+	}
+	if !strings.Contains(openai, "closed") || strings.Contains(openai, "opened") || !strings.Contains(openai, "last failure never") {
+		t.Errorf("openai entry should show a closed circuit:\n%s", openai)
+	}
+
+	output = captureStdout(t, func() {
+		if err := printCouncilCircuits(circuits, true); err != nil {
+			t.Fatalf("printCouncilCircuits failed: %v", err)
+		}
+	})
+	var got []council.CircuitStatus
+	if err := json.Unmarshal([]byte(output), &got); err != nil {
+		t.Fatalf("output is not JSON: %v\n%s", err, output)
+	}
+	if len(got) != 2 || got[0].Provider != "anthropic" || got[0].State != "open" || got[1].State != "closed" {
+		t.Errorf("JSON circuits = %+v", got)
+	}
+}
+
+func TestParseStatsSince(t *testing.T) {
+	now := time.Date(2026, 3, 16, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		input   string
+		want    time.Time
+		wantErr bool
+	}{
+		{"7d", now.Add(-7 * 24 * time.Hour), false},
+		{"12h", now.Add(-12 * time.Hour), false},
+		{"2026-03-01", time.Date(2026, 3, 1, 0, 0, 0, 0, time.Local), false},
+		{"2026-03-01T08:00:00Z", time.Date(2026, 3, 1, 8, 0, 0, 0, time.UTC), false},
+		{"0d", time.Time{}, true},
+		{"last week", time.Time{}, true},
+	}
+	for _, tt := range tests {
+		got, err := parseStatsSince(tt.input, now)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("parseStatsSince(%q) = %v, want error", tt.input, got)
+			}
+			continue
+		}
+		if err != nil || !got.Equal(tt.want) {
+			t.Errorf("parseStatsSince(%q) = %v, %v; want %v", tt.input, got, err, tt.want)
+		}
+	}
+}
@@ -0,0 +1,164 @@
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"slices"
+
+	"github.com/spf13/cobra"
+	"github.com/cursorworkshop/cursor-gastown/internal/council"
+	"github.com/cursorworkshop/cursor-gastown/internal/style"
+	"github.com/cursorworkshop/cursor-gastown/internal/workspace"
+)
+
+func runCouncilTune(cmd *cobra.Command, args []string) error {
+	townRoot, err := workspace.FindFromCwd()
+	if err != nil {
+		return fmt.Errorf("finding town root: %w", err)
+	}
+
+	config, err := council.LoadOrCreate(townRoot)
+	if err != nil {
+		return fmt.Errorf("loading council config: %w", err)
+	}
+
+	store, err := council.NewMetricsStore(townRoot)
+	if err != nil {
+		return fmt.Errorf("loading metrics: %w", err)
+	}
+
+	suggestions := council.SuggestTuning(config, store.GetMetrics())
+	if err := printCouncilTuning(suggestions, councilShowJSON); err != nil {
+		return err
+	}
+
+	if !councilTuneApply || len(suggestions) == 0 {
+		return nil
+	}
+
+	applied := config.ApplyTuning(suggestions)
+	added := config.EnsureProvidersForModels()
+	if err := council.SaveConfig(council.ConfigPath(townRoot), config); err != nil {
+		return fmt.Errorf("saving council config: %w", err)
+	}
+	if !councilShowJSON {
+		fmt.Printf("\n%s Applied %d suggestion(s)\n", style.Success.Render("✓"), applied)
+		printAddedProviders(added)
+	}
+	return nil
+}
+
+// printCouncilTuning renders tuning suggestions as text or JSON.
+func printCouncilTuning(suggestions []council.TuningSuggestion, asJSON bool) error {
+	if asJSON {
+		if suggestions == nil {
+			suggestions = []council.TuningSuggestion{}
+		}
+		return renderOutput(suggestions)
+	}
+
+	fmt.Printf("%s\n\n", style.Bold.Render("Council Tuning Suggestions"))
+	if len(suggestions) == 0 {
+		fmt.Printf("  %s\n", style.Dim.Render(fmt.Sprintf("No suggestions (needs at least %d tasks per role/model)", council.MinTuningSamples)))
+		return nil
+	}
+
+	for _, s := range suggestions {
+		fmt.Printf("  %s %s.%s: %s → %s\n", style.Warning.Render("⚠"), style.Bold.Render(s.Role), s.Field, s.Current, style.Bold.Render(s.Suggested))
+		fmt.Printf("    %s\n", style.Dim.Render(s.Reason))
+	}
+
+	if !councilTuneApply {
+		fmt.Printf("\n%s\n", style.Dim.Render("Apply with: gt council tune --apply"))
+	}
+	return nil
+}
+
+func runCouncilSuggest(cmd *cobra.Command, args []string) error {
+	townRoot, err := workspace.FindFromCwd()
+	if err != nil {
+		return fmt.Errorf("finding town root: %w", err)
+	}
+
+	store, err := council.NewMetricsStore(townRoot)
+	if err != nil {
+		return fmt.Errorf("loading metrics: %w", err)
+	}
+
+	profile := store.SuggestProfile()
+	if councilShowJSON {
+		return renderOutput(profile)
+	}
+	if profile == nil {
+		fmt.Printf("%s\n", style.Dim.Render(fmt.Sprintf("No suggestion (needs at least %d tasks per role/model)", council.MinTuningSamples)))
+		return nil
+	}
+
+	var confirm func(string) bool
+	if !councilSuggestYes {
+		reader := bufio.NewReader(os.Stdin)
+		confirm = func(question string) bool { return promptConfirm(reader, question) }
+	}
+	return applySuggestedProfile(townRoot, profile, confirm)
+}
+
+// applySuggestedProfile merges a suggested profile's role models into the
+// town's config, prepending each suggested fallback to the role's existing
+// chain and leaving other roles and settings alone. The changes are shown
+// first and saved only if confirm (when non-nil) agrees.
+func applySuggestedProfile(townRoot string, profile *council.Profile, confirm func(string) bool) error {
+	config, err := council.LoadOrCreate(townRoot)
+	if err != nil {
+		return fmt.Errorf("loading council config: %w", err)
+	}
+
+	merged, err := config.Clone()
+	if err != nil {
+		return err
+	}
+	if merged.Roles == nil {
+		merged.Roles = make(map[string]*council.RoleConfig)
+	}
+	for role, suggested := range profile.Config.Roles {
+		rc := merged.Roles[role]
+		if rc == nil {
+			rc = &council.RoleConfig{}
+			merged.Roles[role] = rc
+		}
+		// The runner-up goes ahead of the existing chain rather than
+		// replacing it, so tuned roles keep their configured fallbacks.
+		var chain []string
+		for _, m := range append(append([]string(nil), suggested.Fallback...), rc.Fallback...) {
+			if m != suggested.Model && !slices.Contains(chain, m) {
+				chain = append(chain, m)
+			}
+		}
+		rc.Model = suggested.Model
+		rc.Fallback = chain
+	}
+	added := merged.EnsureProvidersForModels()
+
+	changes := council.DiffProfiles(config, merged)
+	if len(changes) == 0 {
+		fmt.Printf("%s Current config already uses the best-performing models\n", style.Success.Render("✓"))
+		return nil
+	}
+	fmt.Printf("%s\n", style.Bold.Render("Suggested changes:"))
+	printConfigChanges(changes)
+	if m := profile.Metrics; m != nil {
+		fmt.Printf("\n%s\n", style.Dim.Render(fmt.Sprintf("Based on %d tasks (%.0f%% success)", m.TotalTasks, m.SuccessRate*100)))
+	}
+	fmt.Println()
+
+	if confirm != nil && !confirm("Apply suggested profile?") {
+		fmt.Println("Not applied")
+		return nil
+	}
+	if err := council.SaveConfig(council.ConfigPath(townRoot), merged); err != nil {
+		return fmt.Errorf("saving council config: %w", err)
+	}
+	fmt.Printf("%s Applied suggested profile\n", style.Success.Render("✓"))
+	printAddedProviders(added)
+	return nil
+}
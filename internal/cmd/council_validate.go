@@ -0,0 +1,90 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/cursorworkshop/cursor-gastown/internal/council"
+	"github.com/cursorworkshop/cursor-gastown/internal/style"
+	"github.com/cursorworkshop/cursor-gastown/internal/workspace"
+)
+
+// councilValidationReport is the JSON shape of 'gt council validate --format json'.
+type councilValidationReport struct {
+	OK       bool     `json:"ok"`
+	Issues   []string `json:"issues"`
+	Warnings []string `json:"warnings"`
+}
+
+func runCouncilValidate(cmd *cobra.Command, args []string) error {
+	if councilValidFormat != "text" && councilValidFormat != "json" {
+		return fmt.Errorf("invalid --format %q (use text or json)", councilValidFormat)
+	}
+
+	var path string
+	if len(args) > 0 {
+		path = args[0]
+	} else {
+		townRoot, err := workspace.FindFromCwd()
+		if err != nil {
+			return fmt.Errorf("finding town root: %w", err)
+		}
+		path = council.ResolveConfigPath(townRoot)
+	}
+
+	report := validateCouncilConfigFile(path, councilValidStrict)
+
+	if councilValidFormat == "json" {
+		if err := renderOutput(report); err != nil {
+			return err
+		}
+	} else {
+		printValidationReport(path, report)
+	}
+
+	if !report.OK {
+		return NewSilentExit(1)
+	}
+	return nil
+}
+
+// validateCouncilConfigFile loads and checks a council config. A config
+// that fails to parse is reported as an issue rather than an error.
+func validateCouncilConfigFile(path string, strict bool) *councilValidationReport {
+	report := &councilValidationReport{Issues: []string{}, Warnings: []string{}}
+
+	cfg, err := council.LoadConfig(path)
+	if err != nil {
+		report.Issues = append(report.Issues, err.Error())
+	} else {
+		for _, issue := range cfg.Validate() {
+			if issue.Severity == council.SeverityError {
+				report.Issues = append(report.Issues, issue.Message)
+			} else {
+				report.Warnings = append(report.Warnings, issue.Message)
+			}
+		}
+	}
+
+	report.OK = len(report.Issues) == 0 && (!strict || len(report.Warnings) == 0)
+	return report
+}
+
+// printValidationReport prints a validation report in human-readable form.
+func printValidationReport(path string, report *councilValidationReport) {
+	fmt.Printf("%s %s\n\n", style.Bold.Render("Validating"), path)
+
+	for _, issue := range report.Issues {
+		fmt.Printf("  %s %s\n", style.Error.Render("✗"), issue)
+	}
+	for _, warning := range report.Warnings {
+		fmt.Printf("  %s %s\n", style.Warning.Render("⚠"), warning)
+	}
+
+	if len(report.Issues) == 0 && len(report.Warnings) == 0 {
+		fmt.Printf("  %s No issues found\n", style.Success.Render("✓"))
+		return
+	}
+
+	fmt.Printf("\n%d error(s), %d warning(s)\n", len(report.Issues), len(report.Warnings))
+}
@@ -0,0 +1,105 @@
+package cmd
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestRunCouncilValidate(t *testing.T) {
+	tests := []struct {
+		name         string
+		config       string
+		strict       bool
+		wantCode     int
+		wantIssues   int
+		wantWarnings bool
+	}{
+		{
+			name:     "clean config",
+			config:   "version = 1\n\n[roles.mayor]\nmodel = \"opus-4.5\"\nfallback = [\"gpt-5.2\"]\n",
+			strict:   true,
+			wantCode: 0,
+		},
+		{
+			name:       "error config",
+			config:     "version = 1\n\n[roles.mayor]\nmodel = \"\"\n",
+			wantCode:   1,
+			wantIssues: 1,
+		},
+		{
+			name:         "warnings only",
+			config:       "version = 1\n\n[roles.mayor]\nmodel = \"opus-4.5\"\nfallback = [\"sonnet-4.5\"]\n",
+			wantCode:     0,
+			wantWarnings: true,
+		},
+		{
+			name:         "warnings only strict",
+			config:       "version = 1\n\n[roles.mayor]\nmodel = \"opus-4.5\"\nfallback = [\"sonnet-4.5\"]\n",
+			strict:       true,
+			wantCode:     1,
+			wantWarnings: true,
+		},
+		{
+			name:         "unknown model",
+			config:       "version = 1\n\n[roles.mayor]\nmodel = \"made-up-model\"\n",
+			wantCode:     0,
+			wantWarnings: true,
+		},
+		{
+			name:       "unparseable config",
+			config:     "version = [\n",
+			wantCode:   1,
+			wantIssues: 1,
+		},
+	}
+
+	oldStrict, oldFormat := councilValidStrict, councilValidFormat
+	defer func() { councilValidStrict, councilValidFormat = oldStrict, oldFormat }()
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "council.toml")
+			if err := os.WriteFile(path, []byte(tt.config), 0644); err != nil {
+				t.Fatal(err)
+			}
+			councilValidStrict = tt.strict
+			councilValidFormat = "json"
+
+			var runErr error
+			out := captureStdout(t, func() {
+				runErr = runCouncilValidate(councilValidateCmd, []string{path})
+			})
+
+			code := 0
+			if runErr != nil {
+				var ok bool
+				if code, ok = IsSilentExit(runErr); !ok {
+					t.Fatalf("unexpected error: %v", runErr)
+				}
+			}
+			if code != tt.wantCode {
+				t.Errorf("exit code = %d, want %d", code, tt.wantCode)
+			}
+
+			var report struct {
+				OK       bool     `json:"ok"`
+				Issues   []string `json:"issues"`
+				Warnings []string `json:"warnings"`
+			}
+			if err := json.Unmarshal([]byte(out), &report); err != nil {
+				t.Fatalf("invalid JSON output %q: %v", out, err)
+			}
+			if report.OK != (tt.wantCode == 0) {
+				t.Errorf("ok = %v, want %v", report.OK, tt.wantCode == 0)
+			}
+			if len(report.Issues) != tt.wantIssues {
+				t.Errorf("issues = %v, want %d", report.Issues, tt.wantIssues)
+			}
+			if (len(report.Warnings) > 0) != tt.wantWarnings {
+				t.Errorf("warnings = %v, want present=%v", report.Warnings, tt.wantWarnings)
+			}
+		})
+	}
+}
@@ -0,0 +1,91 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/cursorworkshop/cursor-gastown/internal/council"
+	"github.com/cursorworkshop/cursor-gastown/internal/style"
+	"github.com/cursorworkshop/cursor-gastown/internal/workspace"
+)
+
+func runCouncilWhy(cmd *cobra.Command, args []string) error {
+	if len(args) == 0 && !councilWhyAll {
+		return fmt.Errorf("specify a role or use --all")
+	}
+
+	townRoot, err := workspace.FindFromCwd()
+	if err != nil {
+		return fmt.Errorf("finding town root: %w", err)
+	}
+
+	config, err := council.LoadOrCreate(townRoot)
+	if err != nil {
+		return fmt.Errorf("loading council config: %w", err)
+	}
+
+	roles := args
+	if councilWhyAll {
+		roles = getKnownRoles(config)
+	} else if _, ok := config.Roles[args[0]]; !ok {
+		return fmt.Errorf("unknown role: %s (known roles: %s)",
+			args[0], strings.Join(getKnownRoles(config), ", "))
+	}
+
+	explanations := make([]*council.Explanation, 0, len(roles))
+	for _, role := range roles {
+		explanations = append(explanations, config.RoleExplanation(role))
+	}
+
+	if councilShowJSON {
+		if !councilWhyAll {
+			return renderOutput(explanations[0])
+		}
+		return renderOutput(explanations)
+	}
+
+	for i, exp := range explanations {
+		if i > 0 {
+			fmt.Println()
+		}
+		printExplanation(exp)
+	}
+	return nil
+}
+
+// printExplanation prints a role explanation in human-readable form.
+func printExplanation(exp *council.Explanation) {
+	fmt.Printf("%s\n\n", style.Bold.Render("Why "+exp.Role+" uses "+exp.Model))
+
+	fmt.Printf("Model:     %s %s\n", exp.Model,
+		style.Dim.Render(fmt.Sprintf("(%s, priority %d, from %s config)", exp.Provider, exp.Priority, exp.Source)))
+	if exp.Rationale != "" {
+		fmt.Printf("Rationale: %s\n", exp.Rationale)
+	}
+
+	forceMode := "enabled"
+	if !exp.ForceMode {
+		forceMode = "disabled"
+	}
+	fmt.Printf("Force:     %s\n", forceMode)
+
+	if len(exp.Fallbacks) > 0 {
+		fmt.Printf("\n%s %s\n", style.Bold.Render("Fallback chain:"), style.Dim.Render("(from "+exp.FallbackSource+" config)"))
+		for i, fb := range exp.Fallbacks {
+			fmt.Printf("  %d. %s %s\n", i+1, fb.Model,
+				style.Dim.Render(fmt.Sprintf("(%s, priority %d)", fb.Provider, fb.Priority)))
+		}
+	}
+
+	if exp.Complexity != nil {
+		fmt.Printf("\n%s\n", style.Bold.Render("Complexity routing:"))
+		fmt.Printf("  High:   %s\n", exp.Complexity.High)
+		fmt.Printf("  Medium: %s\n", exp.Complexity.Medium)
+		fmt.Printf("  Low:    %s\n", exp.Complexity.Low)
+	}
+
+	if len(exp.Profiles) > 0 {
+		fmt.Printf("\n%s %s\n", style.Bold.Render("Same choice in profiles:"), strings.Join(exp.Profiles, ", "))
+	}
+}
@@ -17,27 +17,16 @@ import (
 	"github.com/cursorworkshop/cursor-gastown/internal/workspace"
 )
 
-// inferRigFromCwd tries to determine the rig from the current directory.
+// inferRigFromCwd tries to determine the rig from the current directory
+// (see workspace.InferRigFromPath).
 func inferRigFromCwd(townRoot string) (string, error) {
 	cwd, err := filepath.Abs(".")
 	if err != nil {
 		return "", err
 	}
-
-	// Check if cwd is within a rig
-	rel, err := filepath.Rel(townRoot, cwd)
-	if err != nil {
-		return "", fmt.Errorf("not in workspace")
-	}
-
-	// Normalize and split path - first component is the rig name
-	rel = filepath.ToSlash(rel)
-	parts := strings.Split(rel, "/")
-
-	if len(parts) > 0 && parts[0] != "" && parts[0] != "." {
-		return parts[0], nil
+	if rigName := workspace.InferRigFromPath(townRoot, cwd); rigName != "" {
+		return rigName, nil
 	}
-
 	return "", fmt.Errorf("could not infer rig from current directory")
 }
 
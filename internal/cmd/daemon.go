@@ -33,7 +33,12 @@ var daemonStartCmd = &cobra.Command{
 	Short: "Start the daemon",
 	Long: `Start the Gas Town daemon in the background.
 
-The daemon will run until stopped with 'gt daemon stop'.`,
+The daemon will run until stopped with 'gt daemon stop'.
+
+On startup the daemon validates the council config and beads version.
+Council config errors (an unparseable file or anything 'gt council
+validate' rejects) abort startup; lint findings and beads issues are
+printed as warnings, and abort startup too with --strict.`,
 	RunE: runDaemonStart,
 }
 
@@ -68,6 +73,7 @@ var daemonRunCmd = &cobra.Command{
 var (
 	daemonLogLines int
 	daemonLogFollow bool
+	daemonStrict    bool
 )
 
 func init() {
@@ -77,6 +83,9 @@ func init() {
 	daemonCmd.AddCommand(daemonLogsCmd)
 	daemonCmd.AddCommand(daemonRunCmd)
 
+	daemonStartCmd.Flags().BoolVar(&daemonStrict, "strict", false, "Refuse to start on any council config or beads issue")
+	daemonRunCmd.Flags().BoolVar(&daemonStrict, "strict", false, "Refuse to start on any council config or beads issue")
+
 	daemonLogsCmd.Flags().IntVarP(&daemonLogLines, "lines", "n", 50, "Number of lines to show")
 	daemonLogsCmd.Flags().BoolVarP(&daemonLogFollow, "follow", "f", false, "Follow log output")
 
@@ -98,6 +107,14 @@ func runDaemonStart(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("daemon already running (PID %d)", pid)
 	}
 
+	// Validate here too so a refused startup reports why
+	config := daemon.DefaultConfig(townRoot)
+	config.Strict = daemonStrict
+	config.BeadsCheck = CheckBeadsVersion
+	if err := daemon.Preflight(config, os.Stderr); err != nil {
+		return fmt.Errorf("daemon startup refused: %w", err)
+	}
+
 	// Start daemon in background
 	// We use 'gt daemon run' as the actual daemon process
 	gtPath, err := os.Executable()
@@ -105,7 +122,11 @@ func runDaemonStart(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("finding executable: %w", err)
 	}
 
-	daemonCmd := exec.Command(gtPath, "daemon", "run")
+	runArgs := []string{"daemon", "run"}
+	if daemonStrict {
+		runArgs = append(runArgs, "--strict")
+	}
+	daemonCmd := exec.Command(gtPath, runArgs...)
 	daemonCmd.Dir = townRoot
 
 	// Detach from terminal
@@ -258,6 +279,8 @@ func runDaemonRun(cmd *cobra.Command, args []string) error {
 	}
 
 	config := daemon.DefaultConfig(townRoot)
+	config.Strict = daemonStrict
+	config.BeadsCheck = CheckBeadsVersion
 	d, err := daemon.New(config)
 	if err != nil {
 		return fmt.Errorf("creating daemon: %w", err)
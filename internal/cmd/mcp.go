@@ -0,0 +1,297 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/cursorworkshop/cursor-gastown/internal/cursor"
+	"github.com/cursorworkshop/cursor-gastown/internal/style"
+)
+
+var (
+	mcpJSON        bool
+	mcpEffective   bool
+	mcpNoOverwrite bool
+)
+
+var mcpCmd = &cobra.Command{
+	Use:     "mcp",
+	GroupID: GroupConfig,
+	Short:   "Manage Cursor MCP server configuration",
+	Long: `Manage Cursor MCP (Model Context Protocol) server configuration.
+
+Workspace servers live in .cursor/mcp.json in the current directory.
+Global servers live in ~/.cursor/mcp.json and apply to every workspace.
+
+Commands:
+  gt mcp list               List workspace MCP servers
+  gt mcp list --effective   List the merged global + workspace servers
+  gt mcp diff               Report servers defined in both scopes
+  gt mcp apply <file>       Add or update servers from a file
+  gt mcp export <file>      Write the workspace servers to a file`,
+	RunE: requireSubcommand,
+}
+
+var mcpListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List configured MCP servers",
+	Long: `List MCP servers configured for the current workspace.
+
+With --effective, global and workspace configs are merged the way Cursor
+sees them (workspace wins on name clash), and servers defined in both
+scopes are reported as warnings. With --json, they are listed under
+"conflicts" next to "mcpServers".
+
+Examples:
+  gt mcp list
+  gt mcp list --effective
+  gt mcp list --json`,
+	RunE: runMCPList,
+}
+
+var mcpDiffCmd = &cobra.Command{
+	Use:   "diff",
+	Short: "Compare global and workspace MCP servers",
+	Long: `Report MCP servers defined in both the global and the workspace mcp.json.
+
+Identical definitions are redundant and can be removed from the workspace.
+Divergent definitions are listed with the fields that differ; the workspace
+definition is the one Cursor uses. Interpolation (${env:NAME},
+${workspaceFolder}, ${userHome}) is resolved before comparing.
+
+Examples:
+  gt mcp diff
+  gt mcp diff --json`,
+	RunE: runMCPDiff,
+}
+
+var mcpApplyCmd = &cobra.Command{
+	Use:   "apply <file>",
+	Short: "Add or update MCP servers from a file",
+	Long: `Apply a declarative list of MCP servers to the workspace mcp.json.
+
+The file uses the mcp.json layout: a JSON object with an "mcpServers" map,
+or a .toml file with [mcpServers.<name>] tables. New servers are added and
+existing ones are replaced unless --no-overwrite is given. Every server
+needs a command or url; if any does not, nothing is written.
+
+Examples:
+  gt mcp apply team-servers.json
+  gt mcp apply team-servers.toml --no-overwrite
+  gt mcp export team-servers.json   # produce a file from this workspace`,
+	Args: cobra.ExactArgs(1),
+	RunE: runMCPApply,
+}
+
+var mcpExportCmd = &cobra.Command{
+	Use:   "export <file>",
+	Short: "Write MCP servers to a file",
+	Long: `Write the workspace MCP servers to a file for reuse with 'gt mcp apply'.
+
+Files ending in .toml are written as TOML; anything else as JSON. With
+--effective, the merged global + workspace servers are exported.
+
+Examples:
+  gt mcp export team-servers.json
+  gt mcp export team-servers.toml --effective`,
+	Args: cobra.ExactArgs(1),
+	RunE: runMCPExport,
+}
+
+func init() {
+	mcpListCmd.Flags().BoolVar(&mcpJSON, "json", false, "Output as JSON")
+	mcpListCmd.Flags().BoolVar(&mcpEffective, "effective", false, "Merge global and workspace configs")
+	mcpDiffCmd.Flags().BoolVar(&mcpJSON, "json", false, "Output as JSON")
+	mcpApplyCmd.Flags().BoolVar(&mcpNoOverwrite, "no-overwrite", false, "Keep existing servers with the same name")
+	mcpApplyCmd.Flags().BoolVar(&mcpJSON, "json", false, "Output as JSON")
+	mcpExportCmd.Flags().BoolVar(&mcpEffective, "effective", false, "Merge global and workspace configs")
+
+	mcpCmd.AddCommand(mcpListCmd)
+	mcpCmd.AddCommand(mcpDiffCmd)
+	mcpCmd.AddCommand(mcpApplyCmd)
+	mcpCmd.AddCommand(mcpExportCmd)
+	rootCmd.AddCommand(mcpCmd)
+}
+
+// effectiveMCPList is the JSON form of "gt mcp list --effective": the merged
+// config plus the names defined in both scopes, where the workspace wins.
+type effectiveMCPList struct {
+	*cursor.MCPConfig
+	Conflicts []string `json:"conflicts"`
+}
+
+func runMCPList(cmd *cobra.Command, args []string) error {
+	workDir, err := os.Getwd()
+	if err != nil {
+		return fmt.Errorf("getting current directory: %w", err)
+	}
+
+	cfg, conflicts, err := loadMCPConfigForScope(workDir, mcpEffective)
+	if err != nil {
+		return err
+	}
+
+	if mcpJSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		if !mcpEffective {
+			return enc.Encode(cfg)
+		}
+		if conflicts == nil {
+			conflicts = []string{}
+		}
+		return enc.Encode(effectiveMCPList{MCPConfig: cfg, Conflicts: conflicts})
+	}
+
+	names := make([]string, 0, len(cfg.McpServers))
+	for name := range cfg.McpServers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	if len(names) == 0 {
+		fmt.Printf("%s\n", style.Dim.Render("No MCP servers configured."))
+		return nil
+	}
+
+	fmt.Printf("%s\n\n", style.Bold.Render("MCP Servers"))
+	for _, name := range names {
+		server := cfg.McpServers[name]
+		target := server.URL
+		if server.Command != "" {
+			target = strings.TrimSpace(server.Command + " " + strings.Join(server.Args, " "))
+		}
+		fmt.Printf("  %-20s %-6s %s\n", style.Bold.Render(name), server.MCPServerType(), style.Dim.Render(target))
+	}
+
+	if len(conflicts) > 0 {
+		fmt.Println()
+		style.PrintWarning("defined in both global and workspace config (workspace wins): %s", strings.Join(conflicts, ", "))
+	}
+
+	return nil
+}
+
+func runMCPDiff(cmd *cobra.Command, args []string) error {
+	workDir, err := os.Getwd()
+	if err != nil {
+		return fmt.Errorf("getting current directory: %w", err)
+	}
+
+	conflicts, err := cursor.CompareMCPScopes(workDir)
+	if err != nil {
+		return err
+	}
+
+	if mcpJSON {
+		if conflicts == nil {
+			conflicts = []cursor.MCPScopeConflict{}
+		}
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(conflicts)
+	}
+
+	if len(conflicts) == 0 {
+		fmt.Printf("%s\n", style.Dim.Render("No MCP servers are defined in both global and workspace config."))
+		return nil
+	}
+
+	fmt.Printf("%s\n\n", style.Bold.Render("MCP Servers in Both Scopes"))
+	for _, c := range conflicts {
+		if c.Identical {
+			fmt.Printf("  %s %-20s %s\n", style.Dim.Render("="), c.Name, style.Dim.Render("identical (workspace entry is redundant)"))
+			continue
+		}
+		fmt.Printf("  %s %-20s %s\n", style.Warning.Render("⚠"), c.Name, "differs in: "+strings.Join(c.Fields, ", "))
+	}
+	fmt.Printf("\n%s\n", style.Dim.Render("The workspace definition wins on name clash."))
+
+	return nil
+}
+
+// loadMCPConfigForScope loads the workspace MCP config, merged over the
+// global config when effective is set. It also returns the names defined
+// in both scopes.
+func loadMCPConfigForScope(workDir string, effective bool) (*cursor.MCPConfig, []string, error) {
+	cfg, err := cursor.LoadMCPConfig(cursor.MCPConfigPath(workDir))
+	if err != nil || !effective {
+		return cfg, nil, err
+	}
+
+	globalPath, err := cursor.GlobalMCPConfigPath()
+	if err != nil {
+		return nil, nil, err
+	}
+	global, err := cursor.LoadMCPConfig(globalPath)
+	if err != nil {
+		return nil, nil, err
+	}
+	return cursor.MergeMCPConfigsWithStrategy(cursor.MergeOverride, global, cfg)
+}
+
+func runMCPApply(cmd *cobra.Command, args []string) error {
+	workDir, err := os.Getwd()
+	if err != nil {
+		return fmt.Errorf("getting current directory: %w", err)
+	}
+
+	servers, err := cursor.LoadMCPServersFile(args[0])
+	if err != nil {
+		return err
+	}
+
+	result, err := cursor.ApplyMCPServersWithResult(workDir, servers, !mcpNoOverwrite)
+	if err != nil {
+		return err
+	}
+	return printMCPApplyResult(result, mcpJSON)
+}
+
+// printMCPApplyResult renders what an apply changed as text or JSON.
+func printMCPApplyResult(result *cursor.MCPApplyResult, asJSON bool) error {
+	if asJSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(result)
+	}
+
+	for _, name := range result.Added {
+		fmt.Printf("  %s %-20s %s\n", style.Success.Render("✓"), name, style.Dim.Render("added"))
+	}
+	for _, name := range result.Updated {
+		fmt.Printf("  %s %-20s %s\n", style.Success.Render("✓"), name, style.Dim.Render("updated"))
+	}
+	for _, name := range result.Unchanged {
+		fmt.Printf("  %s %-20s %s\n", style.Dim.Render("="), name, style.Dim.Render("unchanged"))
+	}
+	for _, name := range result.Skipped {
+		fmt.Printf("  %s %-20s %s\n", style.Warning.Render("⚠"), name, style.Dim.Render("exists, skipped (--no-overwrite)"))
+	}
+
+	fmt.Printf("\n%d added, %d updated, %d unchanged, %d skipped\n",
+		len(result.Added), len(result.Updated), len(result.Unchanged), len(result.Skipped))
+	return nil
+}
+
+func runMCPExport(cmd *cobra.Command, args []string) error {
+	workDir, err := os.Getwd()
+	if err != nil {
+		return fmt.Errorf("getting current directory: %w", err)
+	}
+
+	cfg, _, err := loadMCPConfigForScope(workDir, mcpEffective)
+	if err != nil {
+		return err
+	}
+	if err := cursor.WriteMCPServersFile(args[0], cfg); err != nil {
+		return err
+	}
+
+	fmt.Printf("%s Exported %d MCP server(s) to %s\n", style.Success.Render("✓"), len(cfg.McpServers), args[0])
+	return nil
+}
@@ -0,0 +1,59 @@
+package cmd
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestRunMCPList_EffectiveJSONIncludesConflicts(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("USERPROFILE", home)
+	workDir := t.TempDir()
+
+	writeMCP := func(dir, body string) {
+		t.Helper()
+		if err := os.MkdirAll(filepath.Join(dir, ".cursor"), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(dir, ".cursor", "mcp.json"), []byte(body), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	writeMCP(home, `{"mcpServers": {"shared": {"command": "old"}, "global-only": {"url": "https://example.com"}}}`)
+	writeMCP(workDir, `{"mcpServers": {"shared": {"command": "new"}}}`)
+
+	oldWd, _ := os.Getwd()
+	if err := os.Chdir(workDir); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = os.Chdir(oldWd) })
+
+	oldJSON, oldEffective := mcpJSON, mcpEffective
+	mcpJSON, mcpEffective = true, true
+	t.Cleanup(func() { mcpJSON, mcpEffective = oldJSON, oldEffective })
+
+	var runErr error
+	out := captureStdout(t, func() { runErr = runMCPList(nil, nil) })
+	if runErr != nil {
+		t.Fatalf("runMCPList: %v", runErr)
+	}
+
+	var got struct {
+		McpServers map[string]struct {
+			Command string `json:"command"`
+		} `json:"mcpServers"`
+		Conflicts []string `json:"conflicts"`
+	}
+	if err := json.Unmarshal([]byte(out), &got); err != nil {
+		t.Fatalf("decoding output %q: %v", out, err)
+	}
+	if len(got.McpServers) != 2 || got.McpServers["shared"].Command != "new" {
+		t.Errorf("mcpServers = %+v, want merged servers with workspace winning", got.McpServers)
+	}
+	if len(got.Conflicts) != 1 || got.Conflicts[0] != "shared" {
+		t.Errorf("conflicts = %v, want [shared]", got.Conflicts)
+	}
+}
@@ -6,6 +6,8 @@ import (
 	"strings"
 
 	"github.com/spf13/cobra"
+	"github.com/cursorworkshop/cursor-gastown/internal/council"
+	"github.com/cursorworkshop/cursor-gastown/internal/workspace"
 )
 
 var rootCmd = &cobra.Command{
@@ -16,7 +18,41 @@ var rootCmd = &cobra.Command{
 
 It coordinates agent spawning, work distribution, and communication
 across distributed teams of AI agents working on shared codebases.`,
-	PersistentPreRunE: checkBeadsDependency,
+	PersistentPreRunE: persistentPreRun,
+}
+
+// noNetwork is the global --no-network flag.
+var noNetwork bool
+
+// councilConfigPath is the global --config flag.
+var councilConfigPath string
+
+// persistentPreRun applies global flags and town-level council settings,
+// then checks the beads dependency.
+func persistentPreRun(cmd *cobra.Command, args []string) error {
+	if noNetwork {
+		council.SetNetworkDisabled(true)
+	}
+	if councilConfigPath != "" {
+		council.SetConfigPathOverride(councilConfigPath)
+	}
+	applyCouncilRoleTypes()
+	return checkBeadsDependency(cmd, args)
+}
+
+// applyCouncilRoleTypes registers custom role types from the town's council
+// config so workspaces for those roles get the right Cursor rules. Outside a
+// town, or with an unreadable config, the built-in role types apply.
+func applyCouncilRoleTypes() {
+	townRoot, err := workspace.FindFromCwd()
+	if err != nil || townRoot == "" {
+		return
+	}
+	cfg, err := council.Load(townRoot)
+	if err != nil {
+		return
+	}
+	cfg.ApplyRoleTypes()
 }
 
 // Commands that don't require beads to be installed/checked.
@@ -25,6 +61,7 @@ var beadsExemptCommands = map[string]bool{
 	"version":    true,
 	"help":       true,
 	"completion": true,
+	"schema":     true, // gt council schema only prints the config types
 }
 
 // checkBeadsDependency verifies beads meets minimum version requirements.
@@ -86,8 +123,11 @@ func init() {
 	rootCmd.SetHelpCommandGroupID(GroupDiag)
 	rootCmd.SetCompletionCommandGroupID(GroupConfig)
 
-	// Global flags can be added here
-	// rootCmd.PersistentFlags().StringVar(&cfgFile, "config", "", "config file")
+	// Global flags
+	rootCmd.PersistentFlags().BoolVar(&noNetwork, "no-network", false,
+		"Disable network calls (health checks, profile URLs, model refresh); also "+council.NoNetworkEnv+"=1")
+	rootCmd.PersistentFlags().StringVar(&councilConfigPath, "config", "",
+		"Council config file to load and save instead of .beads/council.toml; also "+council.ConfigPathEnv)
 }
 
 // buildCommandPath walks the command hierarchy to build the full command path.
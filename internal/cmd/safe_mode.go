@@ -0,0 +1,78 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/cursorworkshop/cursor-gastown/internal/config"
+	"github.com/cursorworkshop/cursor-gastown/internal/style"
+	"github.com/cursorworkshop/cursor-gastown/internal/workspace"
+)
+
+var safeModeCmd = &cobra.Command{
+	Use:     "safe-mode [on|off|status]",
+	GroupID: GroupConfig,
+	Short:   "Toggle town-wide safe mode (no autonomous agents)",
+	Long: `Control town-wide safe mode, a kill switch for incident response.
+
+While safe mode is on, every agent built from this town runs
+interactively: force mode and MCP auto-approval are off, and session
+commands drop autonomy flags such as -f. Running agents keep their
+current mode; new sessions and daemon-started agents pick up the
+change immediately.
+
+The flag is stored in .beads/safe-mode under the town root.
+
+Subcommands:
+  on      Enable safe mode
+  off     Disable safe mode (restore configured defaults)
+  status  Show whether safe mode is on (default)`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runSafeMode,
+}
+
+func init() {
+	rootCmd.AddCommand(safeModeCmd)
+}
+
+func runSafeMode(cmd *cobra.Command, args []string) error {
+	townRoot, err := workspace.FindFromCwdOrError()
+	if err != nil {
+		return fmt.Errorf("not in a Gas Town workspace: %w", err)
+	}
+
+	action := "status"
+	if len(args) > 0 {
+		action = args[0]
+	}
+
+	switch action {
+	case "on":
+		if err := config.SetSafeMode(townRoot, true); err != nil {
+			return fmt.Errorf("enabling safe mode: %w", err)
+		}
+		fmt.Printf("%s Safe mode enabled - agents will run interactively\n", style.SuccessPrefix)
+		fmt.Printf("  Run %s to restore autonomous operation\n", style.Bold.Render("gt safe-mode off"))
+
+	case "off":
+		if err := config.SetSafeMode(townRoot, false); err != nil {
+			return fmt.Errorf("disabling safe mode: %w", err)
+		}
+		fmt.Printf("%s Safe mode disabled - configured defaults restored\n", style.SuccessPrefix)
+
+	case "status":
+		if !config.SafeModeEnabled(townRoot) {
+			fmt.Printf("Safe mode: %s\n", style.Bold.Render("off"))
+			return nil
+		}
+		fmt.Printf("Safe mode: %s\n", style.Warning.Render("on"))
+		if since := config.SafeModeSince(townRoot); !since.IsZero() {
+			fmt.Printf("  %s\n", style.Dim.Render("since "+since.Local().Format("2006-01-02 15:04:05")))
+		}
+
+	default:
+		return fmt.Errorf("unknown action %q: use on, off, or status", action)
+	}
+
+	return nil
+}
@@ -11,6 +11,8 @@ import (
 
 	"github.com/spf13/cobra"
 	"github.com/cursorworkshop/cursor-gastown/internal/config"
+	"github.com/cursorworkshop/cursor-gastown/internal/constants"
+	"github.com/cursorworkshop/cursor-gastown/internal/cursor"
 	"github.com/cursorworkshop/cursor-gastown/internal/git"
 	"github.com/cursorworkshop/cursor-gastown/internal/polecat"
 	"github.com/cursorworkshop/cursor-gastown/internal/rig"
@@ -23,13 +25,18 @@ import (
 
 // Session command flags
 var (
-	sessionIssue     string
-	sessionForce     bool
-	sessionLines     int
-	sessionMessage   string
-	sessionFile      string
-	sessionRigFilter string
-	sessionListJSON  bool
+	sessionIssue      string
+	sessionForce      bool
+	sessionLines      int
+	sessionMessage    string
+	sessionFile       string
+	sessionRigFilter  string
+	sessionListJSON   bool
+	sessionListAll    bool
+	sessionListSort   string
+	sessionListLimit  int
+	sessionListOffset int
+	sessionListTag    string
 )
 
 var sessionCmd = &cobra.Command{
@@ -89,7 +96,19 @@ var sessionListCmd = &cobra.Command{
 	Short: "List all sessions",
 	Long: `List all running polecat sessions.
 
-Shows session status, rig, and polecat name. Use --rig to filter by rig.`,
+Shows session status, rig, and polecat name. Use --rig to filter by rig.
+
+Without --rig, the list is limited to $GASTOWN_DEFAULT_RIG if set, or to
+the rig containing the current directory. Use --all to list every rig.
+
+Sessions are listed by ID. Use --sort created|active|role to reorder
+(newest first for created and active; role groups sessions by rig) and
+--limit/--offset to page through large towns; ties always break by ID so
+pages never overlap.
+
+Use --tag to show only polecats whose cursor-agent sessions carry a tag.
+Tags are matched case-insensitively against the sessions recorded in the
+polecat's .runtime directory.`,
 	RunE: runSessionList,
 }
 
@@ -174,8 +193,13 @@ func init() {
 	sessionStopCmd.Flags().BoolVarP(&sessionForce, "force", "f", false, "Force immediate shutdown")
 
 	// List flags
-	sessionListCmd.Flags().StringVar(&sessionRigFilter, "rig", "", "Filter by rig name")
+	sessionListCmd.Flags().StringVar(&sessionRigFilter, "rig", "", "Filter by rig name (default: $GASTOWN_DEFAULT_RIG or the current rig)")
+	sessionListCmd.Flags().BoolVar(&sessionListAll, "all", false, "List sessions in every rig")
 	sessionListCmd.Flags().BoolVar(&sessionListJSON, "json", false, "Output as JSON")
+	sessionListCmd.Flags().StringVar(&sessionListSort, "sort", "", "Sort by created, active, or role (default: ID)")
+	sessionListCmd.Flags().IntVar(&sessionListLimit, "limit", 0, "Maximum sessions to show (0 = all)")
+	sessionListCmd.Flags().IntVar(&sessionListOffset, "offset", 0, "Number of sessions to skip")
+	sessionListCmd.Flags().StringVar(&sessionListTag, "tag", "", "Only show polecats with a cursor-agent session carrying this tag")
 
 	// Capture flags
 	sessionCaptureCmd.Flags().IntVarP(&sessionLines, "lines", "n", 100, "Number of lines to capture")
@@ -209,20 +233,24 @@ func parseAddress(addr string) (rigName, polecatName string, err error) {
 		return parts[0], parts[1], nil
 	}
 
-	// No slash - try to infer rig from cwd
+	// No slash - use the default rig or infer it from cwd
 	if !strings.Contains(addr, "/") && addr != "" {
-		townRoot, err := workspace.FindFromCwd()
-		if err == nil && townRoot != "" {
-			inferredRig, err := inferRigFromCwd(townRoot)
-			if err == nil && inferredRig != "" {
-				return inferredRig, addr, nil
-			}
+		if inferredRig := defaultSessionRig(""); inferredRig != "" {
+			return inferredRig, addr, nil
 		}
 	}
 
 	return "", "", fmt.Errorf("invalid address format: expected 'rig/polecat', got '%s'", addr)
 }
 
+// defaultSessionRig resolves the rig for session commands: the --rig flag
+// value, then GASTOWN_DEFAULT_RIG, then the rig containing the cwd.
+func defaultSessionRig(flag string) string {
+	townRoot, _ := workspace.FindFromCwd()
+	cwd, _ := os.Getwd()
+	return workspace.ResolveRig(flag, townRoot, cwd)
+}
+
 // getSessionManager creates a session manager for the given rig.
 func getSessionManager(rigName string) (*polecat.SessionManager, *rig.Rig, error) {
 	_, r, err := getRig(rigName)
@@ -341,9 +369,58 @@ type SessionListItem struct {
 	Polecat   string `json:"polecat"`
 	SessionID string `json:"session_id"`
 	Running   bool   `json:"running"`
+
+	// created and lastActive are only filled when sorting by time.
+	created    time.Time
+	lastActive time.Time
+}
+
+// pageSessionList orders and slices list items with cursor.PaginateSessions.
+// Every item is a polecat session, so each gets its rig-qualified role
+// (e.g. "gastown/polecat") and the role sort orders by rig.
+func pageSessionList(items []SessionListItem, opts cursor.PageOpts) []SessionListItem {
+	byID := make(map[string]SessionListItem, len(items))
+	sessions := make([]*cursor.Session, 0, len(items))
+	for _, item := range items {
+		byID[item.SessionID] = item
+		sessions = append(sessions, &cursor.Session{
+			ID:           item.SessionID,
+			Role:         item.Rig + "/polecat",
+			RigName:      item.Rig,
+			CreatedAt:    item.created,
+			LastActiveAt: item.lastActive,
+		})
+	}
+
+	page := cursor.PaginateSessions(sessions, opts)
+	result := make([]SessionListItem, 0, len(page))
+	for _, sess := range page {
+		result = append(result, byID[sess.ID])
+	}
+	return result
+}
+
+// polecatHasTag reports whether any cursor-agent session recorded in the
+// polecat's .runtime directory carries tag.
+func polecatHasTag(rigPath, polecatName, tag string) bool {
+	store, err := cursor.NewSessionStore(filepath.Join(rigPath, "polecats", polecatName, constants.DirRuntime))
+	if err != nil {
+		return false
+	}
+	return len(store.ByTag(tag)) > 0
 }
 
 func runSessionList(cmd *cobra.Command, args []string) error {
+	switch sessionListSort {
+	case "", cursor.SessionSortID, cursor.SessionSortCreated, cursor.SessionSortActive, cursor.SessionSortRole:
+	default:
+		return fmt.Errorf("invalid --sort %q (want created, active, or role)", sessionListSort)
+	}
+	if sessionListLimit < 0 || sessionListOffset < 0 {
+		return fmt.Errorf("--limit and --offset must not be negative")
+	}
+	byTime := sessionListSort == cursor.SessionSortCreated || sessionListSort == cursor.SessionSortActive
+
 	// Find town root
 	townRoot, err := workspace.FindFromCwdOrError()
 	if err != nil {
@@ -365,11 +442,15 @@ func runSessionList(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("discovering rigs: %w", err)
 	}
 
-	// Filter if requested
-	if sessionRigFilter != "" {
+	// Filter to the requested or default rig
+	rigFilter := ""
+	if !sessionListAll {
+		rigFilter = defaultSessionRig(sessionRigFilter)
+	}
+	if rigFilter != "" {
 		var filtered []*rig.Rig
 		for _, r := range rigs {
-			if r.Name == sessionRigFilter {
+			if r.Name == rigFilter {
 				filtered = append(filtered, r)
 			}
 		}
@@ -388,15 +469,31 @@ func runSessionList(cmd *cobra.Command, args []string) error {
 		}
 
 		for _, info := range infos {
-			allSessions = append(allSessions, SessionListItem{
+			if sessionListTag != "" && !polecatHasTag(r.Path, info.Polecat, sessionListTag) {
+				continue
+			}
+			item := SessionListItem{
 				Rig:       r.Name,
 				Polecat:   info.Polecat,
 				SessionID: info.SessionID,
 				Running:   info.Running,
-			})
+			}
+			if byTime {
+				if status, err := polecatMgr.Status(info.Polecat); err == nil {
+					item.created = status.Created
+					item.lastActive = status.LastActivity
+				}
+			}
+			allSessions = append(allSessions, item)
 		}
 	}
 
+	allSessions = pageSessionList(allSessions, cursor.PageOpts{
+		Sort:   sessionListSort,
+		Offset: sessionListOffset,
+		Limit:  sessionListLimit,
+	})
+
 	// Output
 	if sessionListJSON {
 		enc := json.NewEncoder(os.Stdout)
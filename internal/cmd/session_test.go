@@ -0,0 +1,50 @@
+package cmd
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/cursorworkshop/cursor-gastown/internal/constants"
+	"github.com/cursorworkshop/cursor-gastown/internal/cursor"
+)
+
+func TestPageSessionList_RoleSortOrdersByRig(t *testing.T) {
+	items := []SessionListItem{
+		{Rig: "zeta", Polecat: "a", SessionID: "gt-a"},
+		{Rig: "alpha", Polecat: "b", SessionID: "gt-b"},
+		{Rig: "mid", Polecat: "c", SessionID: "gt-c"},
+	}
+
+	var rigs []string
+	for _, item := range pageSessionList(items, cursor.PageOpts{Sort: cursor.SessionSortRole}) {
+		rigs = append(rigs, item.Rig)
+	}
+	if got := strings.Join(rigs, ","); got != "alpha,mid,zeta" {
+		t.Errorf("role sort = %s, want alpha,mid,zeta", got)
+	}
+}
+
+func TestPolecatHasTag(t *testing.T) {
+	rigPath := t.TempDir()
+	store, err := cursor.NewSessionStore(filepath.Join(rigPath, "polecats", "toast", constants.DirRuntime))
+	if err != nil {
+		t.Fatal(err)
+	}
+	sess := cursor.SessionFromEnv(rigPath, "polecat", "gastown")
+	sess.ID = "chat-1"
+	sess.AddTag("Refactor-Auth")
+	if err := store.Put(sess); err != nil {
+		t.Fatal(err)
+	}
+
+	if !polecatHasTag(rigPath, "toast", " refactor-auth") {
+		t.Error("toast should match its normalized tag")
+	}
+	if polecatHasTag(rigPath, "toast", "spike") {
+		t.Error("toast should not match a tag it lacks")
+	}
+	if polecatHasTag(rigPath, "nux", "refactor-auth") {
+		t.Error("a polecat without sessions should not match")
+	}
+}
@@ -1,12 +1,17 @@
 package cmd
 
 import (
+	"encoding/json"
 	"fmt"
+	"os"
 	"os/exec"
+	"runtime"
 	"runtime/debug"
 	"strings"
 
 	"github.com/spf13/cobra"
+	"github.com/cursorworkshop/cursor-gastown/internal/cursor"
+	"github.com/cursorworkshop/cursor-gastown/internal/style"
 )
 
 // Version information - set at build time via ldflags
@@ -19,28 +24,101 @@ var (
 	Branch = ""
 )
 
+var versionJSON bool
+
 var versionCmd = &cobra.Command{
 	Use:     "version",
 	GroupID: GroupDiag,
 	Short:   "Print version information",
-	Run: func(cmd *cobra.Command, args []string) {
-		commit := resolveCommitHash()
-		branch := resolveBranch()
-
-		if commit != "" && branch != "" {
-			fmt.Printf("gt version %s (%s: %s@%s)\n", Version, Build, branch, shortCommit(commit))
-		} else if commit != "" {
-			fmt.Printf("gt version %s (%s: %s)\n", Version, Build, shortCommit(commit))
-		} else {
-			fmt.Printf("gt version %s (%s)\n", Version, Build)
-		}
+	Long: `Print the gt version along with the versions of the tools it drives.
+
+Reports the gt build, the detected beads (bd) and cursor-agent versions,
+and the Go runtime and platform. Missing components are reported rather
+than treated as errors, so this is safe to paste into a bug report.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return printVersionInfo(collectVersionInfo(), versionJSON)
 	},
 }
 
 func init() {
+	versionCmd.Flags().BoolVar(&versionJSON, "json", false, "Output as JSON")
 	rootCmd.AddCommand(versionCmd)
 }
 
+// componentVersion is the detected version of an external tool.
+type componentVersion struct {
+	Available bool   `json:"available"`
+	Version   string `json:"version,omitempty"`
+	Error     string `json:"error,omitempty"`
+}
+
+// versionInfo is everything `gt version` reports.
+type versionInfo struct {
+	Version     string           `json:"version"`
+	Build       string           `json:"build"`
+	Commit      string           `json:"commit,omitempty"`
+	Branch      string           `json:"branch,omitempty"`
+	GoVersion   string           `json:"go_version"`
+	OS          string           `json:"os"`
+	Arch        string           `json:"arch"`
+	Beads       componentVersion `json:"beads"`
+	CursorAgent componentVersion `json:"cursor_agent"`
+}
+
+// collectVersionInfo gathers the gt build info and probes bd and
+// cursor-agent. Probe failures are recorded on the component.
+func collectVersionInfo() versionInfo {
+	return versionInfo{
+		Version:     Version,
+		Build:       Build,
+		Commit:      resolveCommitHash(),
+		Branch:      resolveBranch(),
+		GoVersion:   runtime.Version(),
+		OS:          runtime.GOOS,
+		Arch:        runtime.GOARCH,
+		Beads:       probeComponent(getBeadsVersion),
+		CursorAgent: probeComponent(cursor.Version),
+	}
+}
+
+func probeComponent(version func() (string, error)) componentVersion {
+	v, err := version()
+	if err != nil {
+		return componentVersion{Error: strings.TrimSpace(err.Error())}
+	}
+	return componentVersion{Available: true, Version: v}
+}
+
+func printVersionInfo(info versionInfo, asJSON bool) error {
+	if asJSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(info)
+	}
+
+	switch {
+	case info.Commit != "" && info.Branch != "":
+		fmt.Printf("gt version %s (%s: %s@%s)\n", info.Version, info.Build, info.Branch, shortCommit(info.Commit))
+	case info.Commit != "":
+		fmt.Printf("gt version %s (%s: %s)\n", info.Version, info.Build, shortCommit(info.Commit))
+	default:
+		fmt.Printf("gt version %s (%s)\n", info.Version, info.Build)
+	}
+
+	printComponentVersion("beads", info.Beads)
+	printComponentVersion("cursor-agent", info.CursorAgent)
+	fmt.Printf("  %-13s %s %s/%s\n", "go", info.GoVersion, info.OS, info.Arch)
+	return nil
+}
+
+func printComponentVersion(name string, c componentVersion) {
+	if c.Available {
+		fmt.Printf("  %-13s %s\n", name, c.Version)
+		return
+	}
+	fmt.Printf("  %-13s %s\n", name, style.Dim.Render("not found"))
+}
+
 func resolveCommitHash() string {
 	if Commit != "" {
 		return Commit
@@ -0,0 +1,61 @@
+package cmd
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestPrintVersionInfo_JSON(t *testing.T) {
+	binDir := t.TempDir()
+	t.Setenv("PATH", binDir)
+	t.Setenv("XDG_CACHE_HOME", t.TempDir())
+
+	// Only cursor-agent is installed; bd is missing.
+	script := "#!/bin/sh\necho '2025.11.25-abc123'\n"
+	if err := os.WriteFile(filepath.Join(binDir, "cursor-agent"), []byte(script), 0755); err != nil {
+		t.Fatalf("write stub: %v", err)
+	}
+
+	var err error
+	out := captureStdout(t, func() {
+		err = printVersionInfo(collectVersionInfo(), true)
+	})
+	if err != nil {
+		t.Fatalf("printVersionInfo failed: %v", err)
+	}
+
+	var got map[string]any
+	if err := json.Unmarshal([]byte(out), &got); err != nil {
+		t.Fatalf("output is not JSON: %v\n%s", err, out)
+	}
+	for _, field := range []string{"version", "build", "go_version", "os", "arch", "beads", "cursor_agent"} {
+		if _, ok := got[field]; !ok {
+			t.Errorf("JSON missing %q: %s", field, out)
+		}
+	}
+
+	beads, _ := got["beads"].(map[string]any)
+	if beads["available"] != false || beads["error"] == "" || beads["error"] == nil {
+		t.Errorf("beads = %v, want unavailable with an error", beads)
+	}
+	agent, _ := got["cursor_agent"].(map[string]any)
+	if agent["available"] != true || agent["version"] != "2025.11.25-abc123" {
+		t.Errorf("cursor_agent = %v, want available 2025.11.25-abc123", agent)
+	}
+}
+
+func TestPrintVersionInfo_TextMissingComponents(t *testing.T) {
+	info := versionInfo{Version: "1.2.3", Build: "dev", GoVersion: "go1.24", OS: "linux", Arch: "amd64"}
+	out := captureStdout(t, func() {
+		_ = printVersionInfo(info, false)
+	})
+	if !strings.HasPrefix(out, "gt version 1.2.3 (dev)\n") {
+		t.Errorf("first line changed: %q", out)
+	}
+	if strings.Count(out, "not found") != 2 {
+		t.Errorf("expected both components marked not found:\n%s", out)
+	}
+}
@@ -802,7 +802,17 @@ func SaveTownSettings(path string, settings *TownSettings) error {
 //
 // townRoot is the path to the town directory (e.g., ~/gt).
 // rigPath is the path to the rig directory (e.g., ~/gt/gastown).
+//
+// While the town is in safe mode the result is Supervised.
 func ResolveAgentConfig(townRoot, rigPath string) *RuntimeConfig {
+	rc := resolveAgentConfig(townRoot, rigPath)
+	if SafeModeEnabled(townRoot) {
+		return rc.Supervised()
+	}
+	return rc
+}
+
+func resolveAgentConfig(townRoot, rigPath string) *RuntimeConfig {
 	// Load rig settings
 	rigSettings, err := LoadRigSettings(RigSettingsPath(rigPath))
 	if err != nil {
@@ -842,7 +852,16 @@ func ResolveAgentConfig(townRoot, rigPath string) *RuntimeConfig {
 // If agentOverride is non-empty, it is used instead of rig/town defaults.
 // Returns the resolved RuntimeConfig, the selected agent name, and an error if the override name
 // does not exist in town custom agents or built-in presets.
+// While the town is in safe mode the result is Supervised.
 func ResolveAgentConfigWithOverride(townRoot, rigPath, agentOverride string) (*RuntimeConfig, string, error) {
+	rc, name, err := resolveAgentConfigWithOverride(townRoot, rigPath, agentOverride)
+	if err == nil && SafeModeEnabled(townRoot) {
+		rc = rc.Supervised()
+	}
+	return rc, name, err
+}
+
+func resolveAgentConfigWithOverride(townRoot, rigPath, agentOverride string) (*RuntimeConfig, string, error) {
 	// Load rig settings
 	rigSettings, err := LoadRigSettings(RigSettingsPath(rigPath))
 	if err != nil {
@@ -1558,3 +1558,38 @@ func TestSaveTownSettings(t *testing.T) {
 		}
 	})
 }
+
+func TestResolveAgentConfig_SafeMode(t *testing.T) {
+	townRoot := t.TempDir()
+	rigPath := filepath.Join(townRoot, "gastown")
+
+	if got := ResolveAgentConfig(townRoot, rigPath).BuildCommand(); got != "cursor-agent -f" {
+		t.Fatalf("default command = %q, want cursor-agent -f", got)
+	}
+
+	if err := SetSafeMode(townRoot, true); err != nil {
+		t.Fatalf("SetSafeMode failed: %v", err)
+	}
+	if got := ResolveAgentConfig(townRoot, rigPath).BuildCommand(); got != "cursor-agent" {
+		t.Errorf("safe-mode command = %q, want cursor-agent without -f", got)
+	}
+	rc, _, err := ResolveAgentConfigWithOverride(townRoot, rigPath, "gemini")
+	if err != nil {
+		t.Fatalf("ResolveAgentConfigWithOverride failed: %v", err)
+	}
+	for _, arg := range rc.Args {
+		if arg == "--approval-mode" || arg == "yolo" {
+			t.Errorf("safe-mode gemini args = %v, want approval mode flags dropped", rc.Args)
+		}
+	}
+	if SafeModeSince(townRoot).IsZero() {
+		t.Error("SafeModeSince should report when safe mode was enabled")
+	}
+
+	if err := SetSafeMode(townRoot, false); err != nil {
+		t.Fatalf("SetSafeMode(off) failed: %v", err)
+	}
+	if got := ResolveAgentConfig(townRoot, rigPath).BuildCommand(); got != "cursor-agent -f" {
+		t.Errorf("command after safe mode off = %q, want cursor-agent -f", got)
+	}
+}
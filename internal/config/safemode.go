@@ -0,0 +1,99 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// SafeModeFileName is the flag file, under the town's .beads directory, that
+// turns on town-wide safe mode. While it exists agents run interactively:
+// no force mode and no auto-approval.
+const SafeModeFileName = "safe-mode"
+
+// SafeModePath returns the path of the safe-mode flag file for a town.
+func SafeModePath(townRoot string) string {
+	return filepath.Join(townRoot, ".beads", SafeModeFileName)
+}
+
+// SafeModeEnabled reports whether safe mode is on for a town.
+func SafeModeEnabled(townRoot string) bool {
+	if townRoot == "" {
+		return false
+	}
+	_, err := os.Stat(SafeModePath(townRoot))
+	return err == nil
+}
+
+// SafeModeSince returns when safe mode was turned on, or the zero time if
+// it is off.
+func SafeModeSince(townRoot string) time.Time {
+	path := SafeModePath(townRoot)
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return time.Time{}
+	}
+	if since, err := time.Parse(time.RFC3339, strings.TrimSpace(string(data))); err == nil {
+		return since
+	}
+	if info, err := os.Stat(path); err == nil {
+		return info.ModTime()
+	}
+	return time.Time{}
+}
+
+// SetSafeMode turns town-wide safe mode on or off. Turning it on when it is
+// already on keeps the original timestamp.
+func SetSafeMode(townRoot string, on bool) error {
+	path := SafeModePath(townRoot)
+	if !on {
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("removing safe-mode flag: %w", err)
+		}
+		return nil
+	}
+	if SafeModeEnabled(townRoot) {
+		return nil
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("creating beads directory: %w", err)
+	}
+	if err := os.WriteFile(path, []byte(time.Now().UTC().Format(time.RFC3339)+"\n"), 0644); err != nil {
+		return fmt.Errorf("writing safe-mode flag: %w", err)
+	}
+	return nil
+}
+
+// autonomyFlags are agent CLI flags that skip human approval. Safe mode
+// strips them from session commands.
+var autonomyFlags = map[string]bool{
+	"-f":                      true,
+	"--force":                 true,
+	"--approve-mcps":          true,
+	"--yolo":                  true,
+	"--dangerously-allow-all": true,
+	"--autonomous":            true,
+	"--no-confirm":            true,
+}
+
+// Supervised returns a copy of rc with autonomy flags removed, so the agent
+// asks before acting. "--approval-mode yolo" is dropped as a pair.
+func (rc *RuntimeConfig) Supervised() *RuntimeConfig {
+	result := fillRuntimeDefaults(rc)
+	args := make([]string, 0, len(result.Args))
+	for i := 0; i < len(result.Args); i++ {
+		arg := result.Args[i]
+		if arg == "--approval-mode" && i+1 < len(result.Args) && result.Args[i+1] == "yolo" {
+			i++
+			continue
+		}
+		if autonomyFlags[arg] {
+			continue
+		}
+		args = append(args, arg)
+	}
+	result.Args = args
+	return result
+}
@@ -0,0 +1,62 @@
+// Package council provides multi-model orchestration for Gas Town.
+package council
+
+import (
+	"github.com/cursorworkshop/cursor-gastown/internal/cursor"
+)
+
+// ForceModeFor returns the effective force-mode policy for a role.
+// The global cursor.DisableForceModeEnv override wins; otherwise the role's
+// ForceMode setting applies, defaulting to enabled.
+func (c *Config) ForceModeFor(role string) bool {
+	if cursor.ForceModeDisabled() {
+		return false
+	}
+	if c != nil {
+		if rc := c.Roles[role]; rc != nil && rc.ForceMode != nil {
+			return *rc.ForceMode
+		}
+	}
+	return true
+}
+
+// AdapterForRole returns a cursor adapter for a role with the council's
+// policy applied: the role's configured model, force-mode setting, and
+// model allow/deny lists. Town safe mode overrides the force-mode setting.
+func (c *Config) AdapterForRole(workDir, role string) *cursor.Adapter {
+	adapter := cursor.AdapterForRole(workDir, role)
+	if c != nil {
+		if rc := c.Roles[role]; rc != nil {
+			if rc.Model != "" {
+				adapter.Model = rc.Model
+			}
+			adapter.AllowedModels = rc.AllowedModels
+			adapter.DeniedModels = rc.DeniedModels
+		}
+	}
+	adapter.ForceMode = c.ForceModeFor(role)
+	if cursor.SafeModeEnabled(workDir) {
+		adapter.ApplySafeMode()
+	}
+	return adapter
+}
+
+// RoleTypes returns the role types declared in the config, keyed by role.
+func (c *Config) RoleTypes() map[string]cursor.RoleType {
+	types := make(map[string]cursor.RoleType)
+	if c == nil {
+		return types
+	}
+	for role, rc := range c.Roles {
+		if rc != nil && rc.RoleType != "" {
+			types[role] = cursor.RoleType(rc.RoleType)
+		}
+	}
+	return types
+}
+
+// ApplyRoleTypes registers the config's declared role types with the cursor
+// package so cursor.RoleTypeFor and cursor.EnsureSettingsForRole honor them.
+func (c *Config) ApplyRoleTypes() {
+	cursor.SetRoleTypes(c.RoleTypes())
+}
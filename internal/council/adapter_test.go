@@ -0,0 +1,56 @@
+package council
+
+import (
+	"testing"
+
+	"github.com/cursorworkshop/cursor-gastown/internal/cursor"
+)
+
+func TestConfig_AdapterForRole_ForceMode(t *testing.T) {
+	t.Setenv(cursor.DisableForceModeEnv, "")
+
+	cfg := DefaultCouncilConfig()
+	disabled := false
+	cfg.Roles["polecat"].ForceMode = &disabled
+
+	adapter := cfg.AdapterForRole("/tmp/work", "polecat")
+	if adapter.ForceMode {
+		t.Error("polecat adapter should have ForceMode disabled")
+	}
+	for _, arg := range adapter.BuildArgs("do work") {
+		if arg == "-f" {
+			t.Errorf("args contain -f with force mode disabled: %v", adapter.BuildArgs("do work"))
+		}
+	}
+	if adapter.Model != cfg.Roles["polecat"].Model {
+		t.Errorf("Model = %q, want council model %q", adapter.Model, cfg.Roles["polecat"].Model)
+	}
+
+	if !cfg.AdapterForRole("/tmp/work", "mayor").ForceMode {
+		t.Error("mayor adapter should keep the default force mode")
+	}
+
+	t.Setenv(cursor.DisableForceModeEnv, "1")
+	if cfg.AdapterForRole("/tmp/work", "mayor").ForceMode {
+		t.Error("env override should disable force mode for every role")
+	}
+}
+
+func TestConfig_ApplyRoleTypes(t *testing.T) {
+	cfg := DefaultCouncilConfig()
+	cfg.Roles["janitor"] = &RoleConfig{Model: "sonnet-4.5", RoleType: "autonomous"}
+	if issues := ValidateConfig(cfg); len(issues) != 0 {
+		t.Fatalf("ValidateConfig = %v, want no issues", issues)
+	}
+
+	cfg.ApplyRoleTypes()
+	t.Cleanup(func() { cursor.SetRoleTypes(nil) })
+	if got := cursor.RoleTypeFor("janitor"); got != cursor.Autonomous {
+		t.Errorf("RoleTypeFor(janitor) = %v, want autonomous", got)
+	}
+
+	cfg.Roles["janitor"].RoleType = "robotic"
+	if issues := ValidateConfig(cfg); len(issues) != 1 {
+		t.Errorf("ValidateConfig = %v, want one role_type issue", issues)
+	}
+}
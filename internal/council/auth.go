@@ -0,0 +1,110 @@
+package council
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// AuthState classifies a provider credential probe.
+type AuthState string
+
+const (
+	// AuthAuthenticated means an authenticated request was accepted.
+	AuthAuthenticated AuthState = "authenticated"
+
+	// AuthBadCredentials means the provider rejected the configured key.
+	AuthBadCredentials AuthState = "bad-credentials"
+
+	// AuthNoCredentials means no key is configured but the provider is reachable.
+	AuthNoCredentials AuthState = "reachable-no-creds"
+
+	// AuthUnreachable means the provider could not be reached or errored.
+	AuthUnreachable AuthState = "unreachable"
+)
+
+// AuthStatus is the result of CheckAuth.
+type AuthStatus struct {
+	Provider     string        `json:"provider"`
+	State        AuthState     `json:"state"`
+	KeyEnv       string        `json:"key_env,omitempty"`
+	StatusCode   int           `json:"status_code,omitempty"`
+	ResponseTime time.Duration `json:"response_time_ms"`
+	Detail       string        `json:"detail,omitempty"`
+}
+
+// AuthEndpoints maps providers to a cheap authenticated GET used by
+// CheckAuth. ProviderConfig.ModelsURL overrides it.
+var AuthEndpoints = map[string]string{
+	"anthropic": "https://api.anthropic.com/v1/models",
+	"openai":    "https://api.openai.com/v1/models",
+	"google":    "https://generativelanguage.googleapis.com/v1/models",
+}
+
+// CheckAuth probes whether a provider accepts the configured credentials.
+// Unlike FallbackManager.CheckHealth, which treats 401/403 as reachable,
+// it makes an authenticated request when a key is set and reports
+// AuthBadCredentials when that request is rejected. Without a key it only
+// checks reachability. Returns ErrNetworkDisabled (wrapped) when network
+// access is off.
+func (c *Config) CheckAuth(ctx context.Context, provider string) (*AuthStatus, error) {
+	pc := c.Providers[provider]
+	if pc == nil {
+		return nil, fmt.Errorf("unknown provider: %s", provider)
+	}
+
+	endpoint := pc.ModelsURL
+	if endpoint == "" {
+		endpoint = AuthEndpoints[provider]
+	}
+	if endpoint == "" {
+		return nil, fmt.Errorf("no auth endpoint for provider: %s", provider)
+	}
+
+	if NetworkDisabled() {
+		return nil, fmt.Errorf("%s: %w", provider, ErrNetworkDisabled)
+	}
+
+	keyEnv, apiKey := providerAPIKey(provider, pc)
+	status := &AuthStatus{Provider: provider, KeyEnv: keyEnv}
+
+	req, err := http.NewRequestWithContext(ctx, "GET", endpoint, nil)
+	if err != nil {
+		return nil, fmt.Errorf("creating request: %w", err)
+	}
+	if apiKey != "" {
+		setProviderAuth(req, provider, pc, apiKey)
+	}
+
+	start := time.Now()
+	client := &http.Client{Timeout: 10 * time.Second}
+	resp, err := client.Do(req)
+	status.ResponseTime = time.Since(start)
+	if err != nil {
+		status.State = AuthUnreachable
+		status.Detail = sanitizeError(err.Error())
+		return status, nil
+	}
+	defer resp.Body.Close()
+	status.StatusCode = resp.StatusCode
+
+	switch {
+	case apiKey == "" && resp.StatusCode < http.StatusInternalServerError:
+		status.State = AuthNoCredentials
+		status.Detail = fmt.Sprintf("%s is not set", keyEnv)
+	case apiKey == "":
+		status.State = AuthUnreachable
+		status.Detail = fmt.Sprintf("HTTP %d", resp.StatusCode)
+	case resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusForbidden:
+		status.State = AuthBadCredentials
+		status.Detail = fmt.Sprintf("key in %s was rejected (HTTP %d)", keyEnv, resp.StatusCode)
+	case resp.StatusCode < http.StatusBadRequest, resp.StatusCode == http.StatusTooManyRequests:
+		// A rate-limited request still got past authentication.
+		status.State = AuthAuthenticated
+	default:
+		status.State = AuthUnreachable
+		status.Detail = fmt.Sprintf("HTTP %d", resp.StatusCode)
+	}
+	return status, nil
+}
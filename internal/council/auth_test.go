@@ -0,0 +1,62 @@
+package council
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestConfig_CheckAuth(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer good-key" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		_, _ = w.Write([]byte(`{"data":[]}`))
+	}))
+	defer server.Close()
+
+	closed := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	closedURL := closed.URL
+	closed.Close()
+
+	tests := []struct {
+		name     string
+		key      string
+		endpoint string
+		want     AuthState
+	}{
+		{"valid credentials", "good-key", server.URL, AuthAuthenticated},
+		{"invalid credentials", "bad-key", server.URL, AuthBadCredentials},
+		{"no credentials", "", server.URL, AuthNoCredentials},
+		{"unreachable", "good-key", closedURL, AuthUnreachable},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("TEST_OPENAI_KEY", tt.key)
+			cfg := DefaultCouncilConfig()
+			cfg.Providers["openai"].ModelsURL = tt.endpoint
+			cfg.Providers["openai"].APIKeyEnv = "TEST_OPENAI_KEY"
+
+			status, err := cfg.CheckAuth(context.Background(), "openai")
+			if err != nil {
+				t.Fatalf("CheckAuth failed: %v", err)
+			}
+			if status.State != tt.want {
+				t.Errorf("State = %s, want %s (%+v)", status.State, tt.want, status)
+			}
+		})
+	}
+}
+
+func TestConfig_CheckAuth_NetworkDisabled(t *testing.T) {
+	SetNetworkDisabled(true)
+	defer SetNetworkDisabled(false)
+
+	if _, err := DefaultCouncilConfig().CheckAuth(context.Background(), "openai"); !errors.Is(err, ErrNetworkDisabled) {
+		t.Errorf("expected ErrNetworkDisabled, got %v", err)
+	}
+}
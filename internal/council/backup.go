@@ -0,0 +1,88 @@
+package council
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// backupSuffix separates the config filename from the backup timestamp.
+const backupSuffix = ".bak-"
+
+// backupTimeFormat sorts lexically in chronological order.
+const backupTimeFormat = "20060102-150405.000"
+
+// ErrNoBackups is returned when restoring without any config backups.
+var ErrNoBackups = errors.New("no council config backups found")
+
+// BackupConfig copies the current council config to
+// <config>.bak-<timestamp> beside it and returns the backup path. The
+// config is found like Load finds it (see ResolveConfigPath).
+func BackupConfig(townRoot string) (string, error) {
+	src := ResolveConfigPath(townRoot)
+	data, err := os.ReadFile(src)
+	if err != nil {
+		return "", fmt.Errorf("reading council config: %w", err)
+	}
+
+	path := src + backupSuffix + time.Now().Format(backupTimeFormat)
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return "", fmt.Errorf("writing backup: %w", err)
+	}
+	return path, nil
+}
+
+// ListBackups returns the council config backups, oldest first.
+func ListBackups(townRoot string) ([]string, error) {
+	backups, err := filepath.Glob(ResolveConfigPath(townRoot) + backupSuffix + "*")
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(backups)
+	return backups, nil
+}
+
+// LatestBackup returns the most recent council config backup, or
+// ErrNoBackups if there are none.
+func LatestBackup(townRoot string) (string, error) {
+	backups, err := ListBackups(townRoot)
+	if err != nil {
+		return "", err
+	}
+	if len(backups) == 0 {
+		return "", ErrNoBackups
+	}
+	return backups[len(backups)-1], nil
+}
+
+// RestoreConfig replaces the council config with a backup. An empty
+// backupPath restores the most recent backup; a bare filename is resolved
+// in the config's directory. The backup must parse as a valid config.
+func RestoreConfig(townRoot, backupPath string) error {
+	if backupPath == "" {
+		latest, err := LatestBackup(townRoot)
+		if err != nil {
+			return err
+		}
+		backupPath = latest
+	} else if !strings.ContainsRune(backupPath, filepath.Separator) {
+		backupPath = filepath.Join(filepath.Dir(ResolveConfigPath(townRoot)), backupPath)
+	}
+
+	data, err := os.ReadFile(backupPath)
+	if err != nil {
+		return fmt.Errorf("reading backup: %w", err)
+	}
+	if _, err := LoadConfig(backupPath); err != nil {
+		return fmt.Errorf("backup %s is not a valid config: %w", backupPath, err)
+	}
+
+	if err := os.WriteFile(ResolveConfigPath(townRoot), data, 0644); err != nil {
+		return fmt.Errorf("restoring config: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,82 @@
+package council
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+)
+
+func TestBackupAndRestoreConfig(t *testing.T) {
+	townRoot := t.TempDir()
+
+	if err := RestoreConfig(townRoot, ""); !errors.Is(err, ErrNoBackups) {
+		t.Fatalf("restore without backups: expected ErrNoBackups, got %v", err)
+	}
+
+	original := DefaultCouncilConfig()
+	original.Roles["mayor"].Model = "opus-4.5"
+	if err := SaveConfig(ConfigPath(townRoot), original); err != nil {
+		t.Fatalf("SaveConfig failed: %v", err)
+	}
+
+	backupPath, err := BackupConfig(townRoot)
+	if err != nil {
+		t.Fatalf("BackupConfig failed: %v", err)
+	}
+	if filepath.Dir(backupPath) != filepath.Dir(ConfigPath(townRoot)) {
+		t.Errorf("backup path %s is not next to the config", backupPath)
+	}
+
+	profile, _ := GetProfile("cost-optimized")
+	if err := ApplyProfile(profile, townRoot); err != nil {
+		t.Fatalf("ApplyProfile failed: %v", err)
+	}
+	applied, err := LoadConfig(ConfigPath(townRoot))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if applied.Roles["mayor"].Model == "opus-4.5" {
+		t.Fatal("profile did not change the mayor model; test cannot detect a restore")
+	}
+
+	if latest, err := LatestBackup(townRoot); err != nil || latest != backupPath {
+		t.Fatalf("LatestBackup = %q, %v; want %q", latest, err, backupPath)
+	}
+	if err := RestoreConfig(townRoot, ""); err != nil {
+		t.Fatalf("RestoreConfig failed: %v", err)
+	}
+
+	restored, err := LoadConfig(ConfigPath(townRoot))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if restored.Roles["mayor"].Model != "opus-4.5" {
+		t.Errorf("restored mayor model = %s, want opus-4.5", restored.Roles["mayor"].Model)
+	}
+
+	// Named backups resolve relative to .beads.
+	if err := RestoreConfig(townRoot, filepath.Base(backupPath)); err != nil {
+		t.Errorf("RestoreConfig by filename failed: %v", err)
+	}
+}
+
+func TestBackupConfig_AlternatePath(t *testing.T) {
+	townRoot := t.TempDir()
+	original := DefaultCouncilConfig()
+	original.Roles["mayor"].Model = "opus-4.5"
+	if err := SaveConfig(AlternateConfigPath(townRoot), original); err != nil {
+		t.Fatalf("SaveConfig failed: %v", err)
+	}
+
+	backupPath, err := BackupConfig(townRoot)
+	if err != nil {
+		t.Fatalf("BackupConfig failed: %v", err)
+	}
+	if filepath.Dir(backupPath) != filepath.Dir(AlternateConfigPath(townRoot)) {
+		t.Errorf("backup path %s is not next to the settings config", backupPath)
+	}
+	backup, err := LoadConfig(backupPath)
+	if err != nil || backup.Roles["mayor"].Model != "opus-4.5" {
+		t.Errorf("backup = %v, %v; want the settings config", backup, err)
+	}
+}
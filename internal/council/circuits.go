@@ -0,0 +1,153 @@
+package council
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+)
+
+// CircuitStateFileName is the default filename for persisted circuit state.
+const CircuitStateFileName = "council-circuits.json"
+
+// CircuitStatePath returns the default circuit state path for a town.
+func CircuitStatePath(townRoot string) string {
+	return filepath.Join(townRoot, ".beads", CircuitStateFileName)
+}
+
+// CircuitStatus is a provider's circuit breaker state at a point in time.
+type CircuitStatus struct {
+	Provider string `json:"provider"`
+	CircuitBreaker
+}
+
+// Circuits returns a snapshot of every provider's circuit, sorted by
+// provider name.
+func (fm *FallbackManager) Circuits() []CircuitStatus {
+	fm.mu.RLock()
+	defer fm.mu.RUnlock()
+
+	circuits := make([]CircuitStatus, 0, len(fm.circuitBreaker))
+	for provider, cb := range fm.circuitBreaker {
+		circuits = append(circuits, CircuitStatus{Provider: provider, CircuitBreaker: *cb})
+	}
+	sort.Slice(circuits, func(i, j int) bool { return circuits[i].Provider < circuits[j].Provider })
+	return circuits
+}
+
+// ResetProvider closes one provider's circuit and clears its failure
+// history, model circuits, and rate-limit reading, leaving other providers
+// untouched.
+func (fm *FallbackManager) ResetProvider(provider string) error {
+	fm.mu.Lock()
+	defer fm.mu.Unlock()
+
+	cb, ok := fm.circuitBreaker[provider]
+	if !ok {
+		return fmt.Errorf("unknown provider: %s", provider)
+	}
+	cb.State = "closed"
+	cb.FailureCount = 0
+	cb.RetryAfter = time.Time{}
+	delete(fm.failureWindow, provider)
+	delete(fm.quota, provider)
+	for model, p := range fm.modelProvider {
+		if p == provider {
+			delete(fm.modelBreaker, model)
+			delete(fm.modelProvider, model)
+		}
+	}
+	fm.router.SetProviderStatus(provider, true)
+	return nil
+}
+
+// SaveCircuits writes the current circuit state to path as JSON so other
+// processes (e.g. gt council circuits) can inspect it.
+func (fm *FallbackManager) SaveCircuits(path string) error {
+	data, err := json.MarshalIndent(fm.Circuits(), "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshaling circuits: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("creating directory: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("writing circuit state: %w", err)
+	}
+	return nil
+}
+
+// PersistCircuits restores circuit state saved at path and then saves it
+// there again whenever a provider's circuit changes state during
+// ExecuteWithFallback or MaybeRecover, so gt council circuits sees circuits
+// opened by this process.
+func (fm *FallbackManager) PersistCircuits(path string) error {
+	if err := fm.LoadCircuits(path); err != nil {
+		return err
+	}
+	fm.mu.Lock()
+	fm.statePath = path
+	fm.mu.Unlock()
+	return nil
+}
+
+// circuitStates returns each provider's circuit state.
+func (fm *FallbackManager) circuitStates() map[string]string {
+	fm.mu.RLock()
+	defer fm.mu.RUnlock()
+
+	states := make(map[string]string, len(fm.circuitBreaker))
+	for provider, cb := range fm.circuitBreaker {
+		states[provider] = cb.State
+	}
+	return states
+}
+
+// saveIfChanged saves circuit state to the PersistCircuits path if any
+// provider's state differs from before. Saving is best-effort: a failed
+// write must not fail the request that changed the circuit.
+func (fm *FallbackManager) saveIfChanged(before map[string]string) {
+	fm.mu.RLock()
+	path := fm.statePath
+	fm.mu.RUnlock()
+	if path == "" || maps.Equal(before, fm.circuitStates()) {
+		return
+	}
+	_ = fm.SaveCircuits(path)
+}
+
+// LoadCircuits restores circuit state saved by SaveCircuits. A missing
+// file is not an error. Providers the manager does not know are ignored,
+// and providers loaded with an open circuit are marked unavailable in the
+// router.
+func (fm *FallbackManager) LoadCircuits(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return fmt.Errorf("reading circuit state: %w", err)
+	}
+
+	var circuits []CircuitStatus
+	if err := json.Unmarshal(data, &circuits); err != nil {
+		return fmt.Errorf("parsing circuit state: %w", err)
+	}
+
+	fm.mu.Lock()
+	defer fm.mu.Unlock()
+	for _, c := range circuits {
+		if _, ok := fm.circuitBreaker[c.Provider]; !ok {
+			continue
+		}
+		cb := c.CircuitBreaker
+		fm.circuitBreaker[c.Provider] = &cb
+		if cb.State == "open" {
+			fm.router.SetProviderStatus(c.Provider, false)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,119 @@
+package council
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestFallbackManager_CircuitsPersistAndReset(t *testing.T) {
+	fm := NewFallbackManager(NewRouter(DefaultCouncilConfig()))
+	fm.RecordRequestOutcome("anthropic", "", false, errors.New("401 unauthorized"))
+	fm.RecordRequestOutcome("openai", "", true, nil)
+
+	path := filepath.Join(t.TempDir(), CircuitStateFileName)
+	if err := fm.SaveCircuits(path); err != nil {
+		t.Fatalf("SaveCircuits failed: %v", err)
+	}
+
+	loaded := NewFallbackManager(NewRouter(DefaultCouncilConfig()))
+	if err := loaded.LoadCircuits(path); err != nil {
+		t.Fatalf("LoadCircuits failed: %v", err)
+	}
+	states := make(map[string]CircuitStatus)
+	for _, c := range loaded.Circuits() {
+		states[c.Provider] = c
+	}
+	if c := states["anthropic"]; c.State != "open" || c.OpenedAt.IsZero() {
+		t.Errorf("anthropic = %+v, want open with OpenedAt", c)
+	}
+	if c := states["openai"]; c.State != "closed" || c.LastSuccess.IsZero() {
+		t.Errorf("openai = %+v, want closed with LastSuccess", c)
+	}
+	if loaded.router.GetProviderStatus("anthropic") {
+		t.Error("loaded open circuit should mark the provider unavailable")
+	}
+
+	if err := loaded.ResetProvider("anthropic"); err != nil {
+		t.Fatalf("ResetProvider failed: %v", err)
+	}
+	if loaded.circuitBreaker["anthropic"].State != "closed" || !loaded.router.GetProviderStatus("anthropic") {
+		t.Error("ResetProvider should close the circuit and restore availability")
+	}
+	if err := loaded.ResetProvider("nope"); err == nil {
+		t.Error("ResetProvider of an unknown provider should fail")
+	}
+
+	if err := NewFallbackManager(NewRouter(nil)).LoadCircuits(filepath.Join(t.TempDir(), "missing.json")); err != nil {
+		t.Errorf("missing state file should not be an error: %v", err)
+	}
+}
+
+func TestFallbackManager_PersistCircuitsOnStateChange(t *testing.T) {
+	path := filepath.Join(t.TempDir(), CircuitStateFileName)
+	fm := newTestFallbackManager()
+	if err := fm.PersistCircuits(path); err != nil {
+		t.Fatalf("PersistCircuits failed: %v", err)
+	}
+
+	ok := funcExecutor(func(ctx context.Context, model, prompt string) (*ModelResponse, error) {
+		return &ModelResponse{Success: true}, nil
+	})
+	if _, _, err := fm.ExecuteWithFallback(context.Background(), ok, &RouteRequest{Role: "mayor"}, "plan"); err != nil {
+		t.Fatalf("ExecuteWithFallback failed: %v", err)
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Fatalf("state should not be written when no circuit changed (stat err %v)", err)
+	}
+
+	fatal := funcExecutor(func(ctx context.Context, model, prompt string) (*ModelResponse, error) {
+		return nil, errors.New("401 unauthorized: invalid api key")
+	})
+	route, _ := fm.RouteWithFallback(&RouteRequest{Role: "mayor"})
+	fm.ExecuteWithFallback(context.Background(), fatal, &RouteRequest{Role: "mayor"}, "plan")
+
+	loaded := NewFallbackManager(NewRouter(DefaultCouncilConfig()))
+	if err := loaded.LoadCircuits(path); err != nil {
+		t.Fatalf("LoadCircuits failed: %v", err)
+	}
+	if got := loaded.circuitBreaker[route.Provider].State; got != "open" {
+		t.Errorf("persisted %s circuit = %q, want open", route.Provider, got)
+	}
+}
+
+func TestFallbackManager_ResetClearsBackoffAndModelState(t *testing.T) {
+	fm := newTestFallbackManager()
+	for i := 0; i < DefaultModelThreshold; i++ {
+		fm.RecordRequestOutcome("anthropic", "sonnet-4.5", false, errors.New("model overloaded"))
+	}
+	if len(fm.modelBreaker) == 0 {
+		t.Fatal("expected a model circuit for sonnet-4.5")
+	}
+	fm.circuitBreaker["anthropic"].RetryAfter = time.Now().Add(time.Hour)
+	fm.RecordRateLimitInfo("openai", RateLimitInfo{HasRemaining: true, Remaining: 0})
+	fm.RecordRateLimitInfo("google", RateLimitInfo{HasRemaining: true, Remaining: 0})
+
+	if err := fm.ResetProvider("openai"); err != nil {
+		t.Fatalf("ResetProvider failed: %v", err)
+	}
+	if _, ok := fm.ProviderQuota("openai"); ok {
+		t.Error("ResetProvider should forget the provider's rate-limit reading")
+	}
+	if _, ok := fm.ProviderQuota("google"); !ok {
+		t.Error("ResetProvider should leave other providers' readings alone")
+	}
+
+	fm.Reset()
+	if cb := fm.circuitBreaker["anthropic"]; cb.State != "closed" || !cb.RetryAfter.IsZero() {
+		t.Errorf("anthropic circuit = %+v, want closed with no RetryAfter", cb)
+	}
+	if len(fm.modelBreaker) != 0 || len(fm.modelProvider) != 0 {
+		t.Errorf("model circuits = %v, want none after Reset", fm.modelBreaker)
+	}
+	if _, ok := fm.ProviderQuota("google"); ok {
+		t.Error("Reset should forget rate-limit readings")
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"sync/atomic"
 
 	"github.com/BurntSushi/toml"
 )
@@ -25,6 +26,16 @@ type Config struct {
 
 	// Providers contains provider-specific settings.
 	Providers map[string]*ProviderConfig `json:"providers,omitempty" toml:"providers"`
+
+	// ModelCost maps models to their price in dollars per 1M tokens, used
+	// to enforce RouteRequest.MaxCostPerTask. Models without an entry use
+	// their catalog list price.
+	ModelCost map[string]float64 `json:"model_cost,omitempty" toml:"model_cost"`
+
+	// MaxFallbackDepth caps how many fallback candidates routing considers
+	// after the primary model before failing with FallbackExhausted. Zero
+	// allows the full chain plus emergency models.
+	MaxFallbackDepth int `json:"max_fallback_depth,omitempty" toml:"max_fallback_depth"`
 }
 
 // RoleConfig defines the model configuration for a Gas Town role.
@@ -46,6 +57,43 @@ type RoleConfig struct {
 
 	// Provider overrides the default provider detection.
 	Provider string `json:"provider,omitempty" toml:"provider"`
+
+	// ForceMode controls whether agents in this role run cursor-agent with
+	// -f (auto-approve tool calls). Nil keeps the default (enabled).
+	ForceMode *bool `json:"force_mode,omitempty" toml:"force_mode"`
+
+	// AllowedModels restricts the models agents in this role may run.
+	// Empty allows any model not in DeniedModels.
+	AllowedModels []string `json:"allowed_models,omitempty" toml:"allowed_models"`
+
+	// DeniedModels lists models agents in this role may never run.
+	DeniedModels []string `json:"denied_models,omitempty" toml:"denied_models"`
+
+	// SampleRate is the fraction (0-1) of this role's tasks kept in metrics
+	// task history; aggregates always count every task. Zero keeps all.
+	SampleRate float64 `json:"sample_rate,omitempty" toml:"sample_rate"`
+
+	// RoleType declares the role "autonomous" or "interactive", selecting
+	// which Cursor rules template its workspaces get. Empty keeps the
+	// built-in default (custom roles are interactive).
+	RoleType string `json:"role_type,omitempty" toml:"role_type"`
+
+	// MinConfidence (0-1) escalates single-model responses whose reported
+	// confidence is below it to the next Fallback model. Zero disables the
+	// gate; responses without a parseable confidence always pass.
+	MinConfidence float64 `json:"min_confidence,omitempty" toml:"min_confidence"`
+}
+
+// SampleRates returns the configured history sample rate for each role
+// that sets one, for MetricsStore.SetSampleRates.
+func (c *Config) SampleRates() map[string]float64 {
+	rates := make(map[string]float64)
+	for role, rc := range c.Roles {
+		if rc != nil && rc.SampleRate > 0 && rc.SampleRate < 1 {
+			rates[role] = rc.SampleRate
+		}
+	}
+	return rates
 }
 
 // ComplexityConfig defines models for different complexity levels.
@@ -80,11 +128,24 @@ type ProviderConfig struct {
 	// RateLimit is the rate limit in requests per minute.
 	RateLimit int `json:"rate_limit,omitempty" toml:"rate_limit"`
 
+	// MaxConcurrent caps simultaneous requests to this provider (0 = unlimited).
+	MaxConcurrent int `json:"max_concurrent,omitempty" toml:"max_concurrent"`
+
 	// Priority is used for fallback ordering (higher = preferred).
 	Priority int `json:"priority,omitempty" toml:"priority"`
 
 	// Models lists available models from this provider.
 	Models []string `json:"models,omitempty" toml:"models"`
+
+	// ModelsURL overrides the provider's models-list endpoint used by RefreshModels.
+	ModelsURL string `json:"models_url,omitempty" toml:"models_url"`
+
+	// APIKeyEnv names the environment variable holding the provider API key.
+	// Defaults to the provider's conventional variable (e.g., OPENAI_API_KEY).
+	APIKeyEnv string `json:"api_key_env,omitempty" toml:"api_key_env"`
+
+	// Headers are extra HTTP headers sent with provider API requests.
+	Headers map[string]string `json:"headers,omitempty" toml:"headers"`
 }
 
 // CurrentConfigVersion is the current schema version.
@@ -162,9 +223,35 @@ func DefaultCouncilConfig() *Config {
 // ConfigFileName is the default filename for council configuration.
 const ConfigFileName = "council.toml"
 
+// ConfigPathEnv is the environment variable that points every council
+// command at a custom config file instead of the town's standard paths.
+const ConfigPathEnv = "GASTOWN_COUNCIL_CONFIG"
+
+var configPathOverride atomic.Value // string
+
+// SetConfigPathOverride sets the council config path for the process, e.g.
+// from the --config flag. It takes precedence over ConfigPathEnv; an empty
+// path clears it.
+func SetConfigPathOverride(path string) {
+	configPathOverride.Store(path)
+}
+
+// ConfigPathOverride returns the custom council config path set via
+// SetConfigPathOverride or ConfigPathEnv, or "" to use the standard paths.
+func ConfigPathOverride() string {
+	if path, _ := configPathOverride.Load().(string); path != "" {
+		return path
+	}
+	return os.Getenv(ConfigPathEnv)
+}
+
 // ConfigPath returns the path to the council configuration file.
-// By default, it's stored in .beads/council.toml in the town root.
+// By default, it's stored in .beads/council.toml in the town root;
+// ConfigPathOverride replaces it when set.
 func ConfigPath(townRoot string) string {
+	if path := ConfigPathOverride(); path != "" {
+		return path
+	}
 	return filepath.Join(townRoot, ".beads", ConfigFileName)
 }
 
@@ -217,22 +304,34 @@ func LoadConfig(path string) (*Config, error) {
 	return config, nil
 }
 
-// SaveConfig saves council configuration to the given path.
-// Saves as TOML for human readability.
+// SaveConfig saves council configuration to the given path. Like
+// LoadConfig, it picks the format by extension: JSON for ".json" paths,
+// TOML (for human readability) otherwise.
 func SaveConfig(path string, config *Config) error {
 	// Ensure directory exists
 	dir := filepath.Dir(path)
 	if err := os.MkdirAll(dir, 0755); err != nil {
 		return fmt.Errorf("creating config directory: %w", err)
 	}
+	if err := checkWritableDir(dir); err != nil {
+		return err
+	}
 
-	// Encode as TOML
 	f, err := os.Create(path)
 	if err != nil {
 		return fmt.Errorf("creating config file: %w", err)
 	}
 	defer f.Close()
 
+	if filepath.Ext(path) == ".json" {
+		encoder := json.NewEncoder(f)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(config); err != nil {
+			return fmt.Errorf("encoding config: %w", err)
+		}
+		return nil
+	}
+
 	encoder := toml.NewEncoder(f)
 	if err := encoder.Encode(config); err != nil {
 		return fmt.Errorf("encoding config: %w", err)
@@ -241,15 +340,56 @@ func SaveConfig(path string, config *Config) error {
 	return nil
 }
 
+// ResolveConfigPath returns the town's council config path: the override
+// if set, else the primary path, or the alternate path if only that one
+// exists.
+func ResolveConfigPath(townRoot string) string {
+	path := ConfigPath(townRoot)
+	if ConfigPathOverride() != "" {
+		return path
+	}
+	if _, err := os.Stat(path); os.IsNotExist(err) {
+		if altPath := AlternateConfigPath(townRoot); fileExists(altPath) {
+			return altPath
+		}
+	}
+	return path
+}
+
+// Load loads the town's council config from the primary or alternate path
+// without creating a file. Returns the default config if neither exists.
+func Load(townRoot string) (*Config, error) {
+	return LoadConfig(ResolveConfigPath(townRoot))
+}
+
+// checkWritableDir reports a clear error when dir can't be written, rather
+// than a bare permission error from creating the config file.
+func checkWritableDir(dir string) error {
+	f, err := os.CreateTemp(dir, ".council-write-check-*")
+	if err != nil {
+		return fmt.Errorf("config directory %s is not writable: %w", dir, err)
+	}
+	name := f.Name()
+	_ = f.Close()
+	_ = os.Remove(name)
+	return nil
+}
+
+// fileExists reports whether path exists.
+func fileExists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
+
 // LoadOrCreate loads config from the path, creating default if it doesn't exist.
 func LoadOrCreate(townRoot string) (*Config, error) {
 	path := ConfigPath(townRoot)
 
 	// Check primary path
 	if _, err := os.Stat(path); os.IsNotExist(err) {
-		// Check alternate path
+		// Check alternate path, unless a custom path was requested
 		altPath := AlternateConfigPath(townRoot)
-		if _, err := os.Stat(altPath); err == nil {
+		if _, err := os.Stat(altPath); err == nil && ConfigPathOverride() == "" {
 			return LoadConfig(altPath)
 		}
 
@@ -286,6 +426,18 @@ func (c *Config) GetFallbackChain(role string) []string {
 	return nil
 }
 
+// ProviderFor returns the provider a role's model is attributed to. A role
+// that pins Provider uses that provider for every model the pinned entry
+// lists; other models fall back to name-based detection via ModelProvider.
+func (c *Config) ProviderFor(role, model string) string {
+	if rc, ok := c.Roles[role]; ok && rc != nil && rc.Provider != "" {
+		if pc := c.Providers[rc.Provider]; pc != nil && contains(pc.Models, model) {
+			return rc.Provider
+		}
+	}
+	return ModelProvider(model)
+}
+
 // GetRationale returns the rationale for a role's model selection.
 func (c *Config) GetRationale(role string) string {
 	if rc, ok := c.Roles[role]; ok {
@@ -302,29 +454,43 @@ func (c *Config) SupportsComplexityRouting(role string) bool {
 	return false
 }
 
-// GetModelForComplexity returns the model for a given complexity level.
+// GetModelForComplexity returns the model for a given complexity level,
+// or the role's model if the role has none for that level (see
+// ComplexityRationale).
 func (c *Config) GetModelForComplexity(role string, complexity ComplexityLevel) string {
+	if model := c.complexityModel(role, complexity); model != "" {
+		return model
+	}
+	return c.GetModelForRole(role)
+}
+
+// complexityModel returns the role's own model for a complexity level, or
+// "" if it has none.
+func (c *Config) complexityModel(role string, complexity ComplexityLevel) string {
 	rc, ok := c.Roles[role]
-	if !ok || !rc.ComplexityRouting || rc.Complexity == nil {
-		return c.GetModelForRole(role)
+	if !ok || rc == nil || !rc.ComplexityRouting || rc.Complexity == nil {
+		return ""
 	}
 
 	switch complexity {
 	case ComplexityHigh:
-		if rc.Complexity.High != "" {
-			return rc.Complexity.High
-		}
+		return rc.Complexity.High
 	case ComplexityMedium:
-		if rc.Complexity.Medium != "" {
-			return rc.Complexity.Medium
-		}
+		return rc.Complexity.Medium
 	case ComplexityLow:
-		if rc.Complexity.Low != "" {
-			return rc.Complexity.Low
-		}
+		return rc.Complexity.Low
 	}
+	return ""
+}
 
-	return c.GetModelForRole(role)
+// ComplexityRationale explains a complexity-based model choice, noting
+// when the role has no model for the level and its own model is used.
+func (c *Config) ComplexityRationale(role string, complexity ComplexityLevel) string {
+	rationale := fmt.Sprintf("Complexity-based routing: %s task", complexity)
+	if c.complexityModel(role, complexity) == "" {
+		rationale += fmt.Sprintf(" (no %s-complexity model; using %s)", complexity, c.GetModelForRole(role))
+	}
+	return rationale
 }
 
 // ComplexityLevel represents task complexity.
@@ -350,6 +516,17 @@ func (c ComplexityLevel) String() string {
 	}
 }
 
+// MarshalText encodes the complexity level as its name (e.g., "high").
+func (c ComplexityLevel) MarshalText() ([]byte, error) {
+	return []byte(c.String()), nil
+}
+
+// UnmarshalText decodes a complexity level name.
+func (c *ComplexityLevel) UnmarshalText(text []byte) error {
+	*c = ParseComplexity(string(text))
+	return nil
+}
+
 // ParseComplexity parses a complexity level from string.
 func ParseComplexity(s string) ComplexityLevel {
 	switch s {
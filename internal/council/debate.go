@@ -0,0 +1,242 @@
+package council
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/cursorworkshop/cursor-gastown/internal/cursor"
+)
+
+// Debate defaults.
+const (
+	DefaultDebateRounds  = 2
+	DefaultDebateTimeout = 5 * time.Minute
+)
+
+// Debate verdicts.
+const (
+	DebatePro = "pro"
+	DebateCon = "con"
+)
+
+// DebateConfig configures a debate: two models argue a question over
+// Rounds rounds and a third model judges the transcript.
+type DebateConfig struct {
+	// ProModel argues for the proposition; ConModel argues against it.
+	ProModel string `json:"pro_model" toml:"pro_model"`
+	ConModel string `json:"con_model" toml:"con_model"`
+
+	// JudgeModel reads the transcript and delivers the verdict.
+	JudgeModel string `json:"judge_model" toml:"judge_model"`
+
+	// Rounds is how many pro/con exchanges to run (default DefaultDebateRounds).
+	Rounds int `json:"rounds" toml:"rounds"`
+
+	// Timeout bounds the whole debate (default DefaultDebateTimeout).
+	Timeout time.Duration `json:"timeout" toml:"timeout"`
+}
+
+// DebateRound holds one round's statements. Con answers after Pro, so
+// Con can rebut the argument it just heard.
+type DebateRound struct {
+	Round int           `json:"round"`
+	Pro   ModelResponse `json:"pro"`
+	Con   ModelResponse `json:"con"`
+}
+
+// DebateResult represents the result of a debate.
+type DebateResult struct {
+	Rounds []DebateRound `json:"rounds"`
+
+	// Judgment is the judge's full response; FinalOutput is its text.
+	Judgment    ModelResponse `json:"judgment"`
+	FinalOutput string        `json:"final_output"`
+
+	// Verdict is DebatePro, DebateCon, or "" when the judge gave none.
+	Verdict string `json:"verdict,omitempty"`
+
+	// Confidence is the judge's self-reported confidence (0-1), or 0.5
+	// when it reported none.
+	Confidence float64 `json:"confidence"`
+
+	TotalDuration time.Duration `json:"total_duration"`
+	TotalCost     float64       `json:"total_cost"`
+	Success       bool          `json:"success"`
+	Error         string        `json:"error,omitempty"`
+}
+
+// DebateExecutor runs debates.
+type DebateExecutor struct {
+	executor ModelExecutor
+	config   *DebateConfig
+}
+
+// NewDebateExecutor creates a new debate executor.
+func NewDebateExecutor(executor ModelExecutor, config *DebateConfig) *DebateExecutor {
+	return &DebateExecutor{
+		executor: executor,
+		config:   config,
+	}
+}
+
+// Execute debates question. It returns cursor.ErrEmptyPrompt for an empty
+// question; model failures end the debate and are reported in the result.
+func (d *DebateExecutor) Execute(ctx context.Context, question string) (*DebateResult, error) {
+	if err := cursor.CheckPrompt(question); err != nil {
+		return nil, err
+	}
+
+	rounds := d.config.Rounds
+	if rounds <= 0 {
+		rounds = DefaultDebateRounds
+	}
+	timeout := d.config.Timeout
+	if timeout <= 0 {
+		timeout = DefaultDebateTimeout
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	startTime := time.Now()
+	result := &DebateResult{Rounds: make([]DebateRound, 0, rounds)}
+	defer func() { result.TotalDuration = time.Since(startTime) }()
+
+	var transcript strings.Builder
+	for i := 1; i <= rounds; i++ {
+		round := DebateRound{Round: i}
+
+		pro, ok := d.speak(ctx, result, d.config.ProModel, debaterPrompt(question, DebatePro, i, rounds, transcript.String()))
+		round.Pro = pro
+		if !ok {
+			result.Rounds = append(result.Rounds, round)
+			result.Error = fmt.Sprintf("round %d pro (%s) failed: %s", i, pro.Model, pro.Error)
+			return result, nil
+		}
+		fmt.Fprintf(&transcript, "Round %d, FOR:\n%s\n\n", i, pro.Output)
+
+		con, ok := d.speak(ctx, result, d.config.ConModel, debaterPrompt(question, DebateCon, i, rounds, transcript.String()))
+		round.Con = con
+		result.Rounds = append(result.Rounds, round)
+		if !ok {
+			result.Error = fmt.Sprintf("round %d con (%s) failed: %s", i, con.Model, con.Error)
+			return result, nil
+		}
+		fmt.Fprintf(&transcript, "Round %d, AGAINST:\n%s\n\n", i, con.Output)
+	}
+
+	judgment, ok := d.speak(ctx, result, d.config.JudgeModel, judgePrompt(question, transcript.String()))
+	result.Judgment = judgment
+	if !ok {
+		result.Error = fmt.Sprintf("judge (%s) failed: %s", judgment.Model, judgment.Error)
+		return result, nil
+	}
+
+	result.FinalOutput = judgment.Output
+	result.Verdict = ParseVerdict(judgment.Output)
+	result.Confidence = 0.5
+	if confidence, ok := ParseConfidence(judgment.Output); ok {
+		result.Confidence = confidence
+	}
+	result.Success = true
+	return result, nil
+}
+
+// speak runs one model turn and charges its cost to result. It reports
+// whether the turn succeeded.
+func (d *DebateExecutor) speak(ctx context.Context, result *DebateResult, model, prompt string) (ModelResponse, bool) {
+	response, err := d.executor.Execute(ctx, model, prompt)
+	if err != nil {
+		return ModelResponse{
+			Model:     model,
+			Error:     sanitizeError(err.Error()),
+			ErrorKind: classifyError(err),
+		}, false
+	}
+	response.Model = model
+	result.TotalCost += response.Cost
+	if !response.Success {
+		response.Error = sanitizeError(response.Error)
+	}
+	return *response, response.Success
+}
+
+// debaterPrompt builds a debater's prompt for a round.
+func debaterPrompt(question, side string, round, rounds int, transcript string) string {
+	stance := "FOR"
+	if side == DebateCon {
+		stance = "AGAINST"
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "You are in a structured debate, arguing %s the proposition below.\n\n", stance)
+	fmt.Fprintf(&b, "Proposition:\n%s\n\n", question)
+	if transcript != "" {
+		fmt.Fprintf(&b, "Debate so far:\n\n%s", transcript)
+	}
+	fmt.Fprintf(&b, "This is round %d of %d. ", round, rounds)
+	if transcript == "" {
+		b.WriteString("Make your strongest opening argument.")
+	} else {
+		b.WriteString("Rebut the other side's latest points and strengthen your case.")
+	}
+	return b.String()
+}
+
+// judgePrompt builds the judge's prompt.
+func judgePrompt(question, transcript string) string {
+	return fmt.Sprintf(`You are judging a structured debate.
+
+Proposition:
+%s
+
+Transcript:
+
+%s
+Weigh both sides on the strength of their arguments, not their length.
+Summarize the decisive points and give a recommendation. End with:
+Verdict: PRO or CON
+Confidence: a number from 0 to 1`, question, transcript)
+}
+
+// verdictPattern matches a "Verdict: PRO" line.
+var verdictPattern = regexp.MustCompile(`(?im)^[\W_]*verdict[\W_]*?[:=]\s*\**\s*(pro|con|for|against)\b`)
+
+// ParseVerdict extracts the judge's verdict from its output, using the last
+// "Verdict: PRO|CON" line ("FOR"/"AGAINST" are accepted too). It returns
+// DebatePro, DebateCon, or "" when there is no verdict.
+func ParseVerdict(output string) string {
+	matches := verdictPattern.FindAllStringSubmatch(output, -1)
+	if len(matches) == 0 {
+		return ""
+	}
+	switch strings.ToLower(matches[len(matches)-1][1]) {
+	case "pro", "for":
+		return DebatePro
+	default:
+		return DebateCon
+	}
+}
+
+// PredefinedDebates contains common debate configurations.
+var PredefinedDebates = map[string]*DebateConfig{
+	// Architecture and design trade-offs
+	"design-tradeoff": {
+		ProModel:   "opus-4.5-thinking",
+		ConModel:   "gpt-5.2-high",
+		JudgeModel: "sonnet-4.5",
+		Rounds:     2,
+		Timeout:    5 * time.Minute,
+	},
+
+	// Quick sanity check of a proposed change
+	"quick-challenge": {
+		ProModel:   "sonnet-4.5",
+		ConModel:   "gpt-5.2",
+		JudgeModel: "gemini-3-pro",
+		Rounds:     1,
+		Timeout:    2 * time.Minute,
+	},
+}
@@ -0,0 +1,80 @@
+package council
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestDebateExecutor(t *testing.T) {
+	var judgePrompt string
+	turns := map[string]int{}
+	executor := funcExecutor(func(ctx context.Context, model, prompt string) (*ModelResponse, error) {
+		turns[model]++
+		switch model {
+		case "sonnet-4.5":
+			judgePrompt = prompt
+			return &ModelResponse{Output: "Con's migration cost point is decisive.\nVerdict: CON\nConfidence: 0.8", Success: true, Cost: 0.03}, nil
+		case "gpt-5.2":
+			if !strings.Contains(prompt, fmt.Sprintf("pro argument %d", turns["opus-4.5"])) {
+				t.Errorf("con turn %d did not see the latest pro argument:\n%s", turns[model], prompt)
+			}
+		}
+		return &ModelResponse{Output: fmt.Sprintf("%s argument %d", map[string]string{"opus-4.5": "pro", "gpt-5.2": "con"}[model], turns[model]), Success: true, Cost: 0.01}, nil
+	})
+
+	config := &DebateConfig{ProModel: "opus-4.5", ConModel: "gpt-5.2", JudgeModel: "sonnet-4.5", Rounds: 2}
+	result, err := NewDebateExecutor(executor, config).Execute(context.Background(), "Should we split the monolith?")
+	if err != nil {
+		t.Fatalf("Execute failed: %v", err)
+	}
+	if !result.Success {
+		t.Fatalf("debate failed: %s", result.Error)
+	}
+	if len(result.Rounds) != 2 || result.Rounds[1].Con.Output != "con argument 2" {
+		t.Errorf("rounds = %+v, want two full rounds", result.Rounds)
+	}
+	for _, s := range []string{"pro argument 1", "con argument 1", "pro argument 2", "con argument 2"} {
+		if !strings.Contains(judgePrompt, s) {
+			t.Errorf("judge prompt missing %q", s)
+		}
+	}
+	if result.Verdict != DebateCon || result.Confidence != 0.8 {
+		t.Errorf("verdict = %q at %.2f, want con at 0.80", result.Verdict, result.Confidence)
+	}
+	if result.FinalOutput != result.Judgment.Output || result.TotalCost < 0.069 {
+		t.Errorf("final output %q, cost %.2f", result.FinalOutput, result.TotalCost)
+	}
+
+	// A failed debater ends the debate before the judge runs.
+	failing := funcExecutor(func(ctx context.Context, model, prompt string) (*ModelResponse, error) {
+		if model == "gpt-5.2" {
+			return nil, errors.New("HTTP 500 Internal Server Error")
+		}
+		return &ModelResponse{Output: "argument", Success: true}, nil
+	})
+	result, err = NewDebateExecutor(failing, config).Execute(context.Background(), "Should we split the monolith?")
+	if err != nil {
+		t.Fatalf("Execute failed: %v", err)
+	}
+	if result.Success || !strings.Contains(result.Error, "round 1 con") || result.Judgment.Model != "" {
+		t.Errorf("result = %+v, want failure at round 1 con without judging", result)
+	}
+}
+
+func TestParseVerdict(t *testing.T) {
+	tests := map[string]string{
+		"Verdict: PRO":                    DebatePro,
+		"**Verdict:** against\nmore text": DebateCon,
+		"verdict = for":                   DebatePro,
+		"Verdict: CON\n...\nVerdict: PRO": DebatePro,
+		"no decision":                     "",
+	}
+	for output, want := range tests {
+		if got := ParseVerdict(output); got != want {
+			t.Errorf("ParseVerdict(%q) = %q, want %q", output, got, want)
+		}
+	}
+}
@@ -0,0 +1,257 @@
+package council
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// ChangeKind says how a ConfigChange alters a config.
+type ChangeKind string
+
+// Change kinds.
+const (
+	ChangeAdded    ChangeKind = "added"
+	ChangeRemoved  ChangeKind = "removed"
+	ChangeModified ChangeKind = "modified"
+)
+
+// Fields a ConfigChange can touch, named as in the config file. Model
+// costs are reported per model as "model_cost.<model>".
+const (
+	FieldModel             = "model"
+	FieldFallback          = "fallback"
+	FieldComplexity        = "complexity"
+	FieldComplexityRouting = "complexity_routing"
+	FieldRationale         = "rationale"
+	FieldProvider          = "provider"
+	FieldForceMode         = "force_mode"
+	FieldAllowedModels     = "allowed_models"
+	FieldDeniedModels      = "denied_models"
+	FieldSampleRate        = "sample_rate"
+	FieldRoleType          = "role_type"
+	FieldMinConfidence     = "min_confidence"
+
+	FieldEnabled       = "enabled"
+	FieldRateLimit     = "rate_limit"
+	FieldMaxConcurrent = "max_concurrent"
+	FieldPriority      = "priority"
+	FieldModels        = "models"
+	FieldModelsURL     = "models_url"
+	FieldAPIKeyEnv     = "api_key_env"
+	FieldHeaders       = "headers"
+
+	FieldModelCost        = "model_cost"
+	FieldMaxFallbackDepth = "max_fallback_depth"
+)
+
+// ConfigChange is one difference between two configs. Role is set for
+// changes to a role and Provider for changes to a providers entry; with
+// neither, the change is to the defaults or, for model_cost and
+// max_fallback_depth, to a config-wide setting. Old is empty for added
+// values and New for removed ones; lists are comma-separated.
+type ConfigChange struct {
+	Role     string     `json:"role,omitempty"`
+	Provider string     `json:"provider,omitempty"`
+	Field    string     `json:"field"`
+	Kind     ChangeKind `json:"kind"`
+	Old      string     `json:"old,omitempty"`
+	New      string     `json:"new,omitempty"`
+}
+
+// Scope names the part of the config the change touches: the role,
+// "provider <name>", "defaults", or "config".
+func (c ConfigChange) Scope() string {
+	switch {
+	case c.Role != "":
+		return c.Role
+	case c.Provider != "":
+		return "provider " + c.Provider
+	case c.Field == FieldMaxFallbackDepth || strings.HasPrefix(c.Field, FieldModelCost+"."):
+		return "config"
+	default:
+		return "defaults"
+	}
+}
+
+// DiffProfiles lists what replacing current with incoming would change in
+// every persisted setting except the schema version: each role's fields,
+// then the defaults, the providers, and the config-wide model costs and
+// fallback depth. Roles, providers, and models are compared in sorted
+// order; an entry present in only one config shows up as added or removed
+// fields.
+func DiffProfiles(current, incoming *Config) []ConfigChange {
+	if current == nil {
+		current = &Config{}
+	}
+	if incoming == nil {
+		incoming = &Config{}
+	}
+
+	var d configDiff
+	for _, role := range unionKeys(current.Roles, incoming.Roles) {
+		before, after := current.Roles[role], incoming.Roles[role]
+		if before == nil {
+			before = &RoleConfig{}
+		}
+		if after == nil {
+			after = &RoleConfig{}
+		}
+		d.scope, d.provider = role, ""
+		d.add(FieldModel, before.Model, after.Model)
+		d.add(FieldFallback, joinList(before.Fallback), joinList(after.Fallback))
+		d.add(FieldComplexity, formatComplexity(before.Complexity), formatComplexity(after.Complexity))
+		d.add(FieldComplexityRouting, formatBool(before.ComplexityRouting), formatBool(after.ComplexityRouting))
+		d.add(FieldProvider, before.Provider, after.Provider)
+		d.add(FieldRationale, before.Rationale, after.Rationale)
+		d.add(FieldForceMode, formatBoolPtr(before.ForceMode), formatBoolPtr(after.ForceMode))
+		d.add(FieldAllowedModels, joinList(before.AllowedModels), joinList(after.AllowedModels))
+		d.add(FieldDeniedModels, joinList(before.DeniedModels), joinList(after.DeniedModels))
+		d.add(FieldSampleRate, formatRate(before.SampleRate), formatRate(after.SampleRate))
+		d.add(FieldRoleType, before.RoleType, after.RoleType)
+		d.add(FieldMinConfidence, formatRate(before.MinConfidence), formatRate(after.MinConfidence))
+	}
+
+	before, after := current.Defaults, incoming.Defaults
+	if before == nil {
+		before = &DefaultConfig{}
+	}
+	if after == nil {
+		after = &DefaultConfig{}
+	}
+	d.scope, d.provider = "", ""
+	d.add(FieldModel, before.Model, after.Model)
+	d.add(FieldFallback, joinList(before.Fallback), joinList(after.Fallback))
+	d.add(FieldProvider, before.Provider, after.Provider)
+
+	for _, name := range unionKeys(current.Providers, incoming.Providers) {
+		before, after := current.Providers[name], incoming.Providers[name]
+		if before == nil {
+			before = &ProviderConfig{}
+		}
+		if after == nil {
+			after = &ProviderConfig{}
+		}
+		d.scope, d.provider = "", name
+		d.add(FieldEnabled, formatBool(before.Enabled), formatBool(after.Enabled))
+		d.add(FieldRateLimit, formatInt(before.RateLimit), formatInt(after.RateLimit))
+		d.add(FieldMaxConcurrent, formatInt(before.MaxConcurrent), formatInt(after.MaxConcurrent))
+		d.add(FieldPriority, formatInt(before.Priority), formatInt(after.Priority))
+		d.add(FieldModels, joinList(before.Models), joinList(after.Models))
+		d.add(FieldModelsURL, before.ModelsURL, after.ModelsURL)
+		d.add(FieldAPIKeyEnv, before.APIKeyEnv, after.APIKeyEnv)
+		oldHeaders, newHeaders := formatHeaders(before.Headers), formatHeaders(after.Headers)
+		if oldHeaders == newHeaders && !maps.Equal(before.Headers, after.Headers) {
+			newHeaders += " (values changed)"
+		}
+		d.add(FieldHeaders, oldHeaders, newHeaders)
+	}
+
+	d.scope, d.provider = "", ""
+	for _, model := range unionKeys(current.ModelCost, incoming.ModelCost) {
+		d.add(FieldModelCost+"."+model, formatCost(current.ModelCost, model), formatCost(incoming.ModelCost, model))
+	}
+	d.add(FieldMaxFallbackDepth, formatInt(current.MaxFallbackDepth), formatInt(incoming.MaxFallbackDepth))
+
+	return d.changes
+}
+
+// configDiff accumulates changes for the role or provider being compared.
+type configDiff struct {
+	scope, provider string
+	changes         []ConfigChange
+}
+
+// add records from → to when they differ.
+func (d *configDiff) add(field, from, to string) {
+	if from == to {
+		return
+	}
+	kind := ChangeModified
+	switch {
+	case from == "":
+		kind = ChangeAdded
+	case to == "":
+		kind = ChangeRemoved
+	}
+	d.changes = append(d.changes, ConfigChange{Role: d.scope, Provider: d.provider, Field: field, Kind: kind, Old: from, New: to})
+}
+
+// unionKeys returns the keys of a and b, sorted.
+func unionKeys[V any](a, b map[string]V) []string {
+	keys := sortedKeys(a)
+	for _, k := range sortedKeys(b) {
+		if _, ok := a[k]; !ok {
+			keys = append(keys, k)
+		}
+	}
+	slices.Sort(keys)
+	return keys
+}
+
+func joinList(items []string) string {
+	return strings.Join(items, ", ")
+}
+
+// formatBool renders true, and false as unset.
+func formatBool(b bool) string {
+	if b {
+		return "true"
+	}
+	return ""
+}
+
+// formatBoolPtr renders an optional bool; nil is unset.
+func formatBoolPtr(b *bool) string {
+	if b == nil {
+		return ""
+	}
+	return strconv.FormatBool(*b)
+}
+
+// formatInt renders n, and zero as unset.
+func formatInt(n int) string {
+	if n == 0 {
+		return ""
+	}
+	return strconv.Itoa(n)
+}
+
+// formatRate renders f, and zero as unset.
+func formatRate(f float64) string {
+	if f == 0 {
+		return ""
+	}
+	return strconv.FormatFloat(f, 'g', -1, 64)
+}
+
+// formatCost renders a model's cost entry, or "" if it has none.
+func formatCost(costs map[string]float64, model string) string {
+	cost, ok := costs[model]
+	if !ok {
+		return ""
+	}
+	return strconv.FormatFloat(cost, 'g', -1, 64)
+}
+
+// formatHeaders renders the header names in h, sorted. Values may hold
+// credentials, so they are never shown.
+func formatHeaders(h map[string]string) string {
+	return joinList(sortedKeys(h))
+}
+
+// formatComplexity renders a role's complexity models, or "" if unset.
+func formatComplexity(cc *ComplexityConfig) string {
+	if cc == nil {
+		return ""
+	}
+	var parts []string
+	for _, l := range complexityLevels(cc) {
+		if l.model != "" {
+			parts = append(parts, fmt.Sprintf("%s=%s", l.name, l.model))
+		}
+	}
+	return strings.Join(parts, " ")
+}
@@ -0,0 +1,87 @@
+package council
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDiffProfiles(t *testing.T) {
+	if changes := DiffProfiles(DefaultCouncilConfig(), DefaultCouncilConfig()); len(changes) != 0 {
+		t.Errorf("identical configs: got %v, want no changes", changes)
+	}
+
+	current := &Config{
+		Roles: map[string]*RoleConfig{
+			"mayor":   {Model: "opus-4.5", Fallback: []string{"sonnet-4.5"}},
+			"polecat": {Model: "sonnet-4.5", Complexity: &ComplexityConfig{High: "opus-4.5"}},
+			"witness": {Model: "gemini-3-flash"},
+		},
+		Defaults: &DefaultConfig{Model: "sonnet-4.5"},
+	}
+	incoming := &Config{
+		Roles: map[string]*RoleConfig{
+			"mayor":    {Model: "opus-4.5", Fallback: []string{"gpt-5.2", "sonnet-4.5"}},
+			"polecat":  {Model: "gpt-5.2", Complexity: &ComplexityConfig{High: "opus-4.5", Low: "gemini-3-flash"}},
+			"refinery": {Model: "gpt-5.2-high"},
+		},
+		Defaults: &DefaultConfig{Model: "sonnet-4.5", Fallback: []string{"gpt-5.2"}},
+	}
+
+	want := []ConfigChange{
+		{Role: "mayor", Field: FieldFallback, Kind: ChangeModified, Old: "sonnet-4.5", New: "gpt-5.2, sonnet-4.5"},
+		{Role: "polecat", Field: FieldModel, Kind: ChangeModified, Old: "sonnet-4.5", New: "gpt-5.2"},
+		{Role: "polecat", Field: FieldComplexity, Kind: ChangeModified, Old: "high=opus-4.5", New: "high=opus-4.5 low=gemini-3-flash"},
+		{Role: "refinery", Field: FieldModel, Kind: ChangeAdded, New: "gpt-5.2-high"},
+		{Role: "witness", Field: FieldModel, Kind: ChangeRemoved, Old: "gemini-3-flash"},
+		{Field: FieldFallback, Kind: ChangeAdded, New: "gpt-5.2"},
+	}
+	if got := DiffProfiles(current, incoming); !reflect.DeepEqual(got, want) {
+		t.Errorf("DiffProfiles =\n%+v\nwant\n%+v", got, want)
+	}
+}
+
+func TestDiffProfiles_AllFields(t *testing.T) {
+	enabled := false
+	current := &Config{
+		Roles:     map[string]*RoleConfig{"polecat": {Model: "sonnet-4.5"}},
+		Providers: map[string]*ProviderConfig{"anthropic": {Enabled: true, Priority: 100, Headers: map[string]string{"X-Org": "a"}}},
+		ModelCost: map[string]float64{"sonnet-4.5": 3},
+	}
+	incoming := &Config{
+		Roles: map[string]*RoleConfig{"polecat": {
+			Model: "sonnet-4.5", ComplexityRouting: true, Provider: "anthropic", ForceMode: &enabled, MinConfidence: 0.7,
+		}},
+		Providers: map[string]*ProviderConfig{
+			"anthropic": {Enabled: true, Priority: 90, Headers: map[string]string{"X-Org": "b"}},
+			"openai":    {Enabled: true},
+		},
+		ModelCost:        map[string]float64{"sonnet-4.5": 3, "gpt-5.2": 1.75},
+		MaxFallbackDepth: 2,
+	}
+
+	want := []ConfigChange{
+		{Role: "polecat", Field: FieldComplexityRouting, Kind: ChangeAdded, New: "true"},
+		{Role: "polecat", Field: FieldProvider, Kind: ChangeAdded, New: "anthropic"},
+		{Role: "polecat", Field: FieldForceMode, Kind: ChangeAdded, New: "false"},
+		{Role: "polecat", Field: FieldMinConfidence, Kind: ChangeAdded, New: "0.7"},
+		{Provider: "anthropic", Field: FieldPriority, Kind: ChangeModified, Old: "100", New: "90"},
+		{Provider: "anthropic", Field: FieldHeaders, Kind: ChangeModified, Old: "X-Org", New: "X-Org (values changed)"},
+		{Provider: "openai", Field: FieldEnabled, Kind: ChangeAdded, New: "true"},
+		{Field: "model_cost.gpt-5.2", Kind: ChangeAdded, New: "1.75"},
+		{Field: FieldMaxFallbackDepth, Kind: ChangeAdded, New: "2"},
+	}
+	got := DiffProfiles(current, incoming)
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("DiffProfiles =\n%+v\nwant\n%+v", got, want)
+	}
+
+	scopes := []string{"polecat", "provider anthropic", "config"}
+	for i, c := range []ConfigChange{got[0], got[4], got[8]} {
+		if c.Scope() != scopes[i] {
+			t.Errorf("Scope() = %q, want %q", c.Scope(), scopes[i])
+		}
+	}
+	if (ConfigChange{Field: FieldModel}).Scope() != "defaults" {
+		t.Error("a defaults change should have scope defaults")
+	}
+}
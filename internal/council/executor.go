@@ -0,0 +1,297 @@
+// Package council provides multi-model orchestration for Gas Town.
+package council
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/cursorworkshop/cursor-gastown/internal/cursor"
+)
+
+// CursorExecutor executes prompts by running cursor-agent in print mode.
+// It implements ModelExecutor so chain and ensemble patterns can run
+// against real models.
+type CursorExecutor struct {
+	// WorkDir is the workspace cursor-agent runs in.
+	WorkDir string
+
+	// Providers supplies per-provider limits such as MaxConcurrent.
+	// Nil means no limits.
+	Providers map[string]*ProviderConfig
+
+	// Role is passed to Processors and selects the role's adapter policy
+	// from Config.
+	Role string
+
+	// Config, when set, supplies the role's cursor-agent policy: its model
+	// allow and deny lists and force-mode setting (see
+	// Config.AdapterForRole). Nil uses the defaults.
+	Config *Config
+
+	// Processors transform every prompt, in order, before it is sent.
+	// A processor error aborts the call.
+	Processors []PromptProcessor
+
+	// MinConfidence and Fallback gate single-model responses: a response
+	// whose parsed confidence is below MinConfidence is escalated to the
+	// next Fallback model. Zero disables the gate. See ForRole.
+	MinConfidence float64
+	Fallback      []string
+
+	// Metrics, when set, records each escalation's response as a fallback
+	// task.
+	Metrics *MetricsStore
+
+	// RateLimits, when set, receives any rate-limit headers cursor-agent
+	// echoes from the provider so routing can steer away from a provider
+	// that is nearly exhausted.
+	RateLimits *FallbackManager
+
+	mu   sync.Mutex
+	sems map[string]chan struct{} // provider -> concurrency slots
+
+	// run invokes cursor-agent; overridden in tests.
+	run func(ctx context.Context, adapter *cursor.Adapter, prompt string) (*cursor.RunResult, error)
+}
+
+// NewCursorExecutor creates an executor that runs cursor-agent in workDir.
+func NewCursorExecutor(workDir string) *CursorExecutor {
+	return &CursorExecutor{
+		WorkDir: workDir,
+		run:     runCursorAgent,
+	}
+}
+
+// ForRole configures the executor from cfg for role: the role's adapter
+// policy, provider limits, the role name for processors, and the role's
+// MinConfidence gate with its fallback chain.
+func (e *CursorExecutor) ForRole(cfg *Config, role string) *CursorExecutor {
+	e.Role = role
+	e.Config = cfg
+	e.Providers = cfg.Providers
+	if rc := cfg.Roles[role]; rc != nil {
+		e.MinConfidence = rc.MinConfidence
+		e.Fallback = rc.Fallback
+	}
+	return e
+}
+
+// Execute runs the prompt against the given model. With MinConfidence set,
+// a successful response reporting lower confidence is escalated through
+// Fallback until one clears the bar; if none does, the most confident
+// response is returned with LowConfidence set.
+func (e *CursorExecutor) Execute(ctx context.Context, model, prompt string) (*ModelResponse, error) {
+	response, err := e.execute(ctx, model, prompt)
+	if err != nil || !e.belowConfidence(response) {
+		return response, err
+	}
+
+	best := response
+	escalated := []string{model}
+	tried := map[string]bool{model: true}
+	for _, next := range e.Fallback {
+		if tried[next] {
+			continue
+		}
+		tried[next] = true
+
+		candidate, err := e.execute(ctx, next, prompt)
+		if err != nil {
+			continue
+		}
+		e.recordEscalation(model, candidate)
+		if !candidate.Success {
+			continue
+		}
+		candidate.EscalatedFrom = append([]string(nil), escalated...)
+		if !e.belowConfidence(candidate) {
+			return candidate, nil
+		}
+		if candidate.Confidence > best.Confidence {
+			best = candidate
+		}
+		escalated = append(escalated, candidate.Model)
+	}
+
+	best.LowConfidence = true
+	return best, nil
+}
+
+// belowConfidence reports whether a successful response reported a
+// confidence under MinConfidence.
+func (e *CursorExecutor) belowConfidence(r *ModelResponse) bool {
+	if e.MinConfidence <= 0 || r == nil || !r.Success {
+		return false
+	}
+	confidence, ok := ParseConfidence(r.Output)
+	return ok && confidence < e.MinConfidence
+}
+
+// recordEscalation records the response of a fallback model escalated to
+// from primary. Metrics write failures never fail the call.
+func (e *CursorExecutor) recordEscalation(primary string, r *ModelResponse) {
+	if e.Metrics == nil {
+		return
+	}
+	now := time.Now()
+	_ = e.Metrics.RecordTask(TaskMetric{
+		ID:            fmt.Sprintf("escalation-%d", now.UnixNano()),
+		Role:          e.Role,
+		Model:         r.Model,
+		OriginalModel: primary,
+		Provider:      e.provider(r.Model),
+		StartedAt:     now.Add(-r.Duration),
+		CompletedAt:   now,
+		Duration:      r.Duration,
+		Tokens:        r.Tokens,
+		InputTokens:   r.InputTokens,
+		OutputTokens:  r.OutputTokens,
+		Success:       r.Success,
+		Error:         r.Error,
+		Fallback:      true,
+	})
+}
+
+// confidencePattern matches a "Confidence: 0.8" or "confidence = 80%" line.
+var confidencePattern = regexp.MustCompile(`(?im)^[\W_]*confidence[\W_]*?[:=]\s*([0-9]*\.?[0-9]+)\s*(%?)`)
+
+// ParseConfidence extracts a self-reported confidence from model output,
+// using the last "Confidence: X" line. Percentages and values above 1 are
+// scaled to 0-1. It reports false when no confidence is found.
+func ParseConfidence(output string) (float64, bool) {
+	matches := confidencePattern.FindAllStringSubmatch(output, -1)
+	if len(matches) == 0 {
+		return 0, false
+	}
+	m := matches[len(matches)-1]
+	value, err := strconv.ParseFloat(m[1], 64)
+	if err != nil {
+		return 0, false
+	}
+	if m[2] == "%" || value > 1 {
+		value /= 100
+	}
+	if value > 1 {
+		value = 1
+	}
+	return value, true
+}
+
+// execute runs the prompt against model once.
+func (e *CursorExecutor) execute(ctx context.Context, model, prompt string) (*ModelResponse, error) {
+	prompt, err := runProcessors(e.Processors, e.Role, model, prompt)
+	if err != nil {
+		return nil, err
+	}
+
+	adapter := e.Config.AdapterForRole(e.WorkDir, e.Role)
+	adapter.Model = model
+
+	provider := e.provider(model)
+	start := time.Now()
+	release, err := e.acquire(ctx, provider)
+	if err != nil {
+		return &ModelResponse{
+			Model:     model,
+			Duration:  time.Since(start),
+			Error:     fmt.Sprintf("waiting for %s concurrency slot: %v", provider, err),
+			ErrorKind: classifyError(err),
+		}, nil
+	}
+	result, err := e.run(ctx, adapter, prompt)
+	release()
+	var output string
+	if result != nil {
+		output = result.Output
+	}
+	response := &ModelResponse{
+		Model:    model,
+		Output:   output,
+		Duration: time.Since(start),
+		Success:  err == nil,
+	}
+	if result != nil {
+		response.InputTokens = result.InputTokens
+		response.OutputTokens = result.OutputTokens
+		response.Tokens = result.InputTokens + result.OutputTokens
+		response.Cost = result.Cost
+	}
+	if err != nil {
+		response.Error = sanitizeError(err.Error())
+		response.ErrorKind = classifyError(err)
+	}
+	// Rate-limit headers only come back in cursor-agent's error report;
+	// the model's answer is never parsed, since it may mention quotas.
+	if e.RateLimits != nil && err != nil {
+		e.RateLimits.RecordRateLimitInfo(provider, parseRateLimitText(err.Error(), time.Now()))
+	}
+
+	// Older cursor-agent versions don't report usage, so estimate it.
+	if response.Tokens == 0 {
+		response.InputTokens = int64(EstimateTokens(prompt, model))
+		response.OutputTokens = int64(EstimateTokens(output, model))
+		response.Tokens = response.InputTokens + response.OutputTokens
+	}
+	if confidence, ok := ParseConfidence(output); ok && response.Success {
+		response.Confidence = confidence
+	}
+
+	return response, nil
+}
+
+// provider returns the provider serving model for the executor's role,
+// honoring the role's provider pin when Config is set.
+func (e *CursorExecutor) provider(model string) string {
+	if e.Config == nil {
+		return ModelProvider(model)
+	}
+	return e.Config.ProviderFor(e.Role, model)
+}
+
+// acquire waits for a concurrency slot for provider, honoring the
+// provider's MaxConcurrent. It returns a release func, or ctx's error if
+// the context ends while queued.
+func (e *CursorExecutor) acquire(ctx context.Context, provider string) (func(), error) {
+	pc := e.Providers[provider]
+	if pc == nil || pc.MaxConcurrent <= 0 {
+		return func() {}, nil
+	}
+
+	e.mu.Lock()
+	if e.sems == nil {
+		e.sems = make(map[string]chan struct{})
+	}
+	sem, ok := e.sems[provider]
+	if !ok {
+		sem = make(chan struct{}, pc.MaxConcurrent)
+		e.sems[provider] = sem
+	}
+	e.mu.Unlock()
+
+	select {
+	case sem <- struct{}{}:
+		return func() { <-sem }, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// runCursorAgent runs cursor-agent non-interactively with JSON output and
+// returns its parsed result, including any usage and cost it reports.
+// Adapter.RunStructuredContext enforces the adapter's policy: prompt and
+// model checks, the output cap, and cancellation. A result cursor-agent
+// reports as an error is returned along with an error carrying its message.
+func runCursorAgent(ctx context.Context, adapter *cursor.Adapter, prompt string) (*cursor.RunResult, error) {
+	result, err := adapter.RunStructuredContext(ctx, prompt)
+	if err != nil {
+		return nil, err
+	}
+	if !result.Success {
+		return result, fmt.Errorf("cursor-agent failed: %s", result.Error)
+	}
+	return result, nil
+}
@@ -0,0 +1,304 @@
+package council
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/cursorworkshop/cursor-gastown/internal/cursor"
+)
+
+// stubCursorExecutor returns a CursorExecutor whose cursor-agent invocation
+// is replaced by fn.
+func stubCursorExecutor(fn func(ctx context.Context, adapter *cursor.Adapter, prompt string) (string, error)) *CursorExecutor {
+	e := NewCursorExecutor("/tmp/workspace")
+	e.run = func(ctx context.Context, adapter *cursor.Adapter, prompt string) (*cursor.RunResult, error) {
+		output, err := fn(ctx, adapter, prompt)
+		return &cursor.RunResult{Output: output, Success: err == nil}, err
+	}
+	return e
+}
+
+func TestCursorExecutor_EstimatesTokens(t *testing.T) {
+	output := strings.Repeat("o", 400)
+	e := stubCursorExecutor(func(ctx context.Context, adapter *cursor.Adapter, prompt string) (string, error) {
+		if adapter.Model != "gpt-5.2" {
+			t.Errorf("adapter model = %q, want gpt-5.2", adapter.Model)
+		}
+		return output, nil
+	})
+
+	resp, err := e.Execute(context.Background(), "gpt-5.2", strings.Repeat("p", 400))
+	if err != nil {
+		t.Fatalf("Execute failed: %v", err)
+	}
+	if !resp.Success || resp.Output != output {
+		t.Errorf("unexpected response: success=%v output=%q", resp.Success, resp.Output)
+	}
+	if resp.Tokens != 200 {
+		t.Errorf("Tokens = %d, want 200 (100 prompt + 100 output)", resp.Tokens)
+	}
+}
+
+func TestCursorExecutor_UsesReportedUsage(t *testing.T) {
+	e := NewCursorExecutor("/tmp/workspace")
+	e.run = func(ctx context.Context, adapter *cursor.Adapter, prompt string) (*cursor.RunResult, error) {
+		return &cursor.RunResult{Output: "done", InputTokens: 1200, OutputTokens: 300, Cost: 0.04, Success: true}, nil
+	}
+
+	resp, err := e.Execute(context.Background(), "sonnet-4.5", "prompt")
+	if err != nil {
+		t.Fatalf("Execute failed: %v", err)
+	}
+	if resp.InputTokens != 1200 || resp.OutputTokens != 300 || resp.Tokens != 1500 || resp.Cost != 0.04 {
+		t.Errorf("response = %+v, want cursor-agent's reported usage and cost", resp)
+	}
+}
+
+func TestCursorExecutor_MaxConcurrent(t *testing.T) {
+	var mu sync.Mutex
+	running, maxRunning, calls := 0, 0, 0
+
+	e := stubCursorExecutor(func(ctx context.Context, adapter *cursor.Adapter, prompt string) (string, error) {
+		mu.Lock()
+		running++
+		calls++
+		if running > maxRunning {
+			maxRunning = running
+		}
+		mu.Unlock()
+
+		time.Sleep(20 * time.Millisecond)
+
+		mu.Lock()
+		running--
+		mu.Unlock()
+		return "same answer", nil
+	})
+	e.Providers = map[string]*ProviderConfig{
+		"anthropic": {Enabled: true, MaxConcurrent: 1},
+	}
+
+	ensemble := NewEnsembleExecutor(e, &EnsembleConfig{
+		Models:         []string{"opus-4.5", "sonnet-4.5", "sonnet-4.5-thinking"},
+		VotingStrategy: VoteMajority,
+	})
+	result, err := ensemble.Execute(context.Background(), "review this")
+	if err != nil {
+		t.Fatalf("Execute failed: %v", err)
+	}
+	if !result.Success {
+		t.Fatalf("ensemble failed: %s", result.Error)
+	}
+	if calls != 3 {
+		t.Errorf("calls = %d, want 3", calls)
+	}
+	if maxRunning != 1 {
+		t.Errorf("max concurrent runs = %d, want 1 (serial)", maxRunning)
+	}
+}
+
+func TestCursorExecutor_MaxConcurrentRespectsContext(t *testing.T) {
+	e := stubCursorExecutor(func(ctx context.Context, adapter *cursor.Adapter, prompt string) (string, error) {
+		return "ok", nil
+	})
+	e.Providers = map[string]*ProviderConfig{"anthropic": {MaxConcurrent: 1}}
+
+	// Hold the only slot so the next request queues.
+	release, err := e.acquire(context.Background(), "anthropic")
+	if err != nil {
+		t.Fatalf("acquire failed: %v", err)
+	}
+	defer release()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	resp, err := e.Execute(ctx, "sonnet-4.5", "hello")
+	if err != nil {
+		t.Fatalf("Execute failed: %v", err)
+	}
+	if resp.Success {
+		t.Error("queued request should fail once the context expires")
+	}
+}
+
+func TestCursorExecutor_MaxConcurrentUsesPinnedProvider(t *testing.T) {
+	cfg := DefaultCouncilConfig()
+	cfg.Providers["bedrock"] = &ProviderConfig{Enabled: true, MaxConcurrent: 1, Models: []string{"sonnet-4.5"}}
+	cfg.Roles["polecat"].Provider = "bedrock"
+	e := stubCursorExecutor(func(ctx context.Context, adapter *cursor.Adapter, prompt string) (string, error) {
+		return "ok", nil
+	}).ForRole(cfg, "polecat")
+
+	release, err := e.acquire(context.Background(), "bedrock")
+	if err != nil {
+		t.Fatalf("acquire failed: %v", err)
+	}
+	defer release()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	resp, _ := e.Execute(ctx, "sonnet-4.5", "hello")
+	if resp.Success || !strings.Contains(resp.Error, "bedrock concurrency slot") {
+		t.Errorf("response = %+v, want it queued on the pinned bedrock slot", resp)
+	}
+}
+
+func TestCursorExecutor_MinConfidenceEscalates(t *testing.T) {
+	cfg := DefaultCouncilConfig()
+	cfg.Roles["polecat"].MinConfidence = 0.7
+
+	answers := map[string]string{
+		"sonnet-4.5": "Patched it, probably.\nConfidence: 0.4",
+		"gpt-5.2":    "Patched and tested.\nConfidence: 90%",
+	}
+	var calls []string
+	e := stubCursorExecutor(func(ctx context.Context, adapter *cursor.Adapter, prompt string) (string, error) {
+		calls = append(calls, adapter.Model)
+		return answers[adapter.Model], nil
+	}).ForRole(cfg, "polecat")
+	e.Metrics = newTestMetricsStore(t)
+
+	resp, err := e.Execute(context.Background(), "sonnet-4.5", "fix the bug")
+	if err != nil {
+		t.Fatalf("Execute failed: %v", err)
+	}
+	if resp.Model != "gpt-5.2" || resp.Confidence != 0.9 || resp.LowConfidence {
+		t.Errorf("response = %s at %.2f (low=%v), want gpt-5.2 at 0.90", resp.Model, resp.Confidence, resp.LowConfidence)
+	}
+	if len(resp.EscalatedFrom) != 1 || resp.EscalatedFrom[0] != "sonnet-4.5" {
+		t.Errorf("EscalatedFrom = %v, want [sonnet-4.5]", resp.EscalatedFrom)
+	}
+	tasks := e.Metrics.GetRecentTasks(10)
+	if len(tasks) != 1 || tasks[0].Model != "gpt-5.2" || !tasks[0].Fallback || tasks[0].OriginalModel != "sonnet-4.5" {
+		t.Errorf("recorded tasks = %+v, want one fallback task on gpt-5.2 replacing sonnet-4.5", tasks)
+	}
+	if tk := tasks[0]; tk.InputTokens == 0 || tk.OutputTokens == 0 || tk.Tokens != tk.InputTokens+tk.OutputTokens {
+		t.Errorf("escalation tokens = %d in + %d out = %d, want a split that sums to the total", tk.InputTokens, tk.OutputTokens, tk.Tokens)
+	}
+
+	// A confident first answer is accepted as-is.
+	calls = nil
+	resp, err = e.Execute(context.Background(), "gpt-5.2", "fix the bug")
+	if err != nil {
+		t.Fatalf("Execute failed: %v", err)
+	}
+	if resp.Model != "gpt-5.2" || len(calls) != 1 || len(resp.EscalatedFrom) != 0 {
+		t.Errorf("confident response escalated: model=%s calls=%v", resp.Model, calls)
+	}
+
+	// Failed and unsuccessful fallbacks don't repeat models in EscalatedFrom.
+	cfg.Roles["polecat"].Fallback = []string{"gemini-3-flash", "haiku-3.5", "gpt-5.2"}
+	answers["haiku-3.5"] = "Maybe.\nConfidence: 0.5"
+	e = stubCursorExecutor(func(ctx context.Context, adapter *cursor.Adapter, prompt string) (string, error) {
+		if adapter.Model == "gemini-3-flash" {
+			return "", errors.New("exit status 1")
+		}
+		return answers[adapter.Model], nil
+	}).ForRole(cfg, "polecat")
+	resp, err = e.Execute(context.Background(), "sonnet-4.5", "fix the bug")
+	if err != nil {
+		t.Fatalf("Execute failed: %v", err)
+	}
+	if got := strings.Join(resp.EscalatedFrom, ","); resp.Model != "gpt-5.2" || got != "sonnet-4.5,haiku-3.5" {
+		t.Errorf("response = %s escalated from %s, want gpt-5.2 from sonnet-4.5,haiku-3.5", resp.Model, got)
+	}
+}
+
+func TestParseConfidence(t *testing.T) {
+	tests := []struct {
+		output string
+		want   float64
+		ok     bool
+	}{
+		{"Done.\nConfidence: 0.85", 0.85, true},
+		{"**Confidence**: 70%", 0.7, true},
+		{"confidence = 95", 0.95, true},
+		{"I am confident this works.", 0, false},
+	}
+	for _, tt := range tests {
+		got, ok := ParseConfidence(tt.output)
+		if ok != tt.ok || (ok && (got < tt.want-1e-9 || got > tt.want+1e-9)) {
+			t.Errorf("ParseConfidence(%q) = %v, %v; want %v, %v", tt.output, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestCursorExecutor_RecordsEchoedRateLimits(t *testing.T) {
+	e := stubCursorExecutor(func(ctx context.Context, adapter *cursor.Adapter, prompt string) (string, error) {
+		return "", errors.New("HTTP 429 Too Many Requests\nretry-after: 30\nx-ratelimit-remaining: 0")
+	})
+	e.RateLimits = NewFallbackManager(NewRouter(DefaultCouncilConfig()))
+
+	if _, err := e.Execute(context.Background(), "sonnet-4.5", "review"); err != nil {
+		t.Fatalf("Execute failed: %v", err)
+	}
+	quota, ok := e.RateLimits.ProviderQuota("anthropic")
+	if !ok || !quota.HasRemaining || quota.Remaining != 0 {
+		t.Fatalf("quota = %+v, %v; want 0 remaining", quota, ok)
+	}
+	if wait := time.Until(quota.RetryAfter); wait < 25*time.Second || wait > 30*time.Second {
+		t.Errorf("RetryAfter is %v away, want ~30s", wait)
+	}
+}
+
+func TestCursorExecutor_EnforcesAdapterPolicy(t *testing.T) {
+	cfg := DefaultCouncilConfig()
+	cfg.Roles["polecat"].DeniedModels = []string{"gpt-5.2"}
+	e := NewCursorExecutor(t.TempDir()).ForRole(cfg, "polecat")
+
+	// Both are rejected before cursor-agent would be started.
+	resp, err := e.Execute(context.Background(), "gpt-5.2", "fix the bug")
+	if err != nil {
+		t.Fatalf("Execute failed: %v", err)
+	}
+	if resp.Success || !strings.Contains(resp.Error, "model not allowed") {
+		t.Errorf("denied model response = %+v, want a policy error", resp)
+	}
+
+	resp, err = e.Execute(context.Background(), "sonnet-4.5", "   ")
+	if err != nil {
+		t.Fatalf("Execute failed: %v", err)
+	}
+	if resp.Success || !strings.Contains(resp.Error, cursor.ErrEmptyPrompt.Error()) {
+		t.Errorf("empty prompt response = %+v, want ErrEmptyPrompt", resp)
+	}
+}
+
+func TestCursorExecutor_IgnoresRateLimitTextInAnswers(t *testing.T) {
+	e := stubCursorExecutor(func(ctx context.Context, adapter *cursor.Adapter, prompt string) (string, error) {
+		return "Set the header like this:\nretry-after: 30\nx-ratelimit-remaining: 0", nil
+	})
+	e.RateLimits = NewFallbackManager(NewRouter(DefaultCouncilConfig()))
+
+	if _, err := e.Execute(context.Background(), "sonnet-4.5", "explain rate limits"); err != nil {
+		t.Fatalf("Execute failed: %v", err)
+	}
+	if quota, ok := e.RateLimits.ProviderQuota("anthropic"); ok {
+		t.Errorf("quota = %+v, want nothing recorded from a successful answer", quota)
+	}
+}
+
+func TestCursorExecutor_RecordsRateLimitsForPinnedProvider(t *testing.T) {
+	cfg := DefaultCouncilConfig()
+	cfg.Providers["bedrock"] = &ProviderConfig{Enabled: true, Models: []string{"sonnet-4.5"}}
+	cfg.Roles["polecat"].Provider = "bedrock"
+
+	e := stubCursorExecutor(func(ctx context.Context, adapter *cursor.Adapter, prompt string) (string, error) {
+		return "", errors.New("HTTP 429 Too Many Requests\nx-ratelimit-remaining: 0")
+	}).ForRole(cfg, "polecat")
+	e.RateLimits = NewFallbackManager(NewRouter(cfg))
+
+	if _, err := e.Execute(context.Background(), "sonnet-4.5", "review"); err != nil {
+		t.Fatalf("Execute failed: %v", err)
+	}
+	if _, ok := e.RateLimits.ProviderQuota("bedrock"); !ok {
+		t.Error("expected the quota to be recorded against the pinned provider")
+	}
+	if _, ok := e.RateLimits.ProviderQuota("anthropic"); ok {
+		t.Error("quota recorded against the model's default provider")
+	}
+}
@@ -0,0 +1,103 @@
+// Package council provides multi-model orchestration for Gas Town.
+package council
+
+import (
+	"sort"
+)
+
+// Explanation describes why a role uses its model, stitching together the
+// role, provider, fallback, and profile information.
+type Explanation struct {
+	Role      string `json:"role"`
+	Model     string `json:"model"`
+	Provider  string `json:"provider"`
+	Priority  int    `json:"provider_priority"`
+	Rationale string `json:"rationale,omitempty"`
+
+	// Source is where the model comes from: "role" or "defaults".
+	Source string `json:"source"`
+
+	// Fallbacks is the resolved fallback chain.
+	Fallbacks []FallbackExplanation `json:"fallbacks,omitempty"`
+
+	// FallbackSource is where the chain comes from: "role" or "defaults".
+	FallbackSource string `json:"fallback_source,omitempty"`
+
+	// Complexity is set when complexity routing applies to the role.
+	Complexity *ComplexityConfig `json:"complexity,omitempty"`
+
+	// ForceMode is the effective force-mode policy.
+	ForceMode bool `json:"force_mode"`
+
+	// Profiles lists predefined profiles that assign the same model to this role.
+	Profiles []string `json:"profiles,omitempty"`
+}
+
+// FallbackExplanation describes one model in a fallback chain.
+type FallbackExplanation struct {
+	Model    string `json:"model"`
+	Provider string `json:"provider"`
+	Priority int    `json:"provider_priority"`
+}
+
+// RoleExplanation explains the model selection for a role.
+func (c *Config) RoleExplanation(role string) *Explanation {
+	model := c.GetModelForRole(role)
+	exp := &Explanation{
+		Role:      role,
+		Model:     model,
+		Provider:  ModelProvider(model),
+		Priority:  c.providerPriority(ModelProvider(model)),
+		Rationale: c.GetRationale(role),
+		Source:    "defaults",
+		ForceMode: c.ForceModeFor(role),
+	}
+
+	rc := c.Roles[role]
+	if rc != nil && rc.Model != "" {
+		exp.Source = "role"
+	}
+	if rc != nil && rc.Provider != "" {
+		exp.Provider = rc.Provider
+		exp.Priority = c.providerPriority(rc.Provider)
+	}
+
+	for _, fb := range c.GetFallbackChain(role) {
+		provider := ModelProvider(fb)
+		exp.Fallbacks = append(exp.Fallbacks, FallbackExplanation{
+			Model:    fb,
+			Provider: provider,
+			Priority: c.providerPriority(provider),
+		})
+	}
+	if len(exp.Fallbacks) > 0 {
+		exp.FallbackSource = "defaults"
+		if rc != nil && len(rc.Fallback) > 0 {
+			exp.FallbackSource = "role"
+		}
+	}
+
+	if c.SupportsComplexityRouting(role) {
+		exp.Complexity = rc.Complexity
+	}
+
+	for name, profile := range PredefinedProfiles {
+		if profile.Config == nil {
+			continue
+		}
+		if prc := profile.Config.Roles[role]; prc != nil && prc.Model == model {
+			exp.Profiles = append(exp.Profiles, name)
+		}
+	}
+	sort.Strings(exp.Profiles)
+
+	return exp
+}
+
+// providerPriority returns a provider's configured priority, or 0.
+func (c *Config) providerPriority(provider string) int {
+	if pc := c.Providers[provider]; pc != nil {
+		return pc.Priority
+	}
+	return 0
+}
@@ -0,0 +1,36 @@
+package council
+
+import (
+	"testing"
+)
+
+func TestConfig_RoleExplanation(t *testing.T) {
+	cfg := DefaultCouncilConfig()
+	rc := cfg.Roles["refinery"]
+	rc.Fallback = []string{"sonnet-4.5", "gemini-3-pro"}
+
+	exp := cfg.RoleExplanation("refinery")
+
+	if exp.Model != rc.Model {
+		t.Errorf("Model = %q, want %q", exp.Model, rc.Model)
+	}
+	if exp.Rationale != rc.Rationale || exp.Rationale == "" {
+		t.Errorf("Rationale = %q, want %q", exp.Rationale, rc.Rationale)
+	}
+	if exp.Source != "role" {
+		t.Errorf("Source = %q, want role", exp.Source)
+	}
+	if exp.Priority != cfg.Providers[exp.Provider].Priority {
+		t.Errorf("Priority = %d, want %s priority", exp.Priority, exp.Provider)
+	}
+
+	wantProviders := []string{"anthropic", "google"}
+	if len(exp.Fallbacks) != len(wantProviders) {
+		t.Fatalf("Fallbacks = %+v, want %d entries", exp.Fallbacks, len(wantProviders))
+	}
+	for i, fb := range exp.Fallbacks {
+		if fb.Provider != wantProviders[i] {
+			t.Errorf("fallback %s provider = %q, want %q", fb.Model, fb.Provider, wantProviders[i])
+		}
+	}
+}
@@ -0,0 +1,115 @@
+package council
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Metrics export formats accepted by 'gt council stats --format'.
+const (
+	ExportCSV        = "csv"
+	ExportPrometheus = "prometheus"
+)
+
+// csvHeader is the header row written by WriteCSV.
+var csvHeader = []string{
+	"model", "provider", "total_tasks", "completed_tasks", "failed_tasks",
+	"success_rate", "avg_duration_seconds", "p95_duration_seconds", "total_cost",
+}
+
+// ExportCSV writes one CSV row per model; see Metrics.WriteCSV.
+func (s *MetricsStore) ExportCSV(w io.Writer) error {
+	return s.GetMetrics().WriteCSV(w)
+}
+
+// ExportPrometheus writes per-model metrics in the Prometheus text
+// exposition format; see Metrics.WritePrometheus.
+func (s *MetricsStore) ExportPrometheus(w io.Writer) error {
+	return s.GetMetrics().WritePrometheus(w)
+}
+
+// WriteCSV writes a header and one row per model, sorted by model, with task
+// counts, success rate, average and p95 duration in seconds, and total cost.
+// Numbers use '.' as the decimal separator regardless of locale.
+func (m *Metrics) WriteCSV(w io.Writer) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(csvHeader); err != nil {
+		return err
+	}
+	for _, mm := range m.sortedModels() {
+		row := []string{
+			mm.Model,
+			mm.Provider,
+			strconv.Itoa(mm.TotalTasks),
+			strconv.Itoa(mm.CompletedTasks),
+			strconv.Itoa(mm.FailedTasks),
+			formatFloat(mm.SuccessRate),
+			formatFloat(mm.AvgDuration.Seconds()),
+			formatFloat(mm.P95Duration.Seconds()),
+			formatFloat(mm.TotalCost),
+		}
+		if err := cw.Write(row); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// WritePrometheus writes gastown_model_tasks_total,
+// gastown_model_success_rate, and gastown_model_cost_dollars, each labeled
+// with model and provider, in the Prometheus text exposition format.
+func (m *Metrics) WritePrometheus(w io.Writer) error {
+	models := m.sortedModels()
+	families := []struct {
+		name, help, kind string
+		value            func(*ModelMetrics) float64
+	}{
+		{"gastown_model_tasks_total", "Tasks run on each model.", "counter",
+			func(mm *ModelMetrics) float64 { return float64(mm.TotalTasks) }},
+		{"gastown_model_success_rate", "Fraction of each model's tasks that succeeded (0-1).", "gauge",
+			func(mm *ModelMetrics) float64 { return mm.SuccessRate }},
+		{"gastown_model_cost_dollars", "Total cost of each model's tasks in dollars.", "gauge",
+			func(mm *ModelMetrics) float64 { return mm.TotalCost }},
+	}
+
+	var b strings.Builder
+	for _, f := range families {
+		fmt.Fprintf(&b, "# HELP %s %s\n# TYPE %s %s\n", f.name, f.help, f.name, f.kind)
+		for _, mm := range models {
+			fmt.Fprintf(&b, "%s{model=\"%s\",provider=\"%s\"} %s\n",
+				f.name, escapeLabel(mm.Model), escapeLabel(mm.Provider), formatFloat(f.value(mm)))
+		}
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// sortedModels returns the model metrics sorted by model name.
+func (m *Metrics) sortedModels() []*ModelMetrics {
+	models := make([]*ModelMetrics, 0, len(m.ByModel))
+	for _, mm := range m.ByModel {
+		if mm != nil {
+			models = append(models, mm)
+		}
+	}
+	sort.Slice(models, func(i, j int) bool { return models[i].Model < models[j].Model })
+	return models
+}
+
+// formatFloat formats v in the shortest exact form, independent of locale.
+func formatFloat(v float64) string {
+	return strconv.FormatFloat(v, 'f', -1, 64)
+}
+
+// labelEscaper escapes Prometheus label values.
+var labelEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+// escapeLabel escapes a Prometheus label value.
+func escapeLabel(v string) string {
+	return labelEscaper.Replace(v)
+}
@@ -0,0 +1,84 @@
+package council
+
+import (
+	"bytes"
+	"encoding/csv"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+)
+
+func newExportTestStore(t *testing.T) *MetricsStore {
+	t.Helper()
+	store := newTestMetricsStore(t)
+	for i := 0; i < 4; i++ {
+		recordTestTask(t, store, TaskMetric{
+			Role: "polecat", Model: "sonnet-4.5", Success: i > 0,
+			Duration: time.Duration(i+1) * time.Second, Cost: 0.25,
+		})
+	}
+	recordTestTask(t, store, TaskMetric{Role: "witness", Model: "gemini-3-flash", Success: true, Duration: 500 * time.Millisecond, Cost: 0.01})
+	return store
+}
+
+func TestMetricsStore_ExportCSV(t *testing.T) {
+	var buf bytes.Buffer
+	if err := newExportTestStore(t).ExportCSV(&buf); err != nil {
+		t.Fatalf("ExportCSV failed: %v", err)
+	}
+
+	rows, err := csv.NewReader(&buf).ReadAll()
+	if err != nil {
+		t.Fatalf("output is not valid CSV: %v", err)
+	}
+	if len(rows) != 3 {
+		t.Fatalf("got %d rows, want header + 2 models:\n%v", len(rows), rows)
+	}
+	if strings.Join(rows[0], ",") != strings.Join(csvHeader, ",") {
+		t.Errorf("header = %v", rows[0])
+	}
+
+	// Sorted by model; gemini-3-flash comes first.
+	want := []string{"sonnet-4.5", "anthropic", "4", "3", "1", "0.75", "2.5", "", "1"}
+	got := rows[2]
+	for i, w := range want {
+		if w != "" && got[i] != w {
+			t.Errorf("%s = %q, want %q", csvHeader[i], got[i], w)
+		}
+	}
+	if got[7] == "0" {
+		t.Errorf("p95_duration_seconds = %q, want the in-memory percentile", got[7])
+	}
+}
+
+func TestMetricsStore_ExportPrometheus(t *testing.T) {
+	store := newExportTestStore(t)
+	recordTestTask(t, store, TaskMetric{Role: "crew", Model: `odd"model\name`, Provider: "custom", Success: true})
+
+	var buf bytes.Buffer
+	if err := store.ExportPrometheus(&buf); err != nil {
+		t.Fatalf("ExportPrometheus failed: %v", err)
+	}
+	out := buf.String()
+
+	for _, want := range []string{
+		"# TYPE gastown_model_tasks_total counter\n",
+		`gastown_model_tasks_total{model="sonnet-4.5",provider="anthropic"} 4`,
+		`gastown_model_success_rate{model="sonnet-4.5",provider="anthropic"} 0.75`,
+		`gastown_model_cost_dollars{model="gemini-3-flash",provider="google"} 0.01`,
+		`model="odd\"model\\name"`,
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+
+	// Every line is a HELP/TYPE comment or a well-formed sample.
+	sample := regexp.MustCompile(`^[a-z_]+\{model="(?:[^"\\]|\\.)*",provider="(?:[^"\\]|\\.)*"\} -?[0-9.]+(?:e[-+]?[0-9]+)?$`)
+	for _, line := range strings.Split(strings.TrimSuffix(out, "\n"), "\n") {
+		if !strings.HasPrefix(line, "# HELP ") && !strings.HasPrefix(line, "# TYPE ") && !sample.MatchString(line) {
+			t.Errorf("malformed line: %q", line)
+		}
+	}
+}
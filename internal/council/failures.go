@@ -0,0 +1,120 @@
+package council
+
+import (
+	"sort"
+	"strings"
+	"time"
+)
+
+// maxFailureSignature caps the length of a failure signature so long
+// provider error bodies group by their leading text.
+const maxFailureSignature = 200
+
+// maxFailureExamples is how many example timestamps a FailureGroup keeps.
+const maxFailureExamples = 3
+
+// FailureGroup is a set of failed tasks sharing an error signature.
+type FailureGroup struct {
+	// Signature is the sanitized first line of the error message.
+	Signature string `json:"signature"`
+
+	Count int `json:"count"`
+
+	// Models and Providers count the group's failures by model and provider.
+	Models    map[string]int `json:"models"`
+	Providers map[string]int `json:"providers"`
+
+	FirstSeen time.Time `json:"first_seen"`
+	LastSeen  time.Time `json:"last_seen"`
+
+	// Examples holds the most recent failure times, newest first.
+	Examples []time.Time `json:"examples"`
+}
+
+// TopFailures groups the failed tasks in history by error signature and
+// returns the n most frequent, most recent first on ties. n <= 0 returns
+// every group.
+func (s *MetricsStore) TopFailures(n int) []FailureGroup {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.metrics.TopFailures(n)
+}
+
+// TopFailures groups the failed tasks in history by error signature.
+// See MetricsStore.TopFailures.
+func (m *Metrics) TopFailures(n int) []FailureGroup {
+	groups := make(map[string]*FailureGroup)
+	for _, task := range m.TaskHistory {
+		if task.Success {
+			continue
+		}
+		sig := failureSignature(task.Error)
+		g := groups[sig]
+		if g == nil {
+			g = &FailureGroup{
+				Signature: sig,
+				Models:    make(map[string]int),
+				Providers: make(map[string]int),
+			}
+			groups[sig] = g
+		}
+
+		at := task.CompletedAt
+		if at.IsZero() {
+			at = task.StartedAt
+		}
+		g.Count++
+		if task.Model != "" {
+			g.Models[task.Model]++
+		}
+		if task.Provider != "" {
+			g.Providers[task.Provider]++
+		}
+		if g.FirstSeen.IsZero() || at.Before(g.FirstSeen) {
+			g.FirstSeen = at
+		}
+		if at.After(g.LastSeen) {
+			g.LastSeen = at
+		}
+		g.Examples = append(g.Examples, at)
+	}
+
+	result := make([]FailureGroup, 0, len(groups))
+	for _, g := range groups {
+		sort.Slice(g.Examples, func(i, j int) bool { return g.Examples[i].After(g.Examples[j]) })
+		if len(g.Examples) > maxFailureExamples {
+			g.Examples = g.Examples[:maxFailureExamples]
+		}
+		result = append(result, *g)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Count != result[j].Count {
+			return result[i].Count > result[j].Count
+		}
+		if !result[i].LastSeen.Equal(result[j].LastSeen) {
+			return result[i].LastSeen.After(result[j].LastSeen)
+		}
+		return result[i].Signature < result[j].Signature
+	})
+
+	if n > 0 && len(result) > n {
+		result = result[:n]
+	}
+	return result
+}
+
+// failureSignature reduces an error message to a stable grouping key: the
+// sanitized, trimmed first line, capped at maxFailureSignature bytes.
+func failureSignature(msg string) string {
+	msg = strings.TrimSpace(sanitizeError(msg))
+	if i := strings.IndexByte(msg, '\n'); i >= 0 {
+		msg = strings.TrimSpace(msg[:i])
+	}
+	if len(msg) > maxFailureSignature {
+		msg = msg[:maxFailureSignature]
+	}
+	if msg == "" {
+		return "(no error message)"
+	}
+	return msg
+}
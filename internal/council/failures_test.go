@@ -0,0 +1,52 @@
+package council
+
+import (
+	"testing"
+	"time"
+)
+
+func TestMetricsStore_TopFailures(t *testing.T) {
+	store := newTestMetricsStore(t)
+	base := time.Now().Add(-time.Hour)
+	fail := func(i int, model, msg string) {
+		at := base.Add(time.Duration(i) * time.Minute)
+		recordTestTask(t, store, TaskMetric{Role: "polecat", Model: model, StartedAt: at, CompletedAt: at, Error: msg})
+	}
+
+	fail(1, "gpt-5.2", "HTTP 429 Too Many Requests")
+	fail(2, "sonnet-4.5", "cursor-agent failed: exit status 1\nstack trace line")
+	fail(3, "gpt-5.2", "HTTP 429 Too Many Requests")
+	fail(4, "gemini-3-pro", "HTTP 429 Too Many Requests")
+	fail(5, "sonnet-4.5", "cursor-agent failed: exit status 1\nother detail")
+	fail(6, "opus-4.5", "auth failed for api_key=sk-abcdefghijklmnopqrstuvwx")
+	recordTestTask(t, store, TaskMetric{Role: "polecat", Model: "sonnet-4.5", Success: true})
+
+	groups := store.TopFailures(0)
+	if len(groups) != 3 {
+		t.Fatalf("got %d groups, want 3: %+v", len(groups), groups)
+	}
+
+	top := groups[0]
+	if top.Signature != "HTTP 429 Too Many Requests" || top.Count != 3 {
+		t.Errorf("top group = %q x%d, want the 429 x3", top.Signature, top.Count)
+	}
+	if top.Models["gpt-5.2"] != 2 || top.Models["gemini-3-pro"] != 1 || top.Providers["openai"] != 2 {
+		t.Errorf("top group breakdown = %v / %v", top.Models, top.Providers)
+	}
+	if len(top.Examples) != 3 || !top.Examples[0].Equal(top.LastSeen) || !top.FirstSeen.Equal(base.Add(time.Minute)) {
+		t.Errorf("top group times = first %v last %v examples %v", top.FirstSeen, top.LastSeen, top.Examples)
+	}
+
+	// Multi-line errors group by their first line.
+	if groups[1].Signature != "cursor-agent failed: exit status 1" || groups[1].Count != 2 {
+		t.Errorf("second group = %q x%d, want exit status 1 x2", groups[1].Signature, groups[1].Count)
+	}
+
+	if groups[2].Count != 1 || groups[2].Signature == "auth failed for api_key=sk-abcdefghijklmnopqrstuvwx" {
+		t.Errorf("unique group = %q x%d, want a single redacted failure", groups[2].Signature, groups[2].Count)
+	}
+
+	if got := store.TopFailures(1); len(got) != 1 || got[0].Signature != top.Signature {
+		t.Errorf("TopFailures(1) = %+v, want only the most frequent group", got)
+	}
+}
@@ -3,11 +3,17 @@ package council
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"net"
 	"net/http"
+	"regexp"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
+
+	"github.com/cursorworkshop/cursor-gastown/internal/cursor"
 )
 
 // FallbackManager handles provider availability and automatic fallback.
@@ -19,30 +25,76 @@ type FallbackManager struct {
 	failureCounts  map[string]int
 	failureWindow  map[string][]time.Time
 	circuitBreaker map[string]*CircuitBreaker
+
+	// modelBreaker holds per-model circuits, created on a model's first
+	// recorded outcome. A broken model is excluded on its own; its provider's
+	// circuit opens only when ProviderEscalationModels of its models are open.
+	modelBreaker  map[string]*CircuitBreaker
+	modelProvider map[string]string
+
+	// quota holds each provider's latest rate-limit headers; see
+	// RecordRateLimitInfo.
+	quota map[string]RateLimitInfo
+
+	// maxRetries and retryBackoff control retries of retryable errors in
+	// ExecuteWithFallback; the backoff doubles on each retry.
+	maxRetries   int
+	retryBackoff time.Duration
+
+	// statePath, set by PersistCircuits, is where circuit state is saved
+	// whenever a provider's circuit changes state.
+	statePath string
 }
 
+// Retry defaults for ExecuteWithFallback.
+const (
+	DefaultMaxRetries   = 2
+	DefaultRetryBackoff = time.Second
+)
+
+// Model circuit defaults.
+const (
+	// DefaultModelThreshold is how many consecutive failures open a model's
+	// circuit.
+	DefaultModelThreshold = 3
+
+	// ProviderEscalationModels is how many of a provider's models must have
+	// open circuits before the provider's circuit opens too.
+	ProviderEscalationModels = 2
+)
+
 // CircuitBreaker implements circuit breaker pattern for providers.
 type CircuitBreaker struct {
 	// State is "closed" (normal), "open" (failing), or "half-open" (testing)
-	State string
+	State string `json:"state"`
 
 	// FailureCount is consecutive failures in current window
-	FailureCount int
+	FailureCount int `json:"failure_count"`
 
 	// LastFailure is the timestamp of the last failure
-	LastFailure time.Time
+	LastFailure time.Time `json:"last_failure,omitempty"`
 
 	// LastSuccess is the timestamp of the last success
-	LastSuccess time.Time
+	LastSuccess time.Time `json:"last_success,omitempty"`
 
 	// OpenedAt is when the circuit was opened
-	OpenedAt time.Time
+	OpenedAt time.Time `json:"opened_at,omitempty"`
 
 	// Threshold is failures before opening
-	Threshold int
+	Threshold int `json:"threshold"`
 
 	// ResetTimeout is how long to wait before testing again
-	ResetTimeout time.Duration
+	ResetTimeout time.Duration `json:"reset_timeout"`
+
+	// RetryAfter is when the provider said (via a 429 Retry-After header)
+	// it will accept requests again. Until then the provider is treated as
+	// unavailable regardless of State and ResetTimeout.
+	RetryAfter time.Time `json:"retry_after,omitempty"`
+}
+
+// backingOff reports whether the provider asked us to wait past now.
+func (cb *CircuitBreaker) backingOff(now time.Time) bool {
+	return now.Before(cb.RetryAfter)
 }
 
 // ProviderHealth represents the health status of a provider.
@@ -54,6 +106,10 @@ type ProviderHealth struct {
 	FailureCount  int           `json:"failure_count"`
 	CircuitState  string        `json:"circuit_state"`
 	RateLimitHits int           `json:"rate_limit_hits"`
+
+	// Unchecked means no probe was made (network disabled); Available
+	// reflects the circuit state only.
+	Unchecked bool `json:"unchecked,omitempty"`
 }
 
 // ProviderEndpoints maps providers to their health check endpoints.
@@ -72,6 +128,11 @@ func NewFallbackManager(router *Router) *FallbackManager {
 		failureCounts:  make(map[string]int),
 		failureWindow:  make(map[string][]time.Time),
 		circuitBreaker: make(map[string]*CircuitBreaker),
+		modelBreaker:   make(map[string]*CircuitBreaker),
+		modelProvider:  make(map[string]string),
+		quota:          make(map[string]RateLimitInfo),
+		maxRetries:     DefaultMaxRetries,
+		retryBackoff:   DefaultRetryBackoff,
 	}
 
 	// Initialize circuit breakers for all providers
@@ -87,12 +148,30 @@ func NewFallbackManager(router *Router) *FallbackManager {
 }
 
 // CheckHealth performs a health check on a provider.
+// When the network is disabled it returns immediately with Unchecked set.
 func (fm *FallbackManager) CheckHealth(ctx context.Context, provider string) (*ProviderHealth, error) {
 	endpoint, ok := ProviderEndpoints[provider]
 	if !ok {
 		return nil, fmt.Errorf("unknown provider: %s", provider)
 	}
 
+	if NetworkDisabled() {
+		health := &ProviderHealth{
+			Provider:    provider,
+			Available:   true,
+			LastChecked: time.Now(),
+			Unchecked:   true,
+		}
+		fm.mu.RLock()
+		if cb := fm.circuitBreaker[provider]; cb != nil {
+			health.CircuitState = cb.State
+			health.FailureCount = cb.FailureCount
+			health.Available = cb.State != "open" && !cb.backingOff(time.Now())
+		}
+		fm.mu.RUnlock()
+		return health, nil
+	}
+
 	health := &ProviderHealth{
 		Provider:    provider,
 		LastChecked: time.Now(),
@@ -139,6 +218,9 @@ func (fm *FallbackManager) CheckHealth(ctx context.Context, provider string) (*P
 		}
 	}()
 
+	limits := ParseRateLimitHeaders(resp.Header, time.Now())
+	fm.RecordRateLimitInfo(provider, limits)
+
 	// 401/403 means the endpoint is reachable (auth failed, but API is up)
 	// 429 means rate limited
 	switch resp.StatusCode {
@@ -149,6 +231,9 @@ func (fm *FallbackManager) CheckHealth(ctx context.Context, provider string) (*P
 		health.Available = false
 		health.RateLimitHits++
 		fm.recordRateLimit(provider)
+		if !limits.RetryAfter.IsZero() {
+			fm.recordRetryAfter(provider, limits.RetryAfter)
+		}
 	default:
 		health.Available = false
 		fm.recordFailure(provider)
@@ -201,6 +286,7 @@ func (fm *FallbackManager) recordSuccess(provider string) {
 	if cb.State == "half-open" {
 		cb.State = "closed"
 		cb.FailureCount = 0
+		cb.RetryAfter = time.Time{}
 		fm.router.SetProviderStatus(provider, true)
 	} else if cb.State == "closed" {
 		// Reset failure count on success
@@ -238,12 +324,55 @@ func (fm *FallbackManager) recordRateLimit(provider string) {
 	}
 }
 
-// MaybeRecover checks if open circuits should be tested.
+// parseRetryAfter parses a Retry-After header in either delay-seconds or
+// HTTP-date form.
+func parseRetryAfter(value string, now time.Time) (time.Time, bool) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return time.Time{}, false
+	}
+	if secs, err := strconv.Atoi(value); err == nil {
+		if secs < 0 {
+			return time.Time{}, false
+		}
+		return now.Add(time.Duration(secs) * time.Second), true
+	}
+	if t, err := http.ParseTime(value); err == nil {
+		return t, true
+	}
+	return time.Time{}, false
+}
+
+// recordRetryAfter opens a provider's circuit until the time it asked us to
+// retry after.
+func (fm *FallbackManager) recordRetryAfter(provider string, until time.Time) {
+	fm.mu.Lock()
+	defer fm.mu.Unlock()
+
+	cb, ok := fm.circuitBreaker[provider]
+	if !ok || !until.After(time.Now()) {
+		return
+	}
+	if until.After(cb.RetryAfter) {
+		cb.RetryAfter = until
+	}
+	if cb.State != "open" {
+		cb.State = "open"
+		cb.OpenedAt = time.Now()
+	}
+	fm.router.SetProviderStatus(provider, false)
+}
+
+// MaybeRecover checks if open circuits should be tested. Circuits stay open
+// until both ResetTimeout and any Retry-After deadline have passed.
 func (fm *FallbackManager) MaybeRecover(ctx context.Context) {
+	defer fm.saveIfChanged(fm.circuitStates())
+
 	fm.mu.Lock()
 	var toTest []string
+	now := time.Now()
 	for provider, cb := range fm.circuitBreaker {
-		if cb.State == "open" && time.Since(cb.OpenedAt) > cb.ResetTimeout {
+		if cb.State == "open" && now.Sub(cb.OpenedAt) > cb.ResetTimeout && !cb.backingOff(now) {
 			cb.State = "half-open"
 			toTest = append(toTest, provider)
 		}
@@ -286,14 +415,25 @@ func (fm *FallbackManager) GetAllHealth(ctx context.Context) map[string]*Provide
 	return result
 }
 
+// HealthSnapshot reduces a GetAllHealth result to provider availability,
+// suitable for Router.WithHealthSnapshot.
+func HealthSnapshot(health map[string]*ProviderHealth) map[string]bool {
+	snapshot := make(map[string]bool, len(health))
+	for provider, h := range health {
+		snapshot[provider] = h != nil && h.Available
+	}
+	return snapshot
+}
+
 // GetAvailableProviders returns a list of currently available providers.
 func (fm *FallbackManager) GetAvailableProviders() []string {
 	fm.mu.RLock()
 	defer fm.mu.RUnlock()
 
 	var available []string
+	now := time.Now()
 	for provider, cb := range fm.circuitBreaker {
-		if cb.State == "closed" || cb.State == "half-open" {
+		if (cb.State == "closed" || cb.State == "half-open") && !cb.backingOff(now) {
 			available = append(available, provider)
 		}
 	}
@@ -301,34 +441,299 @@ func (fm *FallbackManager) GetAvailableProviders() []string {
 }
 
 // RouteWithFallback routes a request with automatic fallback handling.
+// Providers with open circuits or a pending Retry-After, and models with
+// open circuits, are excluded; a model circuit whose reset timeout has
+// passed goes half-open and is allowed one trial. Providers reporting low
+// remaining quota are used only when nothing else can serve the request.
 func (fm *FallbackManager) RouteWithFallback(req *RouteRequest) (*RouteResult, error) {
-	fm.mu.RLock()
+	fm.mu.Lock()
 	unavailable := make([]string, 0)
+	now := time.Now()
 	for provider, cb := range fm.circuitBreaker {
-		if cb.State == "open" {
+		if cb.State == "open" || cb.backingOff(now) {
 			unavailable = append(unavailable, provider)
 		}
 	}
-	fm.mu.RUnlock()
+	var brokenModels []string
+	for model, cb := range fm.modelBreaker {
+		if cb.State != "open" {
+			continue
+		}
+		if time.Since(cb.OpenedAt) > cb.ResetTimeout {
+			cb.State = "half-open"
+			continue
+		}
+		brokenModels = append(brokenModels, model)
+	}
+	waiting, lowQuota := fm.quotaState(now)
+	fm.mu.Unlock()
 
-	// Add unavailable providers to exclude list
+	// Add unavailable providers and models to the exclude lists
 	req.ExcludeProviders = append(req.ExcludeProviders, unavailable...)
-
+	req.ExcludeProviders = append(req.ExcludeProviders, waiting...)
+	req.ExcludeModels = append(req.ExcludeModels, brokenModels...)
+
+	if len(lowQuota) > 0 {
+		preferred := *req
+		preferred.ExcludeProviders = append(append([]string(nil), req.ExcludeProviders...), lowQuota...)
+		if result, err := fm.router.Route(&preferred); err == nil {
+			return result, nil
+		}
+	}
 	return fm.router.Route(req)
 }
 
 // RecordRequestOutcome records the outcome of a request for circuit breaker.
-func (fm *FallbackManager) RecordRequestOutcome(provider string, success bool, err error) {
+// Failures are classified: rate limits count toward the provider's
+// one-minute window, fatal errors (e.g., bad credentials) open the provider
+// circuit immediately, and content-filter refusals and models denied by
+// role policy are ignored.
+//
+// Transient and unclassified errors are charged to the model when one is
+// given, so a single broken model doesn't disable its siblings; see
+// ProviderEscalationModels. Without a model, transient errors count toward
+// the provider's window and unclassified errors as consecutive failures.
+func (fm *FallbackManager) RecordRequestOutcome(provider, model string, success bool, err error) {
 	if success {
+		if model != "" {
+			fm.recordModelSuccess(model)
+		}
 		fm.recordSuccess(provider)
-	} else {
-		// Check if this is a rate limit error
-		if err != nil && isRateLimitError(err) {
-			fm.recordRateLimit(provider)
-		} else {
-			fm.recordFailure(provider)
+		return
+	}
+
+	kind := classifyError(err)
+	switch {
+	case kind == ErrorContentFilter, kind == ErrorModelDenied:
+		// A policy refusal says nothing about the provider's health.
+	case kind == ErrorRateLimit:
+		fm.recordRateLimit(provider)
+	case kind == ErrorFatal:
+		fm.recordFatal(provider)
+	case model != "":
+		fm.recordModelFailure(provider, model)
+	case kind == ErrorRetryable:
+		fm.recordRateLimit(provider)
+	default:
+		fm.recordFailure(provider)
+	}
+}
+
+// recordModelFailure charges a failure to a model's circuit, opening it at
+// DefaultModelThreshold consecutive failures (or at once when half-open),
+// and escalates to the provider when enough of its models are open.
+func (fm *FallbackManager) recordModelFailure(provider, model string) {
+	fm.mu.Lock()
+	defer fm.mu.Unlock()
+
+	cb := fm.modelBreaker[model]
+	if cb == nil {
+		cb = &CircuitBreaker{
+			State:        "closed",
+			Threshold:    DefaultModelThreshold,
+			ResetTimeout: 30 * time.Second,
+		}
+		fm.modelBreaker[model] = cb
+	}
+	fm.modelProvider[model] = provider
+	cb.FailureCount++
+	cb.LastFailure = time.Now()
+	if cb.State == "half-open" || (cb.State == "closed" && cb.FailureCount >= cb.Threshold) {
+		cb.State = "open"
+		cb.OpenedAt = cb.LastFailure
+	}
+	if cb.State != "open" {
+		return
+	}
+
+	open := 0
+	for m, mcb := range fm.modelBreaker {
+		if mcb.State == "open" && fm.modelProvider[m] == provider {
+			open++
 		}
 	}
+	if open < ProviderEscalationModels {
+		return
+	}
+	if pcb := fm.circuitBreaker[provider]; pcb != nil && pcb.State != "open" {
+		pcb.State = "open"
+		pcb.OpenedAt = cb.LastFailure
+		pcb.LastFailure = cb.LastFailure
+		fm.router.SetProviderStatus(provider, false)
+	}
+}
+
+// recordModelSuccess closes a model's circuit and resets its failure count.
+func (fm *FallbackManager) recordModelSuccess(model string) {
+	fm.mu.Lock()
+	defer fm.mu.Unlock()
+
+	cb := fm.modelBreaker[model]
+	if cb == nil {
+		return
+	}
+	cb.State = "closed"
+	cb.FailureCount = 0
+	cb.LastSuccess = time.Now()
+}
+
+// recordFatal opens a provider's circuit immediately.
+func (fm *FallbackManager) recordFatal(provider string) {
+	fm.mu.Lock()
+	defer fm.mu.Unlock()
+
+	cb, ok := fm.circuitBreaker[provider]
+	if !ok {
+		return
+	}
+
+	cb.FailureCount++
+	cb.LastFailure = time.Now()
+	if cb.State != "open" {
+		cb.State = "open"
+		cb.OpenedAt = cb.LastFailure
+		fm.router.SetProviderStatus(provider, false)
+	}
+}
+
+// ErrorKind classifies a model request error for retry and routing decisions.
+type ErrorKind int
+
+const (
+	// ErrorUnknown is an error that could not be classified.
+	ErrorUnknown ErrorKind = iota
+
+	// ErrorRetryable is a transient error (timeout, 5xx, connection reset)
+	// worth retrying against the same model after a backoff.
+	ErrorRetryable
+
+	// ErrorRateLimit means the provider is throttling; re-route elsewhere.
+	ErrorRateLimit
+
+	// ErrorFatal is an error retrying cannot fix, such as bad credentials.
+	ErrorFatal
+
+	// ErrorContentFilter means the provider refused the prompt or output on
+	// policy grounds; another attempt with the same prompt is a real negative.
+	ErrorContentFilter
+
+	// ErrorModelDenied means the role's policy forbids the model. Only that
+	// model is skipped; it says nothing about the provider's health.
+	ErrorModelDenied
+)
+
+// String returns the error kind name.
+func (k ErrorKind) String() string {
+	switch k {
+	case ErrorRetryable:
+		return "retryable"
+	case ErrorRateLimit:
+		return "rate_limit"
+	case ErrorFatal:
+		return "fatal"
+	case ErrorContentFilter:
+		return "content_filter"
+	case ErrorModelDenied:
+		return "model_denied"
+	default:
+		return "unknown"
+	}
+}
+
+// MarshalText encodes the kind by name so JSON output stays readable.
+func (k ErrorKind) MarshalText() ([]byte, error) {
+	return []byte(k.String()), nil
+}
+
+// UnmarshalText decodes a kind name; unrecognized names become ErrorUnknown.
+func (k *ErrorKind) UnmarshalText(text []byte) error {
+	*k = ErrorUnknown
+	for _, kind := range []ErrorKind{ErrorRetryable, ErrorRateLimit, ErrorFatal, ErrorContentFilter, ErrorModelDenied} {
+		if kind.String() == string(text) {
+			*k = kind
+			break
+		}
+	}
+	return nil
+}
+
+// contentFilterMarkers identify prompts or outputs blocked by provider policy.
+var contentFilterMarkers = []string{
+	"content filter", "content_filter", "content policy", "content_policy",
+	"safety system", "flagged by", "responsibleaipolicyviolation",
+}
+
+// fatalErrorMarkers identify errors that retrying will not fix.
+var fatalErrorMarkers = []string{
+	"unauthorized", "forbidden", "invalid api key", "invalid_api_key",
+	"authentication failed", "authentication_error", "permission denied",
+	"not authenticated",
+}
+
+// retryableErrorMarkers identify transient errors.
+var retryableErrorMarkers = []string{
+	"timeout", "timed out", "deadline exceeded", "connection reset",
+	"connection refused", "broken pipe", "unexpected eof", "temporarily unavailable",
+	"service unavailable", "bad gateway", "internal server error", "overloaded",
+}
+
+// statusCodePattern matches an HTTP status code reported as one ("HTTP
+// 503", "status 401", "status code: 500", "code=429"), so numbers that
+// merely contain those digits (line counts, IDs) aren't mistaken for one.
+var statusCodePattern = regexp.MustCompile(`(?i)(?:\bhttp(?:/[\d.]+)?|\bstatus(?:[ _]?code)?|\bcode)\s*[:=]?\s*([1-5]\d\d)\b`)
+
+// httpStatus returns the HTTP status code err reports, or 0.
+func httpStatus(err error) int {
+	m := statusCodePattern.FindStringSubmatch(err.Error())
+	if m == nil {
+		return 0
+	}
+	code, _ := strconv.Atoi(m[1])
+	return code
+}
+
+// classifyError determines how a failed request should be handled.
+func classifyError(err error) ErrorKind {
+	if err == nil {
+		return ErrorUnknown
+	}
+	if isRateLimitError(err) {
+		return ErrorRateLimit
+	}
+	if errors.Is(err, cursor.ErrModelNotAllowed) {
+		return ErrorModelDenied
+	}
+	if errors.Is(err, context.DeadlineExceeded) {
+		return ErrorRetryable
+	}
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return ErrorRetryable
+	}
+
+	errStr := strings.ToLower(err.Error())
+	for _, marker := range contentFilterMarkers {
+		if strings.Contains(errStr, marker) {
+			return ErrorContentFilter
+		}
+	}
+	switch code := httpStatus(err); {
+	case code == http.StatusUnauthorized || code == http.StatusForbidden:
+		return ErrorFatal
+	case code >= 500:
+		return ErrorRetryable
+	}
+	for _, marker := range fatalErrorMarkers {
+		if strings.Contains(errStr, marker) {
+			return ErrorFatal
+		}
+	}
+	for _, marker := range retryableErrorMarkers {
+		if strings.Contains(errStr, marker) {
+			return ErrorRetryable
+		}
+	}
+	return ErrorUnknown
 }
 
 // isRateLimitError checks if an error indicates rate limiting.
@@ -339,7 +744,75 @@ func isRateLimitError(err error) bool {
 	errStr := strings.ToLower(err.Error())
 	return strings.Contains(errStr, "rate limit") ||
 		strings.Contains(errStr, "too many requests") ||
-		strings.Contains(errStr, "429")
+		httpStatus(err) == http.StatusTooManyRequests
+}
+
+// ExecuteWithFallback routes req, runs prompt on the selected model, and
+// handles failures by kind: retryable errors are retried with backoff on the
+// same model, rate limits re-route to another provider, unknown errors (and
+// exhausted retries) re-route to another model, and fatal errors are
+// returned immediately. Config.MaxFallbackDepth bounds how many models are
+// tried after the primary. Every attempt is recorded via
+// RecordRequestOutcome. Returns the response and the route that produced it.
+func (fm *FallbackManager) ExecuteWithFallback(ctx context.Context, executor ModelExecutor, req *RouteRequest, prompt string) (*ModelResponse, *RouteResult, error) {
+	defer fm.saveIfChanged(fm.circuitStates())
+
+	attempt := *req
+	attempt.ExcludeProviders = append([]string(nil), req.ExcludeProviders...)
+
+	// Models already tried are excluded from each re-route, which the
+	// router doesn't count toward MaxFallbackDepth, so count them here.
+	depth := fm.router.GetConfig().MaxFallbackDepth
+	var lastErr error
+	for rerouted := 0; ; rerouted++ {
+		if depth > 0 && rerouted > depth {
+			return nil, nil, fmt.Errorf("%w (last error: %v)", &RouteError{Role: req.Role, Reason: FallbackExhausted, Depth: depth}, lastErr)
+		}
+		route, err := fm.RouteWithFallback(&attempt)
+		if err != nil {
+			if lastErr != nil {
+				return nil, nil, fmt.Errorf("%w (last error: %v)", err, lastErr)
+			}
+			return nil, nil, err
+		}
+
+		var kind ErrorKind
+		for retry := 0; ; retry++ {
+			resp, err := executor.Execute(ctx, route.Model, prompt)
+			if err == nil && resp != nil && !resp.Success {
+				err = errors.New(resp.Error)
+			}
+			if err == nil {
+				fm.RecordRequestOutcome(route.Provider, route.Model, true, nil)
+				return resp, route, nil
+			}
+
+			fm.RecordRequestOutcome(route.Provider, route.Model, false, err)
+			lastErr = fmt.Errorf("%s: %w", route.Model, err)
+
+			kind = classifyError(err)
+			if kind == ErrorFatal {
+				return resp, route, lastErr
+			}
+			if kind != ErrorRetryable || retry >= fm.maxRetries {
+				break
+			}
+
+			select {
+			case <-ctx.Done():
+				return resp, route, fmt.Errorf("%w (last error: %v)", ctx.Err(), lastErr)
+			case <-time.After(fm.retryBackoff << retry):
+			}
+		}
+
+		// A throttled provider is skipped entirely; other failures only
+		// skip the model, leaving its siblings available.
+		if kind == ErrorRateLimit {
+			attempt.ExcludeProviders = append(attempt.ExcludeProviders, route.Provider)
+		} else {
+			attempt.ExcludeModels = append(attempt.ExcludeModels, route.Model)
+		}
+	}
 }
 
 // StartBackgroundRecovery starts a goroutine to periodically check for circuit recovery.
@@ -359,7 +832,9 @@ func (fm *FallbackManager) StartBackgroundRecovery(ctx context.Context) {
 	}()
 }
 
-// Reset resets all circuit breakers to closed state.
+// Reset resets all circuit breakers to closed state and forgets failure
+// history, model circuits, and rate-limit readings, so no provider stays
+// excluded or backing off.
 func (fm *FallbackManager) Reset() {
 	fm.mu.Lock()
 	defer fm.mu.Unlock()
@@ -367,7 +842,11 @@ func (fm *FallbackManager) Reset() {
 	for provider, cb := range fm.circuitBreaker {
 		cb.State = "closed"
 		cb.FailureCount = 0
+		cb.RetryAfter = time.Time{}
 		fm.router.SetProviderStatus(provider, true)
 	}
 	fm.failureWindow = make(map[string][]time.Time)
+	fm.modelBreaker = make(map[string]*CircuitBreaker)
+	fm.modelProvider = make(map[string]string)
+	fm.quota = make(map[string]RateLimitInfo)
 }
@@ -0,0 +1,238 @@
+package council
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/cursorworkshop/cursor-gastown/internal/cursor"
+)
+
+func TestClassifyError(t *testing.T) {
+	tests := []struct {
+		err  error
+		want ErrorKind
+	}{
+		{nil, ErrorUnknown},
+		{errors.New("HTTP 429: Too Many Requests"), ErrorRateLimit},
+		{errors.New("rate limit exceeded"), ErrorRateLimit},
+		{errors.New("request timed out after 30s"), ErrorRetryable},
+		{fmt.Errorf("calling model: %w", context.DeadlineExceeded), ErrorRetryable},
+		{errors.New("read tcp: connection reset by peer"), ErrorRetryable},
+		{errors.New("HTTP 503 Service Unavailable"), ErrorRetryable},
+		{errors.New("HTTP 401 Unauthorized"), ErrorFatal},
+		{errors.New("invalid API key provided"), ErrorFatal},
+		{fmt.Errorf("%w: grok is denied", cursor.ErrModelNotAllowed), ErrorModelDenied},
+		{errors.New("status code: 500"), ErrorRetryable},
+		{errors.New("api error (code=403)"), ErrorFatal},
+		{errors.New("generated 401 lines, then the model stopped"), ErrorUnknown},
+		{errors.New("retrying request 500 of 1000 failed"), ErrorUnknown},
+		{errors.New("authentication middleware produced invalid output"), ErrorUnknown},
+		{errors.New("HTTP 403: content policy violation"), ErrorContentFilter},
+		{errors.New("output blocked by content filter"), ErrorContentFilter},
+		{errors.New("model produced malformed output"), ErrorUnknown},
+	}
+
+	for _, tt := range tests {
+		if got := classifyError(tt.err); got != tt.want {
+			t.Errorf("classifyError(%v) = %s, want %s", tt.err, got, tt.want)
+		}
+	}
+}
+
+func TestFallbackManager_RecordRequestOutcome_Fatal(t *testing.T) {
+	fm := NewFallbackManager(NewRouter(DefaultCouncilConfig()))
+
+	fm.RecordRequestOutcome("anthropic", "", false, errors.New("401 unauthorized"))
+	if state := fm.circuitBreaker["anthropic"].State; state != "open" {
+		t.Errorf("circuit after fatal error = %s, want open", state)
+	}
+
+	// A model denied by role policy is skipped without touching the
+	// provider's circuit.
+	fm.RecordRequestOutcome("google", "gemini-3-pro", false, fmt.Errorf("%w: gemini-3-pro is denied", cursor.ErrModelNotAllowed))
+	if state := fm.circuitBreaker["google"].State; state != "closed" {
+		t.Errorf("circuit after a denied model = %s, want closed", state)
+	}
+
+	// A single transient error does not open the circuit.
+	fm.RecordRequestOutcome("openai", "", false, errors.New("connection reset"))
+	if state := fm.circuitBreaker["openai"].State; state != "closed" {
+		t.Errorf("circuit after transient error = %s, want closed", state)
+	}
+}
+
+func TestFallbackManager_ModelCircuit(t *testing.T) {
+	fm := NewFallbackManager(NewRouter(DefaultCouncilConfig()))
+	broken := errors.New("HTTP 500 Internal Server Error")
+
+	// One bad OpenAI model opens only its own circuit.
+	for i := 0; i < DefaultModelThreshold; i++ {
+		fm.RecordRequestOutcome("openai", "gpt-5.2-high", false, broken)
+	}
+	if state := fm.modelBreaker["gpt-5.2-high"].State; state != "open" {
+		t.Fatalf("gpt-5.2-high circuit = %s, want open", state)
+	}
+	if state := fm.circuitBreaker["openai"].State; state != "closed" {
+		t.Fatalf("openai circuit = %s, want closed with one broken model", state)
+	}
+
+	// Routing skips the broken model but keeps its sibling.
+	route, err := fm.RouteWithFallback(&RouteRequest{Role: "refinery"})
+	if err != nil {
+		t.Fatalf("RouteWithFallback failed: %v", err)
+	}
+	if route.Model == "gpt-5.2-high" {
+		t.Errorf("routed to the broken model %s", route.Model)
+	}
+	route, err = fm.RouteWithFallback(&RouteRequest{Role: "refinery", PreferredModel: "gpt-5.2"})
+	if err != nil {
+		t.Fatalf("RouteWithFallback failed: %v", err)
+	}
+	if route.Model != "gpt-5.2" || route.Provider != "openai" {
+		t.Errorf("route = %s via %s, want sibling gpt-5.2 via openai", route.Model, route.Provider)
+	}
+
+	// A second broken model escalates to the provider.
+	for i := 0; i < DefaultModelThreshold; i++ {
+		fm.RecordRequestOutcome("openai", "gpt-5.2", false, broken)
+	}
+	if state := fm.circuitBreaker["openai"].State; state != "open" {
+		t.Errorf("openai circuit = %s, want open after two broken models", state)
+	}
+
+	// Success closes a model circuit.
+	fm.RecordRequestOutcome("openai", "gpt-5.2", true, nil)
+	if state := fm.modelBreaker["gpt-5.2"].State; state != "closed" {
+		t.Errorf("gpt-5.2 circuit after success = %s, want closed", state)
+	}
+}
+
+func newTestFallbackManager() *FallbackManager {
+	fm := NewFallbackManager(NewRouter(DefaultCouncilConfig()))
+	fm.retryBackoff = 0
+	return fm
+}
+
+func TestFallbackManager_ExecuteWithFallback_RetriesRetryable(t *testing.T) {
+	fm := newTestFallbackManager()
+	var models []string
+	executor := funcExecutor(func(ctx context.Context, model, prompt string) (*ModelResponse, error) {
+		models = append(models, model)
+		if len(models) < 3 {
+			return nil, errors.New("HTTP 503 Service Unavailable")
+		}
+		return &ModelResponse{Model: model, Output: "ok", Success: true}, nil
+	})
+
+	resp, route, err := fm.ExecuteWithFallback(context.Background(), executor, &RouteRequest{Role: "mayor"}, "plan")
+	if err != nil {
+		t.Fatalf("ExecuteWithFallback failed: %v", err)
+	}
+	if resp.Output != "ok" || len(models) != 3 {
+		t.Fatalf("got %q after %d calls, want ok after 3", resp.Output, len(models))
+	}
+	for _, m := range models {
+		if m != route.Model {
+			t.Errorf("retryable error re-routed to %s, want retries on %s", m, route.Model)
+		}
+	}
+}
+
+func TestFallbackManager_ExecuteWithFallback_RateLimitReroutes(t *testing.T) {
+	fm := newTestFallbackManager()
+	var providers []string
+	executor := funcExecutor(func(ctx context.Context, model, prompt string) (*ModelResponse, error) {
+		providers = append(providers, ModelProvider(model))
+		if len(providers) == 1 {
+			return &ModelResponse{Model: model, Error: "429 too many requests"}, nil
+		}
+		return &ModelResponse{Model: model, Output: "ok", Success: true}, nil
+	})
+
+	_, route, err := fm.ExecuteWithFallback(context.Background(), executor, &RouteRequest{Role: "mayor"}, "plan")
+	if err != nil {
+		t.Fatalf("ExecuteWithFallback failed: %v", err)
+	}
+	if len(providers) != 2 || providers[0] == providers[1] || route.Provider != providers[1] {
+		t.Errorf("providers tried = %v (final %s), want a re-route to a different provider", providers, route.Provider)
+	}
+}
+
+func TestFallbackManager_ExecuteWithFallback_FatalFailsFast(t *testing.T) {
+	fm := newTestFallbackManager()
+	calls := 0
+	executor := funcExecutor(func(ctx context.Context, model, prompt string) (*ModelResponse, error) {
+		calls++
+		return nil, errors.New("401 unauthorized: invalid api key")
+	})
+
+	_, _, err := fm.ExecuteWithFallback(context.Background(), executor, &RouteRequest{Role: "mayor"}, "plan")
+	if err == nil {
+		t.Fatal("expected fatal error")
+	}
+	if calls != 1 {
+		t.Errorf("executor called %d times, want 1 (no retry or re-route on fatal)", calls)
+	}
+}
+
+func TestFallbackManager_ExecuteWithFallback_DeniedModelSkipped(t *testing.T) {
+	fm := newTestFallbackManager()
+	var models []string
+	executor := funcExecutor(func(ctx context.Context, model, prompt string) (*ModelResponse, error) {
+		models = append(models, model)
+		if len(models) == 1 {
+			return nil, fmt.Errorf("%w: %s is denied", cursor.ErrModelNotAllowed, model)
+		}
+		return &ModelResponse{Model: model, Output: "ok", Success: true}, nil
+	})
+
+	_, route, err := fm.ExecuteWithFallback(context.Background(), executor, &RouteRequest{Role: "mayor"}, "plan")
+	if err != nil {
+		t.Fatalf("ExecuteWithFallback failed: %v", err)
+	}
+	if len(models) != 2 || route.Model == models[0] {
+		t.Errorf("models tried = %v, want the denied model skipped for another", models)
+	}
+	if state := fm.circuitBreaker[ModelProvider(models[0])].State; state != "closed" {
+		t.Errorf("denied model's provider circuit = %s, want closed", state)
+	}
+}
+
+func TestFallbackManager_ExecuteWithFallback_Exhausted(t *testing.T) {
+	fm := newTestFallbackManager()
+	executor := funcExecutor(func(ctx context.Context, model, prompt string) (*ModelResponse, error) {
+		return nil, errors.New("model produced malformed output")
+	})
+
+	_, _, err := fm.ExecuteWithFallback(context.Background(), executor, &RouteRequest{Role: "mayor"}, "plan")
+	if err == nil {
+		t.Fatal("expected error once every provider failed")
+	}
+}
+
+func TestFallbackManager_ExecuteWithFallback_MaxFallbackDepth(t *testing.T) {
+	cfg := DefaultCouncilConfig()
+	cfg.MaxFallbackDepth = 1
+	fm := NewFallbackManager(NewRouter(cfg))
+	fm.retryBackoff = 0
+
+	var models []string
+	executor := funcExecutor(func(ctx context.Context, model, prompt string) (*ModelResponse, error) {
+		models = append(models, model)
+		return nil, errors.New("model produced malformed output")
+	})
+
+	_, _, err := fm.ExecuteWithFallback(context.Background(), executor, &RouteRequest{Role: "mayor"}, "plan")
+	var routeErr *RouteError
+	if !errors.As(err, &routeErr) || routeErr.Reason != FallbackExhausted {
+		t.Fatalf("err = %v, want RouteError{Reason: FallbackExhausted}", err)
+	}
+
+	want := []string{"opus-4.5-thinking", "sonnet-4.5"}
+	if strings.Join(models, ",") != strings.Join(want, ",") {
+		t.Errorf("tried %v, want primary and exactly one fallback %v", models, want)
+	}
+}
@@ -3,12 +3,17 @@ package council
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
+	"math"
 	"os"
 	"path/filepath"
 	"sort"
+	"strings"
 	"sync"
 	"time"
+
+	"github.com/cursorworkshop/cursor-gastown/internal/cursor"
 )
 
 // MetricsStore stores and retrieves model performance metrics.
@@ -16,6 +21,15 @@ type MetricsStore struct {
 	mu      sync.RWMutex
 	path    string
 	metrics *Metrics
+
+	// sampleRates and sampleAcc implement per-role history sampling.
+	sampleRates map[string]float64
+	sampleAcc   map[string]float64
+
+	// latencies holds each model's most recent task durations for
+	// percentiles. It is kept in memory only and reseeded from TaskHistory
+	// on load, so it never grows the metrics file.
+	latencies map[string]*durationRing
 }
 
 // Metrics contains all collected metrics.
@@ -25,7 +39,13 @@ type Metrics struct {
 	ByRole      map[string]*RoleMetrics  `json:"by_role"`
 	ByModel     map[string]*ModelMetrics `json:"by_model"`
 	ByProvider  map[string]*ProviderMetrics `json:"by_provider"`
+
+	// ByComplexity aggregates tasks by complexity level; tasks without a
+	// known level are bucketed under ComplexityUnspecified.
+	ByComplexity map[string]*ComplexityMetrics `json:"by_complexity,omitempty"`
+
 	TaskHistory []TaskMetric             `json:"task_history,omitempty"`
+	Pending     map[string]TaskMetric    `json:"pending,omitempty"` // started but not yet completed, by ID
 }
 
 // RoleMetrics contains metrics for a specific Gas Town role.
@@ -36,6 +56,8 @@ type RoleMetrics struct {
 	FailedTasks    int                `json:"failed_tasks"`
 	TotalDuration  time.Duration      `json:"total_duration_ms"`
 	TotalTokens    int64              `json:"total_tokens"`
+	InputTokens    int64              `json:"input_tokens"`
+	OutputTokens   int64              `json:"output_tokens"`
 	TotalCost      float64            `json:"total_cost"`
 	ModelUsage     map[string]int     `json:"model_usage"` // model -> count
 	AvgDuration    time.Duration      `json:"avg_duration_ms"`
@@ -51,10 +73,18 @@ type ModelMetrics struct {
 	FailedTasks    int           `json:"failed_tasks"`
 	TotalDuration  time.Duration `json:"total_duration_ms"`
 	TotalTokens    int64         `json:"total_tokens"`
+	InputTokens    int64         `json:"input_tokens"`
+	OutputTokens   int64         `json:"output_tokens"`
 	TotalCost      float64       `json:"total_cost"`
 	AvgDuration    time.Duration `json:"avg_duration_ms"`
 	SuccessRate    float64       `json:"success_rate"`
 	RoleUsage      map[string]int `json:"role_usage"` // role -> count
+
+	// P50Duration, P95Duration, and P99Duration are computed on read from
+	// the model's last LatencySampleSize tasks; they are never persisted.
+	P50Duration time.Duration `json:"p50_duration_ms,omitempty"`
+	P95Duration time.Duration `json:"p95_duration_ms,omitempty"`
+	P99Duration time.Duration `json:"p99_duration_ms,omitempty"`
 }
 
 // ProviderMetrics contains metrics for a provider.
@@ -69,6 +99,32 @@ type ProviderMetrics struct {
 	Availability   float64       `json:"availability"` // 0-1
 }
 
+// ComplexityMetrics contains metrics for a task complexity level.
+type ComplexityMetrics struct {
+	Complexity     string        `json:"complexity"`
+	TotalTasks     int           `json:"total_tasks"`
+	CompletedTasks int           `json:"completed_tasks"`
+	FailedTasks    int           `json:"failed_tasks"`
+	TotalDuration  time.Duration `json:"total_duration_ms"`
+	TotalCost      float64       `json:"total_cost"`
+	AvgDuration    time.Duration `json:"avg_duration_ms"`
+	AvgCost        float64       `json:"avg_cost"`
+	SuccessRate    float64       `json:"success_rate"`
+}
+
+// ComplexityUnspecified buckets tasks with an empty or unknown complexity.
+const ComplexityUnspecified = "unspecified"
+
+// ComplexityBucket returns the ByComplexity key for a task's complexity.
+func ComplexityBucket(complexity string) string {
+	switch complexity {
+	case "low", "medium", "high":
+		return complexity
+	default:
+		return ComplexityUnspecified
+	}
+}
+
 // TaskMetric records a single task execution.
 type TaskMetric struct {
 	ID          string        `json:"id"`
@@ -84,10 +140,34 @@ type TaskMetric struct {
 	Error       string        `json:"error,omitempty"`
 	Complexity  string        `json:"complexity,omitempty"`
 	Fallback    bool          `json:"fallback"`
+
+	// OriginalModel is the model this task's model replaced: the routed
+	// model for an A/B substitution (see RouteResult.OriginalModel), or the
+	// primary model for a Fallback escalation.
+	OriginalModel string `json:"original_model,omitempty"`
+
+	// InputTokens and OutputTokens split Tokens into prompt and completion
+	// tokens, which are priced differently. When either is set, Tokens is
+	// recomputed as their sum; tasks recorded before the split carry only
+	// Tokens.
+	InputTokens  int64 `json:"input_tokens,omitempty"`
+	OutputTokens int64 `json:"output_tokens,omitempty"`
+
+	// SessionID is the cursor-agent session the task ran in, if known.
+	// The session links back through cursor.Session.MetricIDs.
+	SessionID string `json:"session_id,omitempty"`
+}
+
+// normalizeTokens recomputes Tokens from the input/output split, if any.
+func (t *TaskMetric) normalizeTokens() {
+	if t.InputTokens > 0 || t.OutputTokens > 0 {
+		t.Tokens = t.InputTokens + t.OutputTokens
+	}
 }
 
-// CurrentMetricsVersion is the current schema version.
-const CurrentMetricsVersion = 1
+// CurrentMetricsVersion is the current schema version. Version 2 split
+// token counts into input and output tokens.
+const CurrentMetricsVersion = 2
 
 // MetricsFileName is the default filename for metrics storage.
 const MetricsFileName = "council-metrics.json"
@@ -95,6 +175,10 @@ const MetricsFileName = "council-metrics.json"
 // MaxTaskHistory is the maximum number of tasks to keep in history.
 const MaxTaskHistory = 1000
 
+// LatencySampleSize is how many recent durations per model back the
+// latency percentiles.
+const LatencySampleSize = 200
+
 // NewMetricsStore creates a new metrics store.
 func NewMetricsStore(townRoot string) (*MetricsStore, error) {
 	path := filepath.Join(townRoot, ".beads", MetricsFileName)
@@ -128,14 +212,41 @@ func (s *MetricsStore) load() error {
 	if err := json.Unmarshal(data, &metrics); err != nil {
 		return fmt.Errorf("parsing metrics: %w", err)
 	}
+	metrics.migrate()
 
 	s.mu.Lock()
 	s.metrics = &metrics
+	s.latencies = nil
+	for _, task := range metrics.TaskHistory {
+		s.trackLatency(task)
+	}
 	s.mu.Unlock()
 
 	return nil
 }
 
+// migrate upgrades metrics loaded from an older schema version. Version 1
+// files have no input/output token split; their counts stay zero, so
+// totals recorded before the split remain in TotalTokens only and are priced
+// as output tokens by Summary.
+func (m *Metrics) migrate() {
+	if m.Version >= CurrentMetricsVersion {
+		return
+	}
+	if m.Version < 2 {
+		for _, rm := range m.ByRole {
+			rm.InputTokens, rm.OutputTokens = 0, 0
+		}
+		for _, mm := range m.ByModel {
+			mm.InputTokens, mm.OutputTokens = 0, 0
+		}
+		for i := range m.TaskHistory {
+			m.TaskHistory[i].InputTokens, m.TaskHistory[i].OutputTokens = 0, 0
+		}
+	}
+	m.Version = CurrentMetricsVersion
+}
+
 // save writes metrics to disk.
 func (s *MetricsStore) save() error {
 	s.mu.RLock()
@@ -163,25 +274,209 @@ func (s *MetricsStore) RecordTask(task TaskMetric) error {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
+	s.metrics.recordCompleted(task, s.sampleHistory(task.Role))
+	s.trackLatency(task)
+	s.metrics.UpdatedAt = time.Now()
+
+	// Save to disk
+	s.mu.Unlock()
+	err := s.save()
+	s.mu.Lock()
+	return err
+}
+
+// SetSampleRates sets the fraction of each role's tasks kept in task history
+// (see Config.SampleRates). Roles without a rate keep every task.
+func (s *MetricsStore) SetSampleRates(rates map[string]float64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.sampleRates = rates
+	s.sampleAcc = make(map[string]float64)
+}
+
+// sampleHistory reports whether the next task for role belongs in history.
+// Sampling is deterministic: a role with rate r keeps one task each time the
+// accumulated rate reaches 1. Callers must hold s.mu.
+func (s *MetricsStore) sampleHistory(role string) bool {
+	rate, ok := s.sampleRates[role]
+	if !ok || rate <= 0 || rate >= 1 {
+		return true
+	}
+	s.sampleAcc[role] += rate
+	if s.sampleAcc[role] >= 1 {
+		s.sampleAcc[role]--
+		return true
+	}
+	return false
+}
+
+// recordCompleted aggregates a finished task and, if inHistory, appends it
+// to history. The task's error is sanitized so secrets never reach the
+// metrics file.
+func (m *Metrics) recordCompleted(task TaskMetric, inHistory bool) {
+	task.Error = sanitizeError(task.Error)
+	task.normalizeTokens()
+	m.addTask(task)
+	if !inHistory {
+		return
+	}
+
+	m.TaskHistory = append(m.TaskHistory, task)
+	if len(m.TaskHistory) > MaxTaskHistory {
+		m.TaskHistory = m.TaskHistory[len(m.TaskHistory)-MaxTaskHistory:]
+	}
+}
+
+// DefaultPendingTimeout is how long a task may stay pending before
+// ReconcilePending treats it as abandoned.
+const DefaultPendingTimeout = time.Hour
+
+// ErrTaskNotPending is returned when completing a task that was never started
+// or has already been completed.
+var ErrTaskNotPending = errors.New("task is not pending")
+
+// TaskOutcome is the result of a task started with RecordTaskStart.
+type TaskOutcome struct {
+	Success bool
+	Error   string
+	Tokens  int64
+	Cost    float64
+
+	// InputTokens and OutputTokens, when set, replace Tokens with their sum.
+	InputTokens  int64
+	OutputTokens int64
+}
+
+// RecordTaskStart persists a pending record for a task that is about to run
+// and returns its ID. If the process dies before RecordTaskComplete, the task
+// is later reconciled as abandoned by ReconcilePending.
+func (s *MetricsStore) RecordTaskStart(task TaskMetric) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if task.StartedAt.IsZero() {
+		task.StartedAt = time.Now()
+	}
+	if task.ID == "" {
+		task.ID = fmt.Sprintf("task-%d", task.StartedAt.UnixNano())
+	}
+	if s.metrics.Pending == nil {
+		s.metrics.Pending = make(map[string]TaskMetric)
+	}
+	s.metrics.Pending[task.ID] = task
+	s.metrics.UpdatedAt = time.Now()
+
+	s.mu.Unlock()
+	err := s.save()
+	s.mu.Lock()
+	return task.ID, err
+}
+
+// RecordTaskComplete finalizes a pending task with its outcome.
+func (s *MetricsStore) RecordTaskComplete(id string, outcome TaskOutcome) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	task, ok := s.metrics.Pending[id]
+	if !ok {
+		return fmt.Errorf("%w: %s", ErrTaskNotPending, id)
+	}
+	delete(s.metrics.Pending, id)
+
+	task.CompletedAt = time.Now()
+	task.Duration = task.CompletedAt.Sub(task.StartedAt)
+	task.Success = outcome.Success
+	task.Error = outcome.Error
+	task.Tokens = outcome.Tokens
+	task.InputTokens = outcome.InputTokens
+	task.OutputTokens = outcome.OutputTokens
+	task.Cost = outcome.Cost
+
+	s.metrics.recordCompleted(task, s.sampleHistory(task.Role))
+	s.trackLatency(task)
+	s.metrics.UpdatedAt = time.Now()
+
+	s.mu.Unlock()
+	err := s.save()
+	s.mu.Lock()
+	return err
+}
+
+// PendingTasks returns the tasks that have started but not completed.
+func (s *MetricsStore) PendingTasks() []TaskMetric {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	result := make([]TaskMetric, 0, len(s.metrics.Pending))
+	for _, task := range s.metrics.Pending {
+		result = append(result, task)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].StartedAt.Before(result[j].StartedAt)
+	})
+	return result
+}
+
+// ReconcilePending marks pending tasks started more than olderThan before now
+// as failed, recording them as abandoned. Returns the reconciled tasks.
+func (s *MetricsStore) ReconcilePending(olderThan time.Duration, now time.Time) ([]TaskMetric, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var reconciled []TaskMetric
+	for id, task := range s.metrics.Pending {
+		if now.Sub(task.StartedAt) < olderThan {
+			continue
+		}
+		delete(s.metrics.Pending, id)
+
+		task.CompletedAt = now
+		task.Duration = now.Sub(task.StartedAt)
+		task.Success = false
+		task.Error = fmt.Sprintf("abandoned: still pending after %v (process exited before completion)", olderThan)
+		reconciled = append(reconciled, task)
+	}
+	if len(reconciled) == 0 {
+		return nil, nil
+	}
+
+	sort.Slice(reconciled, func(i, j int) bool {
+		return reconciled[i].StartedAt.Before(reconciled[j].StartedAt)
+	})
+	// Abandoned tasks always go to history; they are worth investigating.
+	for _, task := range reconciled {
+		s.metrics.recordCompleted(task, true)
+		s.trackLatency(task)
+	}
+	s.metrics.UpdatedAt = now
+
+	s.mu.Unlock()
+	err := s.save()
+	s.mu.Lock()
+	return reconciled, err
+}
+
+// addTask folds a task into the role, model, and provider aggregates.
+func (m *Metrics) addTask(task TaskMetric) {
 	// Ensure maps are initialized
-	if s.metrics.ByRole == nil {
-		s.metrics.ByRole = make(map[string]*RoleMetrics)
+	if m.ByRole == nil {
+		m.ByRole = make(map[string]*RoleMetrics)
 	}
-	if s.metrics.ByModel == nil {
-		s.metrics.ByModel = make(map[string]*ModelMetrics)
+	if m.ByModel == nil {
+		m.ByModel = make(map[string]*ModelMetrics)
 	}
-	if s.metrics.ByProvider == nil {
-		s.metrics.ByProvider = make(map[string]*ProviderMetrics)
+	if m.ByProvider == nil {
+		m.ByProvider = make(map[string]*ProviderMetrics)
 	}
 
 	// Update role metrics
-	rm := s.metrics.ByRole[task.Role]
+	rm := m.ByRole[task.Role]
 	if rm == nil {
 		rm = &RoleMetrics{
 			Role:       task.Role,
 			ModelUsage: make(map[string]int),
 		}
-		s.metrics.ByRole[task.Role] = rm
+		m.ByRole[task.Role] = rm
 	}
 	rm.TotalTasks++
 	if task.Success {
@@ -191,6 +486,8 @@ func (s *MetricsStore) RecordTask(task TaskMetric) error {
 	}
 	rm.TotalDuration += task.Duration
 	rm.TotalTokens += task.Tokens
+	rm.InputTokens += task.InputTokens
+	rm.OutputTokens += task.OutputTokens
 	rm.TotalCost += task.Cost
 	rm.ModelUsage[task.Model]++
 	rm.AvgDuration = rm.TotalDuration / time.Duration(rm.TotalTasks)
@@ -199,14 +496,14 @@ func (s *MetricsStore) RecordTask(task TaskMetric) error {
 	}
 
 	// Update model metrics
-	mm := s.metrics.ByModel[task.Model]
+	mm := m.ByModel[task.Model]
 	if mm == nil {
 		mm = &ModelMetrics{
 			Model:     task.Model,
 			Provider:  task.Provider,
 			RoleUsage: make(map[string]int),
 		}
-		s.metrics.ByModel[task.Model] = mm
+		m.ByModel[task.Model] = mm
 	}
 	mm.TotalTasks++
 	if task.Success {
@@ -216,6 +513,8 @@ func (s *MetricsStore) RecordTask(task TaskMetric) error {
 	}
 	mm.TotalDuration += task.Duration
 	mm.TotalTokens += task.Tokens
+	mm.InputTokens += task.InputTokens
+	mm.OutputTokens += task.OutputTokens
 	mm.TotalCost += task.Cost
 	mm.RoleUsage[task.Role]++
 	mm.AvgDuration = mm.TotalDuration / time.Duration(mm.TotalTasks)
@@ -224,12 +523,12 @@ func (s *MetricsStore) RecordTask(task TaskMetric) error {
 	}
 
 	// Update provider metrics
-	pm := s.metrics.ByProvider[task.Provider]
+	pm := m.ByProvider[task.Provider]
 	if pm == nil {
 		pm = &ProviderMetrics{
 			Provider: task.Provider,
 		}
-		s.metrics.ByProvider[task.Provider] = pm
+		m.ByProvider[task.Provider] = pm
 	}
 	pm.TotalTasks++
 	if task.Success {
@@ -242,19 +541,27 @@ func (s *MetricsStore) RecordTask(task TaskMetric) error {
 		pm.Availability = float64(pm.CompletedTasks) / float64(pm.TotalTasks)
 	}
 
-	// Add to history
-	s.metrics.TaskHistory = append(s.metrics.TaskHistory, task)
-	if len(s.metrics.TaskHistory) > MaxTaskHistory {
-		s.metrics.TaskHistory = s.metrics.TaskHistory[len(s.metrics.TaskHistory)-MaxTaskHistory:]
+	// Update complexity metrics
+	if m.ByComplexity == nil {
+		m.ByComplexity = make(map[string]*ComplexityMetrics)
 	}
-
-	s.metrics.UpdatedAt = time.Now()
-
-	// Save to disk
-	s.mu.Unlock()
-	err := s.save()
-	s.mu.Lock()
-	return err
+	bucket := ComplexityBucket(task.Complexity)
+	cm := m.ByComplexity[bucket]
+	if cm == nil {
+		cm = &ComplexityMetrics{Complexity: bucket}
+		m.ByComplexity[bucket] = cm
+	}
+	cm.TotalTasks++
+	if task.Success {
+		cm.CompletedTasks++
+	} else {
+		cm.FailedTasks++
+	}
+	cm.TotalDuration += task.Duration
+	cm.TotalCost += task.Cost
+	cm.AvgDuration = cm.TotalDuration / time.Duration(cm.TotalTasks)
+	cm.AvgCost = cm.TotalCost / float64(cm.TotalTasks)
+	cm.SuccessRate = float64(cm.CompletedTasks) / float64(cm.TotalTasks)
 }
 
 // RecordRateLimit records a rate limit hit for a provider.
@@ -296,6 +603,9 @@ func (s *MetricsStore) GetMetrics() *Metrics {
 	if err := json.Unmarshal(data, &copy); err != nil {
 		return s.metrics
 	}
+	for _, mm := range copy.ByModel {
+		s.fillPercentiles(mm)
+	}
 	return &copy
 }
 
@@ -306,11 +616,23 @@ func (s *MetricsStore) GetRoleMetrics(role string) *RoleMetrics {
 	return s.metrics.ByRole[role]
 }
 
-// GetModelMetrics returns metrics for a specific model.
+// GetModelMetrics returns a copy of the metrics for a specific model, with
+// latency percentiles filled in.
 func (s *MetricsStore) GetModelMetrics(model string) *ModelMetrics {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
-	return s.metrics.ByModel[model]
+
+	mm := s.metrics.ByModel[model]
+	if mm == nil {
+		return nil
+	}
+	result := *mm
+	result.RoleUsage = make(map[string]int, len(mm.RoleUsage))
+	for role, n := range mm.RoleUsage {
+		result.RoleUsage[role] = n
+	}
+	s.fillPercentiles(&result)
+	return &result
 }
 
 // GetProviderMetrics returns metrics for a specific provider.
@@ -320,6 +642,28 @@ func (s *MetricsStore) GetProviderMetrics(provider string) *ProviderMetrics {
 	return s.metrics.ByProvider[provider]
 }
 
+// MetricsStore resolves the task costs behind cursor.SessionStore.CostFor.
+var _ cursor.TaskCostLookup = (*MetricsStore)(nil)
+
+// TaskCost returns the cost of the task with the given ID. Pending tasks
+// cost nothing yet. It reports false if the task is neither pending nor in
+// the task history (which is capped and may be sampled).
+func (s *MetricsStore) TaskCost(id string) (float64, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if _, ok := s.metrics.Pending[id]; ok {
+		return 0, true
+	}
+	history := s.metrics.TaskHistory
+	for i := len(history) - 1; i >= 0; i-- {
+		if history[i].ID == id {
+			return history[i].Cost, true
+		}
+	}
+	return 0, false
+}
+
 // GetRecentTasks returns the N most recent tasks.
 func (s *MetricsStore) GetRecentTasks(n int) []TaskMetric {
 	s.mu.RLock()
@@ -352,11 +696,15 @@ type Summary struct {
 func (s *MetricsStore) GetSummary() *Summary {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
+	return s.metrics.Summary()
+}
 
+// Summary returns a high-level summary of the metrics.
+func (m *Metrics) Summary() *Summary {
 	summary := &Summary{}
 
 	// Aggregate totals
-	for _, rm := range s.metrics.ByRole {
+	for _, rm := range m.ByRole {
 		summary.TotalTasks += rm.TotalTasks
 		summary.CompletedTasks += rm.CompletedTasks
 		summary.TotalCost += rm.TotalCost
@@ -368,7 +716,7 @@ func (s *MetricsStore) GetSummary() *Summary {
 
 	// Find top model by task count
 	maxTasks := 0
-	for model, mm := range s.metrics.ByModel {
+	for model, mm := range m.ByModel {
 		if mm.TotalTasks > maxTasks {
 			maxTasks = mm.TotalTasks
 			summary.TopModel = model
@@ -377,21 +725,23 @@ func (s *MetricsStore) GetSummary() *Summary {
 
 	// Find top provider by task count
 	maxProviderTasks := 0
-	for provider, pm := range s.metrics.ByProvider {
+	for provider, pm := range m.ByProvider {
 		if pm.TotalTasks > maxProviderTasks {
 			maxProviderTasks = pm.TotalTasks
 			summary.TopProvider = provider
 		}
 	}
 
-	// Calculate cost savings (compared to using Opus for everything)
-	opusRate := 0.075 // $75/1M tokens estimated
-	var estimatedOpusCost float64
-	for _, rm := range s.metrics.ByRole {
-		estimatedOpusCost += float64(rm.TotalTokens) * opusRate / 1000000
+	// Calculate cost savings (compared to using the baseline for everything)
+	baseline := ModelPrices[SavingsBaselineModel]
+	var estimatedBaselineCost float64
+	for _, rm := range m.ByRole {
+		// Tokens recorded before the input/output split are priced as output.
+		unsplit := rm.TotalTokens - rm.InputTokens - rm.OutputTokens
+		estimatedBaselineCost += baseline.Cost(rm.InputTokens, rm.OutputTokens+max(unsplit, 0))
 	}
-	if estimatedOpusCost > 0 {
-		summary.CostSavings = (1 - summary.TotalCost/estimatedOpusCost) * 100
+	if estimatedBaselineCost > 0 {
+		summary.CostSavings = (1 - summary.TotalCost/estimatedBaselineCost) * 100
 	}
 
 	return summary
@@ -407,11 +757,229 @@ func (s *MetricsStore) Reset() error {
 		ByModel:    make(map[string]*ModelMetrics),
 		ByProvider: make(map[string]*ProviderMetrics),
 	}
+	s.latencies = nil
+	s.mu.Unlock()
+
+	return s.save()
+}
+
+// DefaultHistoryPruneBytes is the metrics file size above which the daemon
+// prunes task history.
+const DefaultHistoryPruneBytes = 2 << 20 // 2 MiB
+
+// PruneHistory clears TaskHistory while keeping the role, model, and
+// provider aggregates (and any pending tasks).
+func (s *MetricsStore) PruneHistory() error {
+	s.mu.Lock()
+	s.metrics.TaskHistory = nil
+	s.metrics.UpdatedAt = time.Now()
 	s.mu.Unlock()
 
 	return s.save()
 }
 
+// PruneHistoryIfLarger prunes task history when the metrics file on disk
+// exceeds maxBytes. It reports whether a prune happened.
+func (s *MetricsStore) PruneHistoryIfLarger(maxBytes int64) (bool, error) {
+	info, err := os.Stat(s.path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return false, nil
+		}
+		return false, fmt.Errorf("checking metrics size: %w", err)
+	}
+	if info.Size() <= maxBytes {
+		return false, nil
+	}
+	return true, s.PruneHistory()
+}
+
+// StatsCursorFileName is the file recording when stats were last viewed.
+const StatsCursorFileName = "council-stats-cursor"
+
+// SinceLast returns metrics aggregated from the tasks recorded since the
+// previous SinceLast call, then advances the cursor to now. On the first
+// call (no cursor yet) all tasks in history are included and since is zero.
+//
+// Because the delta is rebuilt from TaskHistory, tasks that have already
+// been trimmed by MaxTaskHistory are not included.
+func (s *MetricsStore) SinceLast(now time.Time) (metrics *Metrics, since time.Time, err error) {
+	cursorPath := filepath.Join(filepath.Dir(s.path), StatsCursorFileName)
+
+	data, err := os.ReadFile(cursorPath)
+	if err != nil && !os.IsNotExist(err) {
+		return nil, time.Time{}, fmt.Errorf("reading stats cursor: %w", err)
+	}
+	if err == nil {
+		since, err = time.Parse(time.RFC3339Nano, strings.TrimSpace(string(data)))
+		if err != nil {
+			return nil, time.Time{}, fmt.Errorf("parsing stats cursor: %w", err)
+		}
+	}
+
+	metrics = s.metricsSince(since)
+
+	if err := os.MkdirAll(filepath.Dir(cursorPath), 0755); err != nil {
+		return nil, time.Time{}, fmt.Errorf("creating metrics directory: %w", err)
+	}
+	if err := os.WriteFile(cursorPath, []byte(now.Format(time.RFC3339Nano)+"\n"), 0644); err != nil {
+		return nil, time.Time{}, fmt.Errorf("writing stats cursor: %w", err)
+	}
+
+	return metrics, since, nil
+}
+
+// metricsSince aggregates the history entries recorded after since.
+func (s *MetricsStore) metricsSince(since time.Time) *Metrics {
+	return s.aggregateHistory(func(task TaskMetric) bool {
+		return task.recordedAt().After(since)
+	})
+}
+
+// MetricsBetween aggregates the history entries recorded in [start, end);
+// a zero end leaves the window open. A task counts as recorded when it
+// completed (or started, if it has no completion time), the same timestamp
+// SinceLast uses, so --since and --since-last agree.
+//
+// ByRole and ByModel hold cumulative counters, so windowed metrics are
+// rebuilt from TaskHistory instead. Only the last MaxTaskHistory tasks (and
+// only sampled ones; see Config.SampleRates) are kept, so windows reaching
+// further back are truncated.
+func (s *MetricsStore) MetricsBetween(start, end time.Time) *Metrics {
+	return s.aggregateHistory(func(task TaskMetric) bool {
+		at := task.recordedAt()
+		return !at.Before(start) && (end.IsZero() || at.Before(end))
+	})
+}
+
+// GetSummaryBetween returns a summary of the tasks recorded in
+// [start, end). See MetricsBetween for its limits.
+func (s *MetricsStore) GetSummaryBetween(start, end time.Time) *Summary {
+	return s.MetricsBetween(start, end).Summary()
+}
+
+// GetModelMetricsBetween returns metrics for model over the tasks
+// recorded in [start, end), or nil if it ran none. See MetricsBetween for
+// its limits.
+func (s *MetricsStore) GetModelMetricsBetween(model string, start, end time.Time) *ModelMetrics {
+	return s.MetricsBetween(start, end).ByModel[model]
+}
+
+// Cost forecast parameters.
+const (
+	// ForecastWindowDays is the trailing window the burn rate is taken from.
+	ForecastWindowDays = 7
+
+	// ForecastMonthDays is the month length costs are projected over.
+	ForecastMonthDays = 30
+
+	// forecastMinDays is the history span below which a forecast is low
+	// confidence.
+	forecastMinDays = 2
+)
+
+// CostForecast projects monthly cost from the recent burn rate.
+type CostForecast struct {
+	DailyAvg         float64 `json:"daily_avg"`
+	ProjectedMonthly float64 `json:"projected_monthly"`
+
+	// BasedOnDays is how many days of history the daily average covers: the
+	// trailing window, or less when history is younger than that.
+	BasedOnDays int `json:"based_on_days"`
+
+	// LowConfidence is set when history spans less than two days.
+	LowConfidence bool `json:"low_confidence"`
+}
+
+// ForecastMonthlyCost averages the daily cost of the tasks started in the
+// trailing ForecastWindowDays and projects it over ForecastMonthDays. Like
+// MetricsBetween, it only sees tasks still in TaskHistory.
+func (s *MetricsStore) ForecastMonthlyCost() *CostForecast {
+	return s.forecastMonthlyCost(time.Now())
+}
+
+func (s *MetricsStore) forecastMonthlyCost(now time.Time) *CostForecast {
+	start := now.Add(-ForecastWindowDays * 24 * time.Hour)
+
+	s.mu.RLock()
+	var cost float64
+	var earliest time.Time
+	for _, task := range s.metrics.TaskHistory {
+		if task.StartedAt.IsZero() {
+			continue
+		}
+		if earliest.IsZero() || task.StartedAt.Before(earliest) {
+			earliest = task.StartedAt
+		}
+		if !task.StartedAt.Before(start) {
+			cost += task.Cost
+		}
+	}
+	s.mu.RUnlock()
+
+	forecast := &CostForecast{LowConfidence: true}
+	if earliest.IsZero() {
+		return forecast
+	}
+	if earliest.Before(start) {
+		earliest = start
+	}
+
+	span := now.Sub(earliest)
+	days := int(math.Ceil(span.Hours() / 24))
+	forecast.BasedOnDays = min(max(days, 1), ForecastWindowDays)
+	forecast.LowConfidence = span < forecastMinDays*24*time.Hour
+	forecast.DailyAvg = cost / float64(forecast.BasedOnDays)
+	forecast.ProjectedMonthly = forecast.DailyAvg * ForecastMonthDays
+	return forecast
+}
+
+// aggregateHistory aggregates the history entries keep accepts, with
+// latency percentiles computed from them.
+func (s *MetricsStore) aggregateHistory(keep func(TaskMetric) bool) *Metrics {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	result := &Metrics{
+		Version:    CurrentMetricsVersion,
+		UpdatedAt:  s.metrics.UpdatedAt,
+		ByRole:     make(map[string]*RoleMetrics),
+		ByModel:    make(map[string]*ModelMetrics),
+		ByProvider: make(map[string]*ProviderMetrics),
+	}
+	for _, task := range s.metrics.TaskHistory {
+		if !keep(task) {
+			continue
+		}
+		result.addTask(task)
+		result.TaskHistory = append(result.TaskHistory, task)
+	}
+
+	latencies := make(map[string]*durationRing)
+	for _, task := range result.TaskHistory {
+		ring := latencies[task.Model]
+		if ring == nil {
+			ring = &durationRing{}
+			latencies[task.Model] = ring
+		}
+		ring.add(task.Duration)
+	}
+	for model, mm := range result.ByModel {
+		if ring := latencies[model]; ring != nil {
+			mm.P50Duration, mm.P95Duration, mm.P99Duration = ring.percentiles()
+		}
+	}
+	return result
+}
+
+// recordedAt returns when the task finished, falling back to its start time.
+func (t TaskMetric) recordedAt() time.Time {
+	if !t.CompletedAt.IsZero() {
+		return t.CompletedAt
+	}
+	return t.StartedAt
+}
+
 // CompareModels returns a comparison of two models.
 type ModelComparison struct {
 	Model1       string        `json:"model1"`
@@ -472,3 +1040,179 @@ func (s *MetricsStore) GetModelRanking() []string {
 	}
 	return result
 }
+
+// MetricsDelta is the change between two metrics snapshots.
+type MetricsDelta struct {
+	Tasks       int                    `json:"tasks"`
+	SuccessRate float64                `json:"success_rate"`
+	Cost        float64                `json:"cost"`
+	ByRole      map[string]*DeltaEntry `json:"by_role"`
+	ByModel     map[string]*DeltaEntry `json:"by_model"`
+}
+
+// DeltaEntry is the change for a single role or model.
+type DeltaEntry struct {
+	Tasks       int     `json:"tasks"`
+	SuccessRate float64 `json:"success_rate"`
+	Cost        float64 `json:"cost"`
+	Added       bool    `json:"added,omitempty"`   // only in the newer snapshot
+	Removed     bool    `json:"removed,omitempty"` // only in the baseline
+}
+
+// Diff returns the change from other (the baseline snapshot) to m.
+// A nil other is treated as an empty baseline.
+func (m *Metrics) Diff(other *Metrics) *MetricsDelta {
+	if other == nil {
+		other = &Metrics{}
+	}
+
+	cur, base := m.Summary(), other.Summary()
+	delta := &MetricsDelta{
+		Tasks:       cur.TotalTasks - base.TotalTasks,
+		SuccessRate: cur.AvgSuccessRate - base.AvgSuccessRate,
+		Cost:        cur.TotalCost - base.TotalCost,
+		ByRole:      make(map[string]*DeltaEntry),
+		ByModel:     make(map[string]*DeltaEntry),
+	}
+
+	for role, rm := range m.ByRole {
+		entry := &DeltaEntry{Tasks: rm.TotalTasks, SuccessRate: rm.SuccessRate, Cost: rm.TotalCost, Added: true}
+		if prev := other.ByRole[role]; prev != nil {
+			entry.Tasks -= prev.TotalTasks
+			entry.SuccessRate -= prev.SuccessRate
+			entry.Cost -= prev.TotalCost
+			entry.Added = false
+		}
+		delta.ByRole[role] = entry
+	}
+	for role, prev := range other.ByRole {
+		if _, ok := m.ByRole[role]; !ok {
+			delta.ByRole[role] = &DeltaEntry{Tasks: -prev.TotalTasks, SuccessRate: -prev.SuccessRate, Cost: -prev.TotalCost, Removed: true}
+		}
+	}
+
+	for model, mm := range m.ByModel {
+		entry := &DeltaEntry{Tasks: mm.TotalTasks, SuccessRate: mm.SuccessRate, Cost: mm.TotalCost, Added: true}
+		if prev := other.ByModel[model]; prev != nil {
+			entry.Tasks -= prev.TotalTasks
+			entry.SuccessRate -= prev.SuccessRate
+			entry.Cost -= prev.TotalCost
+			entry.Added = false
+		}
+		delta.ByModel[model] = entry
+	}
+	for model, prev := range other.ByModel {
+		if _, ok := m.ByModel[model]; !ok {
+			delta.ByModel[model] = &DeltaEntry{Tasks: -prev.TotalTasks, SuccessRate: -prev.SuccessRate, Cost: -prev.TotalCost, Removed: true}
+		}
+	}
+
+	return delta
+}
+
+// LoadMetricsSnapshot reads a metrics snapshot from disk. It accepts either
+// a raw metrics file or the output of 'gt council stats --json'.
+func LoadMetricsSnapshot(path string) (*Metrics, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading snapshot: %w", err)
+	}
+
+	var wrapped struct {
+		Metrics *Metrics `json:"metrics"`
+	}
+	if err := json.Unmarshal(data, &wrapped); err != nil {
+		return nil, fmt.Errorf("parsing snapshot: %w", err)
+	}
+	if wrapped.Metrics != nil {
+		wrapped.Metrics.migrate()
+		return wrapped.Metrics, nil
+	}
+
+	var metrics Metrics
+	if err := json.Unmarshal(data, &metrics); err != nil {
+		return nil, fmt.Errorf("parsing snapshot: %w", err)
+	}
+	metrics.migrate()
+	return &metrics, nil
+}
+
+// RoleModelSuccessRate returns how often model succeeded at role's tasks and
+// the number of tasks the rate is based on. It uses task history when
+// available, falling back to the model's overall success rate when the
+// model has served the role but history has been pruned.
+func (m *Metrics) RoleModelSuccessRate(role, model string) (float64, int) {
+	total, completed := 0, 0
+	for _, task := range m.TaskHistory {
+		if task.Role == role && task.Model == model {
+			total++
+			if task.Success {
+				completed++
+			}
+		}
+	}
+	if total > 0 {
+		return float64(completed) / float64(total), total
+	}
+
+	if mm := m.ByModel[model]; mm != nil && mm.RoleUsage[role] > 0 {
+		return mm.SuccessRate, mm.RoleUsage[role]
+	}
+	return 0, 0
+}
+
+// durationRing holds up to LatencySampleSize durations, overwriting the
+// oldest once full.
+type durationRing struct {
+	buf  []time.Duration
+	next int
+}
+
+func (r *durationRing) add(d time.Duration) {
+	if len(r.buf) < LatencySampleSize {
+		r.buf = append(r.buf, d)
+		return
+	}
+	r.buf[r.next] = d
+	r.next = (r.next + 1) % LatencySampleSize
+}
+
+// percentiles returns the nearest-rank p50, p95, and p99 of the ring's
+// durations, or zeros when it is empty.
+func (r *durationRing) percentiles() (p50, p95, p99 time.Duration) {
+	if len(r.buf) == 0 {
+		return 0, 0, 0
+	}
+	sorted := append([]time.Duration(nil), r.buf...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	rank := func(p float64) time.Duration {
+		i := int(math.Ceil(p*float64(len(sorted)))) - 1
+		if i < 0 {
+			i = 0
+		}
+		return sorted[i]
+	}
+	return rank(0.50), rank(0.95), rank(0.99)
+}
+
+// trackLatency adds a completed task's duration to its model's ring.
+// Callers must hold s.mu.
+func (s *MetricsStore) trackLatency(task TaskMetric) {
+	if s.latencies == nil {
+		s.latencies = make(map[string]*durationRing)
+	}
+	ring := s.latencies[task.Model]
+	if ring == nil {
+		ring = &durationRing{}
+		s.latencies[task.Model] = ring
+	}
+	ring.add(task.Duration)
+}
+
+// fillPercentiles sets mm's latency percentiles from the store's rings.
+// Callers must hold s.mu.
+func (s *MetricsStore) fillPercentiles(mm *ModelMetrics) {
+	if ring := s.latencies[mm.Model]; ring != nil {
+		mm.P50Duration, mm.P95Duration, mm.P99Duration = ring.percentiles()
+	}
+}
@@ -0,0 +1,515 @@
+package council
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cursorworkshop/cursor-gastown/internal/cursor"
+)
+
+func newTestMetricsStore(t *testing.T) *MetricsStore {
+	t.Helper()
+	store, err := NewMetricsStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewMetricsStore failed: %v", err)
+	}
+	return store
+}
+
+func recordTestTask(t *testing.T, store *MetricsStore, task TaskMetric) {
+	t.Helper()
+	if task.Provider == "" {
+		task.Provider = ModelProvider(task.Model)
+	}
+	if err := store.RecordTask(task); err != nil {
+		t.Fatalf("RecordTask failed: %v", err)
+	}
+}
+
+func TestMetricsStore_SinceLast(t *testing.T) {
+	store := newTestMetricsStore(t)
+	base := time.Now().Add(-time.Hour)
+
+	recordTestTask(t, store, TaskMetric{ID: "t1", Role: "polecat", Model: "sonnet-4.5", StartedAt: base, CompletedAt: base.Add(time.Minute), Success: true, Cost: 1})
+	recordTestTask(t, store, TaskMetric{ID: "t2", Role: "witness", Model: "gemini-3-flash", StartedAt: base, CompletedAt: base.Add(2 * time.Minute), Success: true, Cost: 0.1})
+
+	// First invocation shows everything.
+	first, since, err := store.SinceLast(base.Add(10 * time.Minute))
+	if err != nil {
+		t.Fatalf("SinceLast failed: %v", err)
+	}
+	if !since.IsZero() {
+		t.Errorf("first SinceLast since = %v, want zero", since)
+	}
+	if got := first.Summary().TotalTasks; got != 2 {
+		t.Errorf("first SinceLast TotalTasks = %d, want 2", got)
+	}
+
+	recordTestTask(t, store, TaskMetric{ID: "t3", Role: "refinery", Model: "gpt-5.2-high", StartedAt: base.Add(15 * time.Minute), CompletedAt: base.Add(20 * time.Minute), Success: false, Cost: 2})
+
+	second, since, err := store.SinceLast(base.Add(30 * time.Minute))
+	if err != nil {
+		t.Fatalf("SinceLast failed: %v", err)
+	}
+	if !since.Equal(base.Add(10 * time.Minute)) {
+		t.Errorf("second SinceLast since = %v, want previous cursor", since)
+	}
+	summary := second.Summary()
+	if summary.TotalTasks != 1 || summary.TotalCost != 2 {
+		t.Errorf("second SinceLast = %d tasks / $%.2f, want only t3 (1 task / $2.00)", summary.TotalTasks, summary.TotalCost)
+	}
+	if second.ByRole["refinery"] == nil || second.ByRole["polecat"] != nil {
+		t.Errorf("second SinceLast roles = %v, want only refinery", second.ByRole)
+	}
+
+	// The cumulative store is untouched.
+	if got := store.GetSummary().TotalTasks; got != 3 {
+		t.Errorf("store TotalTasks = %d, want 3", got)
+	}
+
+}
+
+func TestMetrics_Diff(t *testing.T) {
+	baseline := &Metrics{}
+	baseline.addTask(TaskMetric{Role: "polecat", Model: "sonnet-4.5", Provider: "anthropic", Success: true, Cost: 1.0})
+	baseline.addTask(TaskMetric{Role: "polecat", Model: "gpt-5", Provider: "openai", Success: false, Cost: 0.5})
+
+	current := &Metrics{}
+	current.addTask(TaskMetric{Role: "polecat", Model: "sonnet-4.5", Provider: "anthropic", Success: true, Cost: 1.0})
+	current.addTask(TaskMetric{Role: "polecat", Model: "sonnet-4.5", Provider: "anthropic", Success: true, Cost: 2.0})
+	current.addTask(TaskMetric{Role: "mayor", Model: "opus-4.5", Provider: "anthropic", Success: true, Cost: 3.0})
+
+	delta := current.Diff(baseline)
+
+	if delta.Tasks != 1 {
+		t.Errorf("Tasks delta = %d, want 1", delta.Tasks)
+	}
+	if delta.Cost != 4.5 {
+		t.Errorf("Cost delta = %v, want 4.5", delta.Cost)
+	}
+	if delta.SuccessRate != 0.5 {
+		t.Errorf("SuccessRate delta = %v, want 0.5", delta.SuccessRate)
+	}
+
+	sonnet := delta.ByModel["sonnet-4.5"]
+	if sonnet == nil || sonnet.Tasks != 1 || sonnet.Cost != 2.0 || sonnet.Added || sonnet.Removed {
+		t.Errorf("sonnet-4.5 delta = %+v, want +1 task, +$2, neither added nor removed", sonnet)
+	}
+	if opus := delta.ByModel["opus-4.5"]; opus == nil || !opus.Added || opus.Tasks != 1 {
+		t.Errorf("opus-4.5 delta = %+v, want added with 1 task", opus)
+	}
+	if gpt := delta.ByModel["gpt-5"]; gpt == nil || !gpt.Removed || gpt.Tasks != -1 || gpt.Cost != -0.5 {
+		t.Errorf("gpt-5 delta = %+v, want removed with -1 task and -$0.5", gpt)
+	}
+	if mayor := delta.ByRole["mayor"]; mayor == nil || !mayor.Added {
+		t.Errorf("mayor role delta = %+v, want added", mayor)
+	}
+}
+
+func TestMetricsStore_TaskLifecycle(t *testing.T) {
+	store := newTestMetricsStore(t)
+
+	id, err := store.RecordTaskStart(TaskMetric{Role: "polecat", Model: "sonnet-4.5", Provider: "anthropic"})
+	if err != nil {
+		t.Fatalf("RecordTaskStart failed: %v", err)
+	}
+	if id == "" {
+		t.Fatal("RecordTaskStart returned empty ID")
+	}
+	if pending := store.PendingTasks(); len(pending) != 1 || pending[0].ID != id {
+		t.Fatalf("PendingTasks = %+v, want the started task", pending)
+	}
+
+	// Pending records survive a reload
+	reloaded, err := NewMetricsStore(filepath.Dir(filepath.Dir(store.path)))
+	if err != nil {
+		t.Fatalf("reloading store: %v", err)
+	}
+	if len(reloaded.PendingTasks()) != 1 {
+		t.Error("pending task was not persisted")
+	}
+
+	if err := store.RecordTaskComplete(id, TaskOutcome{Success: true, Cost: 0.5}); err != nil {
+		t.Fatalf("RecordTaskComplete failed: %v", err)
+	}
+	if len(store.PendingTasks()) != 0 {
+		t.Error("task still pending after completion")
+	}
+	history := store.GetRecentTasks(10)
+	if len(history) != 1 || !history[0].Success || history[0].CompletedAt.IsZero() {
+		t.Errorf("history = %+v, want one completed task", history)
+	}
+
+	if err := store.RecordTaskComplete(id, TaskOutcome{}); !errors.Is(err, ErrTaskNotPending) {
+		t.Errorf("completing twice: expected ErrTaskNotPending, got %v", err)
+	}
+}
+
+func TestMetricsStore_ReconcilePending(t *testing.T) {
+	store := newTestMetricsStore(t)
+	now := time.Now()
+
+	staleID, err := store.RecordTaskStart(TaskMetric{ID: "stale", Role: "polecat", Model: "sonnet-4.5", Provider: "anthropic", StartedAt: now.Add(-2 * time.Hour)})
+	if err != nil {
+		t.Fatalf("RecordTaskStart failed: %v", err)
+	}
+	if _, err := store.RecordTaskStart(TaskMetric{ID: "fresh", Role: "polecat", Model: "sonnet-4.5", Provider: "anthropic", StartedAt: now.Add(-time.Minute)}); err != nil {
+		t.Fatalf("RecordTaskStart failed: %v", err)
+	}
+
+	reconciled, err := store.ReconcilePending(DefaultPendingTimeout, now)
+	if err != nil {
+		t.Fatalf("ReconcilePending failed: %v", err)
+	}
+	if len(reconciled) != 1 || reconciled[0].ID != staleID {
+		t.Fatalf("reconciled = %+v, want only the stale task", reconciled)
+	}
+	if reconciled[0].Success || !strings.Contains(reconciled[0].Error, "abandoned") {
+		t.Errorf("stale task should be failed as abandoned, got %+v", reconciled[0])
+	}
+
+	if pending := store.PendingTasks(); len(pending) != 1 || pending[0].ID != "fresh" {
+		t.Errorf("PendingTasks = %+v, want only the fresh task", pending)
+	}
+	if rm := store.GetRoleMetrics("polecat"); rm == nil || rm.FailedTasks != 1 {
+		t.Errorf("role metrics = %+v, want one failed task", rm)
+	}
+}
+
+func TestMetricsStore_PruneHistory(t *testing.T) {
+	store := newTestMetricsStore(t)
+	recordTestTask(t, store, TaskMetric{Role: "polecat", Model: "sonnet-4.5", Success: true, Cost: 1.5})
+	recordTestTask(t, store, TaskMetric{Role: "mayor", Model: "opus-4.5", Success: false, Cost: 2.5})
+
+	if err := store.PruneHistory(); err != nil {
+		t.Fatalf("PruneHistory failed: %v", err)
+	}
+
+	if history := store.GetRecentTasks(10); len(history) != 0 {
+		t.Errorf("history = %+v, want empty", history)
+	}
+	if rm := store.GetRoleMetrics("polecat"); rm == nil || rm.TotalTasks != 1 {
+		t.Errorf("polecat aggregates lost: %+v", rm)
+	}
+	if mm := store.GetModelMetrics("opus-4.5"); mm == nil || mm.FailedTasks != 1 {
+		t.Errorf("opus-4.5 aggregates lost: %+v", mm)
+	}
+
+	summary := store.GetSummary()
+	if summary.TotalTasks != 2 || summary.CompletedTasks != 1 || summary.TotalCost != 4.0 {
+		t.Errorf("summary after prune = %+v, want 2 tasks, 1 completed, $4", summary)
+	}
+}
+
+func TestMetricsStore_PruneHistoryIfLarger(t *testing.T) {
+	store := newTestMetricsStore(t)
+	recordTestTask(t, store, TaskMetric{Role: "polecat", Model: "sonnet-4.5", Success: true})
+
+	pruned, err := store.PruneHistoryIfLarger(1 << 20)
+	if err != nil || pruned {
+		t.Fatalf("small file: pruned=%v err=%v, want no prune", pruned, err)
+	}
+
+	pruned, err = store.PruneHistoryIfLarger(10)
+	if err != nil || !pruned {
+		t.Fatalf("large file: pruned=%v err=%v, want prune", pruned, err)
+	}
+	if len(store.GetRecentTasks(10)) != 0 {
+		t.Error("history not pruned")
+	}
+}
+
+func TestMetricsStore_SampleRate(t *testing.T) {
+	store := newTestMetricsStore(t)
+	cfg := DefaultCouncilConfig()
+	cfg.Roles["witness"].SampleRate = 0.1
+	store.SetSampleRates(cfg.SampleRates())
+
+	for i := 0; i < 200; i++ {
+		recordTestTask(t, store, TaskMetric{Role: "witness", Model: "gemini-3-flash", Success: true})
+	}
+	recordTestTask(t, store, TaskMetric{Role: "polecat", Model: "sonnet-4.5", Success: true})
+
+	witnessInHistory := 0
+	for _, task := range store.GetRecentTasks(MaxTaskHistory) {
+		if task.Role == "witness" {
+			witnessInHistory++
+		}
+	}
+	if witnessInHistory < 15 || witnessInHistory > 25 {
+		t.Errorf("witness tasks in history = %d, want ~20 (10%% of 200)", witnessInHistory)
+	}
+	if rm := store.GetRoleMetrics("witness"); rm == nil || rm.TotalTasks != 200 {
+		t.Errorf("witness aggregates = %+v, want all 200 tasks counted", rm)
+	}
+	if got := len(store.GetRecentTasks(MaxTaskHistory)) - witnessInHistory; got != 1 {
+		t.Errorf("unsampled polecat tasks in history = %d, want 1", got)
+	}
+}
+
+func TestMetricsStore_ByComplexity(t *testing.T) {
+	store := newTestMetricsStore(t)
+
+	recordTestTask(t, store, TaskMetric{Role: "polecat", Model: "gemini-3-flash", Complexity: "low", Duration: time.Minute, Cost: 0.1, Success: true})
+	recordTestTask(t, store, TaskMetric{Role: "polecat", Model: "gemini-3-flash", Complexity: "low", Duration: 3 * time.Minute, Cost: 0.3, Success: true})
+	recordTestTask(t, store, TaskMetric{Role: "polecat", Model: "sonnet-4.5", Complexity: "medium", Duration: 5 * time.Minute, Cost: 1, Success: true})
+	recordTestTask(t, store, TaskMetric{Role: "polecat", Model: "opus-4.5", Complexity: "high", Duration: 10 * time.Minute, Cost: 4, Success: true})
+	recordTestTask(t, store, TaskMetric{Role: "polecat", Model: "opus-4.5", Complexity: "high", Duration: 20 * time.Minute, Cost: 6, Success: false})
+	recordTestTask(t, store, TaskMetric{Role: "witness", Model: "gemini-3-flash", Duration: time.Minute, Success: true})
+
+	byComplexity := store.GetMetrics().ByComplexity
+	if len(byComplexity) != 4 {
+		t.Fatalf("ByComplexity has %d levels, want 4: %v", len(byComplexity), byComplexity)
+	}
+
+	low := byComplexity["low"]
+	if low.TotalTasks != 2 || low.SuccessRate != 1 || low.AvgDuration != 2*time.Minute || low.TotalCost < 0.39 || low.TotalCost > 0.41 {
+		t.Errorf("low = %+v, want 2 tasks, 100%%, avg 2m, $0.40", low)
+	}
+	high := byComplexity["high"]
+	if high.TotalTasks != 2 || high.FailedTasks != 1 || high.SuccessRate != 0.5 || high.AvgDuration != 15*time.Minute || high.AvgCost != 5 {
+		t.Errorf("high = %+v, want 2 tasks, 50%%, avg 15m, avg $5", high)
+	}
+	if medium := byComplexity["medium"]; medium.TotalTasks != 1 || medium.TotalCost != 1 {
+		t.Errorf("medium = %+v, want 1 task costing $1", medium)
+	}
+	if unspecified := byComplexity[ComplexityUnspecified]; unspecified == nil || unspecified.TotalTasks != 1 {
+		t.Errorf("unspecified = %+v, want the task without a complexity", unspecified)
+	}
+}
+
+func TestMetricsStore_LatencyPercentiles(t *testing.T) {
+	store := newTestMetricsStore(t)
+
+	// Fewer samples than the ring holds: 1s..10s.
+	for i := 1; i <= 10; i++ {
+		recordTestTask(t, store, TaskMetric{Role: "polecat", Model: "sonnet-4.5", Duration: time.Duration(i) * time.Second, Success: true})
+	}
+	mm := store.GetModelMetrics("sonnet-4.5")
+	if mm.P50Duration != 5*time.Second || mm.P95Duration != 10*time.Second || mm.P99Duration != 10*time.Second {
+		t.Errorf("percentiles = %v/%v/%v, want 5s/10s/10s", mm.P50Duration, mm.P95Duration, mm.P99Duration)
+	}
+
+	// Percentiles are computed on read, never persisted.
+	if stored := store.metrics.ByModel["sonnet-4.5"]; stored.P50Duration != 0 {
+		t.Errorf("stored P50Duration = %v, want 0", stored.P50Duration)
+	}
+
+	// The ring keeps only the last LatencySampleSize durations: push the
+	// slow early samples out with fast ones. (Fold them in directly to
+	// avoid a save per task.)
+	for i := 0; i < LatencySampleSize; i++ {
+		task := TaskMetric{Role: "polecat", Model: "sonnet-4.5", Provider: "anthropic", Duration: time.Millisecond, Success: true}
+		store.metrics.recordCompleted(task, true)
+		store.trackLatency(task)
+	}
+	if err := store.save(); err != nil {
+		t.Fatalf("save failed: %v", err)
+	}
+	mm = store.GetMetrics().ByModel["sonnet-4.5"]
+	if mm.P99Duration != time.Millisecond {
+		t.Errorf("P99Duration = %v, want 1ms once old samples age out", mm.P99Duration)
+	}
+
+	// Percentiles survive a reload via task history.
+	reloaded, err := NewMetricsStore(filepath.Dir(filepath.Dir(store.path)))
+	if err != nil {
+		t.Fatalf("NewMetricsStore failed: %v", err)
+	}
+	if got := reloaded.GetModelMetrics("sonnet-4.5"); got.P50Duration != time.Millisecond {
+		t.Errorf("reloaded P50Duration = %v, want 1ms", got.P50Duration)
+	}
+	if reloaded.GetModelMetrics("gpt-5.2") != nil {
+		t.Error("GetModelMetrics for an unknown model should be nil")
+	}
+
+	// Reset drops the samples along with the aggregates.
+	if err := reloaded.Reset(); err != nil {
+		t.Fatalf("Reset failed: %v", err)
+	}
+	recordTestTask(t, reloaded, TaskMetric{Role: "polecat", Model: "sonnet-4.5", Duration: 4 * time.Second, Success: true})
+	if got := reloaded.GetModelMetrics("sonnet-4.5"); got.P50Duration != 4*time.Second {
+		t.Errorf("P50Duration after Reset = %v, want 4s from the new task alone", got.P50Duration)
+	}
+}
+
+func TestMetricsStore_TokenSplit(t *testing.T) {
+	store := newTestMetricsStore(t)
+	recordTestTask(t, store, TaskMetric{
+		Role: "polecat", Model: "sonnet-4.5", Success: true,
+		InputTokens: 1000, OutputTokens: 500, Cost: 0.00875,
+	})
+
+	rm := store.GetRoleMetrics("polecat")
+	if rm.InputTokens != 1000 || rm.OutputTokens != 500 || rm.TotalTokens != 1500 {
+		t.Errorf("role tokens = %d in / %d out / %d total, want 1000/500/1500", rm.InputTokens, rm.OutputTokens, rm.TotalTokens)
+	}
+	mm := store.GetModelMetrics("sonnet-4.5")
+	if mm.InputTokens != 1000 || mm.OutputTokens != 500 {
+		t.Errorf("model tokens = %d in / %d out, want 1000/500", mm.InputTokens, mm.OutputTokens)
+	}
+	if history := store.GetRecentTasks(1); history[0].Tokens != 1500 {
+		t.Errorf("task Tokens = %d, want computed total 1500", history[0].Tokens)
+	}
+
+	// Baseline: 1000 input at $5/1M + 500 output at $25/1M = $0.0175.
+	if got := store.GetSummary().CostSavings; math.Abs(got-50) > 0.01 {
+		t.Errorf("CostSavings = %.2f%%, want 50%%", got)
+	}
+}
+
+func TestMetricsStore_MigratesUnsplitTokens(t *testing.T) {
+	townRoot := t.TempDir()
+	path := filepath.Join(townRoot, ".beads", MetricsFileName)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	v1 := `{
+  "version": 1,
+  "by_role": {"polecat": {"role": "polecat", "total_tasks": 1, "completed_tasks": 1, "total_tokens": 1000, "total_cost": 0.0125}},
+  "by_model": {"sonnet-4.5": {"model": "sonnet-4.5", "total_tasks": 1, "total_tokens": 1000}},
+  "task_history": [{"id": "t1", "role": "polecat", "model": "sonnet-4.5", "tokens": 1000, "success": true}]
+}`
+	if err := os.WriteFile(path, []byte(v1), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	store, err := NewMetricsStore(townRoot)
+	if err != nil {
+		t.Fatalf("NewMetricsStore failed: %v", err)
+	}
+	metrics := store.GetMetrics()
+	if metrics.Version != CurrentMetricsVersion {
+		t.Errorf("Version = %d, want %d", metrics.Version, CurrentMetricsVersion)
+	}
+	rm := metrics.ByRole["polecat"]
+	if rm.TotalTokens != 1000 || rm.InputTokens != 0 || rm.OutputTokens != 0 {
+		t.Errorf("role tokens = %d total / %d in / %d out, want 1000/0/0", rm.TotalTokens, rm.InputTokens, rm.OutputTokens)
+	}
+	if task := metrics.TaskHistory[0]; task.Tokens != 1000 || task.InputTokens != 0 || task.OutputTokens != 0 {
+		t.Errorf("history task = %+v, want unsplit 1000 tokens", task)
+	}
+
+	// Unsplit tokens are priced at the baseline output rate: 1000 at $25/1M.
+	if got := store.GetSummary().CostSavings; math.Abs(got-50) > 0.01 {
+		t.Errorf("CostSavings = %.2f%%, want 50%%", got)
+	}
+}
+
+func TestMetricsStore_GetSummaryBetween(t *testing.T) {
+	store := newTestMetricsStore(t)
+	boundary := time.Date(2026, 3, 9, 0, 0, 0, 0, time.UTC)
+
+	recordTestTask(t, store, TaskMetric{ID: "old", Role: "polecat", Model: "sonnet-4.5", StartedAt: boundary.Add(-time.Second), Success: true, Cost: 5})
+	recordTestTask(t, store, TaskMetric{ID: "edge", Role: "polecat", Model: "sonnet-4.5", StartedAt: boundary, Success: false, Cost: 1})
+	recordTestTask(t, store, TaskMetric{ID: "new", Role: "witness", Model: "gemini-3-flash", StartedAt: boundary.Add(48 * time.Hour), Success: true, Cost: 0.5})
+
+	week := store.GetSummaryBetween(boundary, boundary.Add(7*24*time.Hour))
+	if week.TotalTasks != 2 || week.TotalCost != 1.5 {
+		t.Errorf("window = %d tasks / $%.2f, want edge and new (2 / $1.50)", week.TotalTasks, week.TotalCost)
+	}
+
+	// The end is exclusive, and a zero end leaves the window open.
+	if got := store.GetSummaryBetween(boundary.Add(-time.Hour), boundary).TotalTasks; got != 1 {
+		t.Errorf("window ending at boundary has %d tasks, want only old", got)
+	}
+	if got := store.GetSummaryBetween(boundary.Add(time.Hour), time.Time{}).TotalTasks; got != 1 {
+		t.Errorf("open window has %d tasks, want only new", got)
+	}
+
+	mm := store.GetModelMetricsBetween("sonnet-4.5", boundary, time.Time{})
+	if mm == nil || mm.TotalTasks != 1 || mm.SuccessRate != 0 || mm.TotalCost != 1 {
+		t.Errorf("sonnet-4.5 in window = %+v, want only the failed edge task", mm)
+	}
+	if mm := store.GetModelMetricsBetween("gemini-3-flash", time.Time{}, boundary); mm != nil {
+		t.Errorf("gemini-3-flash before boundary = %+v, want nil", mm)
+	}
+
+	// Cumulative metrics are untouched.
+	if got := store.GetSummary().TotalTasks; got != 3 {
+		t.Errorf("store TotalTasks = %d, want 3", got)
+	}
+
+	// Windows go by completion time, like SinceLast: a task that started
+	// before the boundary but finished after it falls inside.
+	recordTestTask(t, store, TaskMetric{ID: "long", Role: "mayor", Model: "opus-4.5", StartedAt: boundary.Add(-time.Hour), CompletedAt: boundary.Add(time.Hour), Success: true})
+	if mm := store.GetModelMetricsBetween("opus-4.5", boundary, time.Time{}); mm == nil || mm.TotalTasks != 1 {
+		t.Errorf("opus-4.5 completed after boundary = %+v, want it in the window", mm)
+	}
+}
+
+func TestMetricsStore_ForecastMonthlyCost(t *testing.T) {
+	now := time.Date(2026, 3, 16, 12, 0, 0, 0, time.UTC)
+	day := 24 * time.Hour
+
+	store := newTestMetricsStore(t)
+	if f := store.forecastMonthlyCost(now); f.BasedOnDays != 0 || !f.LowConfidence || f.ProjectedMonthly != 0 {
+		t.Errorf("empty history forecast = %+v, want zero with low confidence", f)
+	}
+
+	// $2/day over the trailing week; an older task is outside the window.
+	recordTestTask(t, store, TaskMetric{Role: "polecat", Model: "sonnet-4.5", StartedAt: now.Add(-10 * day), Cost: 100})
+	for i := 0; i < 7; i++ {
+		recordTestTask(t, store, TaskMetric{Role: "polecat", Model: "sonnet-4.5", StartedAt: now.Add(-time.Duration(i)*day - time.Hour), Cost: 2})
+	}
+	f := store.forecastMonthlyCost(now)
+	if f.BasedOnDays != ForecastWindowDays || f.LowConfidence {
+		t.Errorf("forecast = %+v, want a full-confidence %d-day window", f, ForecastWindowDays)
+	}
+	if math.Abs(f.DailyAvg-2) > 1e-9 || math.Abs(f.ProjectedMonthly-60) > 1e-9 {
+		t.Errorf("forecast = $%.2f/day, $%.2f/month; want $2 and $60", f.DailyAvg, f.ProjectedMonthly)
+	}
+
+	// A day of history is projected from that day alone, at low confidence.
+	young := newTestMetricsStore(t)
+	recordTestTask(t, young, TaskMetric{Role: "polecat", Model: "sonnet-4.5", StartedAt: now.Add(-6 * time.Hour), Cost: 3})
+	f = young.forecastMonthlyCost(now)
+	if f.BasedOnDays != 1 || !f.LowConfidence || math.Abs(f.ProjectedMonthly-90) > 1e-9 {
+		t.Errorf("young forecast = %+v, want 1 day, low confidence, $90/month", f)
+	}
+}
+
+func TestMetricsStore_SessionCost(t *testing.T) {
+	metrics := newTestMetricsStore(t)
+	for i, cost := range []float64{0.25, 0.5, 2} {
+		task := TaskMetric{ID: fmt.Sprintf("task-%d", i), Role: "polecat", Model: "sonnet-4.5", SessionID: "chat-1", Success: true, Cost: cost}
+		if err := metrics.RecordTask(task); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := metrics.RecordTaskStart(TaskMetric{ID: "running", Role: "polecat", Model: "sonnet-4.5"}); err != nil {
+		t.Fatal(err)
+	}
+
+	sessions, err := cursor.NewSessionStore(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := sessions.Put(&cursor.Session{ID: "chat-1", Status: cursor.SessionStatusActive}); err != nil {
+		t.Fatal(err)
+	}
+	for _, id := range []string{"task-0", "task-1", "running"} {
+		if err := sessions.AttachMetric("chat-1", id); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	cost, err := sessions.CostFor("chat-1", metrics)
+	if err != nil || math.Abs(cost-0.75) > 1e-9 {
+		t.Errorf("CostFor = %v, %v; want 0.75", cost, err)
+	}
+
+	if err := sessions.AttachMetric("chat-1", "evicted"); err != nil {
+		t.Fatal(err)
+	}
+	cost, err = sessions.CostFor("chat-1", metrics)
+	if !errors.Is(err, cursor.ErrMetricNotFound) || !strings.Contains(err.Error(), "evicted") || math.Abs(cost-0.75) > 1e-9 {
+		t.Errorf("CostFor with a missing metric = %v, %v; want 0.75 and ErrMetricNotFound", cost, err)
+	}
+}
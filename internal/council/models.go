@@ -0,0 +1,253 @@
+// Package council provides multi-model orchestration for Gas Town.
+package council
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/cursorworkshop/cursor-gastown/internal/cursor"
+)
+
+// ModelsEndpoints maps providers to their models-list endpoints.
+// Providers not listed here do not support RefreshModels.
+var ModelsEndpoints = map[string]string{
+	"openai": "https://api.openai.com/v1/models",
+	"google": "https://generativelanguage.googleapis.com/v1/models",
+}
+
+// ProviderAPIKeyEnv maps providers to their conventional API key variables.
+var ProviderAPIKeyEnv = map[string]string{
+	"anthropic": "ANTHROPIC_API_KEY",
+	"openai":    "OPENAI_API_KEY",
+	"google":    "GEMINI_API_KEY",
+}
+
+var (
+	// ErrModelsListUnsupported is returned when a provider has no models-list endpoint.
+	ErrModelsListUnsupported = errors.New("provider has no models-list endpoint")
+
+	// ErrNoCredentials is returned when the provider API key is not set.
+	ErrNoCredentials = errors.New("provider API key not set")
+)
+
+// RefreshModels fetches the live model list for a provider and stores it in
+// the provider's Models. Returns ErrModelsListUnsupported or ErrNoCredentials
+// (wrapped) when the provider can't be queried, so callers can skip it.
+func (c *Config) RefreshModels(ctx context.Context, provider string) ([]string, error) {
+	pc := c.Providers[provider]
+	if pc == nil {
+		return nil, fmt.Errorf("unknown provider: %s", provider)
+	}
+
+	endpoint := pc.ModelsURL
+	if endpoint == "" {
+		endpoint = ModelsEndpoints[provider]
+	}
+	if endpoint == "" {
+		return nil, fmt.Errorf("%s: %w", provider, ErrModelsListUnsupported)
+	}
+
+	keyEnv, apiKey := providerAPIKey(provider, pc)
+	if apiKey == "" {
+		return nil, fmt.Errorf("%s (%s): %w", provider, keyEnv, ErrNoCredentials)
+	}
+
+	if NetworkDisabled() {
+		return nil, fmt.Errorf("%s: %w", provider, ErrNetworkDisabled)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "GET", endpoint, nil)
+	if err != nil {
+		return nil, fmt.Errorf("creating request: %w", err)
+	}
+	setProviderAuth(req, provider, pc, apiKey)
+
+	client := &http.Client{Timeout: 10 * time.Second}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("fetching %s models: %w", provider, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("fetching %s models: HTTP %d", provider, resp.StatusCode)
+	}
+
+	models, err := parseModelsList(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("parsing %s models: %w", provider, err)
+	}
+
+	pc.Models = models
+	return models, nil
+}
+
+// providerAPIKey returns the API key variable for a provider and its value,
+// which is empty when the variable is unset.
+func providerAPIKey(provider string, pc *ProviderConfig) (keyEnv, apiKey string) {
+	keyEnv = pc.APIKeyEnv
+	if keyEnv == "" {
+		keyEnv = ProviderAPIKeyEnv[provider]
+	}
+	if keyEnv == "" {
+		return "", ""
+	}
+	return keyEnv, os.Getenv(keyEnv)
+}
+
+// setProviderAuth adds the provider's authentication and extra headers.
+func setProviderAuth(req *http.Request, provider string, pc *ProviderConfig, apiKey string) {
+	switch provider {
+	case "google":
+		req.Header.Set("x-goog-api-key", apiKey)
+	case "anthropic":
+		req.Header.Set("x-api-key", apiKey)
+		req.Header.Set("anthropic-version", "2023-06-01")
+	default:
+		req.Header.Set("Authorization", "Bearer "+apiKey)
+	}
+	for k, v := range pc.Headers {
+		req.Header.Set(k, v)
+	}
+}
+
+// parseModelsList decodes an OpenAI-style ({"data":[{"id":...}]}) or
+// Google-style ({"models":[{"name":"models/..."}]}) models list.
+func parseModelsList(r io.Reader) ([]string, error) {
+	var body struct {
+		Data []struct {
+			ID string `json:"id"`
+		} `json:"data"`
+		Models []struct {
+			Name string `json:"name"`
+		} `json:"models"`
+	}
+	if err := json.NewDecoder(r).Decode(&body); err != nil {
+		return nil, err
+	}
+
+	var models []string
+	for _, m := range body.Data {
+		if m.ID != "" {
+			models = append(models, m.ID)
+		}
+	}
+	for _, m := range body.Models {
+		if name := strings.TrimPrefix(m.Name, "models/"); name != "" {
+			models = append(models, name)
+		}
+	}
+	sort.Strings(models)
+	return models, nil
+}
+
+// ModelCapabilities lists notable capabilities of known models, and their
+// aliases, from the model catalog; shown by `gt council models`.
+var ModelCapabilities = catalogCapabilities()
+
+// catalogCapabilities collects the capabilities of the cursor model
+// catalog's models.
+func catalogCapabilities() map[string][]string {
+	caps := make(map[string][]string)
+	for _, m := range cursor.Catalog().Models {
+		if len(m.Capabilities) == 0 {
+			continue
+		}
+		for _, name := range append([]string{m.Name}, m.Aliases...) {
+			caps[name] = m.Capabilities
+		}
+	}
+	return caps
+}
+
+// ModelInfo describes a model in the model listing.
+type ModelInfo struct {
+	Name         string   `json:"name"`
+	Provider     string   `json:"provider"`
+	Capabilities []string `json:"capabilities,omitempty"`
+
+	// AvgCost is the observed average cost per task, if any tasks were recorded.
+	AvgCost float64 `json:"avg_cost,omitempty"`
+
+	// UsedBy lists the roles (or "defaults") whose config references the model.
+	UsedBy []string `json:"used_by,omitempty"`
+	InUse  bool     `json:"in_use"`
+}
+
+// ListModels describes each of models with its provider, capabilities,
+// observed cost from metrics (may be nil), and references in cfg (may be nil).
+// When provider is non-empty, only that provider's models are returned.
+func ListModels(models []string, cfg *Config, metrics *Metrics, provider string) []ModelInfo {
+	usedBy := cfg.modelReferences()
+
+	var infos []ModelInfo
+	for _, model := range models {
+		p := ModelProvider(model)
+		if model == "auto" {
+			p = "cursor"
+		}
+		if provider != "" && p != provider {
+			continue
+		}
+
+		info := ModelInfo{
+			Name:         model,
+			Provider:     p,
+			Capabilities: ModelCapabilities[model],
+			UsedBy:       usedBy[model],
+			InUse:        len(usedBy[model]) > 0,
+		}
+		if metrics != nil {
+			if mm := metrics.ByModel[model]; mm != nil && mm.TotalTasks > 0 {
+				info.AvgCost = mm.TotalCost / float64(mm.TotalTasks)
+			}
+		}
+		infos = append(infos, info)
+	}
+	return infos
+}
+
+// modelReferences maps each model referenced by the config (as a primary,
+// fallback, or complexity model) to the sorted roles that reference it.
+func (c *Config) modelReferences() map[string][]string {
+	refs := make(map[string][]string)
+	if c == nil {
+		return refs
+	}
+
+	add := func(owner string, models ...string) {
+		for _, m := range models {
+			if m != "" && !contains(refs[m], owner) {
+				refs[m] = append(refs[m], owner)
+			}
+		}
+	}
+
+	for role, rc := range c.Roles {
+		if rc == nil {
+			continue
+		}
+		add(role, rc.Model)
+		add(role, rc.Fallback...)
+		if rc.Complexity != nil {
+			add(role, rc.Complexity.High, rc.Complexity.Medium, rc.Complexity.Low)
+		}
+	}
+	if c.Defaults != nil {
+		add("defaults", c.Defaults.Model)
+		add("defaults", c.Defaults.Fallback...)
+	}
+
+	for _, owners := range refs {
+		sort.Strings(owners)
+	}
+	return refs
+}
@@ -0,0 +1,51 @@
+package council
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+func TestConfig_RefreshModels(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer test-key" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		_, _ = w.Write([]byte(`{"data":[{"id":"gpt-6"},{"id":"gpt-5.2"}]}`))
+	}))
+	defer server.Close()
+
+	t.Setenv("TEST_OPENAI_KEY", "test-key")
+	cfg := DefaultCouncilConfig()
+	cfg.Providers["openai"].ModelsURL = server.URL
+	cfg.Providers["openai"].APIKeyEnv = "TEST_OPENAI_KEY"
+
+	models, err := cfg.RefreshModels(context.Background(), "openai")
+	if err != nil {
+		t.Fatalf("RefreshModels failed: %v", err)
+	}
+
+	want := []string{"gpt-5.2", "gpt-6"}
+	if !reflect.DeepEqual(models, want) {
+		t.Errorf("models = %v, want %v", models, want)
+	}
+	if !reflect.DeepEqual(cfg.Providers["openai"].Models, want) {
+		t.Errorf("config Models = %v, want %v", cfg.Providers["openai"].Models, want)
+	}
+}
+
+func TestConfig_RefreshModels_Skips(t *testing.T) {
+	t.Setenv("OPENAI_API_KEY", "")
+	cfg := DefaultCouncilConfig()
+
+	if _, err := cfg.RefreshModels(context.Background(), "openai"); !errors.Is(err, ErrNoCredentials) {
+		t.Errorf("expected ErrNoCredentials, got %v", err)
+	}
+	if _, err := cfg.RefreshModels(context.Background(), "anthropic"); !errors.Is(err, ErrModelsListUnsupported) {
+		t.Errorf("expected ErrModelsListUnsupported, got %v", err)
+	}
+}
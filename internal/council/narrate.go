@@ -0,0 +1,125 @@
+package council
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Explain routes req like Route and describes the decision in prose: the
+// chosen model, why it was chosen (preference, complexity routing with the
+// task breakdown, or role default), any fallback or substitution, and the
+// health of the providers involved. It does not write to the audit log.
+func (r *Router) Explain(req *RouteRequest) (string, error) {
+	result, err := r.route(req)
+	if err != nil {
+		return "", err
+	}
+	r.substitute(req, result)
+	r.estimateCost(req, result)
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "Routed %s to %s because %s", req.Role, result.Model, r.explainReason(req, result))
+	if result.Fallback && result.FallbackReason != "" {
+		fmt.Fprintf(&b, ", but %s, so %s was used as a fallback", lowerFirst(result.FallbackReason), result.Model)
+	}
+	fmt.Fprintf(&b, "; %s.", r.explainProvider(result.Provider))
+
+	if result.OriginalModel != "" {
+		fmt.Fprintf(&b, " An A/B substitution replaced %s with %s.", result.OriginalModel, result.Model)
+	}
+	if result.EstimatedCost > 0 {
+		fmt.Fprintf(&b, " Estimated cost is $%.4f for about %d tokens", result.EstimatedCost, taskTokens(req))
+		if req.MaxCostPerTask > 0 {
+			fmt.Fprintf(&b, ", within the $%.4f ceiling", req.MaxCostPerTask)
+		}
+		b.WriteString(".")
+	}
+	if skipped := r.skippedProviders(req, result.Provider); len(skipped) > 0 {
+		fmt.Fprintf(&b, " Skipped providers: %s.", strings.Join(skipped, ", "))
+	}
+	return b.String(), nil
+}
+
+// explainReason describes why the primary model was selected.
+func (r *Router) explainReason(req *RouteRequest, result *RouteResult) string {
+	if req.PreferredModel != "" && req.PreferredModel != "auto" && !result.Fallback {
+		return "it was requested explicitly"
+	}
+	if r.config.SupportsComplexityRouting(req.Role) {
+		level := result.Complexity
+		return fmt.Sprintf("the task is %s-complexity (%s), and complexity routing maps %s→%s",
+			level, describeTask(req.Task), level, r.config.GetModelForComplexity(req.Role, level))
+	}
+	reason := fmt.Sprintf("%s is the role's configured model", r.config.GetModelForRole(req.Role))
+	if rationale := r.config.GetRationale(req.Role); rationale != "" {
+		reason += fmt.Sprintf(" (%s)", lowerFirst(rationale))
+	}
+	return reason
+}
+
+// describeTask summarizes the task factors assessComplexity scores.
+func describeTask(task *TaskInfo) string {
+	if task == nil {
+		return "no task details, so medium is assumed"
+	}
+	var parts []string
+	if task.FilesAffected > 0 {
+		parts = append(parts, fmt.Sprintf("%d files", task.FilesAffected))
+	}
+	if task.LinesChanged > 0 {
+		parts = append(parts, fmt.Sprintf("%d lines", task.LinesChanged))
+	}
+	if task.IsArchitectural {
+		parts = append(parts, "architectural")
+	}
+	if task.HasTests {
+		parts = append(parts, "needs tests")
+	}
+	if len(parts) == 0 {
+		return "no size signals"
+	}
+	return strings.Join(parts, ", ")
+}
+
+// explainProvider describes a provider's health, banded by its recent
+// availability (see DegradedAvailability) when that is known.
+func (r *Router) explainProvider(provider string) string {
+	if provider == "" {
+		return "the provider is unknown"
+	}
+	if rate, ok := r.availability[provider]; ok {
+		return fmt.Sprintf("%s is %s (%.0f%% recent success)", provider, availabilityHealth(rate), rate*100)
+	}
+	return provider + " is healthy"
+}
+
+// skippedProviders lists configured providers other than chosen that the
+// request could not use, with the reason.
+func (r *Router) skippedProviders(req *RouteRequest, chosen string) []string {
+	var skipped []string
+	for provider := range r.config.Providers {
+		if provider == chosen {
+			continue
+		}
+		switch {
+		case contains(req.ExcludeProviders, provider):
+			skipped = append(skipped, provider+" (excluded)")
+		case !r.isProviderAvailable(provider, nil):
+			skipped = append(skipped, provider+" (unavailable)")
+		}
+	}
+	sort.Strings(skipped)
+	return skipped
+}
+
+// lowerFirst lowercases the first letter of s for use mid-sentence.
+func lowerFirst(s string) string {
+	if s == "" {
+		return s
+	}
+	return strings.ToLower(s[:1]) + s[1:]
+}
@@ -0,0 +1,38 @@
+package council
+
+import (
+	"errors"
+	"os"
+	"strings"
+	"sync/atomic"
+)
+
+// NoNetworkEnv is the environment variable that disables outbound network
+// calls (health checks, profile URL fetches, model refreshes), for offline
+// or air-gapped use.
+const NoNetworkEnv = "GASTOWN_NO_NETWORK"
+
+// ErrNetworkDisabled is returned instead of attempting a connection when the
+// network is disabled.
+var ErrNetworkDisabled = errors.New("network disabled (--no-network or " + NoNetworkEnv + ")")
+
+var networkDisabled atomic.Bool
+
+// SetNetworkDisabled disables (or re-enables) outbound network calls for the
+// process, e.g. from the --no-network flag.
+func SetNetworkDisabled(disabled bool) {
+	networkDisabled.Store(disabled)
+}
+
+// NetworkDisabled reports whether outbound network calls are disabled via
+// SetNetworkDisabled or NoNetworkEnv.
+func NetworkDisabled() bool {
+	if networkDisabled.Load() {
+		return true
+	}
+	switch strings.ToLower(os.Getenv(NoNetworkEnv)) {
+	case "1", "true", "yes":
+		return true
+	}
+	return false
+}
@@ -0,0 +1,154 @@
+package council
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestCheckHealth_NetworkDisabled(t *testing.T) {
+	var hits atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits.Add(1)
+	}))
+	defer server.Close()
+
+	oldEndpoint := ProviderEndpoints["anthropic"]
+	ProviderEndpoints["anthropic"] = server.URL
+	defer func() { ProviderEndpoints["anthropic"] = oldEndpoint }()
+
+	SetNetworkDisabled(true)
+	defer SetNetworkDisabled(false)
+
+	fm := NewFallbackManager(NewRouter(DefaultCouncilConfig()))
+	health, err := fm.CheckHealth(context.Background(), "anthropic")
+	if err != nil {
+		t.Fatalf("CheckHealth failed: %v", err)
+	}
+	if hits.Load() != 0 {
+		t.Errorf("CheckHealth made %d HTTP calls with the network disabled", hits.Load())
+	}
+	if !health.Unchecked {
+		t.Error("health should be marked unchecked")
+	}
+	if !health.Available {
+		t.Error("unchecked provider with a closed circuit should stay available")
+	}
+
+	if _, err := fetchProfileFromURL(server.URL + "/profile.toml"); !errors.Is(err, ErrNetworkDisabled) {
+		t.Errorf("fetchProfileFromURL: expected ErrNetworkDisabled, got %v", err)
+	}
+	if hits.Load() != 0 {
+		t.Error("profile fetch reached the server with the network disabled")
+	}
+}
+
+func TestCheckHealth_RetryAfter(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Retry-After", "60")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+
+	oldEndpoint := ProviderEndpoints["anthropic"]
+	ProviderEndpoints["anthropic"] = server.URL
+	defer func() { ProviderEndpoints["anthropic"] = oldEndpoint }()
+
+	fm := NewFallbackManager(NewRouter(DefaultCouncilConfig()))
+	if _, err := fm.CheckHealth(context.Background(), "anthropic"); err != nil {
+		t.Fatalf("CheckHealth failed: %v", err)
+	}
+
+	cb := fm.circuitBreaker["anthropic"]
+	if cb.State != "open" {
+		t.Fatalf("circuit = %s, want open after 429 with Retry-After", cb.State)
+	}
+	if wait := time.Until(cb.RetryAfter); wait < 55*time.Second || wait > 60*time.Second {
+		t.Errorf("RetryAfter is %v away, want ~60s", wait)
+	}
+
+	// Even with the reset timeout long past, the circuit stays open.
+	cb.OpenedAt = time.Now().Add(-time.Hour)
+	fm.MaybeRecover(context.Background())
+	if cb.State != "open" {
+		t.Errorf("circuit = %s before Retry-After, want open", cb.State)
+	}
+
+	route, err := fm.RouteWithFallback(&RouteRequest{Role: "mayor"})
+	if err != nil {
+		t.Fatalf("RouteWithFallback failed: %v", err)
+	}
+	if route.Provider == "anthropic" {
+		t.Errorf("routed to anthropic (%s) before Retry-After", route.Model)
+	}
+}
+
+func TestCheckHealth_LowQuotaDeprioritized(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-RateLimit-Remaining", "2")
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	oldEndpoint := ProviderEndpoints["anthropic"]
+	ProviderEndpoints["anthropic"] = server.URL
+	defer func() { ProviderEndpoints["anthropic"] = oldEndpoint }()
+
+	fm := NewFallbackManager(NewRouter(DefaultCouncilConfig()))
+	health, err := fm.CheckHealth(context.Background(), "anthropic")
+	if err != nil {
+		t.Fatalf("CheckHealth failed: %v", err)
+	}
+	if !health.Available {
+		t.Fatal("a provider with quota left should stay available")
+	}
+	if quota, ok := fm.ProviderQuota("anthropic"); !ok || !quota.HasRemaining || quota.Remaining != 2 {
+		t.Fatalf("quota = %+v, %v; want 2 remaining", quota, ok)
+	}
+
+	// mayor prefers an Anthropic model but is routed elsewhere.
+	route, err := fm.RouteWithFallback(&RouteRequest{Role: "mayor"})
+	if err != nil {
+		t.Fatalf("RouteWithFallback failed: %v", err)
+	}
+	if route.Provider == "anthropic" {
+		t.Errorf("routed to low-quota anthropic (%s)", route.Model)
+	}
+
+	// With no alternative the low-quota provider is still used.
+	route, err = fm.RouteWithFallback(&RouteRequest{Role: "mayor", ExcludeProviders: []string{"openai", "google", "xai"}})
+	if err != nil {
+		t.Fatalf("RouteWithFallback with only anthropic left failed: %v", err)
+	}
+	if route.Provider != "anthropic" {
+		t.Errorf("route = %s via %s, want anthropic as the last resort", route.Model, route.Provider)
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2026, 1, 2, 15, 4, 5, 0, time.UTC)
+
+	if got, ok := parseRetryAfter("120", now); !ok || !got.Equal(now.Add(2*time.Minute)) {
+		t.Errorf("seconds form = %v, %v", got, ok)
+	}
+	date := now.Add(time.Hour).Format(http.TimeFormat)
+	if got, ok := parseRetryAfter(date, now); !ok || !got.Equal(now.Add(time.Hour)) {
+		t.Errorf("HTTP-date form = %v, %v", got, ok)
+	}
+	for _, bad := range []string{"", "-5", "soon"} {
+		if _, ok := parseRetryAfter(bad, now); ok {
+			t.Errorf("parseRetryAfter(%q) should fail", bad)
+		}
+	}
+}
+
+func TestNetworkDisabled_Env(t *testing.T) {
+	t.Setenv(NoNetworkEnv, "1")
+	if !NetworkDisabled() {
+		t.Errorf("%s=1 should disable the network", NoNetworkEnv)
+	}
+}
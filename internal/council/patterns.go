@@ -3,11 +3,15 @@ package council
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"math/rand"
 	"sort"
 	"strings"
 	"sync"
 	"time"
+
+	"github.com/cursorworkshop/cursor-gastown/internal/cursor"
 )
 
 // Pattern represents an orchestration pattern for multi-model execution.
@@ -40,8 +44,23 @@ type ChainConfig struct {
 
 	// StopOnError halts the chain if any step fails.
 	StopOnError bool `json:"stop_on_error" toml:"stop_on_error"`
+
+	// RetryWholeChainOnFinalFailure re-runs the chain when its final step
+	// fails, up to MaxChainRetries times. By default the retry resumes from
+	// the final step's checkpoint, reusing earlier step outputs.
+	RetryWholeChainOnFinalFailure bool `json:"retry_whole_chain_on_final_failure,omitempty" toml:"retry_whole_chain_on_final_failure"`
+
+	// MaxChainRetries caps chain-level retries (default DefaultMaxChainRetries).
+	MaxChainRetries int `json:"max_chain_retries,omitempty" toml:"max_chain_retries"`
+
+	// RetryFromFirstStep makes chain retries start over from the first step
+	// instead of the final step's checkpoint.
+	RetryFromFirstStep bool `json:"retry_from_first_step,omitempty" toml:"retry_from_first_step"`
 }
 
+// DefaultMaxChainRetries is the chain retry cap when MaxChainRetries is unset.
+const DefaultMaxChainRetries = 1
+
 // ChainStep represents a single step in a chain.
 type ChainStep struct {
 	// Name identifies this step.
@@ -50,6 +69,11 @@ type ChainStep struct {
 	// Model to use for this step.
 	Model string `json:"model" toml:"model"`
 
+	// ParallelModels, when set, replaces Model: the step runs every listed
+	// model concurrently on the same input and passes their successful
+	// outputs, joined by ParallelOutputSeparator, to the next step.
+	ParallelModels []string `json:"parallel_models,omitempty" toml:"parallel_models"`
+
 	// Role to apply (e.g., "refinery" for code review).
 	Role string `json:"role" toml:"role"`
 
@@ -57,9 +81,32 @@ type ChainStep struct {
 	Prompt string `json:"prompt" toml:"prompt"`
 
 	// TransformOutput applies a transformation to the output before passing to next step.
+	// Supported: "extract_code", "first_line", "trim", "regex:<pattern>"
+	// (first capture group), and "json:<dotted.path>".
 	TransformOutput string `json:"transform_output" toml:"transform_output"`
+
+	// LoopUntil is a predicate the step output must satisfy. While it does not
+	// hold, the step is re-run with its own output as input. Supported
+	// predicates are those of evalPredicate, e.g. "no_todos" or
+	// "not_contains:<text>".
+	LoopUntil string `json:"loop_until,omitempty" toml:"loop_until"`
+
+	// MaxLoops caps the total runs of a LoopUntil step (default DefaultMaxLoops).
+	MaxLoops int `json:"max_loops,omitempty" toml:"max_loops"`
+
+	// Condition gates the step on the previous step's output (the chain
+	// input for the first step). When it does not hold, the step is skipped
+	// and the input passes through unchanged. It uses the LoopUntil
+	// grammar; see evalPredicate.
+	Condition string `json:"condition,omitempty" toml:"condition"`
 }
 
+// DefaultMaxLoops is the run cap for a LoopUntil step without MaxLoops.
+const DefaultMaxLoops = 3
+
+// ParallelOutputSeparator joins the outputs of a ParallelModels step.
+const ParallelOutputSeparator = "\n\n---\n\n"
+
 // EnsembleConfig configures an ensemble voting pattern.
 type EnsembleConfig struct {
 	// Models to run in parallel.
@@ -76,6 +123,36 @@ type EnsembleConfig struct {
 
 	// MinResponses is the minimum number of responses required before voting.
 	MinResponses int `json:"min_responses" toml:"min_responses"`
+
+	// RateLimitedAsAbsent treats rate-limited models as absent rather than
+	// failed: they are dropped from the ensemble size when computing the
+	// default MinResponses, and an explicit MinResponses is capped at the
+	// number of models that actually answered or failed. Other failures,
+	// such as content-filter refusals, still count against the quorum.
+	RateLimitedAsAbsent bool `json:"rate_limited_as_absent,omitempty" toml:"rate_limited_as_absent"`
+
+	// Pool, when set, replaces Models: each run samples SampleSize models
+	// from it without replacement, weighted by Weight.
+	Pool []WeightedModel `json:"pool,omitempty" toml:"pool"`
+
+	// SampleSize is how many pool models run per execution. Zero, or a
+	// size at least the pool's, runs the whole pool.
+	SampleSize int `json:"sample_size,omitempty" toml:"sample_size"`
+
+	// ReturnPartialOnTimeout keeps a degraded answer when the timeout hits
+	// with at least one successful response but fewer than MinResponses:
+	// the best available response is returned with Partial set. Success
+	// stays false.
+	ReturnPartialOnTimeout bool `json:"return_partial_on_timeout,omitempty" toml:"return_partial_on_timeout"`
+}
+
+// WeightedModel is a candidate in an ensemble pool.
+type WeightedModel struct {
+	Model string `json:"model" toml:"model"`
+
+	// Weight is the model's relative selection weight. Non-positive
+	// weights count as 1.
+	Weight float64 `json:"weight,omitempty" toml:"weight"`
 }
 
 // VotingStrategy determines how ensemble outputs are combined.
@@ -93,8 +170,33 @@ const (
 
 	// VoteBest selects the best response based on quality metrics.
 	VoteBest VotingStrategy = "best"
+
+	// VoteRanked runs an instant-runoff tally over each model's Rankings.
+	VoteRanked VotingStrategy = "ranked"
 )
 
+// VotingStrategies lists every supported voting strategy.
+var VotingStrategies = []VotingStrategy{VoteMajority, VoteConsensus, VoteWeighted, VoteBest, VoteRanked}
+
+// VotingStrategyDescription returns a one-line explanation of how a voting
+// strategy picks the ensemble output, or "" for an unknown strategy.
+func VotingStrategyDescription(s VotingStrategy) string {
+	switch s {
+	case VoteMajority:
+		return "Selects the most common response among models."
+	case VoteConsensus:
+		return "Requires all models to agree. Falls back to majority if not."
+	case VoteWeighted:
+		return "Weights votes by model confidence scores."
+	case VoteBest:
+		return "Selects the highest quality response based on metrics."
+	case VoteRanked:
+		return "Instant-runoff over each model's ranked answers. Falls back to majority without rankings."
+	default:
+		return ""
+	}
+}
+
 // ModelResponse represents a response from a single model.
 type ModelResponse struct {
 	Model      string        `json:"model"`
@@ -104,7 +206,25 @@ type ModelResponse struct {
 	Cost       float64       `json:"cost"`
 	Success    bool          `json:"success"`
 	Error      string        `json:"error,omitempty"`
-	Confidence float64       `json:"confidence"` // 0-1, model's confidence in response
+	ErrorKind  ErrorKind     `json:"error_kind,omitempty"` // set when Success is false
+	Confidence float64       `json:"confidence"`           // 0-1, model's confidence in response
+
+	// InputTokens and OutputTokens split Tokens into prompt and completion
+	// tokens.
+	InputTokens  int64 `json:"input_tokens,omitempty"`
+	OutputTokens int64 `json:"output_tokens,omitempty"`
+
+	// LowConfidence is set when the response is below the role's
+	// MinConfidence and no fallback model did better.
+	LowConfidence bool `json:"low_confidence,omitempty"`
+
+	// EscalatedFrom lists the models whose low-confidence responses were
+	// escalated away from before this one, in order.
+	EscalatedFrom []string `json:"escalated_from,omitempty"`
+
+	// Rankings is the model's ordered preference over candidate answers,
+	// most preferred first. Used by VoteRanked.
+	Rankings []string `json:"rankings,omitempty"`
 }
 
 // ChainResult represents the result of a chain execution.
@@ -115,6 +235,10 @@ type ChainResult struct {
 	TotalCost   float64       `json:"total_cost"`
 	Success     bool          `json:"success"`
 	Error       string        `json:"error,omitempty"`
+
+	// Attempts is how many times the chain ran (more than 1 after
+	// RetryWholeChainOnFinalFailure retries).
+	Attempts int `json:"attempts,omitempty"`
 }
 
 // StepResult represents the result of a single chain step.
@@ -126,6 +250,26 @@ type StepResult struct {
 	Duration time.Duration `json:"duration"`
 	Success  bool          `json:"success"`
 	Error    string        `json:"error,omitempty"`
+
+	// Iterations is how many times the step ran (more than 1 for LoopUntil steps).
+	Iterations int `json:"iterations,omitempty"`
+
+	// Skipped is set when the step's rendered prompt was empty or its
+	// Condition did not hold, so the model was not called and the input
+	// passed through unchanged.
+	Skipped bool `json:"skipped,omitempty"`
+
+	// SubResults holds each model's outcome for a ParallelModels step, in
+	// the configured order.
+	SubResults []SubStepResult `json:"sub_results,omitempty"`
+}
+
+// SubStepResult is one model's outcome within a ParallelModels step.
+type SubStepResult struct {
+	Model    string        `json:"model"`
+	Duration time.Duration `json:"duration"`
+	Success  bool          `json:"success"`
+	Error    string        `json:"error,omitempty"`
 }
 
 // EnsembleResult represents the result of an ensemble execution.
@@ -138,6 +282,18 @@ type EnsembleResult struct {
 	Duration     time.Duration   `json:"duration"`
 	Success      bool            `json:"success"`
 	Error        string          `json:"error,omitempty"`
+
+	// Absent is the number of rate-limited models excluded from the quorum
+	// when RateLimitedAsAbsent is set.
+	Absent int `json:"absent,omitempty"`
+
+	// Sampled lists the pool models chosen for this run, in draw order.
+	Sampled []string `json:"sampled,omitempty"`
+
+	// Partial is set when the ensemble timed out short of MinResponses and
+	// Winner/WinnerOutput hold the best response that did arrive. See
+	// EnsembleConfig.ReturnPartialOnTimeout.
+	Partial bool `json:"partial,omitempty"`
 }
 
 // ModelExecutor executes prompts against models.
@@ -147,8 +303,115 @@ type ModelExecutor interface {
 
 // ChainExecutor executes chain-of-models patterns.
 type ChainExecutor struct {
-	executor ModelExecutor
-	config   *ChainConfig
+	executor   ModelExecutor
+	config     *ChainConfig
+	processors []PromptProcessor
+
+	// OnStepStart, if set, is called before a step's model runs, with the
+	// step's name, model, and input.
+	OnStepStart func(StepResult)
+
+	// OnStepComplete, if set, is called with each step's result as soon as
+	// it is known, including skipped steps and a failure that stops the
+	// chain. Execute still returns the full ChainResult.
+	OnStepComplete func(StepResult)
+}
+
+// appendStep records a finished step and reports it to OnStepComplete.
+func (c *ChainExecutor) appendStep(result *ChainResult, step StepResult) {
+	result.Steps = append(result.Steps, step)
+	if c.OnStepComplete != nil {
+		c.OnStepComplete(step)
+	}
+}
+
+// WithProcessors sets prompt processors run, in order, on each step's prompt
+// with the step's role. A processor error fails the step.
+func (c *ChainExecutor) WithProcessors(processors ...PromptProcessor) *ChainExecutor {
+	c.processors = processors
+	return c
+}
+
+// executeStep preprocesses a step prompt and runs it.
+func (c *ChainExecutor) executeStep(ctx context.Context, step ChainStep, input string) (*ModelResponse, error) {
+	prompt, err := runProcessors(c.processors, step.Role, step.Model, buildStepPrompt(step, input))
+	if err != nil {
+		return nil, err
+	}
+	if err := cursor.CheckPrompt(prompt); err != nil {
+		return nil, err
+	}
+	return c.executor.Execute(ctx, step.Model, prompt)
+}
+
+// runStep runs a step once on input, fanning out for ParallelModels steps.
+func (c *ChainExecutor) runStep(ctx context.Context, step ChainStep, input string) (*ModelResponse, []SubStepResult, error) {
+	if len(step.ParallelModels) == 0 {
+		response, err := c.executeStep(ctx, step, input)
+		return response, nil, err
+	}
+	return c.executeParallel(ctx, step, input)
+}
+
+// executeParallel runs a ParallelModels step: every model gets the same
+// input concurrently, and the successful outputs are joined in configured
+// order. Failed models are left out unless StopOnError is set, in which
+// case any failure fails the step with an error. The step fails if no
+// model succeeds.
+func (c *ChainExecutor) executeParallel(ctx context.Context, step ChainStep, input string) (*ModelResponse, []SubStepResult, error) {
+	responses := make([]ModelResponse, len(step.ParallelModels))
+	var wg sync.WaitGroup
+	for i, model := range step.ParallelModels {
+		wg.Add(1)
+		go func(i int, m string) {
+			defer wg.Done()
+
+			sub := step
+			sub.Model = m
+			start := time.Now()
+			response, err := c.executeStep(ctx, sub, input)
+			if err != nil {
+				response = &ModelResponse{Error: err.Error(), ErrorKind: classifyError(err)}
+			}
+			response.Model = m
+			if response.Duration == 0 {
+				response.Duration = time.Since(start)
+			}
+			responses[i] = *response
+		}(i, model)
+	}
+	wg.Wait()
+
+	combined := &ModelResponse{Model: strings.Join(step.ParallelModels, ",")}
+	subResults := make([]SubStepResult, 0, len(responses))
+	var outputs, failures []string
+	for _, r := range responses {
+		sub := SubStepResult{Model: r.Model, Duration: r.Duration, Success: r.Success}
+		if !r.Success {
+			sub.Error = sanitizeError(r.Error)
+			failures = append(failures, fmt.Sprintf("%s: %s", r.Model, sub.Error))
+		} else {
+			outputs = append(outputs, r.Output)
+		}
+		subResults = append(subResults, sub)
+		combined.Cost += r.Cost
+		combined.Tokens += r.Tokens
+		combined.InputTokens += r.InputTokens
+		combined.OutputTokens += r.OutputTokens
+		if r.Duration > combined.Duration {
+			combined.Duration = r.Duration
+		}
+	}
+
+	if len(failures) > 0 && c.config.StopOnError {
+		return nil, subResults, fmt.Errorf("parallel models failed: %s", strings.Join(failures, "; "))
+	}
+	combined.Output = strings.Join(outputs, ParallelOutputSeparator)
+	combined.Success = len(outputs) > 0
+	if !combined.Success {
+		combined.Error = strings.Join(failures, "; ")
+	}
+	return combined, subResults, nil
 }
 
 // NewChainExecutor creates a new chain executor.
@@ -159,46 +422,135 @@ func NewChainExecutor(executor ModelExecutor, config *ChainConfig) *ChainExecuto
 	}
 }
 
-// Execute runs the chain of models.
+// Execute runs the chain of models. It returns an error, without running
+// anything, if a step's Condition or LoopUntil is not a known predicate,
+// and cursor.ErrEmptyPrompt if the first step would send an empty prompt.
 func (c *ChainExecutor) Execute(ctx context.Context, initialInput string) (*ChainResult, error) {
-	result := &ChainResult{
-		Steps: make([]StepResult, 0, len(c.config.Steps)),
+	if err := c.checkPredicates(); err != nil {
+		return nil, err
+	}
+	if len(c.config.Steps) > 0 {
+		if err := cursor.CheckPrompt(buildStepPrompt(c.config.Steps[0], initialInput)); err != nil {
+			return nil, err
+		}
 	}
 
 	startTime := time.Now()
-	currentInput := initialInput
+	result := c.runSteps(ctx, initialInput, 0, nil)
+	result.Attempts = 1
+
+	maxRetries := c.config.MaxChainRetries
+	if maxRetries <= 0 {
+		maxRetries = DefaultMaxChainRetries
+	}
+	for c.shouldRetryChain(result) && result.Attempts <= maxRetries && ctx.Err() == nil {
+		from, input, prior := 0, initialInput, []StepResult(nil)
+		if !c.config.RetryFromFirstStep {
+			from = len(result.Steps) - 1
+			input = result.Steps[from].Input
+			prior = result.Steps[:from]
+		}
+		retry := c.runSteps(ctx, input, from, prior)
+		retry.TotalCost += result.TotalCost
+		retry.Attempts = result.Attempts + 1
+		result = retry
+	}
 
+	result.TotalDuration = time.Since(startTime)
+	return result, nil
+}
+
+// checkPredicates returns an error naming the first step whose Condition
+// or LoopUntil is outside evalPredicate's grammar.
+func (c *ChainExecutor) checkPredicates() error {
 	for i, step := range c.config.Steps {
+		if step.Condition != "" && !validPredicate(step.Condition) {
+			return fmt.Errorf("step %d: unknown condition %q", i+1, step.Condition)
+		}
+		if step.LoopUntil != "" && !validPredicate(step.LoopUntil) {
+			return fmt.Errorf("step %d: unknown loop_until predicate %q", i+1, step.LoopUntil)
+		}
+	}
+	return nil
+}
+
+// shouldRetryChain reports whether a chain run reached its final step and
+// that step failed, with chain retries enabled.
+func (c *ChainExecutor) shouldRetryChain(result *ChainResult) bool {
+	if !c.config.RetryWholeChainOnFinalFailure || len(c.config.Steps) == 0 {
+		return false
+	}
+	if len(result.Steps) != len(c.config.Steps) {
+		return false
+	}
+	return !result.Steps[len(result.Steps)-1].Success
+}
+
+// runSteps runs the chain from step index from with the given input. prior
+// holds the already-completed results of the steps before from.
+func (c *ChainExecutor) runSteps(ctx context.Context, input string, from int, prior []StepResult) *ChainResult {
+	result := &ChainResult{
+		Steps: make([]StepResult, 0, len(c.config.Steps)),
+	}
+	result.Steps = append(result.Steps, prior...)
+
+	currentInput := input
+
+	for i := from; i < len(c.config.Steps); i++ {
+		step := c.config.Steps[i]
 		stepResult := StepResult{
 			Name:  step.Name,
 			Model: step.Model,
 			Input: currentInput,
 		}
+		if len(step.ParallelModels) > 0 {
+			stepResult.Model = strings.Join(step.ParallelModels, ",")
+		}
 
-		// Build prompt for this step
-		prompt := step.Prompt
-		if prompt == "" {
-			prompt = currentInput
-		} else {
-			// Replace {{input}} with current input
-			prompt = strings.ReplaceAll(prompt, "{{input}}", currentInput)
+		// Condition failed or nothing to send: skip the step and pass the
+		// input through
+		if !checkCondition(currentInput, step.Condition) || cursor.CheckPrompt(buildStepPrompt(step, currentInput)) != nil {
+			stepResult.Success = true
+			stepResult.Skipped = true
+			c.appendStep(result, stepResult)
+			continue
 		}
 
 		// Execute step
+		if c.OnStepStart != nil {
+			c.OnStepStart(stepResult)
+		}
 		stepStart := time.Now()
-		response, err := c.executor.Execute(ctx, step.Model, prompt)
+		response, subResults, err := c.runStep(ctx, step, currentInput)
+		stepResult.Iterations = 1
+
+		// Re-run the step on its own output until the loop predicate holds
+		if err == nil && response.Success && step.LoopUntil != "" {
+			maxLoops := step.MaxLoops
+			if maxLoops <= 0 {
+				maxLoops = DefaultMaxLoops
+			}
+			for !checkPredicate(response.Output, step.LoopUntil) && stepResult.Iterations < maxLoops {
+				result.TotalCost += response.Cost
+				response, subResults, err = c.runStep(ctx, step, response.Output)
+				stepResult.Iterations++
+				if err != nil || !response.Success {
+					break
+				}
+			}
+		}
 		stepResult.Duration = time.Since(stepStart)
+		stepResult.SubResults = subResults
 
 		if err != nil {
 			stepResult.Success = false
-			stepResult.Error = err.Error()
-			result.Steps = append(result.Steps, stepResult)
+			stepResult.Error = sanitizeError(err.Error())
+			c.appendStep(result, stepResult)
 
 			if c.config.StopOnError {
 				result.Success = false
-				result.Error = fmt.Sprintf("step %d (%s) failed: %s", i+1, step.Name, err.Error())
-				result.TotalDuration = time.Since(startTime)
-				return result, nil
+				result.Error = fmt.Sprintf("step %d (%s) failed: %s", i+1, step.Name, stepResult.Error)
+				return result
 			}
 			continue
 		}
@@ -206,21 +558,28 @@ func (c *ChainExecutor) Execute(ctx context.Context, initialInput string) (*Chai
 		stepResult.Success = response.Success
 		stepResult.Output = response.Output
 		if !response.Success {
-			stepResult.Error = response.Error
+			stepResult.Error = sanitizeError(response.Error)
+		} else if step.LoopUntil != "" && !checkPredicate(response.Output, step.LoopUntil) {
+			stepResult.Success = false
+			stepResult.Error = fmt.Sprintf("loop predicate %q not satisfied after %d iterations", step.LoopUntil, stepResult.Iterations)
 		}
 
-		result.Steps = append(result.Steps, stepResult)
-		result.TotalCost += response.Cost
-
-		// Transform output if specified
+		// Transform output if specified; a bad transform fails the step
+		// and passes the output through untransformed.
+		currentInput = response.Output
 		if step.TransformOutput != "" {
-			currentInput = applyTransform(response.Output, step.TransformOutput)
-		} else {
-			currentInput = response.Output
+			if transformed, err := applyTransform(response.Output, step.TransformOutput); err != nil {
+				stepResult.Success = false
+				stepResult.Error = err.Error()
+			} else {
+				currentInput = transformed
+			}
 		}
+
+		c.appendStep(result, stepResult)
+		result.TotalCost += response.Cost
 	}
 
-	result.TotalDuration = time.Since(startTime)
 	result.FinalOutput = currentInput
 	result.Success = true
 
@@ -232,25 +591,103 @@ func (c *ChainExecutor) Execute(ctx context.Context, initialInput string) (*Chai
 		}
 	}
 
-	return result, nil
+	return result
+}
+
+// buildStepPrompt renders a step's prompt template for the given input.
+func buildStepPrompt(step ChainStep, input string) string {
+	if step.Prompt == "" {
+		return input
+	}
+	// Replace {{input}} with current input
+	return strings.ReplaceAll(step.Prompt, "{{input}}", input)
+}
+
+// evalPredicate evaluates a predicate against text. LoopUntil and Condition
+// share its grammar:
+//
+//	"empty"                text is blank
+//	"not_empty"            text has non-whitespace content
+//	"no_todos"             text does not mention TODO
+//	"has_code"             text contains a fenced code block
+//	"contains:<text>"      text contains the given text
+//	"not_contains:<text>"  text does not contain the given text
+//
+// Keywords may use hyphens instead of underscores ("not-empty",
+// "not-contains:"). known is false for predicates outside the grammar.
+func evalPredicate(text, predicate string) (holds, known bool) {
+	keyword, arg, hasArg := strings.Cut(predicate, ":")
+	keyword = strings.ReplaceAll(keyword, "-", "_")
+	switch {
+	case hasArg && keyword == "contains":
+		return strings.Contains(text, arg), true
+	case hasArg && keyword == "not_contains":
+		return !strings.Contains(text, arg), true
+	case hasArg:
+		return true, false
+	case keyword == "empty":
+		return strings.TrimSpace(text) == "", true
+	case keyword == "not_empty":
+		return strings.TrimSpace(text) != "", true
+	case keyword == "no_todos":
+		return !strings.Contains(text, "TODO"), true
+	case keyword == "has_code":
+		return strings.Contains(text, "```"), true
+	default:
+		return true, false
+	}
 }
 
-// applyTransform applies a simple transformation to output.
-func applyTransform(output, transform string) string {
+// validPredicate reports whether predicate uses evalPredicate's grammar.
+func validPredicate(predicate string) bool {
+	_, known := evalPredicate("", predicate)
+	return known
+}
+
+// checkPredicate reports whether output satisfies a LoopUntil predicate.
+// Unknown predicates are treated as satisfied so a typo cannot loop forever;
+// Execute and ValidatePatterns reject them before any step runs.
+func checkPredicate(output, predicate string) bool {
+	holds, _ := evalPredicate(output, predicate)
+	return holds
+}
+
+// checkCondition reports whether a step's Condition holds for input. An
+// empty condition always holds. Unknown conditions also hold, so a typo
+// runs the step rather than silently skipping it; Execute and
+// ValidatePatterns reject them before any step runs.
+func checkCondition(input, condition string) bool {
+	if condition == "" {
+		return true
+	}
+	holds, _ := evalPredicate(input, condition)
+	return holds
+}
+
+// applyTransform applies a simple transformation to output. It returns an
+// error for transforms validTransform rejects.
+func applyTransform(output, transform string) (string, error) {
 	switch transform {
 	case "extract_code":
 		// Extract code blocks from markdown
-		return extractCodeBlocks(output)
+		return extractCodeBlocks(output), nil
 	case "first_line":
 		// Return first line only
 		if idx := strings.Index(output, "\n"); idx >= 0 {
-			return output[:idx]
+			return output[:idx], nil
 		}
-		return output
+		return output, nil
 	case "trim":
-		return strings.TrimSpace(output)
+		return strings.TrimSpace(output), nil
+	}
+
+	switch {
+	case strings.HasPrefix(transform, "regex:"):
+		return regexTransform(output, strings.TrimPrefix(transform, "regex:"))
+	case strings.HasPrefix(transform, "json:"):
+		return jsonTransform(output, strings.TrimPrefix(transform, "json:")), nil
 	default:
-		return output
+		return "", fmt.Errorf("unknown chain transform %q", transform)
 	}
 }
 
@@ -284,6 +721,77 @@ func extractCodeBlocks(s string) string {
 type EnsembleExecutor struct {
 	executor ModelExecutor
 	config   *EnsembleConfig
+
+	// role and metrics enable per-role weighting in weighted and best voting.
+	role    string
+	metrics *Metrics
+
+	processors []PromptProcessor
+
+	// rng drives pool sampling; rngMu guards it across concurrent runs.
+	rngMu sync.Mutex
+	rng   *rand.Rand
+}
+
+// WithRand sets the random source used to sample from EnsembleConfig.Pool,
+// making selection reproducible. By default a time-seeded source is used.
+func (e *EnsembleExecutor) WithRand(rng *rand.Rand) *EnsembleExecutor {
+	e.rng = rng
+	return e
+}
+
+// models returns the models for one run: a weighted sample of Pool when it
+// is set, otherwise Models.
+func (e *EnsembleExecutor) models() (models []string, sampled bool) {
+	pool := e.config.Pool
+	if len(pool) == 0 {
+		return e.config.Models, false
+	}
+
+	n := e.config.SampleSize
+	if n <= 0 || n > len(pool) {
+		n = len(pool)
+	}
+
+	remaining := append([]WeightedModel(nil), pool...)
+	e.rngMu.Lock()
+	defer e.rngMu.Unlock()
+	if e.rng == nil {
+		e.rng = rand.New(rand.NewSource(time.Now().UnixNano()))
+	}
+
+	models = make([]string, 0, n)
+	for len(models) < n {
+		var total float64
+		for _, wm := range remaining {
+			total += poolWeight(wm)
+		}
+		pick := e.rng.Float64() * total
+		i := 0
+		for ; i < len(remaining)-1; i++ {
+			pick -= poolWeight(remaining[i])
+			if pick < 0 {
+				break
+			}
+		}
+		models = append(models, remaining[i].Model)
+		remaining = append(remaining[:i], remaining[i+1:]...)
+	}
+	return models, true
+}
+
+func poolWeight(wm WeightedModel) float64 {
+	if wm.Weight <= 0 {
+		return 1
+	}
+	return wm.Weight
+}
+
+// WithProcessors sets prompt processors run, in order, on the prompt for
+// each model (with the executor's role). A processor error fails that model.
+func (e *EnsembleExecutor) WithProcessors(processors ...PromptProcessor) *EnsembleExecutor {
+	e.processors = processors
+	return e
 }
 
 // NewEnsembleExecutor creates a new ensemble executor.
@@ -294,12 +802,42 @@ func NewEnsembleExecutor(executor ModelExecutor, config *EnsembleConfig) *Ensemb
 	}
 }
 
+// WithRoleMetrics makes weighted and best voting favor models that have
+// historically succeeded at role's tasks. Models without history for the
+// role are weighted neutrally.
+func (e *EnsembleExecutor) WithRoleMetrics(role string, metrics *Metrics) *EnsembleExecutor {
+	e.role = role
+	e.metrics = metrics
+	return e
+}
+
+// roleWeight returns a multiplier in [0.5, 1.5] from the model's success
+// rate at the executor's role, or 1 when there is no history.
+func (e *EnsembleExecutor) roleWeight(model string) float64 {
+	if e.metrics == nil || e.role == "" {
+		return 1.0
+	}
+	rate, tasks := e.metrics.RoleModelSuccessRate(e.role, model)
+	if tasks == 0 {
+		return 1.0
+	}
+	return 0.5 + rate
+}
+
 // Execute runs models in parallel and votes on output.
 func (e *EnsembleExecutor) Execute(ctx context.Context, prompt string) (*EnsembleResult, error) {
+	if err := cursor.CheckPrompt(prompt); err != nil {
+		return nil, err
+	}
+
+	models, sampled := e.models()
 	result := &EnsembleResult{
-		Responses: make([]ModelResponse, 0, len(e.config.Models)),
+		Responses: make([]ModelResponse, 0, len(models)),
 		Votes:     make(map[string]int),
 	}
+	if sampled {
+		result.Sampled = models
+	}
 
 	startTime := time.Now()
 
@@ -313,19 +851,20 @@ func (e *EnsembleExecutor) Execute(ctx context.Context, prompt string) (*Ensembl
 
 	// Execute all models in parallel
 	var wg sync.WaitGroup
-	responseChan := make(chan ModelResponse, len(e.config.Models))
+	responseChan := make(chan ModelResponse, len(models))
 
-	for _, model := range e.config.Models {
+	for _, model := range models {
 		wg.Add(1)
 		go func(m string) {
 			defer wg.Done()
 
-			response, err := e.executor.Execute(ctx, m, prompt)
+			response, err := e.executeModel(ctx, m, prompt)
 			if err != nil {
 				responseChan <- ModelResponse{
-					Model:   m,
-					Success: false,
-					Error:   err.Error(),
+					Model:     m,
+					Success:   false,
+					Error:     sanitizeError(err.Error()),
+					ErrorKind: classifyError(err),
 				}
 				return
 			}
@@ -340,9 +879,43 @@ func (e *EnsembleExecutor) Execute(ctx context.Context, prompt string) (*Ensembl
 		close(responseChan)
 	}()
 
-	// Collect responses
-	for response := range responseChan {
-		result.Responses = append(result.Responses, response)
+	// Collect responses until every model answers or the timeout hits; a
+	// model that ignores cancellation must not hold up the ensemble.
+	answered := make(map[string]bool, len(models))
+collect:
+	for {
+		select {
+		case response, ok := <-responseChan:
+			if !ok {
+				break collect
+			}
+			result.Responses = append(result.Responses, response)
+			answered[response.Model] = true
+		case <-ctx.Done():
+			// Keep responses that arrived alongside the timeout; select
+			// picks among ready cases at random.
+			for {
+				select {
+				case response, ok := <-responseChan:
+					if !ok {
+						break collect
+					}
+					result.Responses = append(result.Responses, response)
+					answered[response.Model] = true
+				default:
+					break collect
+				}
+			}
+		}
+	}
+	for _, model := range models {
+		if !answered[model] {
+			result.Responses = append(result.Responses, ModelResponse{
+				Model:     model,
+				Error:     fmt.Sprintf("no response before the %s timeout", timeout),
+				ErrorKind: ErrorRetryable,
+			})
+		}
 	}
 
 	result.Duration = time.Since(startTime)
@@ -352,17 +925,33 @@ func (e *EnsembleExecutor) Execute(ctx context.Context, prompt string) (*Ensembl
 	for _, r := range result.Responses {
 		if r.Success {
 			successfulResponses++
+		} else if e.config.RateLimitedAsAbsent && r.ErrorKind == ErrorRateLimit {
+			result.Absent++
 		}
 	}
 
+	present := len(models) - result.Absent
 	minResponses := e.config.MinResponses
 	if minResponses == 0 {
-		minResponses = len(e.config.Models) / 2 + 1
+		minResponses = present/2 + 1
+	} else if result.Absent > 0 && minResponses > present {
+		minResponses = present
+	}
+	if minResponses < 1 {
+		minResponses = 1
 	}
 
 	if successfulResponses < minResponses {
 		result.Success = false
 		result.Error = fmt.Sprintf("insufficient responses: got %d, need %d", successfulResponses, minResponses)
+		if e.config.ReturnPartialOnTimeout && successfulResponses > 0 && errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			winner, agreement := e.voteBest(result.Responses)
+			result.Winner = winner.Model
+			result.WinnerOutput = winner.Output
+			result.Agreement = agreement
+			result.Partial = true
+			result.Error += " before timeout; returning best partial response"
+		}
 		return result, nil
 	}
 
@@ -383,6 +972,15 @@ func (e *EnsembleExecutor) Execute(ctx context.Context, prompt string) (*Ensembl
 	return result, nil
 }
 
+// executeModel preprocesses the prompt for model and runs it.
+func (e *EnsembleExecutor) executeModel(ctx context.Context, model, prompt string) (*ModelResponse, error) {
+	prompt, err := runProcessors(e.processors, e.role, model, prompt)
+	if err != nil {
+		return nil, err
+	}
+	return e.executor.Execute(ctx, model, prompt)
+}
+
 // vote determines the winning response based on voting strategy.
 func (e *EnsembleExecutor) vote(responses []ModelResponse) (ModelResponse, float64) {
 	switch e.config.VotingStrategy {
@@ -392,6 +990,8 @@ func (e *EnsembleExecutor) vote(responses []ModelResponse) (ModelResponse, float
 		return e.voteWeighted(responses)
 	case VoteBest:
 		return e.voteBest(responses)
+	case VoteRanked:
+		return e.voteRanked(responses)
 	default:
 		return e.voteMajority(responses)
 	}
@@ -477,7 +1077,7 @@ func (e *EnsembleExecutor) voteWeighted(responses []ModelResponse) (ModelRespons
 		if confidence == 0 {
 			confidence = 0.5 // Default confidence
 		}
-		weights[normalized] += confidence
+		weights[normalized] += confidence * e.roleWeight(r.Model)
 		groups[normalized] = append(groups[normalized], r)
 	}
 
@@ -515,7 +1115,7 @@ func (e *EnsembleExecutor) voteBest(responses []ModelResponse) (ModelResponse, f
 			continue
 		}
 
-		score := scoreResponse(r)
+		score := scoreResponse(r) * e.roleWeight(r.Model)
 		scored = append(scored, scoredResponse{r, score})
 	}
 
@@ -541,6 +1141,133 @@ func (e *EnsembleExecutor) voteBest(responses []ModelResponse) (ModelResponse, f
 	return scored[0].response, 1.0
 }
 
+// voteRanked runs an instant-runoff tally. Each successful response's
+// Rankings is a ballot (a response without rankings votes for its own
+// output). The candidate with the fewest votes is eliminated and its ballots
+// move to their next surviving choice until one candidate holds a majority.
+// An exact tie goes to the candidate ranked highest on the earliest ballot.
+// Agreement is the winner's final-round votes over all ballots cast.
+func (e *EnsembleExecutor) voteRanked(responses []ModelResponse) (ModelResponse, float64) {
+	type ballot struct {
+		response ModelResponse
+		choices  []string
+	}
+
+	var ballots []ballot
+	ranked := false
+	display := make(map[string]string) // normalized -> first-seen text
+	for _, r := range responses {
+		if !r.Success {
+			continue
+		}
+		prefs := r.Rankings
+		if len(prefs) > 0 {
+			ranked = true
+		} else {
+			prefs = []string{r.Output}
+		}
+		b := ballot{response: r}
+		seen := make(map[string]bool)
+		for _, p := range prefs {
+			key := normalizeOutput(p)
+			if key == "" || seen[key] {
+				continue
+			}
+			seen[key] = true
+			if _, ok := display[key]; !ok {
+				display[key] = p
+			}
+			b.choices = append(b.choices, key)
+		}
+		if len(b.choices) > 0 {
+			ballots = append(ballots, b)
+		}
+	}
+	if !ranked || len(ballots) == 0 {
+		return e.voteMajority(responses)
+	}
+
+	eliminated := make(map[string]bool)
+	top := func(b ballot) string {
+		for _, c := range b.choices {
+			if !eliminated[c] {
+				return c
+			}
+		}
+		return ""
+	}
+
+	var winner string
+	var winnerVotes, active int
+	for {
+		counts := make(map[string]int)
+		for key := range display {
+			if !eliminated[key] {
+				counts[key] = 0
+			}
+		}
+		active = 0
+		for _, b := range ballots {
+			if c := top(b); c != "" {
+				counts[c]++
+				active++
+			}
+		}
+
+		minVotes, maxVotes := -1, 0
+		for _, n := range counts {
+			if minVotes < 0 || n < minVotes {
+				minVotes = n
+			}
+			if n > maxVotes {
+				maxVotes = n
+			}
+		}
+
+		if maxVotes*2 > active || minVotes == maxVotes {
+			// Majority reached, or every survivor is tied: take the
+			// leader ranked highest on the earliest ballot.
+			for _, b := range ballots {
+				for _, c := range b.choices {
+					if !eliminated[c] && counts[c] == maxVotes {
+						winner = c
+						break
+					}
+				}
+				if winner != "" {
+					break
+				}
+			}
+			winnerVotes = maxVotes
+			break
+		}
+
+		for key, n := range counts {
+			if n == minVotes {
+				eliminated[key] = true
+			}
+		}
+	}
+
+	// Prefer a response whose own output is the winning answer; otherwise
+	// take the response that ranked it highest and carry the answer text.
+	for _, b := range ballots {
+		if normalizeOutput(b.response.Output) == winner {
+			return b.response, float64(winnerVotes) / float64(len(ballots))
+		}
+	}
+	best, bestPos := ballots[0].response, -1
+	for _, b := range ballots {
+		for i, c := range b.choices {
+			if c == winner && (bestPos < 0 || i < bestPos) {
+				best, bestPos = b.response, i
+			}
+		}
+	}
+	best.Output = display[winner]
+	return best, float64(winnerVotes) / float64(len(ballots))
+}
+
 // scoreResponse calculates a quality score for a response.
 func scoreResponse(r ModelResponse) float64 {
 	score := 0.0
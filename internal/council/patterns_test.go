@@ -0,0 +1,602 @@
+package council
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/cursorworkshop/cursor-gastown/internal/cursor"
+)
+
+// funcExecutor adapts a function to the ModelExecutor interface.
+type funcExecutor func(ctx context.Context, model, prompt string) (*ModelResponse, error)
+
+func (f funcExecutor) Execute(ctx context.Context, model, prompt string) (*ModelResponse, error) {
+	return f(ctx, model, prompt)
+}
+
+func TestChainExecutor_LoopUntil(t *testing.T) {
+	runs := 0
+	executor := funcExecutor(func(ctx context.Context, model, prompt string) (*ModelResponse, error) {
+		runs++
+		output := fmt.Sprintf("draft %d\n// TODO: finish", runs)
+		if runs == 3 {
+			output = "final draft"
+		}
+		return &ModelResponse{Model: model, Output: output, Success: true}, nil
+	})
+
+	chain := NewChainExecutor(executor, &ChainConfig{
+		Steps: []ChainStep{
+			{Name: "refine", Model: "sonnet-4.5", Prompt: "Refine: {{input}}", LoopUntil: "no_todos", MaxLoops: 5},
+		},
+	})
+
+	result, err := chain.Execute(context.Background(), "initial")
+	if err != nil {
+		t.Fatalf("Execute failed: %v", err)
+	}
+	if runs != 3 {
+		t.Errorf("executor ran %d times, want 3", runs)
+	}
+	if !result.Success {
+		t.Errorf("chain failed: %s", result.Steps[0].Error)
+	}
+	if got := result.Steps[0].Iterations; got != 3 {
+		t.Errorf("Iterations = %d, want 3", got)
+	}
+	if result.FinalOutput != "final draft" {
+		t.Errorf("FinalOutput = %q, want %q", result.FinalOutput, "final draft")
+	}
+}
+
+func TestChainExecutor_StepCallbacks(t *testing.T) {
+	executor := funcExecutor(func(ctx context.Context, model, prompt string) (*ModelResponse, error) {
+		if model == "gpt-5.2" {
+			return nil, errors.New("HTTP 500 Internal Server Error")
+		}
+		return &ModelResponse{Model: model, Output: "done by " + model, Success: true}, nil
+	})
+
+	chain := NewChainExecutor(executor, &ChainConfig{
+		Steps: []ChainStep{
+			{Name: "draft", Model: "sonnet-4.5"},
+			{Name: "review", Model: "gpt-5.2"},
+			{Name: "polish", Model: "opus-4.5"},
+		},
+		StopOnError: true,
+	})
+	var events []string
+	chain.OnStepStart = func(s StepResult) { events = append(events, "start "+s.Name) }
+	chain.OnStepComplete = func(s StepResult) {
+		events = append(events, fmt.Sprintf("done %s success=%v", s.Name, s.Success))
+	}
+
+	result, err := chain.Execute(context.Background(), "spec")
+	if err != nil {
+		t.Fatalf("Execute failed: %v", err)
+	}
+
+	want := []string{"start draft", "done draft success=true", "start review", "done review success=false"}
+	if strings.Join(events, "; ") != strings.Join(want, "; ") {
+		t.Errorf("events = %q, want %q", events, want)
+	}
+	if result.Success || len(result.Steps) != 2 {
+		t.Errorf("result = %d steps, success %v; want the aborted chain's 2 steps", len(result.Steps), result.Success)
+	}
+}
+
+func TestChainExecutor_Condition(t *testing.T) {
+	var ran []string
+	review := "no issues found"
+	executor := funcExecutor(func(ctx context.Context, model, prompt string) (*ModelResponse, error) {
+		ran = append(ran, model)
+		if model == "sonnet-4.5" {
+			return &ModelResponse{Model: model, Output: review, Success: true}, nil
+		}
+		return &ModelResponse{Model: model, Output: "fixed", Success: true}, nil
+	})
+
+	chain := NewChainExecutor(executor, &ChainConfig{
+		Steps: []ChainStep{
+			{Name: "review", Model: "sonnet-4.5"},
+			{Name: "fix", Model: "gpt-5.2", Condition: "contains:ERROR"},
+			{Name: "summarize", Model: "opus-4.5", Condition: "not_empty"},
+		},
+	})
+	result, err := chain.Execute(context.Background(), "diff")
+	if err != nil {
+		t.Fatalf("Execute failed: %v", err)
+	}
+	if strings.Join(ran, ",") != "sonnet-4.5,opus-4.5" {
+		t.Errorf("models run = %v, want the fix step skipped", ran)
+	}
+	fix := result.Steps[1]
+	if !fix.Skipped || !fix.Success || fix.Input != "no issues found" {
+		t.Errorf("fix step = %+v, want skipped with the review output passed through", fix)
+	}
+	if result.Steps[2].Input != "no issues found" || !result.Success {
+		t.Errorf("summarize input = %q, success %v", result.Steps[2].Input, result.Success)
+	}
+
+	// When the condition holds, the step runs on the review output.
+	ran, review = nil, "ERROR: nil deref in handler"
+	result, err = chain.Execute(context.Background(), "diff")
+	if err != nil {
+		t.Fatalf("Execute failed: %v", err)
+	}
+	if strings.Join(ran, ",") != "sonnet-4.5,gpt-5.2,opus-4.5" || result.Steps[1].Skipped {
+		t.Errorf("models run = %v, want every step", ran)
+	}
+	if result.Steps[1].Input != review || result.FinalOutput != "fixed" {
+		t.Errorf("fix input = %q, final = %q", result.Steps[1].Input, result.FinalOutput)
+	}
+	if issues := ValidatePatterns(&PatternsFile{Chains: map[string]*ChainConfig{
+		"c": {Steps: []ChainStep{{Model: "sonnet-4.5", Condition: "has-errors"}}},
+	}}); len(issues) != 1 || !strings.Contains(issues[0], "unknown condition") {
+		t.Errorf("ValidatePatterns issues = %v, want an unknown condition", issues)
+	}
+
+	// Conditions and LoopUntil share one grammar, and both are validated
+	// along with TransformOutput.
+	if issues := ValidatePatterns(&PatternsFile{Chains: map[string]*ChainConfig{
+		"c": {Steps: []ChainStep{
+			{Model: "sonnet-4.5", Condition: "no_todos", LoopUntil: "not_contains:FIXME", TransformOutput: "regex:v(\\d+)"},
+			{Model: "sonnet-4.5", LoopUntil: "nonempty", TransformOutput: "regex:("},
+			{Model: "sonnet-4.5", TransformOutput: "uppercase"},
+		}},
+	}}); len(issues) != 3 {
+		t.Errorf("ValidatePatterns issues = %v, want loop_until and two transform_output issues", issues)
+	}
+
+	// Hyphenated keywords are aliases.
+	for predicate, want := range map[string]bool{"not-empty": true, "no-todos": true, "has-code": false, "not-contains:x": true, "not-contains:ok": false} {
+		if holds, known := evalPredicate("ok", predicate); !known || holds != want {
+			t.Errorf("evalPredicate(%q) = %v, %v; want %v, true", predicate, holds, known, want)
+		}
+	}
+
+	// A bad transform fails its step instead of being logged and ignored.
+	ran = nil
+	result, err = NewChainExecutor(executor, &ChainConfig{Steps: []ChainStep{
+		{Name: "review", Model: "sonnet-4.5", TransformOutput: "uppercase"},
+	}}).Execute(context.Background(), "diff")
+	if err != nil {
+		t.Fatalf("Execute failed: %v", err)
+	}
+	if result.Success || !strings.Contains(result.Steps[0].Error, "uppercase") || result.FinalOutput != review {
+		t.Errorf("result = %+v, want the step failed by its transform and the output passed through", result)
+	}
+
+	// A programmatic chain with an unknown predicate fails before running.
+	ran = nil
+	bad := NewChainExecutor(executor, &ChainConfig{Steps: []ChainStep{
+		{Name: "review", Model: "sonnet-4.5"},
+		{Name: "fix", Model: "gpt-5.2", LoopUntil: "no_todo"},
+	}})
+	if _, err := bad.Execute(context.Background(), "diff"); err == nil || !strings.Contains(err.Error(), "no_todo") {
+		t.Errorf("Execute err = %v, want the unknown loop_until predicate", err)
+	}
+	if len(ran) != 0 {
+		t.Errorf("models run = %v, want none", ran)
+	}
+}
+
+func TestChainExecutor_ParallelModels(t *testing.T) {
+	var mu sync.Mutex
+	var summaryInput string
+	executor := funcExecutor(func(ctx context.Context, model, prompt string) (*ModelResponse, error) {
+		switch model {
+		case "gemini-3-pro":
+			return nil, errors.New("HTTP 500 Internal Server Error")
+		case "opus-4.5":
+			mu.Lock()
+			summaryInput = prompt
+			mu.Unlock()
+			return &ModelResponse{Model: model, Output: "summary", Success: true}, nil
+		}
+		return &ModelResponse{Model: model, Output: "notes from " + model, Success: true, Cost: 0.01}, nil
+	})
+
+	config := &ChainConfig{
+		Steps: []ChainStep{
+			{Name: "review", ParallelModels: []string{"sonnet-4.5", "gemini-3-pro", "gpt-5.2"}},
+			{Name: "summarize", Model: "opus-4.5"},
+		},
+	}
+	result, err := NewChainExecutor(executor, config).Execute(context.Background(), "diff")
+	if err != nil {
+		t.Fatalf("Execute failed: %v", err)
+	}
+	if !result.Success {
+		t.Fatalf("chain failed: %+v", result.Steps)
+	}
+
+	want := "notes from sonnet-4.5" + ParallelOutputSeparator + "notes from gpt-5.2"
+	if summaryInput != want {
+		t.Errorf("summary input = %q, want the successful reviews in order %q", summaryInput, want)
+	}
+	review := result.Steps[0]
+	if len(review.SubResults) != 3 {
+		t.Fatalf("SubResults = %+v, want 3", review.SubResults)
+	}
+	for i, model := range []string{"sonnet-4.5", "gemini-3-pro", "gpt-5.2"} {
+		sub := review.SubResults[i]
+		if sub.Model != model || sub.Success != (model != "gemini-3-pro") || sub.Duration <= 0 {
+			t.Errorf("SubResults[%d] = %+v, want %s", i, sub, model)
+		}
+	}
+	if result.TotalCost != 0.02 {
+		t.Errorf("TotalCost = %v, want both successful reviews charged", result.TotalCost)
+	}
+
+	// With StopOnError, one failed reviewer aborts the chain.
+	config.StopOnError = true
+	summaryInput = ""
+	result, err = NewChainExecutor(executor, config).Execute(context.Background(), "diff")
+	if err != nil {
+		t.Fatalf("Execute failed: %v", err)
+	}
+	if result.Success || len(result.Steps) != 1 || summaryInput != "" {
+		t.Errorf("StopOnError chain = %d steps, success %v; want it stopped at the review", len(result.Steps), result.Success)
+	}
+	if !strings.Contains(result.Error, "gemini-3-pro") {
+		t.Errorf("error = %q, want the failed model named", result.Error)
+	}
+}
+
+func TestChainExecutor_LoopUntilExhausted(t *testing.T) {
+	runs := 0
+	executor := funcExecutor(func(ctx context.Context, model, prompt string) (*ModelResponse, error) {
+		runs++
+		return &ModelResponse{Model: model, Output: "TODO", Success: true}, nil
+	})
+
+	chain := NewChainExecutor(executor, &ChainConfig{
+		Steps: []ChainStep{{Name: "refine", Model: "sonnet-4.5", LoopUntil: "no_todos"}},
+	})
+
+	result, err := chain.Execute(context.Background(), "initial")
+	if err != nil {
+		t.Fatalf("Execute failed: %v", err)
+	}
+	if runs != DefaultMaxLoops {
+		t.Errorf("executor ran %d times, want %d", runs, DefaultMaxLoops)
+	}
+	if result.Success {
+		t.Error("chain should fail when the loop predicate is never satisfied")
+	}
+}
+
+func TestEnsembleExecutor_RoleMetricsBreakTie(t *testing.T) {
+	metrics := &Metrics{}
+	for i := 0; i < 5; i++ {
+		metrics.addTask(TaskMetric{Role: "refinery", Model: "gpt-5.2", Provider: "openai", Success: true})
+		metrics.addTask(TaskMetric{Role: "refinery", Model: "sonnet-4.5", Provider: "anthropic", Success: i == 0})
+		// sonnet-4.5 excels elsewhere; only refinery history should count.
+		metrics.addTask(TaskMetric{Role: "polecat", Model: "sonnet-4.5", Provider: "anthropic", Success: true})
+	}
+
+	executor := funcExecutor(func(ctx context.Context, model, prompt string) (*ModelResponse, error) {
+		return &ModelResponse{Output: "review from " + model, Success: true, Confidence: 0.8}, nil
+	})
+
+	for _, strategy := range []VotingStrategy{VoteWeighted, VoteBest} {
+		config := &EnsembleConfig{Models: []string{"sonnet-4.5", "gpt-5.2"}, VotingStrategy: strategy, MinResponses: 2}
+		result, err := NewEnsembleExecutor(executor, config).WithRoleMetrics("refinery", metrics).Execute(context.Background(), "review")
+		if err != nil {
+			t.Fatalf("%s: Execute failed: %v", strategy, err)
+		}
+		if result.Winner != "gpt-5.2" {
+			t.Errorf("%s: winner = %s, want gpt-5.2 (better refinery history)", strategy, result.Winner)
+		}
+	}
+}
+
+func TestEnsembleExecutor_VoteRanked(t *testing.T) {
+	e := NewEnsembleExecutor(nil, &EnsembleConfig{VotingStrategy: VoteRanked})
+	ballot := func(model string, rankings ...string) ModelResponse {
+		return ModelResponse{Model: model, Output: rankings[0], Success: true, Rankings: rankings}
+	}
+
+	// First round: A 2, B 1, C 2. B is eliminated and its ballot moves to
+	// A, which wins 3 of 5.
+	winner, agreement := e.vote([]ModelResponse{
+		ballot("m1", "A", "B"),
+		ballot("m2", "A", "C"),
+		ballot("m3", "B", "A"),
+		ballot("m4", "C", "B"),
+		ballot("m5", "C", "B"),
+	})
+	if normalizeOutput(winner.Output) != "a" {
+		t.Errorf("winner = %q, want A after B's ballot transfers", winner.Output)
+	}
+	if agreement != 0.6 {
+		t.Errorf("agreement = %.2f, want 0.60", agreement)
+	}
+
+	// An exact tie goes to the earliest ballot's top choice.
+	winner, agreement = e.vote([]ModelResponse{
+		ballot("m1", "A", "B"),
+		ballot("m2", "B", "A"),
+	})
+	if winner.Model != "m1" || agreement != 0.5 {
+		t.Errorf("tie: winner = %s (%.2f), want m1 at 0.50", winner.Model, agreement)
+	}
+
+	// Without rankings the tally falls back to majority.
+	winner, _ = e.vote([]ModelResponse{
+		{Model: "m1", Output: "yes", Success: true},
+		{Model: "m2", Output: "no", Success: true},
+		{Model: "m3", Output: "no", Success: true},
+	})
+	if winner.Output != "no" {
+		t.Errorf("unranked winner = %q, want majority answer no", winner.Output)
+	}
+}
+
+func TestEnsembleExecutor_ReturnPartialOnTimeout(t *testing.T) {
+	hang := make(chan struct{})
+	defer close(hang)
+	executor := funcExecutor(func(ctx context.Context, model, prompt string) (*ModelResponse, error) {
+		if model == "opus-4.5" {
+			<-hang // never returns within the test, even when cancelled
+		}
+		return &ModelResponse{Output: "answer from " + model, Success: true, Confidence: 0.7}, nil
+	})
+	config := &EnsembleConfig{
+		Models:         []string{"sonnet-4.5", "opus-4.5"},
+		VotingStrategy: VoteMajority,
+		MinResponses:   2,
+		Timeout:        50 * time.Millisecond,
+	}
+
+	// Without the option the ensemble fails with nothing to use.
+	result, err := NewEnsembleExecutor(executor, config).Execute(context.Background(), "question")
+	if err != nil {
+		t.Fatalf("Execute failed: %v", err)
+	}
+	if result.Success || result.Partial || result.WinnerOutput != "" {
+		t.Errorf("result = %+v, want a plain failure", result)
+	}
+
+	config.ReturnPartialOnTimeout = true
+	result, err = NewEnsembleExecutor(executor, config).Execute(context.Background(), "question")
+	if err != nil {
+		t.Fatalf("Execute failed: %v", err)
+	}
+	if result.Success || !result.Partial {
+		t.Fatalf("result = %+v, want an unsuccessful partial result", result)
+	}
+	if result.Winner != "sonnet-4.5" || result.WinnerOutput != "answer from sonnet-4.5" {
+		t.Errorf("winner = %s %q, want the response that arrived", result.Winner, result.WinnerOutput)
+	}
+	if len(result.Responses) != 2 {
+		t.Errorf("responses = %+v, want the hung model recorded as timed out", result.Responses)
+	}
+}
+
+func TestEnsembleExecutor_RateLimitedAsAbsent(t *testing.T) {
+	executor := funcExecutor(func(ctx context.Context, model, prompt string) (*ModelResponse, error) {
+		switch model {
+		case "gpt-5.2", "gemini-3-pro":
+			return nil, fmt.Errorf("%s: HTTP 429 Too Many Requests", model)
+		case "grok-4":
+			return nil, fmt.Errorf("%s: response blocked by content filter", model)
+		}
+		return &ModelResponse{Output: "LGTM", Success: true}, nil
+	})
+	models := []string{"sonnet-4.5", "opus-4.5", "gpt-5.2", "gemini-3-pro"}
+
+	// Without the option, two rate limits sink a four-model quorum of three.
+	result, err := NewEnsembleExecutor(executor, &EnsembleConfig{Models: models}).Execute(context.Background(), "review")
+	if err != nil {
+		t.Fatalf("Execute failed: %v", err)
+	}
+	if result.Success {
+		t.Fatalf("expected insufficient responses without RateLimitedAsAbsent, got %+v", result)
+	}
+	for _, r := range result.Responses {
+		if !r.Success && r.ErrorKind != ErrorRateLimit {
+			t.Errorf("%s ErrorKind = %s, want rate_limit", r.Model, r.ErrorKind)
+		}
+	}
+
+	// Rate-limited models leave the quorum: two of two present succeed.
+	config := &EnsembleConfig{Models: models, RateLimitedAsAbsent: true}
+	result, err = NewEnsembleExecutor(executor, config).Execute(context.Background(), "review")
+	if err != nil {
+		t.Fatalf("Execute failed: %v", err)
+	}
+	if !result.Success || result.Absent != 2 || result.WinnerOutput != "LGTM" {
+		t.Errorf("result = %+v, want success with 2 absent", result)
+	}
+
+	// A content-filter refusal is still a real negative.
+	config.Models = []string{"sonnet-4.5", "gpt-5.2", "grok-4"}
+	config.MinResponses = 2
+	result, err = NewEnsembleExecutor(executor, config).Execute(context.Background(), "review")
+	if err != nil {
+		t.Fatalf("Execute failed: %v", err)
+	}
+	if result.Success || result.Absent != 1 {
+		t.Errorf("result = %+v, want failure with 1 absent (content filter counts)", result)
+	}
+}
+
+func TestChainExecutor_SkipsEmptyPromptStep(t *testing.T) {
+	var called []string
+	executor := funcExecutor(func(ctx context.Context, model, prompt string) (*ModelResponse, error) {
+		called = append(called, model)
+		if model == "gemini-3-flash" {
+			return &ModelResponse{Model: model, Output: "   ", Success: true}, nil
+		}
+		return &ModelResponse{Model: model, Output: "reviewed", Success: true}, nil
+	})
+
+	chain := NewChainExecutor(executor, &ChainConfig{
+		Steps: []ChainStep{
+			{Name: "summarize", Model: "gemini-3-flash", Prompt: "Summarize: {{input}}"},
+			{Name: "review", Model: "sonnet-4.5", Prompt: "{{input}}"},
+		},
+	})
+
+	result, err := chain.Execute(context.Background(), "diff")
+	if err != nil {
+		t.Fatalf("Execute failed: %v", err)
+	}
+	if len(called) != 1 || called[0] != "gemini-3-flash" {
+		t.Errorf("models called = %v, want only gemini-3-flash", called)
+	}
+	if !result.Success || len(result.Steps) != 2 || !result.Steps[1].Skipped {
+		t.Errorf("result = %+v, want success with the review step skipped", result)
+	}
+
+	if _, err := chain.Execute(context.Background(), ""); err != nil {
+		t.Errorf("first step template is non-empty, so empty input should run: %v", err)
+	}
+	bare := NewChainExecutor(executor, &ChainConfig{Steps: []ChainStep{{Name: "raw", Model: "sonnet-4.5"}}})
+	if _, err := bare.Execute(context.Background(), " \n"); !errors.Is(err, cursor.ErrEmptyPrompt) {
+		t.Errorf("blank input with no template: err = %v, want ErrEmptyPrompt", err)
+	}
+	ensemble := NewEnsembleExecutor(executor, &EnsembleConfig{Models: []string{"sonnet-4.5"}})
+	if _, err := ensemble.Execute(context.Background(), ""); !errors.Is(err, cursor.ErrEmptyPrompt) {
+		t.Errorf("ensemble with empty prompt: err = %v, want ErrEmptyPrompt", err)
+	}
+}
+
+func TestVotingStrategyDescription(t *testing.T) {
+	for _, s := range VotingStrategies {
+		if VotingStrategyDescription(s) == "" {
+			t.Errorf("VotingStrategyDescription(%q) is empty", s)
+		}
+	}
+	if got := VotingStrategyDescription("plurality"); got != "" {
+		t.Errorf("unknown strategy description = %q, want empty", got)
+	}
+}
+
+func TestChainExecutor_RetryWholeChainOnFinalFailure(t *testing.T) {
+	for _, fromFirst := range []bool{false, true} {
+		t.Run(fmt.Sprintf("fromFirst=%v", fromFirst), func(t *testing.T) {
+			calls := map[string]int{}
+			executor := funcExecutor(func(ctx context.Context, model, prompt string) (*ModelResponse, error) {
+				calls[model]++
+				if model == "gpt-5.2" && calls[model] == 1 {
+					return &ModelResponse{Model: model, Error: "503 service unavailable"}, nil
+				}
+				return &ModelResponse{Model: model, Output: model + " done", Success: true, Cost: 1}, nil
+			})
+
+			chain := NewChainExecutor(executor, &ChainConfig{
+				Steps: []ChainStep{
+					{Name: "analyze", Model: "sonnet-4.5"},
+					{Name: "recommend", Model: "gpt-5.2"},
+				},
+				RetryWholeChainOnFinalFailure: true,
+				MaxChainRetries:               2,
+				RetryFromFirstStep:            fromFirst,
+			})
+
+			result, err := chain.Execute(context.Background(), "input")
+			if err != nil {
+				t.Fatalf("Execute failed: %v", err)
+			}
+			if !result.Success || result.Attempts != 2 {
+				t.Fatalf("result = success %v after %d attempts, want success after 2 (%+v)", result.Success, result.Attempts, result)
+			}
+			if len(result.Steps) != 2 || result.FinalOutput != "gpt-5.2 done" {
+				t.Errorf("steps = %+v, final = %q", result.Steps, result.FinalOutput)
+			}
+
+			wantFirst := 1
+			if fromFirst {
+				wantFirst = 2
+			}
+			if calls["sonnet-4.5"] != wantFirst || calls["gpt-5.2"] != 2 {
+				t.Errorf("calls = %v, want sonnet-4.5 x%d and gpt-5.2 x2", calls, wantFirst)
+			}
+			if result.TotalCost != float64(wantFirst+1) {
+				t.Errorf("TotalCost = %v, want cost of every successful run (%d)", result.TotalCost, wantFirst+1)
+			}
+		})
+	}
+}
+
+func TestChainExecutor_ChainRetriesExhausted(t *testing.T) {
+	runs := 0
+	executor := funcExecutor(func(ctx context.Context, model, prompt string) (*ModelResponse, error) {
+		runs++
+		return &ModelResponse{Model: model, Error: "always fails"}, nil
+	})
+	chain := NewChainExecutor(executor, &ChainConfig{
+		Steps:                         []ChainStep{{Name: "only", Model: "sonnet-4.5"}},
+		RetryWholeChainOnFinalFailure: true,
+	})
+
+	result, err := chain.Execute(context.Background(), "input")
+	if err != nil {
+		t.Fatalf("Execute failed: %v", err)
+	}
+	if result.Success || result.Attempts != 1+DefaultMaxChainRetries || runs != 1+DefaultMaxChainRetries {
+		t.Errorf("success=%v attempts=%d runs=%d, want failure after %d attempts", result.Success, result.Attempts, runs, 1+DefaultMaxChainRetries)
+	}
+}
+
+func TestEnsembleExecutor_PoolSampling(t *testing.T) {
+	var mu sync.Mutex
+	ran := make(map[string]int)
+	executor := funcExecutor(func(ctx context.Context, model, prompt string) (*ModelResponse, error) {
+		mu.Lock()
+		ran[model]++
+		mu.Unlock()
+		return &ModelResponse{Output: "LGTM", Success: true}, nil
+	})
+	config := &EnsembleConfig{
+		Pool: []WeightedModel{
+			{Model: "sonnet-4.5", Weight: 8},
+			{Model: "gpt-5.2", Weight: 1},
+			{Model: "gemini-3-pro", Weight: 1},
+			{Model: "grok-4", Weight: 1},
+		},
+		SampleSize: 2,
+	}
+	ensemble := NewEnsembleExecutor(executor, config).WithRand(rand.New(rand.NewSource(1)))
+
+	const runs = 500
+	for i := 0; i < runs; i++ {
+		result, err := ensemble.Execute(context.Background(), "review")
+		if err != nil {
+			t.Fatalf("Execute failed: %v", err)
+		}
+		if len(result.Sampled) != 2 || len(result.Responses) != 2 {
+			t.Fatalf("run %d sampled %v with %d responses, want 2", i, result.Sampled, len(result.Responses))
+		}
+		if result.Sampled[0] == result.Sampled[1] {
+			t.Fatalf("run %d sampled %s twice", i, result.Sampled[0])
+		}
+	}
+
+	// The heavy model should be in nearly every pair; each light model in
+	// roughly a third.
+	if ran["sonnet-4.5"] < runs*8/10 {
+		t.Errorf("sonnet-4.5 ran %d/%d times, want weighting to favor it", ran["sonnet-4.5"], runs)
+	}
+	for _, m := range []string{"gpt-5.2", "gemini-3-pro", "grok-4"} {
+		if ran[m] == 0 || ran[m] >= ran["sonnet-4.5"] {
+			t.Errorf("%s ran %d times, want some but fewer than sonnet-4.5 (%d)", m, ran[m], ran["sonnet-4.5"])
+		}
+	}
+
+	// The same seed reproduces the same selection.
+	a, _ := NewEnsembleExecutor(executor, config).WithRand(rand.New(rand.NewSource(7))).Execute(context.Background(), "review")
+	b, _ := NewEnsembleExecutor(executor, config).WithRand(rand.New(rand.NewSource(7))).Execute(context.Background(), "review")
+	if strings.Join(a.Sampled, ",") != strings.Join(b.Sampled, ",") {
+		t.Errorf("seeded samples differ: %v vs %v", a.Sampled, b.Sampled)
+	}
+}
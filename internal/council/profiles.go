@@ -2,14 +2,19 @@
 package council
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
 	"os"
 	"path/filepath"
 	"strings"
 	"time"
+
+	"github.com/BurntSushi/toml"
+	"github.com/cursorworkshop/cursor-gastown/internal/cursor"
 )
 
 // Profile represents a shareable council configuration profile.
@@ -334,9 +339,10 @@ func ExportProfile(cfg *Config, name, description, author string) *Profile {
 	}
 }
 
-// ExportProfileToFile exports a profile to a JSON file.
+// ExportProfileToFile exports a profile to a file, as TOML for a .toml path
+// and JSON otherwise.
 func ExportProfileToFile(profile *Profile, path string) error {
-	data, err := json.MarshalIndent(profile, "", "  ")
+	data, err := marshalProfile(profile, filepath.Ext(path))
 	if err != nil {
 		return fmt.Errorf("marshaling profile: %w", err)
 	}
@@ -352,7 +358,20 @@ func ExportProfileToFile(profile *Profile, path string) error {
 	return nil
 }
 
-// ImportProfileFromFile imports a profile from a JSON file.
+func marshalProfile(profile *Profile, ext string) ([]byte, error) {
+	if ext != ".toml" {
+		return json.MarshalIndent(profile, "", "  ")
+	}
+	var buf bytes.Buffer
+	if err := toml.NewEncoder(&buf).Encode(profile); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// ImportProfileFromFile imports a profile from a file or http(s) URL. A
+// .toml path is parsed as TOML and a .json path as JSON, like LoadConfig;
+// anything else is JSON if it starts with "{" and TOML otherwise.
 func ImportProfileFromFile(path string) (*Profile, error) {
 	data, err := readProfileBytes(path)
 	if err != nil {
@@ -360,13 +379,35 @@ func ImportProfileFromFile(path string) (*Profile, error) {
 	}
 
 	var profile Profile
-	if err := json.Unmarshal(data, &profile); err != nil {
+	if profileIsTOML(path, data) {
+		if _, err := toml.Decode(string(data), &profile); err != nil {
+			return nil, fmt.Errorf("parsing TOML profile: %w", err)
+		}
+	} else if err := json.Unmarshal(data, &profile); err != nil {
 		return nil, fmt.Errorf("parsing profile: %w", err)
 	}
 
 	return &profile, nil
 }
 
+// profileIsTOML decides a profile's format from its extension, sniffing
+// the content when the extension doesn't say.
+func profileIsTOML(path string, data []byte) bool {
+	if isHTTPURL(path) {
+		if u, err := url.Parse(path); err == nil {
+			path = u.Path
+		}
+	}
+	switch filepath.Ext(path) {
+	case ".toml":
+		return true
+	case ".json":
+		return false
+	}
+	trimmed := bytes.TrimSpace(data)
+	return len(trimmed) > 0 && trimmed[0] != '{'
+}
+
 func readProfileBytes(path string) ([]byte, error) {
 	if isHTTPURL(path) {
 		return fetchProfileFromURL(path)
@@ -385,6 +426,10 @@ func isHTTPURL(path string) bool {
 }
 
 func fetchProfileFromURL(url string) ([]byte, error) {
+	if NetworkDisabled() {
+		return nil, fmt.Errorf("fetching profile %s: %w", url, ErrNetworkDisabled)
+	}
+
 	client := &http.Client{Timeout: 15 * time.Second}
 	resp, err := client.Get(url)
 	if err != nil {
@@ -415,8 +460,69 @@ func ApplyProfile(profile *Profile, townRoot string) error {
 	}
 
 	// Save the configuration
-	configPath := filepath.Join(townRoot, ".beads", ConfigFileName)
-	return SaveConfig(configPath, profile.Config)
+	return SaveConfig(ConfigPath(townRoot), profile.Config)
+}
+
+// ApplyProfileRoles merges the named roles from a profile into the town's
+// existing config and saves it, keeping every other role along with the
+// current defaults and providers. With no roles it is ApplyProfile.
+func ApplyProfileRoles(profile *Profile, townRoot string, roles []string) error {
+	if len(roles) == 0 {
+		return ApplyProfile(profile, townRoot)
+	}
+
+	cfg, err := LoadConfig(ResolveConfigPath(townRoot))
+	if err != nil {
+		return fmt.Errorf("loading config: %w", err)
+	}
+	if err := cfg.MergeProfileRoles(profile, roles, false); err != nil {
+		return err
+	}
+	return SaveConfig(ConfigPath(townRoot), cfg)
+}
+
+// MergeProfileRoles copies the named roles from a profile into the config,
+// replacing those roles wholesale. With includeDefaults, the profile's
+// defaults (if set) replace the config's and its provider entries replace
+// same-named ones; otherwise both are left alone. Providers needed by the
+// merged models are added if missing.
+func (c *Config) MergeProfileRoles(profile *Profile, roles []string, includeDefaults bool) error {
+	if profile.Config == nil {
+		return fmt.Errorf("profile has no configuration")
+	}
+	for _, role := range roles {
+		if profile.Config.Roles[role] == nil {
+			return fmt.Errorf("profile %q has no role %q", profile.Name, role)
+		}
+	}
+
+	// Round-trip through Clone so the config doesn't share role pointers
+	// with the profile.
+	incoming, err := profile.Config.Clone()
+	if err != nil {
+		return err
+	}
+	if c.Roles == nil {
+		c.Roles = make(map[string]*RoleConfig)
+	}
+	for _, role := range roles {
+		c.Roles[role] = incoming.Roles[role]
+	}
+
+	if includeDefaults {
+		if incoming.Defaults != nil {
+			c.Defaults = incoming.Defaults
+		}
+		for name, pc := range incoming.Providers {
+			if c.Providers == nil {
+				c.Providers = make(map[string]*ProviderConfig)
+			}
+			c.Providers[name] = pc
+		}
+	}
+
+	c.EnsureProvidersForModels()
+	return nil
 }
 
 // GetProfile returns a predefined profile by name.
@@ -480,3 +586,76 @@ func ValidateProfile(profile *Profile) []string {
 
 	return issues
 }
+
+// ProfileInspection is the result of checking a profile without applying it.
+type ProfileInspection struct {
+	// Issues are problems that make the profile unsafe to apply: everything
+	// ValidateProfile reports plus models cursor-agent does not support.
+	Issues []string `json:"issues,omitempty"`
+
+	// Warnings are suspicious but workable settings, such as models whose
+	// provider the profile disables.
+	Warnings []string `json:"warnings,omitempty"`
+
+	// Roles is the role-model matrix the profile would set, sorted by role.
+	Roles []ProfileRoleModel `json:"roles,omitempty"`
+}
+
+// ProfileRoleModel is one row of a profile's role-model matrix.
+type ProfileRoleModel struct {
+	Role     string   `json:"role"`
+	Model    string   `json:"model"`
+	Fallback []string `json:"fallback,omitempty"`
+}
+
+// InspectProfile validates a profile and checks every model it references
+// against cursor.SupportedModels, without touching any config on disk.
+func InspectProfile(profile *Profile) *ProfileInspection {
+	inspection := &ProfileInspection{Issues: ValidateProfile(profile)}
+	cfg := profile.Config
+	if cfg == nil {
+		return inspection
+	}
+
+	check := func(where, model string) {
+		if model == "" {
+			return
+		}
+		if !cursor.IsValidModel(model) {
+			inspection.Issues = append(inspection.Issues, fmt.Sprintf("%s model %q is not a supported model", where, model))
+			return
+		}
+		provider := ModelProvider(model)
+		if pc := cfg.Providers[provider]; pc != nil && !pc.Enabled {
+			inspection.Warnings = append(inspection.Warnings, fmt.Sprintf("%s model %q uses disabled provider %q", where, model, provider))
+		}
+	}
+
+	for _, role := range sortedKeys(cfg.Roles) {
+		rc := cfg.Roles[role]
+		if rc == nil {
+			continue
+		}
+		inspection.Roles = append(inspection.Roles, ProfileRoleModel{Role: role, Model: rc.Model, Fallback: rc.Fallback})
+
+		where := fmt.Sprintf("role %q", role)
+		check(where, rc.Model)
+		for _, fb := range rc.Fallback {
+			check(where+" fallback", fb)
+		}
+		if rc.Complexity != nil {
+			for _, l := range complexityLevels(rc.Complexity) {
+				check(fmt.Sprintf("role %q %s-complexity", role, l.name), l.model)
+			}
+		}
+	}
+
+	if cfg.Defaults != nil {
+		check("default", cfg.Defaults.Model)
+		for _, fb := range cfg.Defaults.Fallback {
+			check("default fallback", fb)
+		}
+	}
+
+	return inspection
+}
@@ -0,0 +1,152 @@
+package council
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func testProfile() *Profile {
+	created := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	return &Profile{
+		Name:        "team",
+		Description: "Team profile",
+		Author:      "someone",
+		Version:     "1.0.0",
+		CreatedAt:   created,
+		UpdatedAt:   created,
+		Tags:        []string{"team", "shared"},
+		Config: &Config{
+			Version: CurrentConfigVersion,
+			Roles: map[string]*RoleConfig{
+				"polecat": {
+					Model:             "sonnet-4.5",
+					Fallback:          []string{"gpt-5.2", "gemini-3-flash"},
+					ComplexityRouting: true,
+					Complexity:        &ComplexityConfig{High: "opus-4.5", Low: "gemini-3-flash"},
+				},
+			},
+			Defaults:  &DefaultConfig{Model: "sonnet-4.5", Fallback: []string{"gpt-5.2"}},
+			Providers: map[string]*ProviderConfig{"anthropic": {Enabled: true, Priority: 100}},
+		},
+		Metrics: &ProfileMetrics{TotalTasks: 42, SuccessRate: 0.9, AvgCostPerTask: 0.12, CostSavings: 35},
+	}
+}
+
+func TestProfileFileRoundTrip(t *testing.T) {
+	for _, name := range []string{"profile.toml", "profile.json"} {
+		t.Run(name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), name)
+			want := testProfile()
+			if err := ExportProfileToFile(want, path); err != nil {
+				t.Fatalf("export: %v", err)
+			}
+			got, err := ImportProfileFromFile(path)
+			if err != nil {
+				t.Fatalf("import: %v", err)
+			}
+
+			if got.Name != want.Name || !got.CreatedAt.Equal(want.CreatedAt) || !reflect.DeepEqual(got.Tags, want.Tags) {
+				t.Errorf("metadata = %+v, want %+v", got, want)
+			}
+			if !reflect.DeepEqual(got.Metrics, want.Metrics) {
+				t.Errorf("metrics = %+v, want %+v", got.Metrics, want.Metrics)
+			}
+			if got.Config == nil {
+				t.Fatal("config missing after round trip")
+			}
+			if !reflect.DeepEqual(got.Config.Roles["polecat"], want.Config.Roles["polecat"]) {
+				t.Errorf("polecat = %+v, want %+v", got.Config.Roles["polecat"], want.Config.Roles["polecat"])
+			}
+			if !reflect.DeepEqual(got.Config.Defaults, want.Config.Defaults) || !reflect.DeepEqual(got.Config.Providers, want.Config.Providers) {
+				t.Errorf("config = %+v, want %+v", got.Config, want.Config)
+			}
+		})
+	}
+}
+
+func TestImportProfileFromFile_SniffsURLFormat(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{"profile.toml", "profile.json"} {
+		if err := ExportProfileToFile(testProfile(), filepath.Join(dir, name)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Serve without extensions so the format must be sniffed.
+		http.ServeFile(w, r, filepath.Join(dir, r.URL.Query().Get("f")))
+	}))
+	defer server.Close()
+
+	for _, name := range []string{"profile.toml", "profile.json"} {
+		profile, err := ImportProfileFromFile(server.URL + "/shared?f=" + name)
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		if profile.Name != "team" || profile.Config == nil || profile.Config.Roles["polecat"] == nil {
+			t.Errorf("%s: got %+v", name, profile)
+		}
+	}
+
+	// An explicit extension wins over sniffing.
+	bad := filepath.Join(dir, "bad.json")
+	if err := os.WriteFile(bad, []byte(`name = "team"`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ImportProfileFromFile(bad); err == nil {
+		t.Error("expected a TOML body in a .json file to fail")
+	}
+}
+
+func TestApplyProfileRoles(t *testing.T) {
+	townRoot := t.TempDir()
+	if err := SaveConfig(ConfigPath(townRoot), DefaultCouncilConfig()); err != nil {
+		t.Fatal(err)
+	}
+	profile := testProfile()
+	profile.Config.Roles["refinery"] = &RoleConfig{Model: "gpt-5.2-high", Fallback: []string{"opus-4.5"}}
+
+	if err := ApplyProfileRoles(profile, townRoot, []string{"mayor"}); err == nil {
+		t.Error("expected an error for a role the profile doesn't define")
+	}
+	if err := ApplyProfileRoles(profile, townRoot, []string{"refinery"}); err != nil {
+		t.Fatalf("ApplyProfileRoles: %v", err)
+	}
+
+	got, err := LoadConfig(ConfigPath(townRoot))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defaults := DefaultCouncilConfig()
+	if got.Roles["refinery"].Model != "gpt-5.2-high" {
+		t.Errorf("refinery model = %s, want gpt-5.2-high", got.Roles["refinery"].Model)
+	}
+	if got.Roles["polecat"].Model != defaults.Roles["polecat"].Model {
+		t.Errorf("polecat model = %s, want untouched %s", got.Roles["polecat"].Model, defaults.Roles["polecat"].Model)
+	}
+	if !reflect.DeepEqual(got.Defaults, defaults.Defaults) || got.Providers["anthropic"].RateLimit != defaults.Providers["anthropic"].RateLimit {
+		t.Errorf("defaults/providers changed: %+v %+v", got.Defaults, got.Providers["anthropic"])
+	}
+
+	cfg := DefaultCouncilConfig()
+	if err := cfg.MergeProfileRoles(profile, []string{"refinery"}, true); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(cfg.Defaults, profile.Config.Defaults) || cfg.Providers["anthropic"].RateLimit != 0 {
+		t.Errorf("include defaults: got %+v %+v, want the profile's", cfg.Defaults, cfg.Providers["anthropic"])
+	}
+	if cfg.Roles["refinery"] == profile.Config.Roles["refinery"] {
+		t.Error("merged role shares its pointer with the profile")
+	}
+
+	if err := ApplyProfileRoles(profile, townRoot, nil); err != nil {
+		t.Fatal(err)
+	}
+	if got, _ := LoadConfig(ConfigPath(townRoot)); got.Roles["mayor"] != nil {
+		t.Error("empty roles should apply the whole profile, replacing the config")
+	}
+}
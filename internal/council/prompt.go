@@ -0,0 +1,104 @@
+package council
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+)
+
+// PromptProcessor transforms a prompt before it is sent to a model, e.g. to
+// inject shared context such as coding standards.
+type PromptProcessor interface {
+	Process(role, model, prompt string) (string, error)
+}
+
+// PromptProcessorFunc adapts a function to the PromptProcessor interface.
+type PromptProcessorFunc func(role, model, prompt string) (string, error)
+
+// Process calls f.
+func (f PromptProcessorFunc) Process(role, model, prompt string) (string, error) {
+	return f(role, model, prompt)
+}
+
+// runProcessors applies processors in order. The first error aborts.
+func runProcessors(processors []PromptProcessor, role, model, prompt string) (string, error) {
+	for i, p := range processors {
+		var err error
+		prompt, err = p.Process(role, model, prompt)
+		if err != nil {
+			return "", fmt.Errorf("prompt processor %d: %w", i+1, err)
+		}
+	}
+	return prompt, nil
+}
+
+// PrependFile returns a processor that prepends the contents of path (read
+// on every call, so edits take effect immediately) followed by a blank line.
+func PrependFile(path string) PromptProcessor {
+	return PromptProcessorFunc(func(role, model, prompt string) (string, error) {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return "", fmt.Errorf("reading %s: %w", path, err)
+		}
+		return strings.TrimRight(string(data), "\n") + "\n\n" + prompt, nil
+	})
+}
+
+// envRefPattern matches ${NAME} references. Bare $NAME is left alone so
+// shell snippets in prompts survive.
+var envRefPattern = regexp.MustCompile(`\$\{([A-Za-z_][A-Za-z0-9_]*)\}`)
+
+// EnvInterpolate returns a processor that replaces ${NAME} with the value of
+// the environment variable NAME. ${GT_ROLE} and ${GT_MODEL} expand to the
+// call's role and model. Unset variables are an error.
+func EnvInterpolate() PromptProcessor {
+	return PromptProcessorFunc(func(role, model, prompt string) (string, error) {
+		var missing []string
+		out := envRefPattern.ReplaceAllStringFunc(prompt, func(ref string) string {
+			name := envRefPattern.FindStringSubmatch(ref)[1]
+			switch name {
+			case "GT_ROLE":
+				return role
+			case "GT_MODEL":
+				return model
+			}
+			value, ok := os.LookupEnv(name)
+			if !ok {
+				missing = append(missing, name)
+			}
+			return value
+		})
+		if len(missing) > 0 {
+			return "", fmt.Errorf("unset environment variable(s): %s", strings.Join(missing, ", "))
+		}
+		return out, nil
+	})
+}
+
+// truncationMarker is appended to prompts shortened by TruncateToBudget.
+const truncationMarker = "\n[truncated to fit token budget]"
+
+// TruncateToBudget returns a processor that cuts prompts whose estimated
+// token count (see EstimateTokens) exceeds maxTokens, keeping the start.
+func TruncateToBudget(maxTokens int) PromptProcessor {
+	return PromptProcessorFunc(func(role, model, prompt string) (string, error) {
+		if maxTokens <= 0 || EstimateTokens(prompt, model) <= maxTokens {
+			return prompt, nil
+		}
+
+		ratio, ok := CharsPerToken[ModelProvider(model)]
+		if !ok || ratio <= 0 {
+			ratio = DefaultCharsPerToken
+		}
+		runes := []rune(prompt)
+		keep := int(float64(maxTokens)*ratio) - len(truncationMarker)
+		if keep < 0 {
+			keep = 0
+		}
+		if keep > len(runes) {
+			keep = len(runes)
+		}
+		return string(runes[:keep]) + truncationMarker, nil
+	})
+}
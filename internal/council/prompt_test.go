@@ -0,0 +1,75 @@
+package council
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/cursorworkshop/cursor-gastown/internal/cursor"
+)
+
+func TestCursorExecutor_Processors(t *testing.T) {
+	standards := filepath.Join(t.TempDir(), "standards.md")
+	if err := os.WriteFile(standards, []byte("Follow ${TEAM} conventions as ${GT_ROLE}.\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("TEAM", "platform")
+
+	var sent string
+	e := stubCursorExecutor(func(ctx context.Context, adapter *cursor.Adapter, prompt string) (string, error) {
+		sent = prompt
+		return "ok", nil
+	})
+	e.Role = "polecat"
+	// Order matters: the file is prepended first, so its ${...} references
+	// are expanded by the second processor.
+	e.Processors = []PromptProcessor{PrependFile(standards), EnvInterpolate()}
+
+	if _, err := e.Execute(context.Background(), "sonnet-4.5", "Fix the bug"); err != nil {
+		t.Fatalf("Execute failed: %v", err)
+	}
+	if want := "Follow platform conventions as polecat.\n\nFix the bug"; sent != want {
+		t.Errorf("sent prompt = %q, want %q", sent, want)
+	}
+}
+
+func TestChainExecutor_ProcessorErrorAborts(t *testing.T) {
+	calls := 0
+	executor := funcExecutor(func(ctx context.Context, model, prompt string) (*ModelResponse, error) {
+		calls++
+		return &ModelResponse{Output: "done", Success: true}, nil
+	})
+	failing := PromptProcessorFunc(func(role, model, prompt string) (string, error) {
+		return "", errors.New("context service down")
+	})
+
+	config := &ChainConfig{Steps: []ChainStep{{Name: "draft", Model: "sonnet-4.5"}}, StopOnError: true}
+	result, err := NewChainExecutor(executor, config).WithProcessors(failing).Execute(context.Background(), "task")
+	if err != nil {
+		t.Fatalf("Execute failed: %v", err)
+	}
+	if calls != 0 {
+		t.Errorf("model called %d times after processor error", calls)
+	}
+	if result.Success || !strings.Contains(result.Error, "context service down") {
+		t.Errorf("result = %+v, want failure from processor", result)
+	}
+}
+
+func TestTruncateToBudget(t *testing.T) {
+	prompt := strings.Repeat("word ", 1000)
+	out, err := TruncateToBudget(100).Process("polecat", "gpt-5.2", prompt)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if EstimateTokens(out, "gpt-5.2") > 100 || !strings.HasSuffix(out, truncationMarker) {
+		t.Errorf("truncated prompt has %d tokens, want <= 100 with marker", EstimateTokens(out, "gpt-5.2"))
+	}
+
+	if out, _ := TruncateToBudget(100).Process("polecat", "gpt-5.2", "short"); out != "short" {
+		t.Errorf("short prompt changed to %q", out)
+	}
+}
@@ -0,0 +1,132 @@
+package council
+
+import (
+	"sort"
+)
+
+// knownProviderOrder is the tie-break order for providers with equal or
+// missing priorities. Unknown providers sort after these, by name.
+var knownProviderOrder = []string{"anthropic", "openai", "google", "xai"}
+
+// priorityStep is the gap between priorities assigned by NormalizePriorities.
+const priorityStep = 10
+
+// EnsureProvidersForModels adds an enabled provider entry for every provider
+// referenced by a role or default model but missing from Providers, then
+// normalizes priorities. Returns the names of the providers added.
+func (c *Config) EnsureProvidersForModels() []string {
+	var models []string
+	for _, rc := range c.Roles {
+		if rc == nil {
+			continue
+		}
+		models = append(models, rc.Model)
+		models = append(models, rc.Fallback...)
+	}
+	if c.Defaults != nil {
+		models = append(models, c.Defaults.Model)
+		models = append(models, c.Defaults.Fallback...)
+	}
+
+	var added []string
+	for _, model := range models {
+		provider := ModelProvider(model)
+		if provider == "unknown" {
+			continue
+		}
+		if c.Providers == nil {
+			c.Providers = make(map[string]*ProviderConfig)
+		}
+		pc := c.Providers[provider]
+		if pc == nil {
+			pc = &ProviderConfig{Enabled: true}
+			c.Providers[provider] = pc
+			added = append(added, provider)
+		}
+		if !contains(pc.Models, model) && contains(added, provider) {
+			pc.Models = append(pc.Models, model)
+		}
+	}
+
+	if len(added) > 0 {
+		c.NormalizePriorities()
+	}
+	sort.Strings(added)
+	return added
+}
+
+// NormalizePriorities assigns every provider a distinct priority so that
+// fallback ordering is deterministic. Providers keep their existing relative
+// order (higher priority first); ties and zero priorities are broken by
+// knownProviderOrder, then name, with zero-priority providers placed last.
+// Existing priorities are kept where they are already distinct.
+func (c *Config) NormalizePriorities() {
+	if len(c.Providers) == 0 {
+		return
+	}
+
+	names := make([]string, 0, len(c.Providers))
+	for name, pc := range c.Providers {
+		if pc != nil {
+			names = append(names, name)
+		}
+	}
+	sort.Slice(names, func(i, j int) bool {
+		pi, pj := c.Providers[names[i]].Priority, c.Providers[names[j]].Priority
+		if (pi == 0) != (pj == 0) {
+			return pj == 0
+		}
+		if pi != pj {
+			return pi > pj
+		}
+		return providerOrderLess(names[i], names[j])
+	})
+
+	// Keep existing values while they strictly decrease; fill the rest
+	// below the previous provider.
+	assigned := make([]int, len(names))
+	prev := 0
+	for i, name := range names {
+		p := c.Providers[name].Priority
+		switch {
+		case i == 0 && p > 0:
+			assigned[i] = p
+		case i == 0:
+			assigned[i] = priorityStep * len(names)
+		case p > 0 && p < prev:
+			assigned[i] = p
+		default:
+			assigned[i] = prev - priorityStep
+		}
+		prev = assigned[i]
+	}
+
+	// Out of room at the bottom: renumber everything evenly.
+	if prev <= 0 {
+		for i := range assigned {
+			assigned[i] = priorityStep * (len(names) - i)
+		}
+	}
+
+	for i, name := range names {
+		c.Providers[name].Priority = assigned[i]
+	}
+}
+
+// providerOrderLess orders providers by knownProviderOrder, then by name.
+func providerOrderLess(a, b string) bool {
+	ia, ib := knownProviderIndex(a), knownProviderIndex(b)
+	if ia != ib {
+		return ia < ib
+	}
+	return a < b
+}
+
+func knownProviderIndex(provider string) int {
+	for i, p := range knownProviderOrder {
+		if p == provider {
+			return i
+		}
+	}
+	return len(knownProviderOrder)
+}
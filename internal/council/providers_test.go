@@ -0,0 +1,71 @@
+package council
+
+import "testing"
+
+func TestConfig_NormalizePriorities(t *testing.T) {
+	cfg := &Config{Providers: map[string]*ProviderConfig{
+		"xai":       {Enabled: true},
+		"google":    {Enabled: true},
+		"anthropic": {Enabled: true},
+		"openai":    {Enabled: true},
+		"mistral":   {Enabled: true},
+	}}
+
+	cfg.NormalizePriorities()
+
+	order := []string{"anthropic", "openai", "google", "xai", "mistral"}
+	seen := make(map[int]string)
+	for i, name := range order {
+		p := cfg.Providers[name].Priority
+		if p <= 0 {
+			t.Errorf("%s priority = %d, want > 0", name, p)
+		}
+		if other, dup := seen[p]; dup {
+			t.Errorf("%s and %s share priority %d", name, other, p)
+		}
+		seen[p] = name
+		if i > 0 && p >= cfg.Providers[order[i-1]].Priority {
+			t.Errorf("%s priority %d should be below %s (%d)", name, p, order[i-1], cfg.Providers[order[i-1]].Priority)
+		}
+	}
+}
+
+func TestConfig_NormalizePriorities_KeepsExistingOrder(t *testing.T) {
+	cfg := &Config{Providers: map[string]*ProviderConfig{
+		"anthropic": {Priority: 50},
+		"openai":    {Priority: 90},
+		"google":    {Priority: 50},
+		"xai":       {},
+	}}
+
+	cfg.NormalizePriorities()
+
+	want := map[string]int{"openai": 90, "anthropic": 50, "google": 40, "xai": 30}
+	for name, p := range want {
+		if got := cfg.Providers[name].Priority; got != p {
+			t.Errorf("%s priority = %d, want %d", name, got, p)
+		}
+	}
+}
+
+func TestConfig_EnsureProvidersForModels(t *testing.T) {
+	cfg := DefaultCouncilConfig()
+	delete(cfg.Providers, "google")
+	cfg.Roles["polecat"].Fallback = []string{"grok"}
+
+	added := cfg.EnsureProvidersForModels()
+
+	if len(added) != 2 || added[0] != "google" || added[1] != "xai" {
+		t.Fatalf("added = %v, want [google xai]", added)
+	}
+	if pc := cfg.Providers["xai"]; pc == nil || !pc.Enabled || !contains(pc.Models, "grok") {
+		t.Errorf("xai provider = %+v, want enabled with grok", pc)
+	}
+	seen := make(map[int]bool)
+	for name, pc := range cfg.Providers {
+		if seen[pc.Priority] {
+			t.Errorf("%s shares priority %d", name, pc.Priority)
+		}
+		seen[pc.Priority] = true
+	}
+}
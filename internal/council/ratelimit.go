@@ -0,0 +1,120 @@
+package council
+
+import (
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Quota thresholds used by RouteWithFallback.
+const (
+	// LowQuotaRemaining is the remaining-request count at or below which a
+	// provider is deprioritized.
+	LowQuotaRemaining = 5
+
+	// quotaTTL is how long a remaining-quota reading is trusted. Provider
+	// windows typically reset every minute.
+	quotaTTL = time.Minute
+)
+
+// rateLimitRemainingHeaders are the provider headers reporting how many
+// requests are left in the current window, in order of preference.
+var rateLimitRemainingHeaders = []string{
+	"X-RateLimit-Remaining",
+	"X-RateLimit-Remaining-Requests",
+	"Anthropic-Ratelimit-Requests-Remaining",
+}
+
+// RateLimitInfo is a provider's self-reported rate-limit state.
+type RateLimitInfo struct {
+	// Remaining is the number of requests left in the current window;
+	// meaningful only when HasRemaining is set.
+	Remaining    int  `json:"remaining"`
+	HasRemaining bool `json:"has_remaining"`
+
+	// RetryAfter is when the provider asked to be retried; zero if unset.
+	RetryAfter time.Time `json:"retry_after,omitempty"`
+
+	// ObservedAt is when the headers were seen.
+	ObservedAt time.Time `json:"observed_at"`
+}
+
+// empty reports whether the info carries no rate-limit data.
+func (ri RateLimitInfo) empty() bool {
+	return !ri.HasRemaining && ri.RetryAfter.IsZero()
+}
+
+// ParseRateLimitHeaders extracts remaining quota and Retry-After from
+// provider response headers.
+func ParseRateLimitHeaders(h http.Header, now time.Time) RateLimitInfo {
+	info := RateLimitInfo{ObservedAt: now}
+	for _, name := range rateLimitRemainingHeaders {
+		if n, err := strconv.Atoi(strings.TrimSpace(h.Get(name))); err == nil && n >= 0 {
+			info.Remaining = n
+			info.HasRemaining = true
+			break
+		}
+	}
+	if until, ok := parseRetryAfter(h.Get("Retry-After"), now); ok {
+		info.RetryAfter = until
+	}
+	return info
+}
+
+// rateLimitLinePattern matches rate-limit header lines that cursor-agent
+// echoes from a provider response, e.g. "x-ratelimit-remaining: 3".
+var rateLimitLinePattern = regexp.MustCompile(`(?im)^[\s>*-]*(x-ratelimit-remaining(?:-requests)?|anthropic-ratelimit-requests-remaining|retry-after)\s*:\s*(.+?)\s*$`)
+
+// parseRateLimitText extracts rate-limit headers echoed in cursor-agent
+// output or error text.
+func parseRateLimitText(text string, now time.Time) RateLimitInfo {
+	h := make(http.Header)
+	for _, m := range rateLimitLinePattern.FindAllStringSubmatch(text, -1) {
+		h.Set(m[1], m[2])
+	}
+	return ParseRateLimitHeaders(h, now)
+}
+
+// RecordRateLimitInfo stores a provider's latest rate-limit reading for
+// RouteWithFallback. Readings without any rate-limit data are ignored.
+func (fm *FallbackManager) RecordRateLimitInfo(provider string, info RateLimitInfo) {
+	if info.empty() {
+		return
+	}
+	if info.ObservedAt.IsZero() {
+		info.ObservedAt = time.Now()
+	}
+
+	fm.mu.Lock()
+	defer fm.mu.Unlock()
+	if prev, ok := fm.quota[provider]; ok && !info.HasRemaining {
+		// A bare Retry-After doesn't reset the known remaining count.
+		info.Remaining, info.HasRemaining = prev.Remaining, prev.HasRemaining
+	}
+	fm.quota[provider] = info
+}
+
+// ProviderQuota returns the latest rate-limit reading for provider.
+func (fm *FallbackManager) ProviderQuota(provider string) (RateLimitInfo, bool) {
+	fm.mu.RLock()
+	defer fm.mu.RUnlock()
+	info, ok := fm.quota[provider]
+	return info, ok
+}
+
+// quotaState splits providers into those that asked to be left alone until
+// a Retry-After still in the future and those reporting a recent low
+// remaining quota. The caller must hold fm.mu.
+func (fm *FallbackManager) quotaState(now time.Time) (waiting, low []string) {
+	for provider, info := range fm.quota {
+		switch {
+		case now.Before(info.RetryAfter):
+			waiting = append(waiting, provider)
+		case info.HasRemaining && info.Remaining <= LowQuotaRemaining && now.Sub(info.ObservedAt) < quotaTTL:
+			low = append(low, provider)
+		}
+	}
+	return waiting, low
+}
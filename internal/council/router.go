@@ -2,8 +2,17 @@
 package council
 
 import (
+	"encoding/json"
+	"errors"
 	"fmt"
+	"hash/fnv"
+	"os"
+	"path/filepath"
+	"sort"
 	"sync"
+	"time"
+
+	"github.com/cursorworkshop/cursor-gastown/internal/cursor"
 )
 
 // Router selects the optimal model for a given task based on role and complexity.
@@ -13,6 +22,14 @@ type Router struct {
 
 	// providerStatus tracks provider availability.
 	providerStatus map[string]bool
+
+	// auditPath is the NDJSON audit log; empty disables auditing.
+	auditMu   sync.Mutex
+	auditPath string
+
+	// availability is the recent success rate per provider (0-1), used by
+	// RouteSmart. Providers without an entry are assumed fully available.
+	availability map[string]float64
 }
 
 // NewRouter creates a new model router with the given configuration.
@@ -41,59 +58,288 @@ func NewRouter(config *Config) *Router {
 // RouteRequest represents a request for model routing.
 type RouteRequest struct {
 	// Role is the Gas Town role making the request.
-	Role string
+	Role string `json:"role"`
 
 	// Task describes the task (optional, for complexity analysis).
-	Task *TaskInfo
+	Task *TaskInfo `json:"task,omitempty"`
 
 	// PreferredModel is an optional model override.
-	PreferredModel string
+	PreferredModel string `json:"preferred_model,omitempty"`
 
 	// ExcludeProviders lists providers to exclude (e.g., due to rate limits).
-	ExcludeProviders []string
+	ExcludeProviders []string `json:"exclude_providers,omitempty"`
+
+	// ExcludeModels lists individual models to exclude (e.g., due to an
+	// open model circuit) without excluding their provider.
+	ExcludeModels []string `json:"exclude_models,omitempty"`
+
+	// Substitutions maps a selected model to a challenger for A/B tests.
+	Substitutions map[string]Substitution `json:"substitutions,omitempty"`
+
+	// RequestID identifies the request for deterministic substitution
+	// bucketing. If empty, the role and task description are used.
+	RequestID string `json:"request_id,omitempty"`
+
+	// MaxCostPerTask caps the estimated dollar cost of the selected model
+	// (0 = no cap). Models without a Config.ModelCost entry are not capped.
+	MaxCostPerTask float64 `json:"max_cost_per_task,omitempty"`
+
+	// EstimatedTokens is the expected task size used for cost estimates.
+	// Defaults to DefaultTaskTokens.
+	EstimatedTokens int `json:"estimated_tokens,omitempty"`
+}
+
+// ErrCostCeiling is returned by Route when every available model's
+// estimated cost exceeds RouteRequest.MaxCostPerTask.
+var ErrCostCeiling = errors.New("no available model fits the cost ceiling")
+
+// RouteFailure names why routing gave up.
+type RouteFailure string
+
+// FallbackExhausted means Config.MaxFallbackDepth fallback candidates were
+// considered without finding a usable model.
+const FallbackExhausted RouteFailure = "fallback_exhausted"
+
+// RouteError is returned by Route when routing stops early.
+type RouteError struct {
+	Role   string
+	Reason RouteFailure
+
+	// Depth is the fallback depth limit that was reached.
+	Depth int
+}
+
+func (e *RouteError) Error() string {
+	return fmt.Sprintf("routing %s: %s after %d fallback(s)", e.Role, e.Reason, e.Depth)
+}
+
+// Substitution replaces a selected model with a challenger for a fraction
+// of requests.
+type Substitution struct {
+	// Model is the challenger model.
+	Model string `json:"model"`
+
+	// Fraction is the share of requests (0-1) routed to the challenger.
+	Fraction float64 `json:"fraction"`
 }
 
 // TaskInfo provides information about the task for complexity analysis.
 type TaskInfo struct {
 	// FilesAffected is the number of files the task will touch.
-	FilesAffected int
+	FilesAffected int `json:"files_affected,omitempty"`
 
 	// LinesChanged is the estimated lines of code changed.
-	LinesChanged int
+	LinesChanged int `json:"lines_changed,omitempty"`
 
 	// IsArchitectural indicates if the change affects architecture.
-	IsArchitectural bool
+	IsArchitectural bool `json:"is_architectural,omitempty"`
 
 	// HasTests indicates if tests need to be written.
-	HasTests bool
+	HasTests bool `json:"has_tests,omitempty"`
 
 	// Description is a text description of the task.
-	Description string
+	Description string `json:"description,omitempty"`
 }
 
 // RouteResult contains the routing decision.
 type RouteResult struct {
 	// Model is the selected model.
-	Model string
+	Model string `json:"model"`
 
 	// Provider is the provider for the model.
-	Provider string
+	Provider string `json:"provider"`
 
 	// Rationale explains why this model was selected.
-	Rationale string
+	Rationale string `json:"rationale"`
 
 	// Complexity is the assessed task complexity.
-	Complexity ComplexityLevel
+	Complexity ComplexityLevel `json:"complexity"`
 
 	// Fallback indicates if this is a fallback selection.
-	Fallback bool
+	Fallback bool `json:"fallback"`
 
 	// FallbackReason explains why fallback was needed.
-	FallbackReason string
+	FallbackReason string `json:"fallback_reason,omitempty"`
+
+	// OriginalModel is the model selected before an A/B substitution
+	// replaced it with Model; empty when no substitution applied.
+	OriginalModel string `json:"original_model,omitempty"`
+
+	// EstimatedCost is the estimated dollar cost of the task on Model,
+	// when the model has a configured price.
+	EstimatedCost float64 `json:"estimated_cost,omitempty"`
+
+	// RequestRole is the role the route was requested for.
+	RequestRole string `json:"request_role"`
+
+	// Timestamp is when the routing decision was made.
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// RouteAudit is one line of the routing audit log.
+type RouteAudit struct {
+	Timestamp time.Time     `json:"timestamp"`
+	Request   *RouteRequest `json:"request"`
+	Result    *RouteResult  `json:"result,omitempty"`
+	Error     string        `json:"error,omitempty"`
+}
+
+// RouteAuditFileName is the default filename for the routing audit log.
+const RouteAuditFileName = "council-routes.ndjson"
+
+// RouteAuditPath returns the default audit log path for a town.
+func RouteAuditPath(townRoot string) string {
+	return filepath.Join(townRoot, ".beads", RouteAuditFileName)
+}
+
+// EnableAudit makes the router append a RouteAudit line to path (NDJSON)
+// for every routing decision. An empty path disables auditing.
+func (r *Router) EnableAudit(path string) error {
+	if path != "" {
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			return fmt.Errorf("creating audit directory: %w", err)
+		}
+	}
+	r.auditMu.Lock()
+	defer r.auditMu.Unlock()
+	r.auditPath = path
+	return nil
+}
+
+// substitute applies a matching A/B substitution to result. Requests are
+// bucketed by a hash of their ID, so the same request always lands in the
+// same arm. Unavailable challengers, and those over the request's cost
+// ceiling, are skipped.
+func (r *Router) substitute(req *RouteRequest, result *RouteResult) {
+	if result == nil || len(req.Substitutions) == 0 {
+		return
+	}
+	sub, ok := req.Substitutions[result.Model]
+	if !ok || sub.Model == "" || sub.Model == result.Model || !inBucket(req, result.Model, sub.Fraction) {
+		return
+	}
+	r.mu.RLock()
+	provider, available := r.modelAvailable(req, sub.Model)
+	available = available && r.withinBudget(req, sub.Model)
+	r.mu.RUnlock()
+	if !available {
+		return
+	}
+
+	result.OriginalModel = result.Model
+	result.Model = sub.Model
+	result.Provider = provider
+	result.Rationale += fmt.Sprintf("; A/B substitution %s -> %s (%.0f%% of traffic)", result.OriginalModel, sub.Model, sub.Fraction*100)
+}
+
+// inBucket reports whether req falls in the first fraction of hash buckets
+// for the given model.
+func inBucket(req *RouteRequest, model string, fraction float64) bool {
+	if fraction <= 0 {
+		return false
+	}
+	key := req.RequestID
+	if key == "" {
+		key = req.Role
+		if req.Task != nil {
+			key += "|" + req.Task.Description
+		}
+	}
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(key + "|" + model))
+	return float64(h.Sum32()%10000) < fraction*10000
+}
+
+// audit stamps the result and appends an audit record if auditing is enabled.
+// Audit write failures never fail the route.
+func (r *Router) audit(req *RouteRequest, result *RouteResult, err error) {
+	now := time.Now()
+	if result != nil {
+		result.RequestRole = req.Role
+		result.Timestamp = now
+	}
+
+	r.auditMu.Lock()
+	defer r.auditMu.Unlock()
+	if r.auditPath == "" {
+		return
+	}
+
+	record := RouteAudit{Timestamp: now, Request: req, Result: result}
+	if err != nil {
+		record.Error = err.Error()
+	}
+	line, merr := json.Marshal(record)
+	if merr != nil {
+		return
+	}
+
+	f, ferr := os.OpenFile(r.auditPath, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644)
+	if ferr != nil {
+		return
+	}
+	defer f.Close()
+	_, _ = f.Write(append(line, '\n'))
 }
 
 // Route selects the optimal model for a request.
 func (r *Router) Route(req *RouteRequest) (*RouteResult, error) {
+	result, err := r.route(req)
+	r.substitute(req, result)
+	r.estimateCost(req, result)
+	r.audit(req, result, err)
+	return result, err
+}
+
+// taskTokens returns the token estimate used for req's cost checks.
+func taskTokens(req *RouteRequest) int {
+	if req.EstimatedTokens > 0 {
+		return req.EstimatedTokens
+	}
+	return DefaultTaskTokens
+}
+
+// withinBudget reports whether model's estimated cost fits req's ceiling.
+// The caller must hold r.mu.
+func (r *Router) withinBudget(req *RouteRequest, model string) bool {
+	if req.MaxCostPerTask <= 0 {
+		return true
+	}
+	cost, ok := r.config.EstimateCost(model, taskTokens(req))
+	return !ok || cost <= req.MaxCostPerTask
+}
+
+// byCostDescending returns models ordered from most to least expensive,
+// keeping the configured order for ties and unpriced models. The caller
+// must hold r.mu.
+func (r *Router) byCostDescending(req *RouteRequest, models []string) []string {
+	sorted := append([]string(nil), models...)
+	if req.MaxCostPerTask <= 0 {
+		return sorted
+	}
+	tokens := taskTokens(req)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		ci, _ := r.config.EstimateCost(sorted[i], tokens)
+		cj, _ := r.config.EstimateCost(sorted[j], tokens)
+		return ci > cj
+	})
+	return sorted
+}
+
+// estimateCost stamps result with its model's estimated cost.
+func (r *Router) estimateCost(req *RouteRequest, result *RouteResult) {
+	if result == nil {
+		return
+	}
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	if cost, ok := r.config.EstimateCost(result.Model, taskTokens(req)); ok {
+		result.EstimatedCost = cost
+	}
+}
+
+// route implements Route without auditing.
+func (r *Router) route(req *RouteRequest) (*RouteResult, error) {
 	r.mu.RLock()
 	defer r.mu.RUnlock()
 
@@ -101,13 +347,18 @@ func (r *Router) Route(req *RouteRequest) (*RouteResult, error) {
 
 	// Check for preferred model override
 	if req.PreferredModel != "" && req.PreferredModel != "auto" {
-		if r.isModelAvailable(req.PreferredModel, req.ExcludeProviders) {
+		provider, available := r.modelAvailable(req, req.PreferredModel)
+		if available && r.withinBudget(req, req.PreferredModel) {
 			result.Model = req.PreferredModel
-			result.Provider = ModelProvider(req.PreferredModel)
+			result.Provider = provider
 			result.Rationale = "User-specified model preference"
 			return result, nil
 		}
-		result.FallbackReason = fmt.Sprintf("Preferred model %s unavailable", req.PreferredModel)
+		if available {
+			result.FallbackReason = r.costCeilingReason(req, "Preferred", req.PreferredModel)
+		} else {
+			result.FallbackReason = fmt.Sprintf("Preferred model %s unavailable", req.PreferredModel)
+		}
 		result.Fallback = true
 	}
 
@@ -118,7 +369,7 @@ func (r *Router) Route(req *RouteRequest) (*RouteResult, error) {
 	var model string
 	if r.config.SupportsComplexityRouting(req.Role) {
 		model = r.config.GetModelForComplexity(req.Role, result.Complexity)
-		result.Rationale = fmt.Sprintf("Complexity-based routing: %s task", result.Complexity)
+		result.Rationale = r.config.ComplexityRationale(req.Role, result.Complexity)
 	} else {
 		model = r.config.GetModelForRole(req.Role)
 		result.Rationale = r.config.GetRationale(req.Role)
@@ -128,24 +379,52 @@ func (r *Router) Route(req *RouteRequest) (*RouteResult, error) {
 	}
 
 	// Check availability and apply fallbacks
-	if r.isModelAvailable(model, req.ExcludeProviders) {
-		result.Model = model
-		result.Provider = ModelProvider(model)
-		return result, nil
+	overBudget := false
+	if provider, ok := r.modelAvailable(req, model); ok {
+		if r.withinBudget(req, model) {
+			result.Model = model
+			result.Provider = provider
+			return result, nil
+		}
+		overBudget = true
 	}
 
-	// Try fallback chain
-	fallbacks := r.config.GetFallbackChain(req.Role)
+	// Try fallback chain; under a cost ceiling, the priciest model that fits.
+	// Only candidates actually considered count toward MaxFallbackDepth;
+	// unavailable and excluded models are skipped without counting.
+	depth := r.config.MaxFallbackDepth
+	tried := 0
+	countAttempt := func() error {
+		if depth > 0 && tried == depth {
+			return &RouteError{Role: req.Role, Reason: FallbackExhausted, Depth: depth}
+		}
+		tried++
+		return nil
+	}
+	fallbacks := r.byCostDescending(req, r.config.GetFallbackChain(req.Role))
 	for _, fb := range fallbacks {
-		if r.isModelAvailable(fb, req.ExcludeProviders) {
-			result.Model = fb
-			result.Provider = ModelProvider(fb)
-			result.Fallback = true
-			if result.FallbackReason == "" {
+		provider, ok := r.modelAvailable(req, fb)
+		if !ok {
+			continue
+		}
+		if err := countAttempt(); err != nil {
+			return nil, err
+		}
+		if !r.withinBudget(req, fb) {
+			overBudget = true
+			continue
+		}
+		result.Model = fb
+		result.Provider = provider
+		result.Fallback = true
+		if result.FallbackReason == "" {
+			if overBudget && fb != model {
+				result.FallbackReason = r.costCeilingReason(req, "Primary", model)
+			} else {
 				result.FallbackReason = fmt.Sprintf("Primary model %s unavailable", model)
 			}
-			return result, nil
 		}
+		return result, nil
 	}
 
 	// Last resort: any available model
@@ -154,6 +433,16 @@ func (r *Router) Route(req *RouteRequest) (*RouteResult, error) {
 			continue
 		}
 		for _, m := range pc.Models {
+			if contains(req.ExcludeModels, m) {
+				continue
+			}
+			if err := countAttempt(); err != nil {
+				return nil, err
+			}
+			if !r.withinBudget(req, m) {
+				overBudget = true
+				continue
+			}
 			result.Model = m
 			result.Provider = provider
 			result.Fallback = true
@@ -162,9 +451,18 @@ func (r *Router) Route(req *RouteRequest) (*RouteResult, error) {
 		}
 	}
 
+	if overBudget {
+		return nil, fmt.Errorf("%w: $%.4f for role %s", ErrCostCeiling, req.MaxCostPerTask, req.Role)
+	}
 	return nil, fmt.Errorf("no available models for role %s", req.Role)
 }
 
+// costCeilingReason explains why a model was passed over for cost.
+func (r *Router) costCeilingReason(req *RouteRequest, kind, model string) string {
+	cost, _ := r.config.EstimateCost(model, taskTokens(req))
+	return fmt.Sprintf("%s model %s estimated $%.4f exceeds cost ceiling $%.4f", kind, model, cost, req.MaxCostPerTask)
+}
+
 // assessComplexity determines the complexity level of a task.
 func (r *Router) assessComplexity(task *TaskInfo) ComplexityLevel {
 	if task == nil {
@@ -214,38 +512,36 @@ func (r *Router) assessComplexity(task *TaskInfo) ComplexityLevel {
 	}
 }
 
-// isModelAvailable checks if a model is available.
-func (r *Router) isModelAvailable(model string, excludeProviders []string) bool {
-	provider := ModelProvider(model)
+// modelAvailable returns the provider serving model for req's role and
+// whether the model is usable: not excluded itself and on an available
+// provider.
+func (r *Router) modelAvailable(req *RouteRequest, model string) (string, bool) {
+	provider := r.config.ProviderFor(req.Role, model)
+	if contains(req.ExcludeModels, model) {
+		return provider, false
+	}
+	return provider, r.isProviderAvailable(provider, req.ExcludeProviders)
+}
 
+// isProviderAvailable checks if a provider is configured, enabled, and not
+// excluded.
+func (r *Router) isProviderAvailable(provider string, excludeProviders []string) bool {
 	// Check if provider is excluded
 	if contains(excludeProviders, provider) {
 		return false
 	}
 
-	// Check provider status
-	if status, ok := r.providerStatus[provider]; ok && !status {
-		return false
+	// Models with no known provider (e.g. "auto") are always routable;
+	// a named provider the config doesn't list is not.
+	if provider == "unknown" {
+		return true
 	}
-
-	return true
+	return r.providerStatus[provider]
 }
 
-// ModelProvider returns the provider for a model.
-// Duplicated from cursor package to avoid circular imports.
+// ModelProvider returns the provider for a model, from the model catalog.
 func ModelProvider(model string) string {
-	switch {
-	case hasPrefix(model, "opus-", "sonnet-", "haiku-", "claude-"):
-		return "anthropic"
-	case hasPrefix(model, "gpt-", "o4-"):
-		return "openai"
-	case hasPrefix(model, "gemini-"):
-		return "google"
-	case model == "grok":
-		return "xai"
-	default:
-		return "unknown"
-	}
+	return cursor.ModelProvider(model)
 }
 
 // SetProviderStatus updates a provider's availability status.
@@ -262,11 +558,181 @@ func (r *Router) GetProviderStatus(provider string) bool {
 	return r.providerStatus[provider]
 }
 
+// WithHealthSnapshot seeds provider availability from a health snapshot,
+// typically built from FallbackManager.GetAllHealth via HealthSnapshot.
+// This lets a single probe inform every routing decision in one command.
+//
+// The snapshot is a point-in-time view: it is not refreshed as providers
+// recover or fail, so it should only be used for the short-lived routing
+// done by a single command. Long-running callers should rely on a
+// FallbackManager, which updates status as outcomes are recorded.
+// Providers missing from the snapshot keep their current status.
+func (r *Router) WithHealthSnapshot(snapshot map[string]bool) *Router {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for provider, available := range snapshot {
+		r.providerStatus[provider] = available
+	}
+	return r
+}
+
+// WithAvailability sets recent per-provider availability (0-1) for
+// RouteSmart, typically from ProviderAvailability on the metrics store.
+func (r *Router) WithAvailability(availability map[string]float64) *Router {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.availability = availability
+	return r
+}
+
+// Availability bands for a provider's recent success rate: below
+// DegradedAvailability a provider is degraded, below UnhealthyAvailability
+// it is unhealthy.
+const (
+	DegradedAvailability  = 0.9
+	UnhealthyAvailability = 0.5
+)
+
+// availabilityHealth names the band availability falls in.
+func availabilityHealth(availability float64) string {
+	switch {
+	case availability < UnhealthyAvailability:
+		return "unhealthy"
+	case availability < DegradedAvailability:
+		return "degraded"
+	default:
+		return "healthy"
+	}
+}
+
+// ProviderAvailability extracts per-provider availability from metrics,
+// skipping providers with no recorded tasks.
+func ProviderAvailability(m *Metrics) map[string]float64 {
+	result := make(map[string]float64)
+	if m == nil {
+		return result
+	}
+	for provider, pm := range m.ByProvider {
+		if pm != nil && pm.TotalTasks > 0 {
+			result[provider] = pm.Availability
+		}
+	}
+	return result
+}
+
+// RouteSmart routes like Route, but orders the primary and fallback models
+// by their provider's recent availability so the model most likely to
+// succeed is tried first. Ties keep the configured order. Requests with a
+// preferred model, or with no available candidates, are handled by Route.
+func (r *Router) RouteSmart(req *RouteRequest) (*RouteResult, error) {
+	result, err := r.routeSmart(req)
+	r.substitute(req, result)
+	r.estimateCost(req, result)
+	r.audit(req, result, err)
+	return result, err
+}
+
+// routeSmart implements RouteSmart without auditing. Cost-capped requests
+// are routed by cost rather than availability.
+func (r *Router) routeSmart(req *RouteRequest) (*RouteResult, error) {
+	if (req.PreferredModel != "" && req.PreferredModel != "auto") || req.MaxCostPerTask > 0 {
+		return r.route(req)
+	}
+
+	r.mu.RLock()
+	complexity := r.assessComplexity(req.Task)
+	var primary, rationale string
+	if r.config.SupportsComplexityRouting(req.Role) {
+		primary = r.config.GetModelForComplexity(req.Role, complexity)
+		rationale = r.config.ComplexityRationale(req.Role, complexity)
+	} else {
+		primary = r.config.GetModelForRole(req.Role)
+		rationale = r.config.GetRationale(req.Role)
+		if rationale == "" {
+			rationale = "Role-based model selection"
+		}
+	}
+
+	var candidates []string
+	seen := make(map[string]bool)
+	for _, m := range append([]string{primary}, r.config.GetFallbackChain(req.Role)...) {
+		if m == "" || seen[m] {
+			continue
+		}
+		if _, ok := r.modelAvailable(req, m); !ok {
+			continue
+		}
+		seen[m] = true
+		candidates = append(candidates, m)
+	}
+	config := r.config
+	// Snapshot availability while holding the lock; WithAvailability may
+	// update it concurrently once the lock is released.
+	scores := make(map[string]float64, len(candidates))
+	for _, m := range candidates {
+		scores[m] = 1
+		if a, ok := r.availability[config.ProviderFor(req.Role, m)]; ok {
+			scores[m] = a
+		}
+	}
+	r.mu.RUnlock()
+	score := func(model string) float64 { return scores[model] }
+
+	if len(candidates) == 0 {
+		return r.route(req)
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return score(candidates[i]) > score(candidates[j])
+	})
+
+	model := candidates[0]
+	result := &RouteResult{
+		Model:      model,
+		Provider:   config.ProviderFor(req.Role, model),
+		Rationale:  rationale,
+		Complexity: complexity,
+		Fallback:   model != primary,
+	}
+	if result.Fallback {
+		if seen[primary] {
+			result.FallbackReason = fmt.Sprintf("Reordered by provider availability: %s %.0f%% > %s %.0f%%",
+				result.Provider, score(model)*100, config.ProviderFor(req.Role, primary), score(primary)*100)
+		} else {
+			result.FallbackReason = fmt.Sprintf("Primary model %s unavailable", primary)
+		}
+		result.Rationale += "; " + result.FallbackReason
+	}
+	return result, nil
+}
+
 // ReloadConfig reloads the router configuration.
+// Provider statuses are reconciled with the new config: new providers start
+// available per Enabled, providers whose Enabled setting is unchanged keep
+// their runtime status (e.g., from health checks), and removed providers
+// are dropped, so their models no longer route.
 func (r *Router) ReloadConfig(config *Config) {
+	if config == nil {
+		config = DefaultCouncilConfig()
+	}
+
 	r.mu.Lock()
 	defer r.mu.Unlock()
+
+	status := make(map[string]bool, len(config.Providers))
+	for provider, pc := range config.Providers {
+		enabled := pc == nil || pc.Enabled
+		old, known := r.config.Providers[provider]
+		current, tracked := r.providerStatus[provider]
+		if known && tracked && (old == nil || old.Enabled) == enabled {
+			status[provider] = current
+		} else {
+			status[provider] = enabled
+		}
+	}
+
 	r.config = config
+	r.providerStatus = status
 }
 
 // GetConfig returns the current configuration.
@@ -286,16 +752,6 @@ func contains(slice []string, item string) bool {
 	return false
 }
 
-// hasPrefix checks if a string has any of the given prefixes.
-func hasPrefix(s string, prefixes ...string) bool {
-	for _, p := range prefixes {
-		if len(s) >= len(p) && s[:len(p)] == p {
-			return true
-		}
-	}
-	return false
-}
-
 // QuickRoute is a convenience function for simple routing.
 func QuickRoute(role string) (string, error) {
 	config := DefaultCouncilConfig()
@@ -0,0 +1,424 @@
+package council
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestRouter_WithHealthSnapshot(t *testing.T) {
+	router := NewRouter(DefaultCouncilConfig()).WithHealthSnapshot(map[string]bool{
+		"anthropic": false,
+		"openai":    true,
+	})
+
+	for _, role := range []string{"mayor", "polecat", "refinery", "witness", "deacon"} {
+		result, err := router.Route(&RouteRequest{Role: role})
+		if err != nil {
+			t.Fatalf("Route(%s) failed: %v", role, err)
+		}
+		if result.Provider == "anthropic" {
+			t.Errorf("Route(%s) chose %s from anthropic, which the snapshot marked down", role, result.Model)
+		}
+	}
+
+	if !router.GetProviderStatus("google") {
+		t.Error("provider missing from snapshot should keep its configured status")
+	}
+}
+
+func TestHealthSnapshot(t *testing.T) {
+	snapshot := HealthSnapshot(map[string]*ProviderHealth{
+		"anthropic": {Provider: "anthropic", Available: true},
+		"openai":    {Provider: "openai", Available: false},
+		"google":    nil,
+	})
+
+	want := map[string]bool{"anthropic": true, "openai": false, "google": false}
+	for provider, available := range want {
+		if snapshot[provider] != available {
+			t.Errorf("snapshot[%s] = %v, want %v", provider, snapshot[provider], available)
+		}
+	}
+}
+
+func TestRouter_RouteSmart(t *testing.T) {
+	cfg := DefaultCouncilConfig()
+	cfg.Roles["polecat"].Model = "sonnet-4.5"
+	cfg.Roles["polecat"].Fallback = []string{"gpt-5.2"}
+
+	metrics := &Metrics{}
+	for i := 0; i < 10; i++ {
+		metrics.addTask(TaskMetric{Role: "polecat", Model: "sonnet-4.5", Provider: "anthropic", Success: i < 3})
+		metrics.addTask(TaskMetric{Role: "polecat", Model: "gpt-5.2", Provider: "openai", Success: true})
+	}
+
+	router := NewRouter(cfg).WithAvailability(ProviderAvailability(metrics))
+	result, err := router.RouteSmart(&RouteRequest{Role: "polecat"})
+	if err != nil {
+		t.Fatalf("RouteSmart failed: %v", err)
+	}
+
+	if result.Model != "gpt-5.2" {
+		t.Errorf("Model = %q, want gpt-5.2 (openai 100%% vs anthropic 30%%)", result.Model)
+	}
+	if !result.Fallback || !strings.Contains(result.Rationale, "availability") {
+		t.Errorf("expected reordering recorded in rationale, got %+v", result)
+	}
+
+	// Without availability data the configured order is kept.
+	result, err = NewRouter(cfg).RouteSmart(&RouteRequest{Role: "polecat"})
+	if err != nil {
+		t.Fatalf("RouteSmart failed: %v", err)
+	}
+	if result.Model != "sonnet-4.5" || result.Fallback {
+		t.Errorf("without availability data, got %+v, want primary sonnet-4.5", result)
+	}
+}
+
+func TestRouter_EnableAudit(t *testing.T) {
+	path := filepath.Join(t.TempDir(), ".beads", RouteAuditFileName)
+	router := NewRouter(DefaultCouncilConfig())
+	if err := router.EnableAudit(path); err != nil {
+		t.Fatalf("EnableAudit failed: %v", err)
+	}
+
+	roles := []string{"mayor", "polecat"}
+	for _, role := range roles {
+		if _, err := router.Route(&RouteRequest{Role: role, Task: &TaskInfo{FilesAffected: 12, LinesChanged: 600, IsArchitectural: true}}); err != nil {
+			t.Fatalf("Route(%s) failed: %v", role, err)
+		}
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatalf("opening audit log: %v", err)
+	}
+	defer f.Close()
+
+	var lines []map[string]any
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var line map[string]any
+		if err := json.Unmarshal(scanner.Bytes(), &line); err != nil {
+			t.Fatalf("audit line %q is not valid JSON: %v", scanner.Text(), err)
+		}
+		lines = append(lines, line)
+	}
+	if len(lines) != len(roles) {
+		t.Fatalf("audit log has %d lines, want %d", len(lines), len(roles))
+	}
+
+	for i, line := range lines {
+		result, _ := line["result"].(map[string]any)
+		request, _ := line["request"].(map[string]any)
+		if line["timestamp"] == nil || request == nil || result == nil {
+			t.Fatalf("audit line %d missing fields: %v", i, line)
+		}
+		if request["role"] != roles[i] || result["request_role"] != roles[i] {
+			t.Errorf("audit line %d role = %v/%v, want %s", i, request["role"], result["request_role"], roles[i])
+		}
+		if result["complexity"] != "high" {
+			t.Errorf("audit line %d complexity = %v, want \"high\"", i, result["complexity"])
+		}
+		if result["model"] == "" || result["provider"] == "" {
+			t.Errorf("audit line %d missing model/provider: %v", i, result)
+		}
+	}
+}
+
+func TestRouter_ReloadConfigReconcilesProviders(t *testing.T) {
+	cfg := DefaultCouncilConfig()
+	router := NewRouter(cfg)
+	router.SetProviderStatus("openai", false) // runtime status, e.g. from a health check
+
+	reloaded := DefaultCouncilConfig()
+	delete(reloaded.Providers, "google")
+	reloaded.Providers["xai"] = &ProviderConfig{Enabled: true, Models: []string{"grok"}}
+	reloaded.Roles["witness"] = &RoleConfig{Model: "grok"}
+	router.ReloadConfig(reloaded)
+
+	result, err := router.Route(&RouteRequest{Role: "witness"})
+	if err != nil {
+		t.Fatalf("Route failed: %v", err)
+	}
+	if result.Model != "grok" || result.Fallback {
+		t.Errorf("Route(witness) = %s (fallback=%v), want newly added grok", result.Model, result.Fallback)
+	}
+
+	if router.GetProviderStatus("openai") {
+		t.Error("unchanged provider lost its runtime status")
+	}
+	router.mu.RLock()
+	_, hasGoogle := router.providerStatus["google"]
+	router.mu.RUnlock()
+	if hasGoogle {
+		t.Error("removed provider still has a status entry")
+	}
+	if _, ok := router.modelAvailable(&RouteRequest{Role: "witness"}, "gemini-3-flash"); ok {
+		t.Error("a removed provider's model should be unavailable")
+	}
+	if _, ok := router.modelAvailable(&RouteRequest{Role: "crew"}, "auto"); !ok {
+		t.Error("auto has no provider and should stay available")
+	}
+}
+
+func TestRouter_MaxFallbackDepthCountsAttempts(t *testing.T) {
+	cfg := DefaultCouncilConfig()
+	cfg.MaxFallbackDepth = 1
+	router := NewRouter(cfg)
+
+	// Excluded and unavailable fallbacks don't use up the depth.
+	router.SetProviderStatus("anthropic", false)
+	result, err := router.Route(&RouteRequest{Role: "mayor"})
+	if err != nil {
+		t.Fatalf("Route failed: %v", err)
+	}
+	if result.Model != "gpt-5.2-high" {
+		t.Errorf("route = %s, want gpt-5.2-high past the unavailable sonnet-4.5", result.Model)
+	}
+
+	// The emergency loop counts the same way: excluded models are free,
+	// the first usable one is the single allowed attempt.
+	result, err = router.Route(&RouteRequest{Role: "mayor", ExcludeModels: []string{"gpt-5.2-high"}})
+	if err != nil {
+		t.Fatalf("Route failed: %v", err)
+	}
+	if !result.Fallback || result.Provider == "anthropic" || result.Model == "gpt-5.2-high" {
+		t.Errorf("route = %+v, want an emergency fallback off anthropic", result)
+	}
+
+	// Once a usable candidate is passed over, the depth is spent.
+	_, err = router.Route(&RouteRequest{Role: "mayor", MaxCostPerTask: 0.0001})
+	var routeErr *RouteError
+	if !errors.As(err, &routeErr) || routeErr.Reason != FallbackExhausted {
+		t.Errorf("err = %v, want FallbackExhausted", err)
+	}
+}
+
+func TestRouter_Substitutions(t *testing.T) {
+	router := NewRouter(DefaultCouncilConfig())
+	subs := map[string]Substitution{"opus-4.5-thinking": {Model: "gpt-5.2-high", Fraction: 0.1}}
+
+	const requests = 2000
+	substituted := 0
+	for i := 0; i < requests; i++ {
+		req := &RouteRequest{Role: "mayor", RequestID: fmt.Sprintf("req-%d", i), Substitutions: subs}
+		result, err := router.Route(req)
+		if err != nil {
+			t.Fatalf("Route failed: %v", err)
+		}
+		if result.OriginalModel == "" {
+			if result.Model != "opus-4.5-thinking" {
+				t.Fatalf("control arm model = %s, want opus-4.5-thinking", result.Model)
+			}
+			continue
+		}
+		substituted++
+		if result.OriginalModel != "opus-4.5-thinking" || result.Model != "gpt-5.2-high" || result.Provider != "openai" {
+			t.Fatalf("substituted result = %+v, want opus-4.5-thinking -> gpt-5.2-high", result)
+		}
+
+		// Bucketing is deterministic per request.
+		again, _ := router.Route(req)
+		if again.Model != result.Model {
+			t.Fatal("same request landed in a different arm")
+		}
+	}
+
+	if rate := float64(substituted) / requests; rate < 0.07 || rate > 0.13 {
+		t.Errorf("substitution rate = %.3f, want ~0.10", rate)
+	}
+}
+
+func TestRouter_ProviderPin(t *testing.T) {
+	cfg := DefaultCouncilConfig()
+	cfg.Providers["gateway"] = &ProviderConfig{Enabled: true, Models: []string{"sonnet-4.5"}}
+	cfg.Roles["polecat"].Model = "sonnet-4.5"
+	cfg.Roles["polecat"].Provider = "gateway"
+	if issues := ValidateConfig(cfg); len(issues) != 0 {
+		t.Fatalf("pinned config should be valid, got %v", issues)
+	}
+
+	router := NewRouter(cfg)
+	result, err := router.Route(&RouteRequest{Role: "polecat"})
+	if err != nil {
+		t.Fatalf("Route failed: %v", err)
+	}
+	if result.Model != "sonnet-4.5" || result.Provider != "gateway" {
+		t.Errorf("route = %s via %s, want sonnet-4.5 via the pinned gateway", result.Model, result.Provider)
+	}
+
+	// Other roles still attribute sonnet-4.5 by name.
+	if got := cfg.ProviderFor("mayor", "sonnet-4.5"); got != "anthropic" {
+		t.Errorf("unpinned ProviderFor = %s, want anthropic", got)
+	}
+
+	// A down gateway fails the pinned model over, even though anthropic is up.
+	router.SetProviderStatus("gateway", false)
+	result, err = router.Route(&RouteRequest{Role: "polecat"})
+	if err != nil {
+		t.Fatalf("Route failed: %v", err)
+	}
+	if result.Model == "sonnet-4.5" || !result.Fallback {
+		t.Errorf("route = %+v, want a fallback away from the down gateway", result)
+	}
+}
+
+func TestRouter_CostCeiling(t *testing.T) {
+	cfg := DefaultCouncilConfig()
+	cfg.ModelCost = map[string]float64{}
+	for _, pc := range cfg.Providers {
+		for _, m := range pc.Models {
+			cfg.ModelCost[m] = 20
+		}
+	}
+	cfg.ModelCost["sonnet-4.5"] = 15    // $0.15 at DefaultTaskTokens
+	cfg.ModelCost["gpt-5.2"] = 10       // $0.10
+	cfg.ModelCost["gemini-3-flash"] = 1 // $0.01
+	router := NewRouter(cfg)
+
+	// Under the ceiling the primary model is kept.
+	result, err := router.Route(&RouteRequest{Role: "polecat", MaxCostPerTask: 0.20})
+	if err != nil {
+		t.Fatalf("Route failed: %v", err)
+	}
+	if result.Model != "sonnet-4.5" || result.Fallback {
+		t.Errorf("route = %+v, want sonnet-4.5 without fallback", result)
+	}
+	if result.EstimatedCost < 0.149 || result.EstimatedCost > 0.151 {
+		t.Errorf("EstimatedCost = %v, want 0.15", result.EstimatedCost)
+	}
+
+	// Over the ceiling, the next-cheapest fallback that fits is chosen.
+	result, err = router.Route(&RouteRequest{Role: "polecat", MaxCostPerTask: 0.12})
+	if err != nil {
+		t.Fatalf("Route failed: %v", err)
+	}
+	if result.Model != "gpt-5.2" || !result.Fallback {
+		t.Errorf("route = %+v, want a fallback to gpt-5.2", result)
+	}
+	if !strings.Contains(result.FallbackReason, "cost ceiling") {
+		t.Errorf("FallbackReason = %q, want it to mention the cost ceiling", result.FallbackReason)
+	}
+
+	// A larger task pushes gpt-5.2 over too.
+	result, err = router.Route(&RouteRequest{Role: "polecat", MaxCostPerTask: 0.12, EstimatedTokens: 20000})
+	if err != nil {
+		t.Fatalf("Route failed: %v", err)
+	}
+	if result.Model != "gemini-3-flash" {
+		t.Errorf("route = %s, want gemini-3-flash", result.Model)
+	}
+
+	// Nothing fits: a distinct error.
+	_, err = router.Route(&RouteRequest{Role: "polecat", MaxCostPerTask: 0.001})
+	if !errors.Is(err, ErrCostCeiling) {
+		t.Errorf("err = %v, want ErrCostCeiling", err)
+	}
+
+	// An A/B challenger over the ceiling is not substituted in.
+	cfg.ModelCost["opus-4.5"] = 20 // $0.20
+	subs := map[string]Substitution{"sonnet-4.5": {Model: "opus-4.5", Fraction: 1}}
+	result, err = router.Route(&RouteRequest{Role: "polecat", MaxCostPerTask: 0.16, Substitutions: subs})
+	if err != nil {
+		t.Fatalf("Route failed: %v", err)
+	}
+	if result.Model != "sonnet-4.5" || result.OriginalModel != "" {
+		t.Errorf("route = %s (from %q), want sonnet-4.5 without the over-budget substitution", result.Model, result.OriginalModel)
+	}
+
+	// Models without a ModelCost entry use the catalog price: opus-4.5
+	// lists $5 in and $25 out, a $15 blend.
+	if cost, ok := (&Config{}).EstimateCost("opus-4.5", 1_000_000); !ok || cost != 15 {
+		t.Errorf("catalog EstimateCost = %v, %v; want 15, true", cost, ok)
+	}
+	if _, ok := (&Config{}).EstimateCost("grok", 1_000_000); ok {
+		t.Error("a model priced nowhere should report false")
+	}
+}
+
+func TestRouter_SubstituteDuringReload(t *testing.T) {
+	router := NewRouter(DefaultCouncilConfig())
+	subs := map[string]Substitution{"sonnet-4.5": {Model: "opus-4.5", Fraction: 1}}
+
+	// Run with -race: substitution's budget check must not read the config
+	// while ReloadConfig replaces it.
+	done := make(chan struct{})
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for {
+			select {
+			case <-done:
+				return
+			default:
+				router.ReloadConfig(DefaultCouncilConfig())
+			}
+		}
+	}()
+	for i := 0; i < 200; i++ {
+		if _, err := router.Route(&RouteRequest{Role: "polecat", MaxCostPerTask: 1, Substitutions: subs}); err != nil {
+			t.Errorf("Route failed: %v", err)
+		}
+	}
+	close(done)
+	wg.Wait()
+}
+
+func TestRouter_Explain(t *testing.T) {
+	router := NewRouter(DefaultCouncilConfig())
+	req := &RouteRequest{
+		Role: "polecat",
+		Task: &TaskInfo{FilesAffected: 12, LinesChanged: 800, IsArchitectural: true},
+	}
+
+	narrative, err := router.Explain(req)
+	if err != nil {
+		t.Fatalf("Explain failed: %v", err)
+	}
+	for _, want := range []string{"opus-4.5", "high-complexity", "12 files", "architectural", "anthropic is healthy"} {
+		if !strings.Contains(narrative, want) {
+			t.Errorf("narrative missing %q:\n%s", want, narrative)
+		}
+	}
+
+	// Recent availability is banded rather than always called healthy.
+	router.WithAvailability(map[string]float64{"anthropic": 0.97, "openai": 0.7, "google": 0.12})
+	for provider, want := range map[string]string{
+		"anthropic": "anthropic is healthy (97% recent success)",
+		"openai":    "openai is degraded (70% recent success)",
+		"google":    "google is unhealthy (12% recent success)",
+	} {
+		if got := router.explainProvider(provider); got != want {
+			t.Errorf("explainProvider(%s) = %q, want %q", provider, got, want)
+		}
+	}
+	router.WithAvailability(nil)
+
+	// Explain agrees with Route.
+	result, err := router.Route(req)
+	if err != nil {
+		t.Fatalf("Route failed: %v", err)
+	}
+	if !strings.Contains(narrative, "to "+result.Model+" ") {
+		t.Errorf("narrative names a different model than Route chose (%s):\n%s", result.Model, narrative)
+	}
+
+	// A down provider shows up as a fallback and as skipped.
+	router.SetProviderStatus("anthropic", false)
+	narrative, err = router.Explain(req)
+	if err != nil {
+		t.Fatalf("Explain failed: %v", err)
+	}
+	if !strings.Contains(narrative, "fallback") || !strings.Contains(narrative, "anthropic (unavailable)") {
+		t.Errorf("narrative should explain the fallback around anthropic:\n%s", narrative)
+	}
+}
@@ -0,0 +1,32 @@
+// Package council provides multi-model orchestration for Gas Town.
+package council
+
+import (
+	"regexp"
+)
+
+// redacted replaces secret values in sanitized errors.
+const redacted = "[REDACTED]"
+
+// secretPatterns match common credential shapes in provider error messages.
+// Each pattern keeps its non-secret prefix in group 1.
+var secretPatterns = []*regexp.Regexp{
+	regexp.MustCompile(`(?i)(bearer\s+)[A-Za-z0-9._~+/=-]+`),
+	regexp.MustCompile(`\b(sk-)[A-Za-z0-9_-]{8,}`),
+	regexp.MustCompile(`\b(AIza)[A-Za-z0-9_-]{20,}`),
+	regexp.MustCompile(`(://)[^/\s:@]+:[^/\s@]+(@)`),
+	regexp.MustCompile(`(?i)((?:api[_-]?key|access[_-]?token|token|secret|password)["']?\s*[:=]\s*["']?)[^\s"'&,;]+`),
+}
+
+// sanitizeError redacts API keys, bearer tokens, and URL credentials from
+// an error message before it is persisted to metrics or logged.
+func sanitizeError(msg string) string {
+	for _, re := range secretPatterns {
+		if re.NumSubexp() == 2 {
+			msg = re.ReplaceAllString(msg, "${1}"+redacted+"${2}")
+			continue
+		}
+		msg = re.ReplaceAllString(msg, "${1}"+redacted)
+	}
+	return msg
+}
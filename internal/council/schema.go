@@ -0,0 +1,117 @@
+package council
+
+import (
+	"reflect"
+	"strings"
+	"time"
+)
+
+// SchemaURI is the JSON Schema dialect ConfigSchema emits.
+const SchemaURI = "https://json-schema.org/draft/2020-12/schema"
+
+// ConfigSchema returns a JSON Schema describing Config, for editor
+// validation and autocompletion of council.json (TOML editors that accept
+// JSON Schema can use it too, since the TOML keys match the JSON names).
+//
+// The schema is generated from Config's json tags, so it never drifts from
+// the struct: each struct type becomes a $defs entry that rejects unknown
+// keys, and maps allow any key.
+func ConfigSchema() map[string]any {
+	g := &schemaGen{defs: make(map[string]any)}
+	root := g.schemaFor(reflect.TypeOf(Config{}))
+
+	// Let a config file point at this schema itself.
+	props := g.defs["Config"].(map[string]any)["properties"].(map[string]any)
+	props["$schema"] = map[string]any{"type": "string"}
+
+	return map[string]any{
+		"$schema": SchemaURI,
+		"title":   "Gas Town council config",
+		"$ref":    root["$ref"],
+		"$defs":   g.defs,
+	}
+}
+
+// schemaGen builds schemas, collecting struct definitions as it goes.
+type schemaGen struct {
+	defs map[string]any
+}
+
+var (
+	durationType = reflect.TypeOf(time.Duration(0))
+	timeType     = reflect.TypeOf(time.Time{})
+)
+
+func (g *schemaGen) schemaFor(t reflect.Type) map[string]any {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	switch {
+	case t == durationType:
+		return map[string]any{"type": "integer", "description": "duration in nanoseconds"}
+	case t == timeType:
+		return map[string]any{"type": "string", "format": "date-time"}
+	}
+
+	switch t.Kind() {
+	case reflect.String:
+		return map[string]any{"type": "string"}
+	case reflect.Bool:
+		return map[string]any{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return map[string]any{"type": "integer"}
+	case reflect.Float32, reflect.Float64:
+		return map[string]any{"type": "number"}
+	case reflect.Slice, reflect.Array:
+		return map[string]any{"type": "array", "items": g.schemaFor(t.Elem())}
+	case reflect.Map:
+		return map[string]any{"type": "object", "additionalProperties": g.schemaFor(t.Elem())}
+	case reflect.Struct:
+		return g.structRef(t)
+	default:
+		return map[string]any{}
+	}
+}
+
+// structRef defines t under $defs (once) and returns a reference to it.
+func (g *schemaGen) structRef(t reflect.Type) map[string]any {
+	ref := map[string]any{"$ref": "#/$defs/" + t.Name()}
+	if _, ok := g.defs[t.Name()]; ok {
+		return ref
+	}
+
+	props := make(map[string]any)
+	def := map[string]any{
+		"type":                 "object",
+		"properties":           props,
+		"additionalProperties": false,
+	}
+	g.defs[t.Name()] = def // before recursing, so cycles terminate
+
+	for _, f := range reflect.VisibleFields(t) {
+		name, ok := schemaFieldName(f)
+		if !ok {
+			continue
+		}
+		props[name] = g.schemaFor(f.Type)
+	}
+	return ref
+}
+
+// schemaFieldName returns the JSON key of an exported field, or false for
+// fields JSON skips.
+func schemaFieldName(f reflect.StructField) (string, bool) {
+	if !f.IsExported() || f.Anonymous {
+		return "", false
+	}
+	tag := f.Tag.Get("json")
+	if tag == "-" {
+		return "", false
+	}
+	if name, _, _ := strings.Cut(tag, ","); name != "" {
+		return name, true
+	}
+	return f.Name, true
+}
@@ -0,0 +1,53 @@
+package council
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestConfigSchema_CoversEveryField(t *testing.T) {
+	schema := ConfigSchema()
+	if _, err := json.Marshal(schema); err != nil {
+		t.Fatalf("schema does not marshal: %v", err)
+	}
+	defs := schema["$defs"].(map[string]any)
+
+	seen := make(map[reflect.Type]bool)
+	var check func(reflect.Type)
+	check = func(typ reflect.Type) {
+		for typ.Kind() == reflect.Pointer || typ.Kind() == reflect.Slice || typ.Kind() == reflect.Map {
+			typ = typ.Elem()
+		}
+		if typ.Kind() != reflect.Struct || typ == timeType || seen[typ] {
+			return
+		}
+		seen[typ] = true
+
+		def, ok := defs[typ.Name()].(map[string]any)
+		if !ok {
+			t.Errorf("schema has no definition for %s", typ.Name())
+			return
+		}
+		props := def["properties"].(map[string]any)
+		for _, f := range reflect.VisibleFields(typ) {
+			name, ok := schemaFieldName(f)
+			if !ok {
+				continue
+			}
+			if _, ok := props[name]; !ok {
+				t.Errorf("schema for %s is missing field %s (%q)", typ.Name(), f.Name, name)
+			}
+			check(f.Type)
+		}
+	}
+	check(reflect.TypeOf(Config{}))
+
+	role := defs["RoleConfig"].(map[string]any)["properties"].(map[string]any)
+	if got := role["min_confidence"]; !reflect.DeepEqual(got, map[string]any{"type": "number"}) {
+		t.Errorf("min_confidence schema = %v, want number", got)
+	}
+	if _, ok := defs["Config"].(map[string]any)["properties"].(map[string]any)["$schema"]; !ok {
+		t.Error("config schema should allow a $schema key")
+	}
+}
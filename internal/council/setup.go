@@ -0,0 +1,174 @@
+// Package council provides multi-model orchestration for Gas Town.
+package council
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+)
+
+// SetupAnswers holds the responses collected by the setup wizard.
+type SetupAnswers struct {
+	// Priority is "cost", "quality", or "balanced".
+	Priority string
+
+	// Providers lists the providers the user has access to.
+	Providers []string
+
+	// MonthlyBudget is the approximate monthly spend limit in USD (0 = no limit).
+	MonthlyBudget float64
+}
+
+// Budget thresholds used by RecommendProfile.
+const (
+	// LowBudget is the monthly spend below which cost is always prioritized.
+	LowBudget = 100.0
+
+	// HighBudget is the monthly spend at or above which quality-focused is affordable.
+	HighBudget = 500.0
+)
+
+// RecommendProfile picks the predefined profile that best fits the answers.
+// Single-provider setups get that provider's profile; otherwise priority
+// and budget choose between cost-optimized, balanced, and quality-focused.
+func RecommendProfile(answers SetupAnswers) string {
+	if len(answers.Providers) == 1 {
+		if name := answers.Providers[0] + "-only"; PredefinedProfiles[name] != nil {
+			return name
+		}
+	}
+
+	lowBudget := answers.MonthlyBudget > 0 && answers.MonthlyBudget < LowBudget
+	highBudget := answers.MonthlyBudget == 0 || answers.MonthlyBudget >= HighBudget
+
+	switch {
+	case answers.Priority == "cost" || lowBudget:
+		return "cost-optimized"
+	case answers.Priority == "quality" && highBudget:
+		return "quality-focused"
+	default:
+		return "balanced"
+	}
+}
+
+// DetectProviders returns the providers whose API key environment variable
+// is set, sorted by name.
+func DetectProviders() []string {
+	var providers []string
+	for provider, env := range ProviderAPIKeyEnv {
+		if os.Getenv(env) != "" {
+			providers = append(providers, provider)
+		}
+	}
+	sort.Strings(providers)
+	return providers
+}
+
+// Clone returns a deep copy of the config. It fails only if the config
+// can't be encoded, e.g. a cost that is NaN or infinite.
+func (c *Config) Clone() (*Config, error) {
+	data, err := json.Marshal(c)
+	if err != nil {
+		return nil, fmt.Errorf("copying council config: %w", err)
+	}
+	var clone Config
+	if err := json.Unmarshal(data, &clone); err != nil {
+		return nil, fmt.Errorf("copying council config: %w", err)
+	}
+	return &clone, nil
+}
+
+// PruneProviders removes providers not in keep from the config, along with
+// fallback entries and provider pins that point at them. A role (or the
+// defaults) whose primary model is served by a pruned provider is switched
+// to its first remaining fallback; if it has none, PruneProviders returns
+// an error and leaves the config unchanged. Remaining providers' priorities
+// are then normalized.
+func (c *Config) PruneProviders(keep []string) error {
+	if len(keep) == 0 {
+		return nil
+	}
+	allowed := make(map[string]bool, len(keep))
+	for _, p := range keep {
+		allowed[p] = true
+	}
+	// Models with no known provider (e.g. "auto") are never pruned.
+	kept := func(provider string) bool {
+		return provider == "unknown" || allowed[provider]
+	}
+	filter := func(role string, models []string) []string {
+		var out []string
+		for _, m := range models {
+			if kept(c.ProviderFor(role, m)) {
+				out = append(out, m)
+			}
+		}
+		return out
+	}
+
+	// Work out every rewrite before changing anything, so a role that
+	// can't be rewritten leaves the config intact.
+	type rewrite struct {
+		model    string
+		fallback []string
+	}
+	rewrites := make(map[string]rewrite)
+	roles := make([]string, 0, len(c.Roles))
+	for role := range c.Roles {
+		roles = append(roles, role)
+	}
+	sort.Strings(roles)
+	for _, role := range roles {
+		rc := c.Roles[role]
+		if rc == nil {
+			continue
+		}
+		rw := rewrite{model: rc.Model, fallback: filter(role, rc.Fallback)}
+		if rc.Model != "" && !kept(c.ProviderFor(role, rc.Model)) {
+			if len(rw.fallback) == 0 {
+				return fmt.Errorf("role %s: model %s is served by pruned provider %s and no fallback remains",
+					role, rc.Model, c.ProviderFor(role, rc.Model))
+			}
+			rw.model, rw.fallback = rw.fallback[0], rw.fallback[1:]
+		}
+		rewrites[role] = rw
+	}
+	var defaults rewrite
+	if d := c.Defaults; d != nil {
+		defaults = rewrite{model: d.Model, fallback: filter("", d.Fallback)}
+		if d.Model != "" && !kept(ModelProvider(d.Model)) {
+			if len(defaults.fallback) == 0 {
+				return fmt.Errorf("defaults: model %s is served by pruned provider %s and no fallback remains",
+					d.Model, ModelProvider(d.Model))
+			}
+			defaults.model, defaults.fallback = defaults.fallback[0], defaults.fallback[1:]
+		}
+	}
+
+	for role, rw := range rewrites {
+		rc := c.Roles[role]
+		rc.Model, rc.Fallback = rw.model, rw.fallback
+		if cx := rc.Complexity; cx != nil {
+			// An empty tier falls back to the role's model.
+			for _, tier := range []*string{&cx.High, &cx.Medium, &cx.Low} {
+				if *tier != "" && !kept(c.ProviderFor(role, *tier)) {
+					*tier = ""
+				}
+			}
+		}
+		if rc.Provider != "" && !allowed[rc.Provider] {
+			rc.Provider = ""
+		}
+	}
+	if c.Defaults != nil {
+		c.Defaults.Model, c.Defaults.Fallback = defaults.model, defaults.fallback
+	}
+	for name := range c.Providers {
+		if !allowed[name] {
+			delete(c.Providers, name)
+		}
+	}
+	c.NormalizePriorities()
+	return nil
+}
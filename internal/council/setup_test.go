@@ -0,0 +1,109 @@
+package council
+
+import (
+	"math"
+	"strings"
+	"testing"
+)
+
+func TestRecommendProfile(t *testing.T) {
+	tests := []struct {
+		name    string
+		answers SetupAnswers
+		want    string
+	}{
+		{"single provider", SetupAnswers{Priority: "quality", Providers: []string{"openai"}}, "openai-only"},
+		{"cost priority", SetupAnswers{Priority: "cost", Providers: []string{"anthropic", "google"}}, "cost-optimized"},
+		{"low budget overrides quality", SetupAnswers{Priority: "quality", Providers: []string{"anthropic", "openai"}, MonthlyBudget: 50}, "cost-optimized"},
+		{"quality with high budget", SetupAnswers{Priority: "quality", Providers: []string{"anthropic", "openai"}, MonthlyBudget: 1000}, "quality-focused"},
+		{"quality with mid budget", SetupAnswers{Priority: "quality", Providers: []string{"anthropic", "openai"}, MonthlyBudget: 200}, "balanced"},
+		{"balanced", SetupAnswers{Priority: "balanced", Providers: []string{"anthropic", "openai", "google"}}, "balanced"},
+		{"no providers detected", SetupAnswers{Priority: "quality"}, "quality-focused"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := RecommendProfile(tt.answers); got != tt.want {
+				t.Errorf("RecommendProfile(%+v) = %q, want %q", tt.answers, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestConfig_PruneProviders(t *testing.T) {
+	cfg := DefaultCouncilConfig()
+	cfg.Roles["polecat"].Fallback = []string{"gpt-5.2", "gemini-3-pro"}
+
+	if err := cfg.PruneProviders([]string{"anthropic", "openai"}); err != nil {
+		t.Fatalf("PruneProviders failed: %v", err)
+	}
+
+	if _, ok := cfg.Providers["google"]; ok {
+		t.Error("google provider should be pruned")
+	}
+	for _, fb := range cfg.Roles["polecat"].Fallback {
+		if ModelProvider(fb) == "google" {
+			t.Errorf("fallback %s should be pruned", fb)
+		}
+	}
+	if DefaultCouncilConfig().Providers["google"] == nil {
+		t.Error("pruning a clone must not affect the source config")
+	}
+}
+
+func TestConfig_PruneProviders_RewritesReferences(t *testing.T) {
+	cfg := DefaultCouncilConfig()
+	cfg.Roles["witness"].Model = "gemini-3-flash"
+	cfg.Roles["witness"].Fallback = []string{"gemini-3-pro", "haiku-3.5", "gpt-5.2"}
+	cfg.Roles["witness"].Provider = "google"
+	cfg.Roles["polecat"].ComplexityRouting = true
+	cfg.Roles["polecat"].Complexity = &ComplexityConfig{High: "gemini-3-pro", Low: "haiku-3.5"}
+
+	if err := cfg.PruneProviders([]string{"anthropic", "openai"}); err != nil {
+		t.Fatalf("PruneProviders failed: %v", err)
+	}
+	w := cfg.Roles["witness"]
+	if w.Model != "haiku-3.5" || strings.Join(w.Fallback, ",") != "gpt-5.2" || w.Provider != "" {
+		t.Errorf("witness = %s %v pinned %q, want haiku-3.5 [gpt-5.2] unpinned", w.Model, w.Fallback, w.Provider)
+	}
+	if cx := cfg.Roles["polecat"].Complexity; cx.High != "" || cx.Low != "haiku-3.5" {
+		t.Errorf("complexity = %+v, want the google tier cleared", cx)
+	}
+	if p := cfg.Providers; p["anthropic"].Priority == p["openai"].Priority {
+		t.Error("priorities should be normalized after pruning")
+	}
+	for model, owners := range cfg.modelReferences() {
+		if ModelProvider(model) == "google" {
+			t.Errorf("%v still reference pruned model %s", owners, model)
+		}
+	}
+
+	// A role left with no model from a kept provider is an error, and the
+	// config is not touched.
+	cfg = DefaultCouncilConfig()
+	cfg.Roles["witness"].Model = "gemini-3-flash"
+	cfg.Roles["witness"].Fallback = []string{"gemini-3-pro"}
+	if err := cfg.PruneProviders([]string{"anthropic"}); err == nil || !strings.Contains(err.Error(), "witness") {
+		t.Errorf("err = %v, want the witness role named", err)
+	}
+	if cfg.Providers["google"] == nil || cfg.Roles["witness"].Model != "gemini-3-flash" {
+		t.Error("a failed prune must leave the config unchanged")
+	}
+}
+
+func TestConfig_Clone(t *testing.T) {
+	cfg := DefaultCouncilConfig()
+	clone, err := cfg.Clone()
+	if err != nil {
+		t.Fatalf("Clone failed: %v", err)
+	}
+	clone.Roles["polecat"].Model = "gpt-5.2"
+	if cfg.Roles["polecat"].Model == "gpt-5.2" {
+		t.Error("Clone shares role configs with the original")
+	}
+
+	cfg.ModelCost = map[string]float64{"sonnet-4.5": math.NaN()}
+	if _, err := cfg.Clone(); err == nil {
+		t.Error("Clone of an unencodable config should fail")
+	}
+}
@@ -0,0 +1,90 @@
+// Package council provides multi-model orchestration for Gas Town.
+package council
+
+import (
+	"math"
+	"unicode/utf8"
+
+	"github.com/cursorworkshop/cursor-gastown/internal/cursor"
+)
+
+// DefaultCharsPerToken is the characters-per-token ratio used for model
+// families without a specific entry in CharsPerToken.
+const DefaultCharsPerToken = 4.0
+
+// CharsPerToken maps a provider family to its approximate characters per
+// token. These are rough averages for English prose and code; adjust them
+// if estimates drift from provider-reported usage.
+var CharsPerToken = map[string]float64{
+	"anthropic": 3.5,
+	"openai":    4.0,
+	"google":    4.0,
+}
+
+// EstimateTokens estimates the number of tokens in text for the given model.
+// It is a heuristic based on the model family's characters-per-token ratio,
+// intended for cost estimates when the provider doesn't report usage.
+func EstimateTokens(text string, model string) int {
+	if text == "" {
+		return 0
+	}
+
+	ratio, ok := CharsPerToken[ModelProvider(model)]
+	if !ok || ratio <= 0 {
+		ratio = DefaultCharsPerToken
+	}
+
+	return int(math.Ceil(float64(utf8.RuneCountInString(text)) / ratio))
+}
+
+// DefaultTaskTokens is the token estimate used for cost ceilings when a
+// RouteRequest doesn't set EstimatedTokens.
+const DefaultTaskTokens = 10000
+
+// EstimateCost returns the estimated dollar cost of tokens on model. A
+// ModelCost entry takes precedence; otherwise the catalog's list price
+// from ModelPrices is used, charging the unsplit tokens the mean of its
+// input and output rates. It reports false when neither prices the model.
+func (c *Config) EstimateCost(model string, tokens int) (float64, bool) {
+	if price, ok := c.ModelCost[model]; ok {
+		return price * float64(tokens) / 1e6, true
+	}
+	if price, ok := ModelPrices[model]; ok {
+		return (price.InputCostPer1M + price.OutputCostPer1M) / 2 * float64(tokens) / 1e6, true
+	}
+	return 0, false
+}
+
+// ModelPricing is a model's price in dollars per 1M input and output tokens.
+type ModelPricing struct {
+	InputCostPer1M  float64 `json:"input_cost_per_1m" toml:"input_cost_per_1m"`
+	OutputCostPer1M float64 `json:"output_cost_per_1m" toml:"output_cost_per_1m"`
+}
+
+// Cost returns the dollar cost of input and output tokens.
+func (p ModelPricing) Cost(input, output int64) float64 {
+	return (float64(input)*p.InputCostPer1M + float64(output)*p.OutputCostPer1M) / 1e6
+}
+
+// SavingsBaselineModel is the model Summary measures cost savings against.
+const SavingsBaselineModel = "opus-4.5"
+
+// ModelPrices maps known models, and their aliases, to their approximate
+// list prices from the model catalog. Adjust them there as providers
+// change pricing.
+var ModelPrices = catalogPrices()
+
+// catalogPrices collects the priced models in the cursor model catalog.
+func catalogPrices() map[string]ModelPricing {
+	prices := make(map[string]ModelPricing)
+	for _, m := range cursor.Catalog().Models {
+		if !m.Priced() {
+			continue
+		}
+		price := ModelPricing{InputCostPer1M: m.InputCostPer1M, OutputCostPer1M: m.OutputCostPer1M}
+		for _, name := range append([]string{m.Name}, m.Aliases...) {
+			prices[name] = price
+		}
+	}
+	return prices
+}
@@ -0,0 +1,39 @@
+package council
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestEstimateTokens_ScalesWithLength(t *testing.T) {
+	short := EstimateTokens(strings.Repeat("a", 400), "gpt-5.2")
+	long := EstimateTokens(strings.Repeat("a", 4000), "gpt-5.2")
+
+	if short != 100 {
+		t.Errorf("EstimateTokens(400 chars) = %d, want 100", short)
+	}
+	if long != 10*short {
+		t.Errorf("EstimateTokens(4000 chars) = %d, want %d", long, 10*short)
+	}
+	if got := EstimateTokens("", "gpt-5.2"); got != 0 {
+		t.Errorf("EstimateTokens(\"\") = %d, want 0", got)
+	}
+}
+
+func TestEstimateTokens_VariesByFamily(t *testing.T) {
+	text := strings.Repeat("x", 700)
+
+	anthropic := EstimateTokens(text, "sonnet-4.5")
+	openai := EstimateTokens(text, "gpt-5.2")
+	unknown := EstimateTokens(text, "mystery-model")
+
+	if anthropic != 200 {
+		t.Errorf("anthropic estimate = %d, want 200", anthropic)
+	}
+	if openai != 175 {
+		t.Errorf("openai estimate = %d, want 175", openai)
+	}
+	if unknown != openai {
+		t.Errorf("unknown family estimate = %d, want default ratio result %d", unknown, openai)
+	}
+}
@@ -0,0 +1,100 @@
+package council
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// regexTransform returns the first capture group of pattern's first match in
+// output, or the whole match when pattern has no groups. It returns "" when
+// nothing matches, and an error when pattern does not compile.
+func regexTransform(output, pattern string) (string, error) {
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return "", fmt.Errorf("invalid regex transform %q: %w", pattern, err)
+	}
+	m := re.FindStringSubmatch(output)
+	switch {
+	case m == nil:
+		return "", nil
+	case len(m) > 1:
+		return m[1], nil
+	default:
+		return m[0], nil
+	}
+}
+
+// jsonTransform extracts the value at path from a JSON answer. The answer
+// may be wrapped in a markdown code block. Strings are returned bare; other
+// values are re-encoded as JSON. It returns "" when the answer is not JSON or
+// the path does not exist.
+func jsonTransform(output, path string) string {
+	var doc any
+	if err := json.Unmarshal([]byte(strings.TrimSpace(output)), &doc); err != nil {
+		if err := json.Unmarshal([]byte(extractCodeBlocks(output)), &doc); err != nil {
+			return ""
+		}
+	}
+
+	value, ok := walkJSONPath(doc, path)
+	if !ok {
+		return ""
+	}
+	if s, ok := value.(string); ok {
+		return s
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		return ""
+	}
+	return string(data)
+}
+
+// walkJSONPath follows a dotted path such as "result.items.0.name" through
+// decoded JSON. Numeric segments index arrays; an empty path or "." selects
+// the whole document.
+func walkJSONPath(doc any, path string) (any, bool) {
+	path = strings.TrimPrefix(path, ".")
+	if path == "" {
+		return doc, true
+	}
+
+	current := doc
+	for _, key := range strings.Split(path, ".") {
+		switch node := current.(type) {
+		case map[string]any:
+			next, ok := node[key]
+			if !ok {
+				return nil, false
+			}
+			current = next
+		case []any:
+			i, err := strconv.Atoi(key)
+			if err != nil || i < 0 || i >= len(node) {
+				return nil, false
+			}
+			current = node[i]
+		default:
+			return nil, false
+		}
+	}
+	return current, true
+}
+
+// validTransform reports whether transform is one applyTransform supports.
+func validTransform(transform string) bool {
+	switch {
+	case transform == "extract_code", transform == "first_line", transform == "trim":
+		return true
+	case strings.HasPrefix(transform, "regex:"):
+		_, err := regexp.Compile(strings.TrimPrefix(transform, "regex:"))
+		return err == nil
+	case strings.HasPrefix(transform, "json:"):
+		return strings.TrimPrefix(transform, "json:") != ""
+	default:
+		return false
+	}
+}
@@ -0,0 +1,40 @@
+package council
+
+import "testing"
+
+func TestApplyTransform_RegexAndJSON(t *testing.T) {
+	answer := `{"verdict": {"decision": "approve", "scores": [0.9, 0.4]}, "notes": [{"text": "ship it"}]}`
+	fenced := "Here you go:\n```json\n" + answer + "\n```"
+
+	tests := []struct {
+		name      string
+		output    string
+		transform string
+		want      string
+	}{
+		{"nested json path", answer, "json:verdict.decision", "approve"},
+		{"json array index", answer, "json:notes.0.text", "ship it"},
+		{"json non-string value", answer, "json:verdict.scores", "[0.9,0.4]"},
+		{"json in code block", fenced, "json:verdict.decision", "approve"},
+		{"json missing path", answer, "json:verdict.reason", ""},
+		{"json index out of range", answer, "json:notes.3.text", ""},
+		{"json not json", "no json here", "json:verdict", ""},
+		{"regex capture group", "ANSWER: 42 apples", `regex:ANSWER:\s*(\d+)`, "42"},
+		{"regex whole match", "ANSWER: 42 apples", `regex:\d+`, "42"},
+		{"regex no match", "nothing", `regex:(\d+)`, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got, err := applyTransform(tt.output, tt.transform); err != nil || got != tt.want {
+				t.Errorf("applyTransform(%q) = %q, %v; want %q", tt.transform, got, err, tt.want)
+			}
+		})
+	}
+
+	for _, transform := range []string{"regex:([unclosed", "uppercase"} {
+		if _, err := applyTransform("keep me", transform); err == nil {
+			t.Errorf("applyTransform(%q) should fail", transform)
+		}
+	}
+}
@@ -0,0 +1,322 @@
+package council
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+// Tuning thresholds used by SuggestTuning.
+const (
+	// MinTuningSamples is the number of tasks a role/model pair needs
+	// before its success rate or cost is trusted for a suggestion.
+	MinTuningSamples = 10
+
+	// lowSuccessRate marks a model as unreliable for a role.
+	lowSuccessRate = 0.6
+
+	// reliabilityGain is how much better an alternative must succeed to be
+	// suggested for an unreliable model.
+	reliabilityGain = 0.2
+
+	// equalSuccessTolerance is how much lower an alternative's success rate
+	// may be while still counting as "equal" for a cost suggestion.
+	equalSuccessTolerance = 0.05
+
+	// costRatioThreshold is how many times more expensive the current model
+	// must be before a cheaper alternative is suggested.
+	costRatioThreshold = 2.0
+)
+
+// Tuning suggestion kinds.
+const (
+	TuningReliability = "reliability"
+	TuningCost        = "cost"
+)
+
+// TuningSuggestion is a concrete config edit proposed from observed metrics.
+type TuningSuggestion struct {
+	Role string `json:"role"`
+
+	// Field is the role setting to change: "model" or
+	// "complexity.high|medium|low".
+	Field string `json:"field"`
+
+	Current   string `json:"current"`
+	Suggested string `json:"suggested"`
+	Kind      string `json:"kind"`
+	Reason    string `json:"reason"`
+}
+
+// tuningStats is a model's observed record for one role.
+type tuningStats struct {
+	model   string
+	rate    float64
+	avgCost float64
+}
+
+// SuggestTuning compares each role's configured models against the other
+// models observed serving that role and suggests replacing models that
+// fail often (reliability) or cost far more than an equally successful
+// alternative (cost). Only pairs with at least MinTuningSamples tasks are
+// considered. Suggestions are ordered by role, primary model first.
+func SuggestTuning(cfg *Config, m *Metrics) []TuningSuggestion {
+	if cfg == nil || m == nil {
+		return nil
+	}
+
+	var suggestions []TuningSuggestion
+	for _, role := range sortedKeys(cfg.Roles) {
+		rc := cfg.Roles[role]
+		if rc == nil {
+			continue
+		}
+
+		candidates := tuningCandidates(m, role, rc)
+		slots := []complexityLevel{{"model", rc.Model}}
+		if rc.ComplexityRouting && rc.Complexity != nil {
+			for _, l := range complexityLevels(rc.Complexity) {
+				slots = append(slots, complexityLevel{"complexity." + l.name, l.model})
+			}
+		}
+
+		for _, slot := range slots {
+			if slot.model == "" || slot.model == "auto" {
+				continue
+			}
+			current, ok := roleModelStats(m, role, slot.model)
+			if !ok {
+				continue
+			}
+			if s, ok := suggestForSlot(role, slot, current, candidates); ok {
+				suggestions = append(suggestions, s)
+			}
+		}
+	}
+	return suggestions
+}
+
+func suggestForSlot(role string, slot complexityLevel, current tuningStats, candidates []tuningStats) (TuningSuggestion, bool) {
+	label := fmt.Sprintf("%s's %s", role, slotLabel(slot.name))
+
+	if current.rate < lowSuccessRate {
+		var best *tuningStats
+		for i := range candidates {
+			c := &candidates[i]
+			if c.model == current.model || c.rate < current.rate+reliabilityGain {
+				continue
+			}
+			if best == nil || c.rate > best.rate || (c.rate == best.rate && c.avgCost < best.avgCost) {
+				best = c
+			}
+		}
+		if best != nil {
+			return TuningSuggestion{
+				Role:      role,
+				Field:     slot.name,
+				Current:   current.model,
+				Suggested: best.model,
+				Kind:      TuningReliability,
+				Reason: fmt.Sprintf("%s %s has a %.0f%% success rate; consider %s (%.0f%%)",
+					label, current.model, current.rate*100, best.model, best.rate*100),
+			}, true
+		}
+		return TuningSuggestion{}, false
+	}
+
+	if current.avgCost <= 0 {
+		return TuningSuggestion{}, false
+	}
+	var cheapest *tuningStats
+	for i := range candidates {
+		c := &candidates[i]
+		if c.model == current.model || c.avgCost <= 0 || c.rate < current.rate-equalSuccessTolerance {
+			continue
+		}
+		if current.avgCost < c.avgCost*costRatioThreshold {
+			continue
+		}
+		if cheapest == nil || c.avgCost < cheapest.avgCost {
+			cheapest = c
+		}
+	}
+	if cheapest == nil {
+		return TuningSuggestion{}, false
+	}
+	return TuningSuggestion{
+		Role:      role,
+		Field:     slot.name,
+		Current:   current.model,
+		Suggested: cheapest.model,
+		Kind:      TuningCost,
+		Reason: fmt.Sprintf("%s %s costs %.1fx %s with equal success; consider %s",
+			label, current.model, current.avgCost/cheapest.avgCost, cheapest.model, cheapest.model),
+	}, true
+}
+
+func slotLabel(field string) string {
+	if field == "model" {
+		return "model"
+	}
+	return field[len("complexity."):] + "-complexity model"
+}
+
+// tuningCandidates returns the stats of every model with enough history for
+// role: models observed serving it plus its configured fallbacks.
+func tuningCandidates(m *Metrics, role string, rc *RoleConfig) []tuningStats {
+	seen := make(map[string]bool)
+	var models []string
+	if rm := m.ByRole[role]; rm != nil {
+		models = append(models, sortedKeys(rm.ModelUsage)...)
+	}
+	models = append(models, rc.Fallback...)
+
+	var candidates []tuningStats
+	for _, model := range models {
+		if seen[model] {
+			continue
+		}
+		seen[model] = true
+		if stats, ok := roleModelStats(m, role, model); ok {
+			candidates = append(candidates, stats)
+		}
+	}
+	return candidates
+}
+
+// roleModelStats returns model's success rate and average cost for role.
+// It reports false when fewer than MinTuningSamples tasks back the numbers.
+func roleModelStats(m *Metrics, role, model string) (tuningStats, bool) {
+	rate, n := m.RoleModelSuccessRate(role, model)
+	if n < MinTuningSamples {
+		return tuningStats{}, false
+	}
+
+	stats := tuningStats{model: model, rate: rate}
+	var cost float64
+	var costed int
+	for _, task := range m.TaskHistory {
+		if task.Role == role && task.Model == model {
+			cost += task.Cost
+			costed++
+		}
+	}
+	if costed > 0 {
+		stats.avgCost = cost / float64(costed)
+	} else if mm := m.ByModel[model]; mm != nil && mm.TotalTasks > 0 {
+		stats.avgCost = mm.TotalCost / float64(mm.TotalTasks)
+	}
+	return stats, true
+}
+
+// ApplyTuning writes suggestions into the config. Suggestions for unknown
+// roles or fields are skipped; the number applied is returned.
+func (c *Config) ApplyTuning(suggestions []TuningSuggestion) int {
+	applied := 0
+	for _, s := range suggestions {
+		rc := c.Roles[s.Role]
+		if rc == nil {
+			continue
+		}
+		switch s.Field {
+		case "model":
+			rc.Model = s.Suggested
+		case "complexity.high", "complexity.medium", "complexity.low":
+			if rc.Complexity == nil {
+				continue
+			}
+			rc.Complexity.set(s.Field[len("complexity."):], s.Suggested)
+		default:
+			continue
+		}
+		applied++
+	}
+	return applied
+}
+
+// set assigns the model for a complexity level ("high", "medium", "low").
+func (cc *ComplexityConfig) set(level, model string) {
+	switch level {
+	case "high":
+		cc.High = model
+	case "medium":
+		cc.Medium = model
+	case "low":
+		cc.Low = model
+	}
+}
+
+// SuggestedProfileTag marks profiles built by SuggestProfile.
+const SuggestedProfileTag = "auto-generated"
+
+// SuggestProfile builds a profile from what has worked so far. Each role
+// observed in the metrics gets the model with the highest success rate
+// among those with at least MinTuningSamples tasks for it (the cheaper one
+// on ties), and the runner-up, if any, as its first fallback. Roles with no
+// such model are left out; nil is returned when no role qualifies.
+func (s *MetricsStore) SuggestProfile() *Profile {
+	return suggestProfile(s.GetMetrics(), time.Now())
+}
+
+func suggestProfile(m *Metrics, now time.Time) *Profile {
+	roles := make(map[string]*RoleConfig)
+	for _, role := range sortedKeys(m.ByRole) {
+		var ranked []tuningStats
+		for _, model := range sortedKeys(m.ByRole[role].ModelUsage) {
+			if stats, ok := roleModelStats(m, role, model); ok {
+				ranked = append(ranked, stats)
+			}
+		}
+		if len(ranked) == 0 {
+			continue
+		}
+		sort.SliceStable(ranked, func(i, j int) bool {
+			if ranked[i].rate != ranked[j].rate {
+				return ranked[i].rate > ranked[j].rate
+			}
+			return ranked[i].avgCost < ranked[j].avgCost
+		})
+
+		best := ranked[0]
+		rc := &RoleConfig{
+			Model:     best.model,
+			Rationale: fmt.Sprintf("%.0f%% success rate in recorded tasks", best.rate*100),
+		}
+		if len(ranked) > 1 {
+			rc.Fallback = []string{ranked[1].model}
+		}
+		roles[role] = rc
+	}
+	if len(roles) == 0 {
+		return nil
+	}
+
+	cfg := &Config{
+		Version:  CurrentConfigVersion,
+		Roles:    roles,
+		Defaults: DefaultCouncilConfig().Defaults,
+	}
+	cfg.EnsureProvidersForModels()
+
+	summary := m.Summary()
+	metrics := &ProfileMetrics{
+		TotalTasks:  summary.TotalTasks,
+		SuccessRate: summary.AvgSuccessRate,
+		CostSavings: summary.CostSavings,
+	}
+	if summary.TotalTasks > 0 {
+		metrics.AvgCostPerTask = summary.TotalCost / float64(summary.TotalTasks)
+	}
+
+	return &Profile{
+		Name:        "suggested",
+		Description: "Best-performing model per role from recorded council metrics",
+		Author:      "Gas Town",
+		Version:     "1.0.0",
+		CreatedAt:   now,
+		UpdatedAt:   now,
+		Tags:        []string{SuggestedProfileTag},
+		Config:      cfg,
+		Metrics:     metrics,
+	}
+}
@@ -0,0 +1,120 @@
+package council
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func seedTuningTasks(m *Metrics, role, model string, n, successes int, cost float64) {
+	for i := 0; i < n; i++ {
+		task := TaskMetric{Role: role, Model: model, Provider: ModelProvider(model), Success: i < successes, Cost: cost}
+		m.addTask(task)
+		m.TaskHistory = append(m.TaskHistory, task)
+	}
+}
+
+func TestSuggestTuning(t *testing.T) {
+	cfg := &Config{Roles: map[string]*RoleConfig{
+		"polecat": {
+			Model:             "sonnet-4.5",
+			ComplexityRouting: true,
+			Complexity:        &ComplexityConfig{High: "opus-4.5", Medium: "sonnet-4.5", Low: "gemini-3-flash"},
+		},
+		"refinery": {Model: "opus-4.5", Fallback: []string{"gpt-5.2"}},
+	}}
+
+	m := &Metrics{}
+	// Polecat: gemini-3-flash fails often, sonnet-4.5 is reliable.
+	seedTuningTasks(m, "polecat", "gemini-3-flash", 10, 4, 0.01)
+	seedTuningTasks(m, "polecat", "sonnet-4.5", 20, 19, 0.10)
+	// Refinery: opus-4.5 costs 8x gpt-5.2 with the same success rate.
+	seedTuningTasks(m, "refinery", "opus-4.5", 10, 9, 0.80)
+	seedTuningTasks(m, "refinery", "gpt-5.2", 10, 9, 0.10)
+
+	suggestions := SuggestTuning(cfg, m)
+	if len(suggestions) != 2 {
+		t.Fatalf("suggestions = %+v, want one reliability and one cost suggestion", suggestions)
+	}
+
+	rel := suggestions[0]
+	if rel.Role != "polecat" || rel.Field != "complexity.low" || rel.Kind != TuningReliability ||
+		rel.Current != "gemini-3-flash" || rel.Suggested != "sonnet-4.5" {
+		t.Errorf("reliability suggestion = %+v", rel)
+	}
+	if !strings.Contains(rel.Reason, "40% success rate") {
+		t.Errorf("reliability reason = %q, want the 40%% success rate", rel.Reason)
+	}
+
+	cost := suggestions[1]
+	if cost.Role != "refinery" || cost.Field != "model" || cost.Kind != TuningCost ||
+		cost.Current != "opus-4.5" || cost.Suggested != "gpt-5.2" {
+		t.Errorf("cost suggestion = %+v", cost)
+	}
+	if !strings.Contains(cost.Reason, "8.0x") {
+		t.Errorf("cost reason = %q, want the 8x ratio", cost.Reason)
+	}
+
+	if n := cfg.ApplyTuning(suggestions); n != 2 {
+		t.Errorf("ApplyTuning applied %d, want 2", n)
+	}
+	if cfg.Roles["polecat"].Complexity.Low != "sonnet-4.5" || cfg.Roles["refinery"].Model != "gpt-5.2" {
+		t.Errorf("config after ApplyTuning: polecat low=%s refinery=%s",
+			cfg.Roles["polecat"].Complexity.Low, cfg.Roles["refinery"].Model)
+	}
+}
+
+func TestSuggestTuning_TooFewSamples(t *testing.T) {
+	cfg := &Config{Roles: map[string]*RoleConfig{"refinery": {Model: "opus-4.5"}}}
+	m := &Metrics{}
+	seedTuningTasks(m, "refinery", "opus-4.5", MinTuningSamples-1, 1, 1.0)
+	seedTuningTasks(m, "refinery", "gpt-5.2", 20, 20, 0.1)
+
+	if got := SuggestTuning(cfg, m); len(got) != 0 {
+		t.Errorf("suggestions = %+v, want none below MinTuningSamples", got)
+	}
+}
+
+func TestSuggestProfile(t *testing.T) {
+	m := &Metrics{}
+	if p := suggestProfile(m, time.Now()); p != nil {
+		t.Errorf("empty metrics: got %+v, want nil", p)
+	}
+
+	// Polecat: sonnet-4.5 wins, gpt-5.2 is runner-up, opus-4.5 has too few tasks.
+	seedTuningTasks(m, "polecat", "sonnet-4.5", 20, 19, 0.10)
+	seedTuningTasks(m, "polecat", "gpt-5.2", 10, 8, 0.05)
+	seedTuningTasks(m, "polecat", "opus-4.5", 5, 5, 0.80)
+	// Refinery: equal success rates, so the cheaper gpt-5.2 wins.
+	seedTuningTasks(m, "refinery", "opus-4.5", 10, 9, 0.80)
+	seedTuningTasks(m, "refinery", "gpt-5.2", 10, 9, 0.10)
+	// Witness: not enough history for any model.
+	seedTuningTasks(m, "witness", "gemini-3-flash", 9, 9, 0.01)
+
+	p := suggestProfile(m, time.Now())
+	if p == nil {
+		t.Fatal("SuggestProfile returned nil")
+	}
+	if len(p.Tags) != 1 || p.Tags[0] != SuggestedProfileTag {
+		t.Errorf("tags = %v, want [%s]", p.Tags, SuggestedProfileTag)
+	}
+	if issues := ValidateProfile(p); len(issues) != 0 {
+		t.Errorf("suggested profile should be valid, got %v", issues)
+	}
+
+	roles := p.Config.Roles
+	if len(roles) != 2 || roles["witness"] != nil {
+		t.Fatalf("roles = %v, want polecat and refinery only", sortedKeys(roles))
+	}
+	if rc := roles["polecat"]; rc.Model != "sonnet-4.5" || !reflect.DeepEqual(rc.Fallback, []string{"gpt-5.2"}) {
+		t.Errorf("polecat = %s %v, want sonnet-4.5 [gpt-5.2]", rc.Model, rc.Fallback)
+	}
+	if rc := roles["refinery"]; rc.Model != "gpt-5.2" || !reflect.DeepEqual(rc.Fallback, []string{"opus-4.5"}) {
+		t.Errorf("refinery = %s %v, want gpt-5.2 [opus-4.5]", rc.Model, rc.Fallback)
+	}
+
+	if p.Metrics == nil || p.Metrics.TotalTasks != 64 {
+		t.Errorf("metrics = %+v, want 64 total tasks", p.Metrics)
+	}
+}
@@ -0,0 +1,135 @@
+package council
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+
+	"github.com/cursorworkshop/cursor-gastown/internal/cursor"
+)
+
+// PatternsFileName is the filename for user-defined chain and ensemble patterns.
+const PatternsFileName = "council-patterns.toml"
+
+// PatternsPath returns the path to the user-defined patterns file.
+func PatternsPath(townRoot string) string {
+	return filepath.Join(townRoot, ".beads", PatternsFileName)
+}
+
+// PatternsFile is the on-disk format of the user-defined patterns file.
+type PatternsFile struct {
+	Chains    map[string]*ChainConfig    `json:"chains,omitempty" toml:"chains"`
+	Ensembles map[string]*EnsembleConfig `json:"ensembles,omitempty" toml:"ensembles"`
+}
+
+// LoadPatterns returns the predefined chains and ensembles merged with any
+// user-defined ones from .beads/council-patterns.toml. User patterns replace
+// predefined patterns of the same name. A missing file is not an error; an
+// invalid pattern is.
+func LoadPatterns(townRoot string) (chains map[string]*ChainConfig, ensembles map[string]*EnsembleConfig, err error) {
+	chains = make(map[string]*ChainConfig, len(PredefinedChains))
+	for name, chain := range PredefinedChains {
+		chains[name] = chain
+	}
+	ensembles = make(map[string]*EnsembleConfig, len(PredefinedEnsembles))
+	for name, ensemble := range PredefinedEnsembles {
+		ensembles[name] = ensemble
+	}
+
+	path := PatternsPath(townRoot)
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return chains, ensembles, nil
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("reading patterns file: %w", err)
+	}
+
+	var file PatternsFile
+	if _, err := toml.Decode(string(data), &file); err != nil {
+		return nil, nil, fmt.Errorf("parsing %s: %w", path, err)
+	}
+	if issues := ValidatePatterns(&file); len(issues) > 0 {
+		return nil, nil, fmt.Errorf("invalid patterns in %s: %s", path, strings.Join(issues, "; "))
+	}
+
+	for name, chain := range file.Chains {
+		chains[name] = chain
+	}
+	for name, ensemble := range file.Ensembles {
+		ensembles[name] = ensemble
+	}
+	return chains, ensembles, nil
+}
+
+// ValidatePatterns checks user-defined patterns for empty definitions,
+// unsupported models, unknown step conditions, and unknown voting
+// strategies.
+func ValidatePatterns(file *PatternsFile) []string {
+	var issues []string
+
+	for _, name := range sortedKeys(file.Chains) {
+		chain := file.Chains[name]
+		if chain == nil || len(chain.Steps) == 0 {
+			issues = append(issues, fmt.Sprintf("chain %q has no steps", name))
+			continue
+		}
+		for i, step := range chain.Steps {
+			models := step.ParallelModels
+			if len(models) == 0 {
+				models = []string{step.Model}
+			}
+			for _, model := range models {
+				if !cursor.IsValidModel(model) {
+					issues = append(issues, fmt.Sprintf("chain %q step %d: unsupported model %q", name, i+1, model))
+				}
+			}
+			if step.Condition != "" && !validPredicate(step.Condition) {
+				issues = append(issues, fmt.Sprintf("chain %q step %d: unknown condition %q", name, i+1, step.Condition))
+			}
+			if step.LoopUntil != "" && !validPredicate(step.LoopUntil) {
+				issues = append(issues, fmt.Sprintf("chain %q step %d: unknown loop_until predicate %q", name, i+1, step.LoopUntil))
+			}
+			if step.TransformOutput != "" && !validTransform(step.TransformOutput) {
+				issues = append(issues, fmt.Sprintf("chain %q step %d: invalid transform_output %q", name, i+1, step.TransformOutput))
+			}
+		}
+	}
+
+	for _, name := range sortedKeys(file.Ensembles) {
+		ensemble := file.Ensembles[name]
+		if ensemble == nil || len(ensemble.Models) == 0 {
+			issues = append(issues, fmt.Sprintf("ensemble %q has no models", name))
+			continue
+		}
+		for _, model := range ensemble.Models {
+			if !cursor.IsValidModel(model) {
+				issues = append(issues, fmt.Sprintf("ensemble %q: unsupported model %q", name, model))
+			}
+		}
+		switch ensemble.VotingStrategy {
+		case "", VoteMajority, VoteConsensus, VoteWeighted, VoteBest, VoteRanked:
+		default:
+			issues = append(issues, fmt.Sprintf("ensemble %q: unknown voting strategy %q", name, ensemble.VotingStrategy))
+		}
+		if ensemble.Threshold < 0 || ensemble.Threshold > 1 {
+			issues = append(issues, fmt.Sprintf("ensemble %q: threshold %.2f is outside 0-1", name, ensemble.Threshold))
+		}
+	}
+
+	return issues
+}
+
+// sortedKeys returns a map's keys in sorted order.
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
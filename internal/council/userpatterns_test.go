@@ -0,0 +1,95 @@
+package council
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func writePatternsFile(t *testing.T, townRoot, content string) {
+	t.Helper()
+	path := PatternsPath(townRoot)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestLoadPatterns(t *testing.T) {
+	townRoot := t.TempDir()
+	writePatternsFile(t, townRoot, `
+[chains.draft-and-review]
+stop_on_error = true
+
+[[chains.draft-and-review.steps]]
+name = "draft"
+model = "sonnet-4.5"
+
+[[chains.draft-and-review.steps]]
+name = "review"
+model = "gpt-5.2"
+role = "refinery"
+
+[ensembles.quick-vote]
+models = ["gemini-3-flash", "gpt-5.2"]
+voting_strategy = "majority"
+timeout = "30s"
+`)
+
+	chains, ensembles, err := LoadPatterns(townRoot)
+	if err != nil {
+		t.Fatalf("LoadPatterns failed: %v", err)
+	}
+
+	chain := chains["draft-and-review"]
+	if chain == nil || len(chain.Steps) != 2 || chain.Steps[1].Role != "refinery" || !chain.StopOnError {
+		t.Errorf("custom chain = %+v, want 2 steps ending in refinery review", chain)
+	}
+	for name := range PredefinedChains {
+		if chains[name] == nil {
+			t.Errorf("predefined chain %q missing after merge", name)
+		}
+	}
+
+	ensemble := ensembles["quick-vote"]
+	if ensemble == nil || ensemble.Timeout != 30*time.Second || len(ensemble.Models) != 2 {
+		t.Errorf("custom ensemble = %+v, want 2 models with 30s timeout", ensemble)
+	}
+	if len(ensembles) != len(PredefinedEnsembles)+1 {
+		t.Errorf("got %d ensembles, want predefined plus one", len(ensembles))
+	}
+}
+
+func TestLoadPatterns_Invalid(t *testing.T) {
+	townRoot := t.TempDir()
+	writePatternsFile(t, townRoot, `
+[chains.empty]
+
+[ensembles.bad]
+models = ["not-a-model"]
+`)
+
+	_, _, err := LoadPatterns(townRoot)
+	if err == nil {
+		t.Fatal("expected validation error")
+	}
+	for _, want := range []string{`chain "empty" has no steps`, `unsupported model "not-a-model"`} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("error %q missing %q", err, want)
+		}
+	}
+}
+
+func TestLoadPatterns_NoFile(t *testing.T) {
+	chains, ensembles, err := LoadPatterns(t.TempDir())
+	if err != nil {
+		t.Fatalf("LoadPatterns failed: %v", err)
+	}
+	if len(chains) != len(PredefinedChains) || len(ensembles) != len(PredefinedEnsembles) {
+		t.Error("expected only predefined patterns without a patterns file")
+	}
+}
@@ -0,0 +1,279 @@
+// Package council provides multi-model orchestration for Gas Town.
+package council
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/cursorworkshop/cursor-gastown/internal/cursor"
+)
+
+// Severity grades a config issue.
+type Severity string
+
+// Issue severities.
+const (
+	// SeverityError marks a config that would make routing fail.
+	SeverityError Severity = "error"
+
+	// SeverityWarning marks a setting that works but is likely a mistake.
+	SeverityWarning Severity = "warning"
+)
+
+// ConfigIssue is one finding from Config.Validate.
+type ConfigIssue struct {
+	Severity Severity `json:"severity"`
+	Message  string   `json:"message"`
+}
+
+// Validate checks the config and returns every finding: ValidateConfig's
+// hard errors followed by LintConfig's warnings. Use HasErrors to decide
+// whether the config is usable.
+func (c *Config) Validate() []ConfigIssue {
+	var issues []ConfigIssue
+	for _, msg := range ValidateConfig(c) {
+		issues = append(issues, ConfigIssue{Severity: SeverityError, Message: msg})
+	}
+	for _, msg := range LintConfig(c) {
+		issues = append(issues, ConfigIssue{Severity: SeverityWarning, Message: msg})
+	}
+	return issues
+}
+
+// HasErrors reports whether any issue is an error.
+func HasErrors(issues []ConfigIssue) bool {
+	for _, issue := range issues {
+		if issue.Severity == SeverityError {
+			return true
+		}
+	}
+	return false
+}
+
+// ValidateConfig checks a council configuration for hard errors that would
+// make routing fail or behave unpredictably. It returns one message per
+// issue, or nil if the config is usable.
+func ValidateConfig(cfg *Config) []string {
+	if cfg == nil {
+		return []string{"council configuration is missing"}
+	}
+
+	var issues []string
+
+	if cfg.Version > CurrentConfigVersion {
+		issues = append(issues, fmt.Sprintf("config version %d is newer than supported version %d", cfg.Version, CurrentConfigVersion))
+	}
+
+	roles := make([]string, 0, len(cfg.Roles))
+	for role := range cfg.Roles {
+		roles = append(roles, role)
+	}
+	sort.Strings(roles)
+
+	for _, role := range roles {
+		rc := cfg.Roles[role]
+		if rc == nil {
+			issues = append(issues, fmt.Sprintf("role %q has an empty configuration", role))
+			continue
+		}
+		if rc.Model == "" {
+			issues = append(issues, fmt.Sprintf("role %q has no model specified", role))
+		}
+		for i, fb := range rc.Fallback {
+			if fb == "" {
+				issues = append(issues, fmt.Sprintf("role %q fallback %d is empty", role, i+1))
+			}
+		}
+		if rc.ComplexityRouting {
+			issues = append(issues, validateComplexity(role, rc.Complexity)...)
+		}
+		if rc.SampleRate < 0 || rc.SampleRate > 1 {
+			issues = append(issues, fmt.Sprintf("role %q sample_rate %.2f is outside 0-1", role, rc.SampleRate))
+		}
+		if rc.MinConfidence < 0 || rc.MinConfidence > 1 {
+			issues = append(issues, fmt.Sprintf("role %q min_confidence %.2f is outside 0-1", role, rc.MinConfidence))
+		}
+		switch cursor.RoleType(rc.RoleType) {
+		case "", cursor.Autonomous, cursor.Interactive:
+		default:
+			issues = append(issues, fmt.Sprintf("role %q role_type %q must be %q or %q", role, rc.RoleType, cursor.Autonomous, cursor.Interactive))
+		}
+		if rc.Provider != "" {
+			issues = append(issues, validateProviderPin(cfg, role, rc)...)
+		}
+	}
+
+	if cfg.Defaults != nil {
+		for i, fb := range cfg.Defaults.Fallback {
+			if fb == "" {
+				issues = append(issues, fmt.Sprintf("default fallback %d is empty", i+1))
+			}
+		}
+	}
+
+	for name, pc := range cfg.Providers {
+		if pc != nil && pc.RateLimit < 0 {
+			issues = append(issues, fmt.Sprintf("provider %q has a negative rate limit", name))
+		}
+		if pc != nil && pc.MaxConcurrent < 0 {
+			issues = append(issues, fmt.Sprintf("provider %q has a negative max_concurrent", name))
+		}
+	}
+
+	return issues
+}
+
+// validateProviderPin checks that a role's pinned provider exists, is
+// enabled, and serves the role's model.
+func validateProviderPin(cfg *Config, role string, rc *RoleConfig) []string {
+	pc := cfg.Providers[rc.Provider]
+	if pc == nil {
+		return []string{fmt.Sprintf("role %q pins unknown provider %q", role, rc.Provider)}
+	}
+	var issues []string
+	if !pc.Enabled {
+		issues = append(issues, fmt.Sprintf("role %q pins disabled provider %q", role, rc.Provider))
+	}
+	if rc.Model != "" && rc.Model != "auto" && !contains(pc.Models, rc.Model) {
+		issues = append(issues, fmt.Sprintf("role %q pins provider %q, which does not list model %q", role, rc.Provider, rc.Model))
+	}
+	return issues
+}
+
+// validateComplexity checks a complexity-routing role's level models.
+func validateComplexity(role string, cc *ComplexityConfig) []string {
+	if cc == nil {
+		return []string{fmt.Sprintf("role %q enables complexity routing without a [complexity] table", role)}
+	}
+	levels := complexityLevels(cc)
+	if levels[0].model == "" && levels[1].model == "" && levels[2].model == "" {
+		return []string{fmt.Sprintf("role %q enables complexity routing but every complexity model is empty", role)}
+	}
+
+	var issues []string
+	for _, l := range levels {
+		if l.model != "" && l.model != "auto" && ModelProvider(l.model) == "unknown" {
+			issues = append(issues, fmt.Sprintf("role %q %s-complexity model %q is not a known model", role, l.name, l.model))
+		}
+	}
+	return issues
+}
+
+type complexityLevel struct {
+	name  string
+	model string
+}
+
+// complexityLevels lists a complexity config's models from high to low.
+func complexityLevels(cc *ComplexityConfig) []complexityLevel {
+	return []complexityLevel{{"high", cc.High}, {"medium", cc.Medium}, {"low", cc.Low}}
+}
+
+// LintConfig reports advisory findings: settings that work but are likely
+// mistakes or reduce resilience. Unlike ValidateConfig, these never block
+// routing.
+func LintConfig(cfg *Config) []string {
+	if cfg == nil {
+		return nil
+	}
+
+	var warnings []string
+
+	known := make(map[string]bool)
+	for _, pc := range cfg.Providers {
+		if pc == nil {
+			continue
+		}
+		for _, m := range pc.Models {
+			known[m] = true
+		}
+	}
+
+	roles := make([]string, 0, len(cfg.Roles))
+	for role := range cfg.Roles {
+		roles = append(roles, role)
+	}
+	sort.Strings(roles)
+
+	for _, role := range roles {
+		rc := cfg.Roles[role]
+		if rc == nil || rc.Model == "" {
+			continue
+		}
+
+		switch {
+		case !cursor.IsValidModel(rc.Model):
+			warnings = append(warnings, fmt.Sprintf("role %q model %q is not a supported cursor-agent model", role, rc.Model))
+		case len(known) > 0 && rc.Model != "auto" && !known[rc.Model]:
+			warnings = append(warnings, fmt.Sprintf("role %q model %q is not listed by any provider", role, rc.Model))
+		}
+		if provider, disabled := cfg.disabledProvider(role, rc.Model); disabled {
+			warnings = append(warnings, fmt.Sprintf("role %q model %q belongs to disabled provider %q", role, rc.Model, provider))
+		}
+
+		seen := map[string]bool{rc.Model: true}
+		diverse := false
+		for _, fb := range rc.Fallback {
+			if fb == "" {
+				continue
+			}
+			if seen[fb] {
+				warnings = append(warnings, fmt.Sprintf("role %q fallback %q is duplicated or repeats the primary model", role, fb))
+			}
+			if !cursor.IsValidModel(fb) {
+				warnings = append(warnings, fmt.Sprintf("role %q fallback %q is not a supported cursor-agent model", role, fb))
+			}
+			if provider, disabled := cfg.disabledProvider(role, fb); disabled {
+				warnings = append(warnings, fmt.Sprintf("role %q fallback %q belongs to disabled provider %q", role, fb, provider))
+			}
+			seen[fb] = true
+			if cfg.ProviderFor(role, fb) != cfg.ProviderFor(role, rc.Model) {
+				diverse = true
+			}
+		}
+		if len(rc.Fallback) > 0 && !diverse {
+			warnings = append(warnings, fmt.Sprintf("role %q fallbacks all use provider %q; a provider outage leaves no fallback", role, cfg.ProviderFor(role, rc.Model)))
+		}
+
+		if rc.ComplexityRouting && rc.Complexity != nil {
+			var missing []string
+			for _, l := range complexityLevels(rc.Complexity) {
+				switch {
+				case l.model == "":
+					missing = append(missing, l.name)
+				case ModelProvider(l.model) != "unknown" && !cursor.IsValidModel(l.model):
+					// Models with no known provider are already ValidateConfig errors.
+					warnings = append(warnings, fmt.Sprintf("role %q %s-complexity model %q is not a supported cursor-agent model", role, l.name, l.model))
+				}
+			}
+			if len(missing) > 0 && len(missing) < 3 {
+				warnings = append(warnings, fmt.Sprintf("role %q has no %s-complexity model; those tasks use %q", role, strings.Join(missing, "/"), rc.Model))
+			}
+		}
+	}
+
+	if cfg.Defaults != nil {
+		for _, fb := range cfg.Defaults.Fallback {
+			if fb == "" {
+				continue
+			}
+			if !cursor.IsValidModel(fb) {
+				warnings = append(warnings, fmt.Sprintf("default fallback %q is not a supported cursor-agent model", fb))
+			}
+			if provider, disabled := cfg.disabledProvider("", fb); disabled {
+				warnings = append(warnings, fmt.Sprintf("default fallback %q belongs to disabled provider %q", fb, provider))
+			}
+		}
+	}
+
+	return warnings
+}
+
+// disabledProvider returns the provider serving model for role, honoring
+// the role's provider pin, and whether the config explicitly disables it.
+func (c *Config) disabledProvider(role, model string) (string, bool) {
+	provider := c.ProviderFor(role, model)
+	pc := c.Providers[provider]
+	return provider, pc != nil && !pc.Enabled
+}
@@ -0,0 +1,130 @@
+package council
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestValidateConfig(t *testing.T) {
+	if issues := ValidateConfig(DefaultCouncilConfig()); len(issues) != 0 {
+		t.Errorf("default config should be valid, got %v", issues)
+	}
+
+	cfg := DefaultCouncilConfig()
+	cfg.Version = CurrentConfigVersion + 1
+	cfg.Roles["mayor"].Model = ""
+	cfg.Roles["broken"] = nil
+	if issues := ValidateConfig(cfg); len(issues) != 3 {
+		t.Errorf("expected 3 issues, got %d: %v", len(issues), issues)
+	}
+}
+
+func TestValidateConfig_ProviderPin(t *testing.T) {
+	cfg := DefaultCouncilConfig()
+	cfg.Providers["gateway"] = &ProviderConfig{Enabled: false, Models: []string{"opus-4.5"}}
+	cfg.Roles["polecat"].Provider = "gateway"
+	cfg.Roles["mayor"].Provider = "nowhere"
+
+	issues := ValidateConfig(cfg)
+	if len(issues) != 3 {
+		t.Errorf("expected 3 issues (disabled, unlisted model, unknown provider), got %d: %v", len(issues), issues)
+	}
+}
+
+func TestLintConfig(t *testing.T) {
+	if warnings := LintConfig(DefaultCouncilConfig()); len(warnings) != 0 {
+		t.Errorf("default config should lint clean, got %v", warnings)
+	}
+
+	cfg := DefaultCouncilConfig()
+	cfg.Roles["polecat"].Fallback = []string{"opus-4.5", "opus-4.5"}
+	cfg.Roles["witness"].Model = "mystery-model"
+	cfg.Roles["witness"].Fallback = nil
+
+	warnings := LintConfig(cfg)
+	if len(warnings) != 3 {
+		t.Errorf("expected 3 warnings (duplicate fallback, single-provider fallbacks, unknown model), got %d: %v", len(warnings), warnings)
+	}
+}
+
+func TestValidateConfig_Complexity(t *testing.T) {
+	for name, profile := range PredefinedProfiles {
+		if issues := ValidateConfig(profile.Config); len(issues) != 0 {
+			t.Errorf("profile %s should be valid, got %v", name, issues)
+		}
+	}
+
+	empty := DefaultCouncilConfig()
+	empty.Roles["polecat"].Complexity = &ComplexityConfig{}
+	if issues := ValidateConfig(empty); len(issues) != 1 {
+		t.Errorf("empty complexity: expected 1 issue, got %v", issues)
+	}
+
+	missing := DefaultCouncilConfig()
+	missing.Roles["polecat"].Complexity = nil
+	if issues := ValidateConfig(missing); len(issues) != 1 || !strings.Contains(issues[0], "[complexity]") {
+		t.Errorf("missing complexity table: expected 1 issue, got %v", issues)
+	}
+
+	partial := DefaultCouncilConfig()
+	partial.Roles["polecat"].Complexity = &ComplexityConfig{High: "opus-4.5"}
+	if issues := ValidateConfig(partial); len(issues) != 0 {
+		t.Errorf("partial complexity with known models should be valid, got %v", issues)
+	}
+	if warnings := LintConfig(partial); len(warnings) != 1 {
+		t.Errorf("partial complexity: expected 1 fallback warning, got %v", warnings)
+	}
+	if got := partial.GetModelForComplexity("polecat", ComplexityLow); got != partial.Roles["polecat"].Model {
+		t.Errorf("low complexity model = %s, want role model fallback", got)
+	}
+	if got := partial.ComplexityRationale("polecat", ComplexityLow); !strings.Contains(got, "no low-complexity model") {
+		t.Errorf("low complexity rationale = %q, want it to note the fallback", got)
+	}
+	if got := partial.ComplexityRationale("polecat", ComplexityHigh); strings.Contains(got, "no high-complexity") {
+		t.Errorf("high complexity rationale = %q, want no fallback note", got)
+	}
+
+	invalid := DefaultCouncilConfig()
+	invalid.Roles["polecat"].Complexity = &ComplexityConfig{High: "opus-4.5", Low: "mystery-model"}
+	if issues := ValidateConfig(invalid); len(issues) != 1 {
+		t.Errorf("unknown complexity model: expected 1 issue, got %v", issues)
+	}
+}
+
+func TestConfig_Validate(t *testing.T) {
+	if issues := DefaultCouncilConfig().Validate(); len(issues) != 0 {
+		t.Errorf("default config should validate clean, got %v", issues)
+	}
+
+	cfg := DefaultCouncilConfig()
+	cfg.Roles["mayor"].Model = ""
+	cfg.Roles["refinery"].Model = "made-up-model"
+	cfg.Roles["witness"].Fallback = []string{"gpt-5.2", "gemini-3-flash"}
+	cfg.Providers["google"].Enabled = false
+	cfg.Providers["bedrock"] = &ProviderConfig{Models: []string{"sonnet-4.5"}}
+	cfg.Roles["polecat"].Provider = "bedrock"
+
+	issues := cfg.Validate()
+	if !HasErrors(issues) {
+		t.Fatalf("expected an error for the empty mayor model, got %v", issues)
+	}
+
+	want := map[string]Severity{
+		`role "mayor" has no model specified`:                                            SeverityError,
+		`role "refinery" model "made-up-model" is not a supported cursor-agent model`:    SeverityWarning,
+		`role "witness" fallback "gemini-3-flash" belongs to disabled provider "google"`: SeverityWarning,
+		`default fallback "gemini-3-flash" belongs to disabled provider "google"`:        SeverityWarning,
+		`role "polecat" model "sonnet-4.5" belongs to disabled provider "bedrock"`:       SeverityWarning,
+	}
+	for _, issue := range issues {
+		if sev, ok := want[issue.Message]; ok {
+			if sev != issue.Severity {
+				t.Errorf("%q: severity = %s, want %s", issue.Message, issue.Severity, sev)
+			}
+			delete(want, issue.Message)
+		}
+	}
+	for msg := range want {
+		t.Errorf("missing issue %q in %v", msg, issues)
+	}
+}
@@ -2,13 +2,21 @@
 package cursor
 
 import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"os"
 	"os/exec"
 	"path/filepath"
+	"regexp"
 	"strings"
+	"time"
 
 	"github.com/cursorworkshop/cursor-gastown/internal/config"
+	"github.com/cursorworkshop/cursor-gastown/internal/workspace"
 )
 
 // Adapter translates Gas Town operations to Cursor CLI commands.
@@ -37,45 +45,167 @@ type Adapter struct {
 
 	// AdditionalArgs are extra arguments to pass to cursor-agent.
 	AdditionalArgs []string
+
+	// AllowedModels restricts Model to this list when non-empty.
+	AllowedModels []string
+
+	// DeniedModels lists models that may never be used.
+	DeniedModels []string
+
+	// MaxOutputBytes caps how much cursor-agent output Run and RunJSON
+	// buffer. Zero uses DefaultMaxOutputBytes.
+	MaxOutputBytes int
+
+	// TransientPatterns match cursor-agent stderr for failures that
+	// RunWithRetry retries. Nil uses DefaultTransientPatterns.
+	TransientPatterns []*regexp.Regexp
+
+	// RetryBaseDelay is RunWithRetry's first backoff delay, doubled after
+	// each attempt up to MaxRetryDelay. Zero uses DefaultRetryBaseDelay.
+	RetryBaseDelay time.Duration
+}
+
+// DefaultMaxOutputBytes is the output cap used when MaxOutputBytes is unset.
+const DefaultMaxOutputBytes = 50 << 20 // 50MB
+
+// ErrOutputTooLarge is returned when cursor-agent writes more than
+// MaxOutputBytes. The output captured up to the cap is returned with it.
+var ErrOutputTooLarge = errors.New("cursor-agent output too large")
+
+// ErrInvalidJSON is returned by RunJSON when cursor-agent exits cleanly
+// but its output doesn't parse as JSON.
+var ErrInvalidJSON = errors.New("cursor-agent did not emit JSON")
+
+// ErrModelNotAllowed is returned when the adapter's model is denied or
+// missing from a non-empty allowlist.
+var ErrModelNotAllowed = errors.New("model not allowed")
+
+// ErrUnsupportedModel is returned when the adapter's model is not one of
+// SupportedModels.
+var ErrUnsupportedModel = errors.New("unsupported model")
+
+// ErrEmptyPrompt is returned when a prompt is empty or only whitespace.
+// It is reported before cursor-agent is started.
+var ErrEmptyPrompt = errors.New("prompt is empty")
+
+// CheckPrompt returns ErrEmptyPrompt if prompt has no non-whitespace content.
+func CheckPrompt(prompt string) error {
+	if strings.TrimSpace(prompt) == "" {
+		return ErrEmptyPrompt
+	}
+	return nil
+}
+
+// CheckModel validates Model against DeniedModels and AllowedModels.
+func (a *Adapter) CheckModel() error {
+	for _, m := range a.DeniedModels {
+		if m == a.Model {
+			return fmt.Errorf("%w: %s is denied", ErrModelNotAllowed, a.Model)
+		}
+	}
+	if len(a.AllowedModels) == 0 {
+		return nil
+	}
+	for _, m := range a.AllowedModels {
+		if m == a.Model {
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: %s is not in the allowlist (%s)", ErrModelNotAllowed, a.Model, strings.Join(a.AllowedModels, ", "))
+}
+
+// Validate checks the adapter before cursor-agent is started: Model must
+// be empty or one of SupportedModels, and must pass CheckModel. BuildArgs
+// does not validate, so callers building commands themselves (such as for
+// tmux) should call it first.
+func (a *Adapter) Validate() error {
+	if a.Model != "" && !IsValidModel(a.Model) {
+		return fmt.Errorf("%w: %q (supported: %s)", ErrUnsupportedModel, a.Model, strings.Join(SupportedModels, ", "))
+	}
+	return a.CheckModel()
+}
+
+// DisableForceModeEnv is the environment variable that turns off force mode
+// for every Gas Town agent, keeping a human in the loop for tool approval.
+const DisableForceModeEnv = "GASTOWN_DISABLE_FORCE_MODE"
+
+// ForceModeDisabled reports whether force mode is globally disabled via
+// DisableForceModeEnv.
+func ForceModeDisabled() bool {
+	switch strings.ToLower(os.Getenv(DisableForceModeEnv)) {
+	case "1", "true", "yes":
+		return true
+	}
+	return false
+}
+
+// SafeModeEnabled reports whether the town containing workDir is in safe
+// mode (see config.SafeModePath). A workDir outside any town is never in
+// safe mode.
+func SafeModeEnabled(workDir string) bool {
+	townRoot, err := workspace.Find(workDir)
+	if err != nil {
+		return false
+	}
+	return config.SafeModeEnabled(townRoot)
+}
+
+// ApplySafeMode makes the adapter interactive: no force mode, no
+// auto-approval, and no print mode.
+func (a *Adapter) ApplySafeMode() {
+	a.ForceMode = false
+	a.ApproveAll = false
+	a.PrintMode = false
 }
 
 // DefaultAdapter returns an adapter with sensible defaults for Gas Town.
+// Force mode is on unless disabled via DisableForceModeEnv, and safe mode
+// turns off force, auto-approval, and print mode.
 func DefaultAdapter(workDir string) *Adapter {
-	return &Adapter{
+	adapter := &Adapter{
 		WorkDir:   workDir,
-		ForceMode: true,  // Gas Town agents need autonomy
+		ForceMode: !ForceModeDisabled(), // Gas Town agents need autonomy
 		ApproveAll: true, // Auto-approve for autonomous operation
 	}
+	if SafeModeEnabled(workDir) {
+		adapter.ApplySafeMode()
+	}
+	return adapter
 }
 
-// AdapterForRole returns an adapter configured for a specific Gas Town role.
+// AdapterForRole returns an adapter configured for a specific Gas Town role,
+// using the role's default model from the model catalog.
 func AdapterForRole(workDir, role string) *Adapter {
 	adapter := DefaultAdapter(workDir)
+	adapter.Model = GetModelForRole(role)
+	return adapter
+}
 
-	// Role-specific configurations
-	switch role {
-	case "mayor":
-		// Mayor uses the best model for coordination
-		adapter.Model = "opus-4.5-thinking"
-	case "refinery":
-		// Refinery uses a different model family for code review diversity
-		adapter.Model = "gpt-5.2-high"
-	case "witness":
-		// Witness uses fast, cheap model for monitoring
-		adapter.Model = "gemini-3-flash"
-	case "polecat":
-		// Polecats use good coding model by default
-		adapter.Model = "sonnet-4.5"
-	case "crew":
-		// Crew uses auto (user preference)
-		adapter.Model = "auto"
-	default:
-		adapter.Model = "auto"
-	}
-
+// AdapterForRoleWithResume returns AdapterForRole's adapter, set to resume
+// the role's most recent active session in store if there is one, so a
+// restarted agent picks up where it left off. A nil store or no active
+// session gives a fresh adapter.
+func AdapterForRoleWithResume(store *SessionStore, workDir, role, rigName string) *Adapter {
+	adapter := AdapterForRole(workDir, role)
+	adapter.ResumeLatest(store, role, rigName)
 	return adapter
 }
 
+// ResumeLatest sets SessionID to the most recent active session for role
+// and rigName in store. It reports whether a session was found; if not,
+// SessionID is left unchanged.
+func (a *Adapter) ResumeLatest(store *SessionStore, role, rigName string) bool {
+	if store == nil {
+		return false
+	}
+	sess := store.GetByRole(role, rigName)
+	if sess == nil {
+		return false
+	}
+	a.SessionID = sess.ID
+	return true
+}
+
 // BuildCommand builds the cursor-agent command with all configured options.
 func (a *Adapter) BuildCommand(prompt string) *exec.Cmd {
 	args := a.BuildArgs(prompt)
@@ -84,6 +214,22 @@ func (a *Adapter) BuildCommand(prompt string) *exec.Cmd {
 	return cmd
 }
 
+// BuildCommandContext builds a non-interactive cursor-agent command bound to
+// ctx. The agent runs in its own process group, and cancelling ctx kills the
+// whole group so MCP servers and subagents it spawned are not orphaned.
+func (a *Adapter) BuildCommandContext(ctx context.Context, prompt string) *exec.Cmd {
+	cmd := exec.CommandContext(ctx, "cursor-agent", a.BuildArgs(prompt)...)
+	cmd.Dir = a.WorkDir
+	setProcessGroup(cmd)
+	cmd.Cancel = func() error { return killProcessGroup(cmd) }
+	cmd.WaitDelay = processWaitDelay
+	return cmd
+}
+
+// processWaitDelay bounds how long Wait blocks on output pipes held open by
+// stray descendants after a cancelled run.
+const processWaitDelay = 5 * time.Second
+
 // BuildArgs builds the command-line arguments for cursor-agent.
 func (a *Adapter) BuildArgs(prompt string) []string {
 	var args []string
@@ -95,7 +241,7 @@ func (a *Adapter) BuildArgs(prompt string) []string {
 
 	// Model selection
 	if a.Model != "" && a.Model != "auto" {
-		args = append(args, "--model", a.Model)
+		args = append(args, "--model", catalog.Canonical(a.Model))
 	}
 
 	// Force mode (YOLO equivalent)
@@ -140,35 +286,200 @@ func (a *Adapter) BuildCommandString(prompt string) string {
 	return "cursor-agent " + strings.Join(args, " ")
 }
 
-// Run executes cursor-agent and returns the output.
+// Run executes cursor-agent and returns the output. The prompt and the
+// adapter (see Validate) are checked before cursor-agent is started.
 // For non-interactive use; use BuildCommand for interactive sessions.
 func (a *Adapter) Run(prompt string) (string, error) {
-	a.PrintMode = true
-	cmd := a.BuildCommand(prompt)
+	return a.RunContext(context.Background(), prompt)
+}
 
-	output, err := cmd.Output()
-	if err != nil {
-		if exitErr, ok := err.(*exec.ExitError); ok {
-			return string(output), fmt.Errorf("cursor-agent failed: %s\n%s", exitErr.Error(), string(exitErr.Stderr))
+// RunContext is Run bound to ctx: cancelling ctx kills cursor-agent and
+// everything it spawned, and returns an error wrapping ctx.Err().
+func (a *Adapter) RunContext(ctx context.Context, prompt string) (string, error) {
+	return a.runCaptured(ctx, prompt, false)
+}
+
+// StdinPromptArg is the conventional "read from stdin" argument. RunStdin
+// does not pass it; add it to AdditionalArgs if a cursor-agent version
+// only reads the prompt from stdin when told to.
+const StdinPromptArg = "-"
+
+// RunStdin is Run with the prompt written to cursor-agent's stdin instead
+// of passed as the last argument, which avoids argument length limits and
+// keeps the prompt out of process listings. It relies on cursor-agent
+// reading the prompt from stdin in print mode (see StdinPromptArg); the
+// cost is that a prompt cursor-agent ignores fails less obviously than a
+// bad argument would.
+func (a *Adapter) RunStdin(prompt string) (string, error) {
+	return a.runCaptured(context.Background(), prompt, true)
+}
+
+// runCaptured runs cursor-agent and returns its capped stdout, keeping the
+// output alongside exit and output-cap errors.
+func (a *Adapter) runCaptured(ctx context.Context, prompt string, promptOnStdin bool) (string, error) {
+	output := &cappedBuffer{limit: a.outputLimit()}
+	if err := a.runStream(ctx, prompt, promptOnStdin, output, nil); err != nil {
+		var exitErr *exec.ExitError
+		if errors.Is(err, ErrOutputTooLarge) || errors.As(err, &exitErr) {
+			return output.String(), err
 		}
-		return "", fmt.Errorf("running cursor-agent: %w", err)
+		return "", err
 	}
+	return output.String(), nil
+}
 
-	return string(output), nil
+// RunStream executes cursor-agent non-interactively, copying its stdout and
+// stderr to the given writers as output arrives (nil discards a stream).
+// A nonzero exit is reported like Run, with the captured stderr appended.
+// If writing to stdout fails, cursor-agent is killed and the write error
+// is returned.
+func (a *Adapter) RunStream(prompt string, stdout, stderr io.Writer) error {
+	return a.runStream(context.Background(), prompt, false, stdout, stderr)
 }
 
-// RunJSON executes cursor-agent and returns JSON output.
-func (a *Adapter) RunJSON(prompt string) ([]byte, error) {
+func (a *Adapter) runStream(ctx context.Context, prompt string, promptOnStdin bool, stdout, stderr io.Writer) error {
+	if err := CheckPrompt(prompt); err != nil {
+		return err
+	}
+	if err := a.Validate(); err != nil {
+		return err
+	}
 	a.PrintMode = true
+	var cmd *exec.Cmd
+	if promptOnStdin {
+		cmd = a.BuildCommandContext(ctx, "")
+		cmd.Stdin = strings.NewReader(prompt)
+	} else {
+		cmd = a.BuildCommandContext(ctx, prompt)
+	}
+
+	if stdout == nil {
+		stdout = io.Discard
+	}
+	if stderr == nil {
+		stderr = io.Discard
+	}
+	errBuf := &limitedBuffer{limit: a.outputLimit()}
+	err := runPiped(cmd, stdout, io.MultiWriter(errBuf, stderr))
+	if err == nil || errors.Is(err, ErrOutputTooLarge) {
+		return err
+	}
+	if ctx.Err() != nil {
+		return fmt.Errorf("running cursor-agent: %w", ctx.Err())
+	}
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		return fmt.Errorf("cursor-agent failed: %w\n%s", exitErr, errBuf.Bytes())
+	}
+	return fmt.Errorf("running cursor-agent: %w", err)
+}
+
+// RunJSON executes cursor-agent and returns JSON output. Failures are
+// reported like Run, with cursor-agent's stderr in the error; output that
+// isn't valid JSON is an error wrapping ErrInvalidJSON.
+func (a *Adapter) RunJSON(prompt string) ([]byte, error) {
+	return a.RunJSONContext(context.Background(), prompt)
+}
+
+// RunJSONContext is RunJSON bound to ctx, like RunContext.
+func (a *Adapter) RunJSONContext(ctx context.Context, prompt string) ([]byte, error) {
 	a.OutputFormat = "json"
-	cmd := a.BuildCommand(prompt)
+	output := &cappedBuffer{limit: a.outputLimit()}
+	if err := a.runStream(ctx, prompt, false, output, nil); err != nil {
+		if errors.Is(err, ErrOutputTooLarge) {
+			return output.Bytes(), err
+		}
+		return nil, err
+	}
 
-	output, err := cmd.Output()
+	if !json.Valid(output.Bytes()) {
+		return nil, fmt.Errorf("%w: got %q", ErrInvalidJSON, truncateOutput(output.String(), 200))
+	}
+	return output.Bytes(), nil
+}
+
+// truncateOutput shortens s to at most n bytes for error messages.
+func truncateOutput(s string, n int) string {
+	s = strings.TrimSpace(s)
+	if len(s) <= n {
+		return s
+	}
+	return s[:n] + "..."
+}
+
+// outputLimit returns MaxOutputBytes, or DefaultMaxOutputBytes if unset.
+func (a *Adapter) outputLimit() int {
+	if a.MaxOutputBytes <= 0 {
+		return DefaultMaxOutputBytes
+	}
+	return a.MaxOutputBytes
+}
+
+// runPiped starts cmd in its process group and copies its stdout to stdout
+// as it arrives, with stderr going to stderr. If copying stdout fails the
+// process is killed and the copy error returned; otherwise the result is
+// cmd.Wait's.
+func runPiped(cmd *exec.Cmd, stdout, stderr io.Writer) error {
+	cmd.Stderr = stderr
+	pipe, err := cmd.StdoutPipe()
 	if err != nil {
-		return nil, fmt.Errorf("running cursor-agent: %w", err)
+		return err
+	}
+	release, err := startProcessGroup(cmd)
+	if err != nil {
+		return err
+	}
+	defer release()
+
+	if _, err := io.Copy(stdout, pipe); err != nil {
+		if cmd.Cancel != nil {
+			_ = cmd.Cancel()
+		} else {
+			_ = cmd.Process.Kill()
+		}
+		_ = cmd.Wait()
+		return err
 	}
+	return cmd.Wait()
+}
 
-	return output, nil
+// cappedBuffer keeps up to limit bytes and fails the write that would go
+// past it with ErrOutputTooLarge, which makes runPiped kill the process.
+// It deliberately doesn't embed bytes.Buffer, whose ReadFrom would let
+// io.Copy bypass the cap.
+type cappedBuffer struct {
+	buf   bytes.Buffer
+	limit int
+}
+
+func (b *cappedBuffer) Write(p []byte) (int, error) {
+	room := max(b.limit-b.buf.Len(), 0)
+	if len(p) <= room {
+		return b.buf.Write(p)
+	}
+	b.buf.Write(p[:room])
+	return room, fmt.Errorf("%w: output exceeded %d bytes", ErrOutputTooLarge, b.limit)
+}
+
+func (b *cappedBuffer) Bytes() []byte  { return b.buf.Bytes() }
+func (b *cappedBuffer) String() string { return b.buf.String() }
+
+// limitedBuffer keeps the first limit bytes written and discards the rest,
+// so a chatty process can't grow it without bound.
+type limitedBuffer struct {
+	bytes.Buffer
+	limit int
+}
+
+func (b *limitedBuffer) Write(p []byte) (int, error) {
+	if room := b.limit - b.Len(); room > 0 {
+		if len(p) > room {
+			b.Buffer.Write(p[:room])
+		} else {
+			b.Buffer.Write(p)
+		}
+	}
+	return len(p), nil
 }
 
 // Available checks if cursor-agent is available in PATH.
@@ -187,46 +498,19 @@ func Version() (string, error) {
 	return strings.TrimSpace(string(output)), nil
 }
 
-// SupportedModels returns the list of supported models.
-// These are the models available via cursor-agent.
-var SupportedModels = []string{
-	"auto",
-	"opus-4.5-thinking",
-	"opus-4.5",
-	"sonnet-4.5",
-	"sonnet-4.5-thinking",
-	"gpt-5.2",
-	"gpt-5.2-high",
-	"gpt-5.1-codex-max",
-	"gemini-3-pro",
-	"gemini-3-flash",
-	"grok",
-}
+// SupportedModels lists the models available via cursor-agent, in
+// catalog order. It is populated from the model catalog.
+var SupportedModels = catalog.Names()
 
-// IsValidModel checks if a model name is valid.
+// IsValidModel checks if a model name or alias is in the model catalog.
 func IsValidModel(model string) bool {
-	for _, m := range SupportedModels {
-		if m == model {
-			return true
-		}
-	}
-	return false
+	return catalog.Lookup(model) != nil
 }
 
-// ModelProvider returns the provider for a given model.
+// ModelProvider returns the provider for a given model, as recorded in the
+// model catalog or inferred from its name.
 func ModelProvider(model string) string {
-	switch {
-	case strings.HasPrefix(model, "opus-"), strings.HasPrefix(model, "sonnet-"), strings.HasPrefix(model, "haiku-"):
-		return "anthropic"
-	case strings.HasPrefix(model, "gpt-"), strings.HasPrefix(model, "o4-"):
-		return "openai"
-	case strings.HasPrefix(model, "gemini-"):
-		return "google"
-	case model == "grok":
-		return "xai"
-	default:
-		return "unknown"
-	}
+	return catalog.Provider(model)
 }
 
 // TranslateRuntimeConfig converts a Gas Town RuntimeConfig to an Adapter.
@@ -293,42 +577,12 @@ func EnsureWorkspaceReady(workDir, role string) error {
 }
 
 // GetModelForRole returns the recommended model for a Gas Town role.
-// This implements the Council's role-model matrix.
+// This implements the Council's role-model matrix from the model catalog.
 func GetModelForRole(role string) string {
-	switch role {
-	case "mayor":
-		return "opus-4.5-thinking"
-	case "refinery":
-		return "gpt-5.2-high"
-	case "witness":
-		return "gemini-3-flash"
-	case "polecat":
-		return "sonnet-4.5"
-	case "crew":
-		return "auto"
-	case "deacon":
-		return "gemini-3-flash"
-	default:
-		return "auto"
-	}
+	return catalog.RoleDefault(role).Model
 }
 
 // GetModelRationale returns the reasoning for a role's model choice.
 func GetModelRationale(role string) string {
-	switch role {
-	case "mayor":
-		return "Strategic coordination requires sustained reasoning"
-	case "refinery":
-		return "Different model family catches bugs Claude misses"
-	case "witness":
-		return "Fast, cheap monitoring with good reasoning"
-	case "polecat":
-		return "Best coding model for implementation tasks"
-	case "crew":
-		return "User preference for interactive work"
-	case "deacon":
-		return "Lightweight lifecycle management"
-	default:
-		return "Default selection"
-	}
+	return catalog.RoleDefault(role).Rationale
 }
@@ -0,0 +1,275 @@
+package cursor
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cursorworkshop/cursor-gastown/internal/config"
+)
+
+func TestAdapter_CheckModel(t *testing.T) {
+	tests := []struct {
+		name    string
+		adapter Adapter
+		wantErr bool
+	}{
+		{"denied model", Adapter{Model: "opus-4.5", DeniedModels: []string{"opus-4.5"}}, true},
+		{"allowlisted model", Adapter{Model: "sonnet-4.5", AllowedModels: []string{"sonnet-4.5", "gemini-3-flash"}}, false},
+		{"not in allowlist", Adapter{Model: "opus-4.5", AllowedModels: []string{"sonnet-4.5"}}, true},
+		{"empty allowlist", Adapter{Model: "gpt-5.2"}, false},
+		{"empty allowlist with deny", Adapter{Model: "gpt-5.2", DeniedModels: []string{"opus-4.5"}}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.adapter.CheckModel()
+			if tt.wantErr {
+				if !errors.Is(err, ErrModelNotAllowed) {
+					t.Errorf("expected ErrModelNotAllowed, got %v", err)
+				}
+			} else if err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func TestAdapter_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		model   string
+		wantErr bool
+	}{
+		{"valid model", "sonnet-4.5", false},
+		{"auto", "auto", false},
+		{"empty", "", false},
+		{"invalid model", "totally-fake", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := (&Adapter{Model: tt.model}).Validate()
+			if tt.wantErr {
+				if !errors.Is(err, ErrUnsupportedModel) || !strings.Contains(err.Error(), "sonnet-4.5") {
+					t.Errorf("expected ErrUnsupportedModel listing supported models, got %v", err)
+				}
+			} else if err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+		})
+	}
+
+	// No cursor-agent on PATH: Run must refuse before exec.
+	t.Setenv("PATH", t.TempDir())
+	if _, err := (&Adapter{Model: "totally-fake"}).Run("hello"); !errors.Is(err, ErrUnsupportedModel) {
+		t.Errorf("Run error = %v, want ErrUnsupportedModel", err)
+	}
+}
+
+func TestAdapter_RunRejectsDeniedModel(t *testing.T) {
+	a := &Adapter{Model: "opus-4.5", DeniedModels: []string{"opus-4.5"}}
+	if _, err := a.Run("hello"); !errors.Is(err, ErrModelNotAllowed) {
+		t.Errorf("Run should refuse a denied model before invoking cursor-agent, got %v", err)
+	}
+}
+
+func TestAdapter_RunRejectsEmptyPrompt(t *testing.T) {
+	// No cursor-agent on PATH: the guard must fire before exec.
+	t.Setenv("PATH", t.TempDir())
+
+	a := &Adapter{WorkDir: t.TempDir()}
+	for _, prompt := range []string{"", "  \n\t "} {
+		if _, err := a.Run(prompt); !errors.Is(err, ErrEmptyPrompt) {
+			t.Errorf("Run(%q) error = %v, want ErrEmptyPrompt", prompt, err)
+		}
+		if _, err := a.RunJSON(prompt); !errors.Is(err, ErrEmptyPrompt) {
+			t.Errorf("RunJSON(%q) error = %v, want ErrEmptyPrompt", prompt, err)
+		}
+	}
+}
+
+func TestAdapter_RunOutputCap(t *testing.T) {
+	installFakeAgent(t, "#!/bin/sh\nhead -c 4096 /dev/zero | tr '\\0' 'x'\n")
+
+	a := &Adapter{WorkDir: t.TempDir(), MaxOutputBytes: 1000}
+	output, err := a.Run("hello")
+	if !errors.Is(err, ErrOutputTooLarge) {
+		t.Fatalf("Run error = %v, want ErrOutputTooLarge", err)
+	}
+	if !strings.Contains(err.Error(), "output exceeded 1000 bytes") {
+		t.Errorf("error = %q, want the cap in the message", err)
+	}
+	if len(output) != 1000 || strings.Trim(output, "x") != "" {
+		t.Errorf("captured %d bytes, want the first 1000 bytes of output", len(output))
+	}
+
+	a.MaxOutputBytes = 8192
+	output, err = a.Run("hello")
+	if err != nil || len(output) != 4096 {
+		t.Errorf("under the cap: len=%d err=%v, want full 4096 bytes", len(output), err)
+	}
+}
+
+func TestAdapter_RunStream(t *testing.T) {
+	installFakeAgent(t, "#!/bin/sh\necho \"args: $*\"\necho oops >&2\nexit 3\n")
+
+	var stdout, stderr strings.Builder
+	a := &Adapter{WorkDir: t.TempDir()}
+	err := a.RunStream("hello", &stdout, &stderr)
+	if err == nil || !strings.Contains(err.Error(), "cursor-agent failed: exit status 3\noops") {
+		t.Errorf("RunStream error = %v, want the exit status with stderr", err)
+	}
+	if !strings.Contains(stdout.String(), "-p") || !strings.HasSuffix(strings.TrimSpace(stdout.String()), "hello") {
+		t.Errorf("stdout = %q, want print-mode args ending in the prompt", stdout.String())
+	}
+	if stderr.String() != "oops\n" {
+		t.Errorf("stderr = %q, want oops", stderr.String())
+	}
+
+	output, err := a.Run("hello")
+	if err == nil || !strings.HasPrefix(output, "args: ") {
+		t.Errorf("Run = %q, %v; want the output alongside the exit error", output, err)
+	}
+}
+
+func TestAdapter_RunContextTimeout(t *testing.T) {
+	installFakeAgent(t, "#!/bin/sh\necho started\nsleep 30\n")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	a := &Adapter{WorkDir: t.TempDir()}
+	_, err := a.RunContext(ctx, "hello")
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("RunContext error = %v, want context.DeadlineExceeded", err)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("RunContext took %v; the agent was not killed on timeout", elapsed)
+	}
+}
+
+func TestAdapter_RunJSON(t *testing.T) {
+	a := &Adapter{WorkDir: t.TempDir()}
+
+	installFakeAgent(t, "#!/bin/sh\necho 'garbage{'\necho 'error: invalid model' >&2\nexit 1\n")
+	if _, err := a.RunJSON("hello"); err == nil || !strings.Contains(err.Error(), "error: invalid model") {
+		t.Errorf("failing run: error = %v, want cursor-agent's stderr", err)
+	}
+
+	installFakeAgent(t, "#!/bin/sh\necho 'garbage{'\n")
+	if _, err := a.RunJSON("hello"); !errors.Is(err, ErrInvalidJSON) {
+		t.Errorf("non-JSON output: error = %v, want ErrInvalidJSON", err)
+	}
+
+	installFakeAgent(t, "#!/bin/sh\necho '{\"result\": \"ok\"}'\n")
+	if output, err := a.RunJSON("hello"); err != nil || !strings.Contains(string(output), `"ok"`) {
+		t.Errorf("JSON output: got %q, %v", output, err)
+	}
+}
+
+func TestAdapter_RunStdin(t *testing.T) {
+	installFakeAgent(t, "#!/bin/sh\necho \"args: $*\"\necho \"stdin: $(cat)\"\n")
+
+	prompt := "a very long secret prompt"
+	a := &Adapter{WorkDir: t.TempDir(), AdditionalArgs: []string{StdinPromptArg}}
+	output, err := a.RunStdin(prompt)
+	if err != nil {
+		t.Fatalf("RunStdin: %v", err)
+	}
+
+	args, stdin, _ := strings.Cut(output, "\n")
+	if strings.Contains(args, prompt) {
+		t.Errorf("prompt leaked into args: %q", args)
+	}
+	if !strings.HasSuffix(args, " -p --workspace "+a.WorkDir+" -") {
+		t.Errorf("args = %q, want print mode and the stdin placeholder last", args)
+	}
+	if strings.TrimSpace(stdin) != "stdin: "+prompt {
+		t.Errorf("stdin = %q, want the prompt", stdin)
+	}
+}
+
+// installFakeAgent puts a cursor-agent shell script first on PATH.
+func installFakeAgent(t *testing.T, script string) {
+	t.Helper()
+	binDir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(binDir, "cursor-agent"), []byte(script), 0755); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("PATH", binDir+string(os.PathListSeparator)+os.Getenv("PATH"))
+}
+
+func TestAdapterForRoleWithResume(t *testing.T) {
+	store, err := NewSessionStore(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	now := time.Now()
+	for _, sess := range []*Session{
+		{ID: "old", Role: "polecat", RigName: "gastown", Status: SessionStatusActive, LastActiveAt: now.Add(-time.Hour)},
+		{ID: "latest", Role: "polecat", RigName: "gastown", Status: SessionStatusActive, LastActiveAt: now},
+		{ID: "done", Role: "polecat", RigName: "gastown", Status: SessionStatusCompleted, LastActiveAt: now.Add(time.Hour)},
+	} {
+		if err := store.Put(sess); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	a := AdapterForRoleWithResume(store, t.TempDir(), "polecat", "gastown")
+	args := a.BuildArgs("continue")
+	if len(args) < 2 || args[0] != "--resume" || args[1] != "latest" {
+		t.Errorf("args = %v, want to start with --resume latest", args)
+	}
+	if a.Model != "sonnet-4.5" {
+		t.Errorf("model = %q, want the polecat default", a.Model)
+	}
+
+	for _, fresh := range []*Adapter{
+		AdapterForRoleWithResume(store, t.TempDir(), "polecat", "other-rig"),
+		AdapterForRoleWithResume(nil, t.TempDir(), "polecat", "gastown"),
+	} {
+		if fresh.SessionID != "" {
+			t.Errorf("SessionID = %q, want a fresh adapter", fresh.SessionID)
+		}
+	}
+}
+
+func TestDefaultAdapter_SafeMode(t *testing.T) {
+	t.Setenv(DisableForceModeEnv, "")
+	townRoot := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(townRoot, "mayor"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(townRoot, "mayor", "town.json"), []byte("{}"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	rigDir := filepath.Join(townRoot, "gastown", "polecats", "toast")
+	if err := os.MkdirAll(rigDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := config.SetSafeMode(townRoot, true); err != nil {
+		t.Fatalf("SetSafeMode(on) failed: %v", err)
+	}
+	for _, adapter := range []*Adapter{DefaultAdapter(rigDir), AdapterForRole(rigDir, "polecat")} {
+		if adapter.ForceMode || adapter.ApproveAll || adapter.PrintMode {
+			t.Errorf("safe-mode adapter = force:%v approve:%v print:%v, want all off",
+				adapter.ForceMode, adapter.ApproveAll, adapter.PrintMode)
+		}
+	}
+
+	if err := config.SetSafeMode(townRoot, false); err != nil {
+		t.Fatalf("SetSafeMode(off) failed: %v", err)
+	}
+	adapter := AdapterForRole(rigDir, "polecat")
+	if !adapter.ForceMode || !adapter.ApproveAll {
+		t.Errorf("adapter after safe mode off = force:%v approve:%v, want defaults restored",
+			adapter.ForceMode, adapter.ApproveAll)
+	}
+}
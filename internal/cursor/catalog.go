@@ -0,0 +1,172 @@
+package cursor
+
+import (
+	_ "embed"
+	"fmt"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+)
+
+//go:embed config/models.toml
+var modelsTOML []byte
+
+// ModelCatalog describes the models cursor-agent accepts: their providers,
+// aliases, thinking variants, pricing, and capabilities, plus each role's
+// default model.
+// The adapter and the council both read it so they agree on which models
+// exist.
+type ModelCatalog struct {
+	Providers map[string]ProviderFamily `toml:"providers"`
+	Models    []CatalogModel            `toml:"models"`
+	Roles     map[string]RoleDefault    `toml:"roles"`
+
+	// byName indexes Models by name and alias.
+	byName map[string]*CatalogModel
+}
+
+// ProviderFamily lists the name prefixes that identify a provider's models.
+type ProviderFamily struct {
+	Prefixes []string `toml:"prefixes"`
+}
+
+// CatalogModel is one model in the catalog.
+type CatalogModel struct {
+	Name     string   `toml:"name"`
+	Provider string   `toml:"provider"`
+	Aliases  []string `toml:"aliases"`
+
+	// Thinking names the model's extended-reasoning variant, if it has one.
+	Thinking string `toml:"thinking"`
+
+	// Approximate list prices in dollars per 1M tokens; zero if unknown.
+	InputCostPer1M  float64 `toml:"input_cost_per_1m"`
+	OutputCostPer1M float64 `toml:"output_cost_per_1m"`
+
+	// Capabilities lists what the model is notably good at (e.g. "coding",
+	// "long-context").
+	Capabilities []string `toml:"capabilities"`
+}
+
+// Priced reports whether the catalog knows the model's pricing.
+func (m *CatalogModel) Priced() bool {
+	return m.InputCostPer1M > 0 || m.OutputCostPer1M > 0
+}
+
+// RoleDefault is a role's default model and why it was chosen.
+type RoleDefault struct {
+	Model     string `toml:"model"`
+	Rationale string `toml:"rationale"`
+}
+
+// defaultRoleKey is the Roles entry used for roles without their own.
+const defaultRoleKey = "default"
+
+// catalog is the embedded model catalog.
+var catalog = mustLoadCatalog()
+
+func mustLoadCatalog() *ModelCatalog {
+	c, err := ParseModelCatalog(modelsTOML)
+	if err != nil {
+		panic(fmt.Sprintf("embedded model catalog: %v", err))
+	}
+	return c
+}
+
+// Catalog returns the built-in model catalog.
+func Catalog() *ModelCatalog {
+	return catalog
+}
+
+// ParseModelCatalog decodes a TOML model catalog and checks that its
+// names are unique and its providers, thinking variants, and role defaults
+// refer to entries it defines.
+func ParseModelCatalog(data []byte) (*ModelCatalog, error) {
+	var c ModelCatalog
+	if _, err := toml.Decode(string(data), &c); err != nil {
+		return nil, fmt.Errorf("parsing model catalog: %w", err)
+	}
+
+	c.byName = make(map[string]*CatalogModel)
+	for i := range c.Models {
+		m := &c.Models[i]
+		if m.Name == "" {
+			return nil, fmt.Errorf("model %d has no name", i+1)
+		}
+		if m.Provider != "" {
+			if _, ok := c.Providers[m.Provider]; !ok {
+				return nil, fmt.Errorf("model %q: unknown provider %q", m.Name, m.Provider)
+			}
+		}
+		for _, name := range append([]string{m.Name}, m.Aliases...) {
+			if _, dup := c.byName[name]; dup {
+				return nil, fmt.Errorf("model %q is defined more than once", name)
+			}
+			c.byName[name] = m
+		}
+	}
+	for _, m := range c.Models {
+		if m.Thinking != "" && c.Lookup(m.Thinking) == nil {
+			return nil, fmt.Errorf("model %q: unknown thinking variant %q", m.Name, m.Thinking)
+		}
+	}
+	for role, rd := range c.Roles {
+		if c.Lookup(rd.Model) == nil {
+			return nil, fmt.Errorf("role %q: unknown model %q", role, rd.Model)
+		}
+	}
+	return &c, nil
+}
+
+// Lookup returns the model with the given name or alias, or nil.
+func (c *ModelCatalog) Lookup(name string) *CatalogModel {
+	return c.byName[name]
+}
+
+// Names returns the catalog's model names in catalog order, without
+// aliases.
+func (c *ModelCatalog) Names() []string {
+	names := make([]string, len(c.Models))
+	for i, m := range c.Models {
+		names[i] = m.Name
+	}
+	return names
+}
+
+// Canonical returns the catalog name for a model name or alias. Unknown
+// names are returned unchanged.
+func (c *ModelCatalog) Canonical(name string) string {
+	if m := c.Lookup(name); m != nil {
+		return m.Name
+	}
+	return name
+}
+
+// Provider returns the provider serving model. Listed models (and their
+// aliases) use their catalog entry; others are matched against provider
+// name prefixes. It returns "unknown" if neither matches.
+func (c *ModelCatalog) Provider(model string) string {
+	if m := c.Lookup(model); m != nil {
+		if m.Provider == "" {
+			return "unknown"
+		}
+		return m.Provider
+	}
+	for name, p := range c.Providers {
+		for _, prefix := range p.Prefixes {
+			if strings.HasPrefix(model, prefix) {
+				return name
+			}
+		}
+	}
+	return "unknown"
+}
+
+// RoleDefault returns role's default model and rationale, falling back to
+// the catalog's "default" entry.
+func (c *ModelCatalog) RoleDefault(role string) RoleDefault {
+	if rd, ok := c.Roles[role]; ok {
+		return rd
+	}
+	return c.Roles[defaultRoleKey]
+}
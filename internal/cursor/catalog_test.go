@@ -0,0 +1,76 @@
+package cursor
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestModelCatalog_Lookups(t *testing.T) {
+	tests := []struct {
+		model     string
+		valid     bool
+		provider  string
+		canonical string
+	}{
+		{"auto", true, "unknown", "auto"},
+		{"opus-4.5", true, "anthropic", "opus-4.5"},
+		{"claude-opus-4.5", true, "anthropic", "opus-4.5"},
+		{"haiku-3.5", true, "anthropic", "haiku-3.5"},
+		{"o4-mini", true, "openai", "o4-mini"},
+		{"gemini-3-ultra", true, "google", "gemini-3-ultra"},
+		{"grok", true, "xai", "grok"},
+		{"gemini-4-nano", false, "google", "gemini-4-nano"},
+		{"made-up", false, "unknown", "made-up"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.model, func(t *testing.T) {
+			if got := IsValidModel(tt.model); got != tt.valid {
+				t.Errorf("IsValidModel = %v, want %v", got, tt.valid)
+			}
+			if got := ModelProvider(tt.model); got != tt.provider {
+				t.Errorf("ModelProvider = %q, want %q", got, tt.provider)
+			}
+			if got := Catalog().Canonical(tt.model); got != tt.canonical {
+				t.Errorf("Canonical = %q, want %q", got, tt.canonical)
+			}
+		})
+	}
+
+	if m := Catalog().Lookup("opus-4.5"); m == nil || m.Thinking != "opus-4.5-thinking" || !m.Priced() || len(m.Capabilities) == 0 {
+		t.Errorf("opus-4.5 entry = %+v, want a priced model with a thinking variant and capabilities", m)
+	}
+	if got := GetModelForRole("mayor"); got != "opus-4.5-thinking" {
+		t.Errorf("GetModelForRole(mayor) = %q", got)
+	}
+	if got := GetModelForRole("nobody"); got != "auto" || GetModelRationale("nobody") != "Default selection" {
+		t.Errorf("unknown role should use the default entry, got %q", got)
+	}
+	for _, role := range []string{"mayor", "refinery", "witness", "polecat", "crew", "deacon"} {
+		if got := AdapterForRole("/tmp", role).Model; got != GetModelForRole(role) {
+			t.Errorf("AdapterForRole(%s).Model = %q, want %q", role, got, GetModelForRole(role))
+		}
+	}
+}
+
+func TestAdapter_BuildArgsCanonicalizesAlias(t *testing.T) {
+	args := (&Adapter{Model: "claude-sonnet-4.5"}).BuildArgs("hi")
+	if !strings.Contains(strings.Join(args, " "), "--model sonnet-4.5") {
+		t.Errorf("args = %v, want the canonical model name", args)
+	}
+}
+
+func TestParseModelCatalog_Errors(t *testing.T) {
+	tests := map[string]string{
+		"unknown provider": "[[models]]\nname = \"x\"\nprovider = \"acme\"\n",
+		"duplicate alias":  "[[models]]\nname = \"x\"\n[[models]]\nname = \"y\"\naliases = [\"x\"]\n",
+		"unknown thinking": "[[models]]\nname = \"x\"\nthinking = \"x-thinking\"\n",
+		"unknown role":     "[[models]]\nname = \"x\"\n[roles.mayor]\nmodel = \"y\"\n",
+		"bad toml":         "[[models]\n",
+	}
+	for name, data := range tests {
+		if _, err := ParseModelCatalog([]byte(data)); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
@@ -1,7 +1,9 @@
 package cursor
 
 import (
+	"bytes"
 	"embed"
+	"encoding/json"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -22,7 +24,9 @@ type HookEntry struct {
 }
 
 // EnsureHooks ensures Gas Town hooks are installed in the workspace.
-// This creates .cursor/hooks.json and .cursor/hooks/ directory with hook scripts.
+// This creates .cursor/hooks/ with the hook scripts and adds Gas Town's
+// entries to .cursor/hooks.json, merging them into any hooks the user has
+// already configured (see MergeHooksJSON).
 func EnsureHooks(workDir string) error {
 	cursorDir := filepath.Join(workDir, ".cursor")
 	hooksDir := filepath.Join(cursorDir, "hooks")
@@ -32,12 +36,22 @@ func EnsureHooks(workDir string) error {
 		return fmt.Errorf("creating hooks directory: %w", err)
 	}
 
-	// Always install/update hooks.json to ensure latest hooks are configured
+	// Merge Gas Town's hooks into hooks.json, keeping the user's own
 	hooksJsonPath := filepath.Join(cursorDir, "hooks.json")
 	content, err := hooksFS.ReadFile("config/hooks.json")
 	if err != nil {
 		return fmt.Errorf("reading hooks.json template: %w", err)
 	}
+	existing, err := os.ReadFile(hooksJsonPath)
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("reading hooks.json: %w", err)
+	}
+	if len(bytes.TrimSpace(existing)) == 0 {
+		existing = []byte("{}")
+	}
+	if content, err = MergeHooksJSON(existing, content); err != nil {
+		return fmt.Errorf("merging hooks.json: %w", err)
+	}
 	if err := os.WriteFile(hooksJsonPath, content, 0644); err != nil {
 		return fmt.Errorf("writing hooks.json: %w", err)
 	}
@@ -68,6 +82,72 @@ func EnsureHooks(workDir string) error {
 	return nil
 }
 
+// MergeHooksJSON adds the hook entries in gastown to the hooks.json
+// document existing. Entries are appended under each event unless one with
+// the same command is already there, so merging is idempotent. The user's
+// version, other top-level keys, other events, and extra entry fields are
+// preserved.
+func MergeHooksJSON(existing, gastown []byte) ([]byte, error) {
+	var doc map[string]json.RawMessage
+	if err := json.Unmarshal(existing, &doc); err != nil {
+		return nil, fmt.Errorf("parsing existing hooks: %w", err)
+	}
+	if doc == nil {
+		doc = make(map[string]json.RawMessage)
+	}
+	var ours HooksConfig
+	if err := json.Unmarshal(gastown, &ours); err != nil {
+		return nil, fmt.Errorf("parsing Gas Town hooks: %w", err)
+	}
+
+	hooks := make(map[string][]json.RawMessage)
+	if raw, ok := doc["hooks"]; ok && string(raw) != "null" {
+		if err := json.Unmarshal(raw, &hooks); err != nil {
+			return nil, fmt.Errorf("parsing existing hooks: %w", err)
+		}
+	}
+
+	for event, entries := range ours.Hooks {
+		for _, entry := range entries {
+			if hasHookCommand(hooks[event], entry.Command) {
+				continue
+			}
+			raw, err := json.Marshal(entry)
+			if err != nil {
+				return nil, err
+			}
+			hooks[event] = append(hooks[event], raw)
+		}
+	}
+
+	merged, err := json.Marshal(hooks)
+	if err != nil {
+		return nil, err
+	}
+	doc["hooks"] = merged
+	if _, ok := doc["version"]; !ok {
+		doc["version"] = json.RawMessage(fmt.Sprint(ours.Version))
+	}
+
+	out, err := json.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append(out, '\n'), nil
+}
+
+// hasHookCommand reports whether entries include one running command.
+// Entries that aren't objects with a command are ignored.
+func hasHookCommand(entries []json.RawMessage, command string) bool {
+	for _, raw := range entries {
+		var entry HookEntry
+		if json.Unmarshal(raw, &entry) == nil && entry.Command == command {
+			return true
+		}
+	}
+	return false
+}
+
 // HooksInstalled checks if Gas Town hooks are installed in the workspace.
 func HooksInstalled(workDir string) bool {
 	hooksJsonPath := filepath.Join(workDir, ".cursor", "hooks.json")
@@ -105,8 +105,79 @@ func TestEnsureHooks_Idempotent(t *testing.T) {
 
 	// Verify still works
 	hooksJsonPath := filepath.Join(tmpDir, ".cursor", "hooks.json")
-	if _, err := os.Stat(hooksJsonPath); os.IsNotExist(err) {
-		t.Error("hooks.json should still exist")
+	first, err := os.ReadFile(hooksJsonPath)
+	if err != nil {
+		t.Fatalf("hooks.json should still exist: %v", err)
+	}
+
+	// Further runs leave hooks.json unchanged rather than adding duplicates.
+	if err := EnsureHooks(tmpDir); err != nil {
+		t.Fatalf("EnsureHooks failed: %v", err)
+	}
+	second, err := os.ReadFile(hooksJsonPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(first) != string(second) {
+		t.Errorf("hooks.json changed on rerun:\n%s\nthen\n%s", first, second)
+	}
+
+	var config HooksConfig
+	if err := json.Unmarshal(second, &config); err != nil {
+		t.Fatal(err)
+	}
+	if len(config.Hooks["stop"]) != 1 {
+		t.Errorf("stop hooks = %v, want one entry", config.Hooks["stop"])
+	}
+}
+
+func TestEnsureHooks_MergesUserHooks(t *testing.T) {
+	tmpDir := t.TempDir()
+	cursorDir := filepath.Join(tmpDir, ".cursor")
+	if err := os.MkdirAll(cursorDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	user := `{
+  "version": 2,
+  "hooks": {
+    "stop": [{"command": "./notify.sh", "timeout": 5}],
+    "afterFileEdit": [{"command": "./format.sh"}]
+  }
+}`
+	hooksJsonPath := filepath.Join(cursorDir, "hooks.json")
+	if err := os.WriteFile(hooksJsonPath, []byte(user), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := EnsureHooks(tmpDir); err != nil {
+		t.Fatalf("EnsureHooks failed: %v", err)
+	}
+
+	content, err := os.ReadFile(hooksJsonPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var config struct {
+		Version int                         `json:"version"`
+		Hooks   map[string][]map[string]any `json:"hooks"`
+	}
+	if err := json.Unmarshal(content, &config); err != nil {
+		t.Fatalf("merged hooks.json is not valid JSON: %v", err)
+	}
+
+	if config.Version != 2 {
+		t.Errorf("version = %d, want the user's 2", config.Version)
+	}
+	stop := config.Hooks["stop"]
+	if len(stop) != 2 || stop[0]["command"] != "./notify.sh" || stop[0]["timeout"] != float64(5) ||
+		stop[1]["command"] != "bash -lc '.cursor/hooks/gastown-stop.sh'" {
+		t.Errorf("stop hooks = %v, want the user's hook then Gas Town's", stop)
+	}
+	if edits := config.Hooks["afterFileEdit"]; len(edits) != 1 || edits[0]["command"] != "./format.sh" {
+		t.Errorf("afterFileEdit hooks = %v, want the user's hook preserved", edits)
+	}
+	if len(config.Hooks["beforeSubmitPrompt"]) != 1 {
+		t.Errorf("beforeSubmitPrompt hooks = %v, want Gas Town's entry", config.Hooks["beforeSubmitPrompt"])
 	}
 }
 
@@ -1,17 +1,24 @@
 package cursor
 
 import (
+	"bytes"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/BurntSushi/toml"
 )
 
 // MCPConfig represents the structure of a Cursor mcp.json file.
 // See: https://cursor.com/docs/context/mcp
 type MCPConfig struct {
 	// McpServers maps server names to their configurations.
-	McpServers map[string]MCPServer `json:"mcpServers"`
+	McpServers map[string]MCPServer `json:"mcpServers" toml:"mcpServers"`
 }
 
 // MCPServer represents an MCP server configuration.
@@ -19,43 +26,43 @@ type MCPConfig struct {
 type MCPServer struct {
 	// Type indicates the server transport type: "stdio" for local commands.
 	// If URL is set and Command is empty, the type is implied to be HTTP/SSE.
-	Type string `json:"type,omitempty"`
+	Type string `json:"type,omitempty" toml:"type,omitempty"`
 
 	// URL is the endpoint URL for the MCP server (for HTTP-based servers).
-	URL string `json:"url,omitempty"`
+	URL string `json:"url,omitempty" toml:"url,omitempty"`
 
 	// Command is the command to run for stdio-based MCP servers.
-	Command string `json:"command,omitempty"`
+	Command string `json:"command,omitempty" toml:"command,omitempty"`
 
 	// Args are command-line arguments for stdio-based servers.
-	Args []string `json:"args,omitempty"`
+	Args []string `json:"args,omitempty" toml:"args,omitempty"`
 
 	// Env contains environment variables for the server process.
 	// Supports interpolation: ${env:NAME}, ${workspaceFolder}, ${userHome}
-	Env map[string]string `json:"env,omitempty"`
+	Env map[string]string `json:"env,omitempty" toml:"env,omitempty"`
 
 	// EnvFile is the path to an environment file to load additional variables.
 	// Supports interpolation: ${workspaceFolder}/.env
-	EnvFile string `json:"envFile,omitempty"`
+	EnvFile string `json:"envFile,omitempty" toml:"envFile,omitempty"`
 
 	// Headers contains HTTP headers for HTTP-based servers.
 	// Supports interpolation: ${env:MY_TOKEN}
-	Headers map[string]string `json:"headers,omitempty"`
+	Headers map[string]string `json:"headers,omitempty" toml:"headers,omitempty"`
 
 	// Auth contains OAuth configuration for remote servers.
-	Auth *MCPAuth `json:"auth,omitempty"`
+	Auth *MCPAuth `json:"auth,omitempty" toml:"auth,omitempty"`
 }
 
 // MCPAuth contains OAuth configuration for remote MCP servers.
 type MCPAuth struct {
 	// ClientID is the OAuth 2.0 Client ID from the MCP provider.
-	ClientID string `json:"CLIENT_ID,omitempty"`
+	ClientID string `json:"CLIENT_ID,omitempty" toml:"CLIENT_ID,omitempty"`
 
 	// ClientSecret is the OAuth 2.0 Client Secret (for confidential clients).
-	ClientSecret string `json:"CLIENT_SECRET,omitempty"`
+	ClientSecret string `json:"CLIENT_SECRET,omitempty" toml:"CLIENT_SECRET,omitempty"`
 
 	// Scopes are the OAuth scopes to request.
-	Scopes []string `json:"scopes,omitempty"`
+	Scopes []string `json:"scopes,omitempty" toml:"scopes,omitempty"`
 }
 
 // MCPConfigPath returns the path to the workspace-level mcp.json.
@@ -200,22 +207,323 @@ func GlobalMCPConfigPath() (string, error) {
 	return filepath.Join(home, ".cursor", "mcp.json"), nil
 }
 
+// MergeStrategy controls how MergeMCPConfigsWithStrategy resolves servers
+// defined under the same name in more than one config.
+type MergeStrategy string
+
+const (
+	// MergeOverride lets later configs replace earlier ones ("later wins").
+	MergeOverride MergeStrategy = "override"
+
+	// MergeKeepFirst keeps the first definition and ignores later ones.
+	MergeKeepFirst MergeStrategy = "keep-first"
+
+	// MergeErrorOnConflict fails the merge if any name is defined twice.
+	MergeErrorOnConflict MergeStrategy = "error-on-conflict"
+)
+
+// ErrMCPConflict is returned by MergeMCPConfigsWithStrategy when the
+// error-on-conflict strategy finds servers defined in more than one config.
+var ErrMCPConflict = errors.New("conflicting MCP server definitions")
+
 // MergeMCPConfigs merges multiple MCP configurations.
 // Later configs override earlier ones for servers with the same name.
 func MergeMCPConfigs(configs ...*MCPConfig) *MCPConfig {
+	result, _, _ := MergeMCPConfigsWithStrategy(MergeOverride, configs...)
+	return result
+}
+
+// MergeMCPConfigsWithStrategy merges multiple MCP configurations, resolving
+// name clashes according to strategy. It also returns the sorted names of
+// servers defined in more than one config so callers can warn about them.
+// With MergeErrorOnConflict, a clash returns a nil config and an error
+// wrapping ErrMCPConflict.
+func MergeMCPConfigsWithStrategy(strategy MergeStrategy, configs ...*MCPConfig) (*MCPConfig, []string, error) {
+	switch strategy {
+	case MergeOverride, MergeKeepFirst, MergeErrorOnConflict:
+	default:
+		return nil, nil, fmt.Errorf("unknown merge strategy %q", strategy)
+	}
+
 	result := &MCPConfig{
 		McpServers: make(map[string]MCPServer),
 	}
 
+	var conflicts []string
+	seen := make(map[string]bool)
 	for _, cfg := range configs {
 		if cfg == nil {
 			continue
 		}
 		for name, server := range cfg.McpServers {
+			if _, exists := result.McpServers[name]; exists {
+				if !seen[name] {
+					conflicts = append(conflicts, name)
+					seen[name] = true
+				}
+				if strategy == MergeKeepFirst {
+					continue
+				}
+			}
 			result.McpServers[name] = server
 		}
 	}
+	sort.Strings(conflicts)
 
-	return result
+	if strategy == MergeErrorOnConflict && len(conflicts) > 0 {
+		return nil, conflicts, fmt.Errorf("%w: %s", ErrMCPConflict, strings.Join(conflicts, ", "))
+	}
+
+	return result, conflicts, nil
+}
+
+// MCPScopeConflict describes a server defined in both the global and the
+// workspace mcp.json.
+type MCPScopeConflict struct {
+	// Name is the server name shared by both scopes.
+	Name string `json:"name"`
+
+	// Identical is true when both definitions resolve to the same server,
+	// making the workspace entry redundant.
+	Identical bool `json:"identical"`
+
+	// Fields lists the mcp.json fields that differ (e.g. "url", "env").
+	// Empty when Identical.
+	Fields []string `json:"fields,omitempty"`
 }
 
+// CompareMCPScopes loads the global and workspace MCP configs and reports
+// every server defined in both, sorted by name. Values are interpolated
+// before comparing so "${env:TOKEN}" and the token itself are not reported
+// as a difference.
+func CompareMCPScopes(workDir string) ([]MCPScopeConflict, error) {
+	globalPath, err := GlobalMCPConfigPath()
+	if err != nil {
+		return nil, err
+	}
+	global, err := LoadMCPConfig(globalPath)
+	if err != nil {
+		return nil, fmt.Errorf("loading global config: %w", err)
+	}
+	workspace, err := LoadMCPConfig(MCPConfigPath(workDir))
+	if err != nil {
+		return nil, fmt.Errorf("loading workspace config: %w", err)
+	}
+	return compareMCPConfigs(global, workspace, workDir), nil
+}
+
+func compareMCPConfigs(global, workspace *MCPConfig, workDir string) []MCPScopeConflict {
+	var conflicts []MCPScopeConflict
+	for name, ws := range workspace.McpServers {
+		gs, ok := global.McpServers[name]
+		if !ok {
+			continue
+		}
+		fields := diffMCPServers(gs.Resolve(workDir), ws.Resolve(workDir))
+		conflicts = append(conflicts, MCPScopeConflict{
+			Name:      name,
+			Identical: len(fields) == 0,
+			Fields:    fields,
+		})
+	}
+	sort.Slice(conflicts, func(i, j int) bool {
+		return conflicts[i].Name < conflicts[j].Name
+	})
+	return conflicts
+}
+
+// diffMCPServers returns the mcp.json names of the fields that differ.
+// Nil and empty collections compare equal.
+func diffMCPServers(a, b MCPServer) []string {
+	var fields []string
+	check := func(name string, x, y interface{}, empty bool) {
+		if !empty && !reflect.DeepEqual(x, y) {
+			fields = append(fields, name)
+		}
+	}
+	check("type", a.Type, b.Type, false)
+	check("url", a.URL, b.URL, false)
+	check("command", a.Command, b.Command, false)
+	check("args", a.Args, b.Args, len(a.Args) == 0 && len(b.Args) == 0)
+	check("env", a.Env, b.Env, len(a.Env) == 0 && len(b.Env) == 0)
+	check("envFile", a.EnvFile, b.EnvFile, false)
+	check("headers", a.Headers, b.Headers, len(a.Headers) == 0 && len(b.Headers) == 0)
+	check("auth", a.Auth, b.Auth, false)
+	return fields
+}
+
+// Resolve returns a copy of the server with ${env:NAME}, ${workspaceFolder}
+// and ${userHome} expanded the way Cursor does at launch. Unknown or unset
+// references are left as written.
+func (s MCPServer) Resolve(workDir string) MCPServer {
+	home, _ := os.UserHomeDir()
+	expand := func(v string) string {
+		return os.Expand(v, func(name string) string {
+			switch {
+			case name == "workspaceFolder":
+				return workDir
+			case name == "userHome" && home != "":
+				return home
+			case strings.HasPrefix(name, "env:"):
+				if val, ok := os.LookupEnv(strings.TrimPrefix(name, "env:")); ok {
+					return val
+				}
+			}
+			return "${" + name + "}"
+		})
+	}
+	expandMap := func(m map[string]string) map[string]string {
+		if m == nil {
+			return nil
+		}
+		out := make(map[string]string, len(m))
+		for k, v := range m {
+			out[k] = expand(v)
+		}
+		return out
+	}
+
+	r := s
+	r.URL = expand(s.URL)
+	r.Command = expand(s.Command)
+	if s.Args != nil {
+		r.Args = make([]string, len(s.Args))
+		for i, arg := range s.Args {
+			r.Args[i] = expand(arg)
+		}
+	}
+	r.Env = expandMap(s.Env)
+	r.EnvFile = expand(s.EnvFile)
+	r.Headers = expandMap(s.Headers)
+	if s.Auth != nil {
+		auth := *s.Auth
+		auth.ClientID = expand(auth.ClientID)
+		auth.ClientSecret = expand(auth.ClientSecret)
+		r.Auth = &auth
+	}
+	return r
+}
+
+// MCPApplyResult reports what ApplyMCPServersWithResult did to each server.
+type MCPApplyResult struct {
+	// Added are servers that were not configured before.
+	Added []string `json:"added"`
+
+	// Updated are existing servers replaced with a new definition.
+	Updated []string `json:"updated"`
+
+	// Unchanged are existing servers whose definition already matched.
+	Unchanged []string `json:"unchanged"`
+
+	// Skipped are existing servers left alone because overwrite was false.
+	Skipped []string `json:"skipped"`
+}
+
+// ApplyMCPServers adds servers to the workspace configuration. Existing
+// servers with the same name are replaced only when overwrite is true.
+// Every server must pass IsConfigured or nothing is written.
+func ApplyMCPServers(workDir string, servers map[string]MCPServer, overwrite bool) error {
+	_, err := ApplyMCPServersWithResult(workDir, servers, overwrite)
+	return err
+}
+
+// ApplyMCPServersWithResult is ApplyMCPServers, also reporting which
+// servers were added, updated, unchanged, or skipped (each sorted by name).
+func ApplyMCPServersWithResult(workDir string, servers map[string]MCPServer, overwrite bool) (*MCPApplyResult, error) {
+	names := make([]string, 0, len(servers))
+	var invalid []string
+	for name, server := range servers {
+		names = append(names, name)
+		if !server.IsConfigured() {
+			invalid = append(invalid, name)
+		}
+	}
+	sort.Strings(names)
+	if len(invalid) > 0 {
+		sort.Strings(invalid)
+		return nil, fmt.Errorf("MCP servers need a command or url: %s", strings.Join(invalid, ", "))
+	}
+
+	path := MCPConfigPath(workDir)
+	config, err := LoadMCPConfig(path)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &MCPApplyResult{}
+	for _, name := range names {
+		server := servers[name]
+		existing, exists := config.McpServers[name]
+		switch {
+		case !exists:
+			result.Added = append(result.Added, name)
+		case len(diffMCPServers(existing, server)) == 0:
+			result.Unchanged = append(result.Unchanged, name)
+			continue
+		case !overwrite:
+			result.Skipped = append(result.Skipped, name)
+			continue
+		default:
+			result.Updated = append(result.Updated, name)
+		}
+		config.McpServers[name] = server
+	}
+
+	if len(result.Added)+len(result.Updated) == 0 {
+		return result, nil
+	}
+	if err := SaveMCPConfig(path, config); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// LoadMCPServersFile reads a server list in mcp.json layout. Files ending
+// in .toml are parsed as TOML ([mcpServers.<name>] tables); anything else
+// is parsed as JSON.
+func LoadMCPServersFile(path string) (map[string]MCPServer, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading %s: %w", path, err)
+	}
+
+	var config MCPConfig
+	if isTOMLPath(path) {
+		if _, err := toml.Decode(string(data), &config); err != nil {
+			return nil, fmt.Errorf("parsing %s: %w", path, err)
+		}
+	} else if err := json.Unmarshal(data, &config); err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", path, err)
+	}
+
+	if config.McpServers == nil {
+		config.McpServers = make(map[string]MCPServer)
+	}
+	return config.McpServers, nil
+}
+
+// WriteMCPServersFile writes config to path in the format LoadMCPServersFile
+// reads: TOML for .toml paths, indented JSON otherwise.
+func WriteMCPServersFile(path string, config *MCPConfig) error {
+	var buf bytes.Buffer
+	if isTOMLPath(path) {
+		if err := toml.NewEncoder(&buf).Encode(config); err != nil {
+			return fmt.Errorf("encoding %s: %w", path, err)
+		}
+	} else {
+		data, err := json.MarshalIndent(config, "", "  ")
+		if err != nil {
+			return fmt.Errorf("encoding %s: %w", path, err)
+		}
+		buf.Write(append(data, '\n'))
+	}
+
+	if err := os.WriteFile(path, buf.Bytes(), 0644); err != nil {
+		return fmt.Errorf("writing %s: %w", path, err)
+	}
+	return nil
+}
+
+func isTOMLPath(path string) bool {
+	return strings.EqualFold(filepath.Ext(path), ".toml")
+}
@@ -1,8 +1,10 @@
 package cursor
 
 import (
+	"errors"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 )
 
@@ -147,6 +149,96 @@ func TestAddMCPServer(t *testing.T) {
 	}
 }
 
+func TestApplyMCPServers_FromFile(t *testing.T) {
+	workDir := t.TempDir()
+	if err := AddMCPServer(workDir, "github", MCPServer{URL: "https://old.example.com/mcp"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := AddMCPServer(workDir, "docs", MCPServer{URL: "https://docs.example.com/mcp"}); err != nil {
+		t.Fatal(err)
+	}
+
+	file := filepath.Join(t.TempDir(), "servers.toml")
+	content := `[mcpServers.github]
+url = "https://api.githubcopilot.com/mcp"
+
+[mcpServers.github.headers]
+Authorization = "Bearer ${env:GITHUB_TOKEN}"
+
+[mcpServers.filesystem]
+command = "npx"
+args = ["-y", "@modelcontextprotocol/server-filesystem", "${workspaceFolder}"]
+envFile = "${workspaceFolder}/.env"
+
+[mcpServers.docs]
+url = "https://docs.example.com/mcp"
+`
+	if err := os.WriteFile(file, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	servers, err := LoadMCPServersFile(file)
+	if err != nil {
+		t.Fatalf("LoadMCPServersFile failed: %v", err)
+	}
+
+	kept, err := ApplyMCPServersWithResult(workDir, servers, false)
+	if err != nil {
+		t.Fatalf("apply without overwrite failed: %v", err)
+	}
+	if strings.Join(kept.Added, ",") != "filesystem" || strings.Join(kept.Skipped, ",") != "github" || strings.Join(kept.Unchanged, ",") != "docs" {
+		t.Errorf("no-overwrite result = %+v", kept)
+	}
+
+	result, err := ApplyMCPServersWithResult(workDir, servers, true)
+	if err != nil {
+		t.Fatalf("apply failed: %v", err)
+	}
+	if strings.Join(result.Updated, ",") != "github" || len(result.Added) != 0 {
+		t.Errorf("overwrite result = %+v, want github updated", result)
+	}
+
+	config, err := LoadMCPConfig(MCPConfigPath(workDir))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(config.McpServers) != 3 {
+		t.Fatalf("expected 3 servers, got %v", config.McpServers)
+	}
+	github := config.McpServers["github"]
+	if github.MCPServerType() != "http" || github.Headers["Authorization"] != "Bearer ${env:GITHUB_TOKEN}" {
+		t.Errorf("github = %+v, want updated http server with headers", github)
+	}
+	fs := config.McpServers["filesystem"]
+	if fs.MCPServerType() != "stdio" || len(fs.Args) != 3 || fs.EnvFile != "${workspaceFolder}/.env" {
+		t.Errorf("filesystem = %+v, want stdio server with args and envFile", fs)
+	}
+
+	// Export round-trips through JSON.
+	exported := filepath.Join(t.TempDir(), "servers.json")
+	if err := WriteMCPServersFile(exported, config); err != nil {
+		t.Fatalf("WriteMCPServersFile failed: %v", err)
+	}
+	roundTrip, err := LoadMCPServersFile(exported)
+	if err != nil || len(roundTrip) != 3 || roundTrip["filesystem"].Command != "npx" {
+		t.Errorf("round trip = %+v, %v", roundTrip, err)
+	}
+}
+
+func TestApplyMCPServers_RejectsUnconfigured(t *testing.T) {
+	workDir := t.TempDir()
+	err := ApplyMCPServers(workDir, map[string]MCPServer{
+		"good":  {Command: "good-cli"},
+		"empty": {Env: map[string]string{"A": "1"}},
+	}, true)
+	if err == nil || !strings.Contains(err.Error(), "empty") {
+		t.Fatalf("expected an error naming the unconfigured server, got %v", err)
+	}
+	if _, statErr := os.Stat(MCPConfigPath(workDir)); !os.IsNotExist(statErr) {
+		t.Error("nothing should be written when a server is invalid")
+	}
+}
+
 func TestRemoveMCPServer(t *testing.T) {
 	tmpDir := t.TempDir()
 
@@ -347,3 +439,138 @@ func TestMergeMCPConfigs_NilHandling(t *testing.T) {
 		t.Errorf("expected 1 server, got %d", len(result.McpServers))
 	}
 }
+
+func TestMergeMCPConfigsWithStrategy(t *testing.T) {
+	first := &MCPConfig{
+		McpServers: map[string]MCPServer{
+			"only-first": {URL: "https://first.com"},
+			"shared":     {URL: "https://first.com/shared"},
+			"also":       {Command: "first"},
+		},
+	}
+	second := &MCPConfig{
+		McpServers: map[string]MCPServer{
+			"only-second": {Command: "second"},
+			"shared":      {URL: "https://second.com/shared"},
+			"also":        {Command: "second"},
+		},
+	}
+
+	t.Run("override", func(t *testing.T) {
+		result, conflicts, err := MergeMCPConfigsWithStrategy(MergeOverride, first, second)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(result.McpServers) != 4 {
+			t.Errorf("expected 4 servers, got %d", len(result.McpServers))
+		}
+		if result.McpServers["shared"].URL != "https://second.com/shared" {
+			t.Errorf("shared URL = %q, want later config to win", result.McpServers["shared"].URL)
+		}
+		if strings.Join(conflicts, ",") != "also,shared" {
+			t.Errorf("conflicts = %v, want [also shared]", conflicts)
+		}
+	})
+
+	t.Run("keep-first", func(t *testing.T) {
+		result, conflicts, err := MergeMCPConfigsWithStrategy(MergeKeepFirst, first, second)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if result.McpServers["shared"].URL != "https://first.com/shared" {
+			t.Errorf("shared URL = %q, want first config to win", result.McpServers["shared"].URL)
+		}
+		if result.McpServers["also"].Command != "first" {
+			t.Errorf("also command = %q, want first", result.McpServers["also"].Command)
+		}
+		if result.McpServers["only-second"].Command != "second" {
+			t.Error("non-conflicting servers from later configs should be added")
+		}
+		if len(conflicts) != 2 {
+			t.Errorf("conflicts = %v, want 2 names", conflicts)
+		}
+	})
+
+	t.Run("error-on-conflict", func(t *testing.T) {
+		result, conflicts, err := MergeMCPConfigsWithStrategy(MergeErrorOnConflict, first, second)
+		if !errors.Is(err, ErrMCPConflict) {
+			t.Fatalf("err = %v, want ErrMCPConflict", err)
+		}
+		if result != nil {
+			t.Error("result should be nil on conflict")
+		}
+		if strings.Join(conflicts, ",") != "also,shared" {
+			t.Errorf("conflicts = %v, want [also shared]", conflicts)
+		}
+		if !strings.Contains(err.Error(), "shared") {
+			t.Errorf("error %q should name the conflicting server", err)
+		}
+	})
+
+	t.Run("error-on-conflict without clashes", func(t *testing.T) {
+		result, conflicts, err := MergeMCPConfigsWithStrategy(MergeErrorOnConflict, first, nil)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(conflicts) != 0 || len(result.McpServers) != 3 {
+			t.Errorf("got %d servers and conflicts %v, want 3 and none", len(result.McpServers), conflicts)
+		}
+	})
+
+	t.Run("unknown strategy", func(t *testing.T) {
+		if _, _, err := MergeMCPConfigsWithStrategy("latest", first); err == nil {
+			t.Error("expected error for unknown strategy")
+		}
+	})
+}
+
+func TestCompareMCPScopes(t *testing.T) {
+	home := t.TempDir()
+	workDir := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("GT_TEST_MCP_TOKEN", "secret")
+
+	global := &MCPConfig{McpServers: map[string]MCPServer{
+		"github": {
+			URL:     "https://api.example.com/mcp",
+			Headers: map[string]string{"Authorization": "Bearer ${env:GT_TEST_MCP_TOKEN}"},
+		},
+		"db":          {Command: "db-mcp", Args: []string{"--port", "5432"}},
+		"global-only": {URL: "https://global.example.com"},
+	}}
+	workspace := &MCPConfig{McpServers: map[string]MCPServer{
+		"github": {
+			URL:     "https://api.example.com/mcp",
+			Headers: map[string]string{"Authorization": "Bearer secret"},
+		},
+		"db":             {Command: "db-mcp", Args: []string{"--port", "5433"}, Env: map[string]string{"DEBUG": "1"}},
+		"workspace-only": {Command: "local-mcp"},
+	}}
+
+	globalPath, err := GlobalMCPConfigPath()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := SaveMCPConfig(globalPath, global); err != nil {
+		t.Fatal(err)
+	}
+	if err := SaveMCPConfig(MCPConfigPath(workDir), workspace); err != nil {
+		t.Fatal(err)
+	}
+
+	conflicts, err := CompareMCPScopes(workDir)
+	if err != nil {
+		t.Fatalf("CompareMCPScopes failed: %v", err)
+	}
+	if len(conflicts) != 2 {
+		t.Fatalf("conflicts = %+v, want db and github only", conflicts)
+	}
+
+	db, gh := conflicts[0], conflicts[1]
+	if db.Name != "db" || db.Identical || strings.Join(db.Fields, ",") != "args,env" {
+		t.Errorf("db = %+v, want divergent on args,env", db)
+	}
+	if gh.Name != "github" || !gh.Identical || len(gh.Fields) != 0 {
+		t.Errorf("github = %+v, want identical after interpolation", gh)
+	}
+}
@@ -0,0 +1,135 @@
+//go:build windows
+
+package cursor
+
+import (
+	"fmt"
+	"os/exec"
+	"sync"
+	"syscall"
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+)
+
+// jobs maps a running command to the job object holding it and its
+// descendants.
+var jobs sync.Map // map[*exec.Cmd]windows.Handle
+
+// setProcessGroup starts cmd in a new process group so it doesn't receive
+// the console's Ctrl+C meant for gt.
+func setProcessGroup(cmd *exec.Cmd) {
+	if cmd.SysProcAttr == nil {
+		cmd.SysProcAttr = &syscall.SysProcAttr{}
+	}
+	cmd.SysProcAttr.CreationFlags |= syscall.CREATE_NEW_PROCESS_GROUP
+}
+
+// startProcessGroup starts cmd inside a job object created with
+// JOB_OBJECT_LIMIT_KILL_ON_JOB_CLOSE, which processes it spawns inherit.
+// The process is created suspended and resumed only once it is in the job,
+// so nothing it spawns can escape. gt holds the only handle to the job, so
+// the agent's whole tree is killed when release closes it or when gt
+// itself exits.
+func startProcessGroup(cmd *exec.Cmd) (release func(), err error) {
+	job, err := newKillOnCloseJob()
+	if err != nil {
+		return nil, fmt.Errorf("creating job object for cursor-agent: %w", err)
+	}
+	if cmd.SysProcAttr == nil {
+		cmd.SysProcAttr = &syscall.SysProcAttr{}
+	}
+	cmd.SysProcAttr.CreationFlags |= windows.CREATE_SUSPENDED
+	if err := cmd.Start(); err != nil {
+		_ = windows.CloseHandle(job)
+		return nil, err
+	}
+
+	err = assignToJob(job, cmd.Process.Pid)
+	if err == nil {
+		err = resumeProcess(cmd.Process.Pid)
+	}
+	if err != nil {
+		_ = cmd.Process.Kill()
+		_ = cmd.Wait()
+		_ = windows.CloseHandle(job)
+		return nil, fmt.Errorf("starting cursor-agent in its job object: %w", err)
+	}
+	jobs.Store(cmd, job)
+	return func() {
+		if job, ok := jobs.LoadAndDelete(cmd); ok {
+			_ = windows.CloseHandle(job.(windows.Handle))
+		}
+	}, nil
+}
+
+func newKillOnCloseJob() (windows.Handle, error) {
+	job, err := windows.CreateJobObject(nil, nil)
+	if err != nil {
+		return 0, err
+	}
+	info := windows.JOBOBJECT_EXTENDED_LIMIT_INFORMATION{
+		BasicLimitInformation: windows.JOBOBJECT_BASIC_LIMIT_INFORMATION{
+			LimitFlags: windows.JOB_OBJECT_LIMIT_KILL_ON_JOB_CLOSE,
+		},
+	}
+	if _, err := windows.SetInformationJobObject(job, windows.JobObjectExtendedLimitInformation,
+		uintptr(unsafe.Pointer(&info)), uint32(unsafe.Sizeof(info))); err != nil {
+		_ = windows.CloseHandle(job)
+		return 0, err
+	}
+	return job, nil
+}
+
+func assignToJob(job windows.Handle, pid int) error {
+	proc, err := windows.OpenProcess(windows.PROCESS_SET_QUOTA|windows.PROCESS_TERMINATE, false, uint32(pid))
+	if err != nil {
+		return err
+	}
+	defer windows.CloseHandle(proc)
+	return windows.AssignProcessToJobObject(job, proc)
+}
+
+// resumeProcess resumes the threads of a process created suspended.
+func resumeProcess(pid int) error {
+	snapshot, err := windows.CreateToolhelp32Snapshot(windows.TH32CS_SNAPTHREAD, 0)
+	if err != nil {
+		return err
+	}
+	defer windows.CloseHandle(snapshot)
+
+	entry := windows.ThreadEntry32{Size: uint32(unsafe.Sizeof(windows.ThreadEntry32{}))}
+	resumed := false
+	for err = windows.Thread32First(snapshot, &entry); err == nil; err = windows.Thread32Next(snapshot, &entry) {
+		if entry.OwnerProcessID != uint32(pid) {
+			continue
+		}
+		thread, err := windows.OpenThread(windows.THREAD_SUSPEND_RESUME, false, entry.ThreadID)
+		if err != nil {
+			return err
+		}
+		_, err = windows.ResumeThread(thread)
+		_ = windows.CloseHandle(thread)
+		if err != nil {
+			return err
+		}
+		resumed = true
+	}
+	if !resumed {
+		return fmt.Errorf("no threads found for process %d", pid)
+	}
+	return nil
+}
+
+// killProcessGroup kills cmd and its descendant processes by terminating
+// its job object. Commands not started by startProcessGroup only have
+// their own process killed.
+func killProcessGroup(cmd *exec.Cmd) error {
+	if cmd.Process == nil {
+		return nil
+	}
+	if job, ok := jobs.Load(cmd); ok {
+		return windows.TerminateJobObject(job.(windows.Handle), 1)
+	}
+	return cmd.Process.Kill()
+}
@@ -0,0 +1,125 @@
+package cursor
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// RunResult is the typed result of a non-interactive cursor-agent run.
+type RunResult struct {
+	// SessionID is the chat ID, usable with --resume.
+	SessionID string `json:"session_id,omitempty"`
+
+	// Model is the model that served the request.
+	Model string `json:"model,omitempty"`
+
+	// Output is the final assistant text.
+	Output string `json:"output"`
+
+	// InputTokens and OutputTokens are token usage, if reported.
+	InputTokens  int64 `json:"input_tokens,omitempty"`
+	OutputTokens int64 `json:"output_tokens,omitempty"`
+
+	// Cost is the reported cost in USD, if any.
+	Cost float64 `json:"cost,omitempty"`
+
+	// Duration is the wall-clock time reported by cursor-agent.
+	Duration time.Duration `json:"duration_ms,omitempty"`
+
+	// Success is false when cursor-agent reported an error result.
+	Success bool `json:"success"`
+
+	// Error is the error message for failed runs.
+	Error string `json:"error,omitempty"`
+}
+
+// rawRunResult mirrors the cursor-agent JSON result event. All fields are
+// optional; older cursor-agent versions omit usage and cost.
+type rawRunResult struct {
+	Type         string  `json:"type"`
+	Subtype      string  `json:"subtype"`
+	IsError      bool    `json:"is_error"`
+	Result       string  `json:"result"`
+	Error        string  `json:"error"`
+	SessionID    string  `json:"session_id"`
+	ChatID       string  `json:"chatId"`
+	Model        string  `json:"model"`
+	DurationMs   int64   `json:"duration_ms"`
+	TotalCostUSD float64 `json:"total_cost_usd"`
+	Usage        *struct {
+		InputTokens  int64 `json:"input_tokens"`
+		OutputTokens int64 `json:"output_tokens"`
+	} `json:"usage"`
+}
+
+// ParseRunResult parses cursor-agent JSON output. It accepts a single JSON
+// object (--output-format json) or NDJSON (--output-format stream-json), in
+// which case the last "result" event is used. Missing fields are left zero.
+func ParseRunResult(data []byte) (*RunResult, error) {
+	data = bytes.TrimSpace(data)
+	if len(data) == 0 {
+		return nil, fmt.Errorf("parsing cursor-agent result: empty output")
+	}
+
+	var raw rawRunResult
+	if err := json.Unmarshal(data, &raw); err != nil {
+		// Not a single object; scan NDJSON for the final result event.
+		found := false
+		for _, line := range bytes.Split(data, []byte("\n")) {
+			var event rawRunResult
+			if json.Unmarshal(bytes.TrimSpace(line), &event) == nil && event.Type == "result" {
+				raw, found = event, true
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("parsing cursor-agent result: %w", err)
+		}
+	}
+
+	result := &RunResult{
+		SessionID: raw.SessionID,
+		Model:     raw.Model,
+		Output:    raw.Result,
+		Cost:      raw.TotalCostUSD,
+		Duration:  time.Duration(raw.DurationMs) * time.Millisecond,
+		Success:   !raw.IsError && raw.Subtype != "error",
+		Error:     raw.Error,
+	}
+	if result.SessionID == "" {
+		result.SessionID = raw.ChatID
+	}
+	if raw.Usage != nil {
+		result.InputTokens = raw.Usage.InputTokens
+		result.OutputTokens = raw.Usage.OutputTokens
+	}
+	if !result.Success && result.Error == "" {
+		result.Error = raw.Result
+	}
+
+	return result, nil
+}
+
+// RunStructured executes cursor-agent with JSON output and parses the result.
+func (a *Adapter) RunStructured(prompt string) (*RunResult, error) {
+	return a.RunStructuredContext(context.Background(), prompt)
+}
+
+// RunStructuredContext is RunStructured bound to ctx, like RunContext.
+func (a *Adapter) RunStructuredContext(ctx context.Context, prompt string) (*RunResult, error) {
+	output, err := a.RunJSONContext(ctx, prompt)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := ParseRunResult(output)
+	if err != nil {
+		return nil, err
+	}
+	if result.Model == "" {
+		result.Model = a.Model
+	}
+	return result, nil
+}
@@ -0,0 +1,57 @@
+package cursor
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseRunResult(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+		want RunResult
+	}{
+		{
+			name: "full json result",
+			data: `{"type":"result","subtype":"success","is_error":false,"duration_ms":1500,"result":"done","session_id":"chat-1","model":"sonnet-4.5","total_cost_usd":0.02,"usage":{"input_tokens":100,"output_tokens":40}}`,
+			want: RunResult{SessionID: "chat-1", Model: "sonnet-4.5", Output: "done", InputTokens: 100, OutputTokens: 40, Cost: 0.02, Duration: 1500 * time.Millisecond, Success: true},
+		},
+		{
+			name: "partial result",
+			data: `{"result":"ok"}`,
+			want: RunResult{Output: "ok", Success: true},
+		},
+		{
+			name: "error result",
+			data: `{"type":"result","subtype":"error","is_error":true,"result":"model unavailable","session_id":"chat-2"}`,
+			want: RunResult{SessionID: "chat-2", Output: "model unavailable", Error: "model unavailable"},
+		},
+		{
+			name: "ndjson final event",
+			data: "{\"type\":\"system\",\"subtype\":\"init\",\"session_id\":\"chat-3\"}\n" +
+				"{\"type\":\"assistant\",\"message\":{}}\n" +
+				"{\"type\":\"result\",\"subtype\":\"success\",\"result\":\"final\",\"session_id\":\"chat-3\",\"duration_ms\":20}\n",
+			want: RunResult{SessionID: "chat-3", Output: "final", Duration: 20 * time.Millisecond, Success: true},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseRunResult([]byte(tt.data))
+			if err != nil {
+				t.Fatalf("ParseRunResult failed: %v", err)
+			}
+			if *got != tt.want {
+				t.Errorf("got %+v\nwant %+v", *got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseRunResult_Invalid(t *testing.T) {
+	for _, data := range []string{"", "not json"} {
+		if _, err := ParseRunResult([]byte(data)); err == nil {
+			t.Errorf("ParseRunResult(%q) should fail", data)
+		}
+	}
+}
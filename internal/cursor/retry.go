@@ -0,0 +1,100 @@
+package cursor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"os/exec"
+	"regexp"
+	"time"
+)
+
+// Retry backoff bounds for RunWithRetry.
+const (
+	DefaultRetryBaseDelay = time.Second
+	MaxRetryDelay         = 30 * time.Second
+)
+
+// DefaultTransientPatterns match cursor-agent stderr for failures that are
+// likely to succeed on retry: rate limits, timeouts, dropped connections,
+// and overloaded upstreams.
+var DefaultTransientPatterns = []*regexp.Regexp{
+	regexp.MustCompile(`(?i)rate.?limit|too many requests|\b429\b`),
+	regexp.MustCompile(`(?i)timed? ?out|timeout|deadline exceeded`),
+	regexp.MustCompile(`(?i)connection (reset|refused|closed)|broken pipe|unexpected EOF`),
+	regexp.MustCompile(`(?i)overloaded|service unavailable|bad gateway|\b50[234]\b`),
+}
+
+// RetryError is returned by RunWithRetry when the last attempt fails.
+type RetryError struct {
+	// Attempts is how many times cursor-agent was run.
+	Attempts int
+
+	// Err is the last attempt's error.
+	Err error
+}
+
+func (e *RetryError) Error() string {
+	return fmt.Sprintf("after %d attempt(s): %v", e.Attempts, e.Err)
+}
+
+func (e *RetryError) Unwrap() error { return e.Err }
+
+// RunWithRetry is Run, retried up to attempts times in total when
+// cursor-agent exits nonzero with stderr matching TransientPatterns. Other
+// failures, such as bad flags or an invalid model, end it immediately.
+// Retries wait with exponential backoff and jitter, starting at
+// RetryBaseDelay. On failure the last attempt's output is returned with a
+// *RetryError recording the attempts made.
+func (a *Adapter) RunWithRetry(prompt string, attempts int) (string, error) {
+	attempts = max(attempts, 1)
+	delay := a.RetryBaseDelay
+	if delay <= 0 {
+		delay = DefaultRetryBaseDelay
+	}
+
+	for attempt := 1; ; attempt++ {
+		output := &cappedBuffer{limit: a.outputLimit()}
+		stderr := &limitedBuffer{limit: a.outputLimit()}
+		err := a.runStream(context.Background(), prompt, false, output, stderr)
+		if err == nil {
+			return output.String(), nil
+		}
+		if attempt == attempts || !a.transient(err, stderr.String()) {
+			return output.String(), &RetryError{Attempts: attempt, Err: err}
+		}
+
+		time.Sleep(withJitter(delay))
+		delay = min(delay*2, MaxRetryDelay)
+	}
+}
+
+// transient reports whether err is a nonzero exit whose stderr matches one
+// of the adapter's transient patterns.
+func (a *Adapter) transient(err error, stderr string) bool {
+	var exitErr *exec.ExitError
+	if !errors.As(err, &exitErr) {
+		return false
+	}
+	patterns := a.TransientPatterns
+	if patterns == nil {
+		patterns = DefaultTransientPatterns
+	}
+	for _, re := range patterns {
+		if re.MatchString(stderr) {
+			return true
+		}
+	}
+	return false
+}
+
+// withJitter returns a random duration in [d/2, d), so agents that failed
+// together don't retry in lockstep.
+func withJitter(d time.Duration) time.Duration {
+	half := d / 2
+	if half <= 0 {
+		return d
+	}
+	return half + rand.N(half)
+}
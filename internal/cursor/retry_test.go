@@ -0,0 +1,72 @@
+package cursor
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// installFlakyAgent installs a cursor-agent that fails `failures` times
+// with stderr before succeeding.
+func installFlakyAgent(t *testing.T, failures int, stderr string) {
+	t.Helper()
+	counter := filepath.Join(t.TempDir(), "count")
+	installFakeAgent(t, fmt.Sprintf(`#!/bin/sh
+n=$(cat %[1]q 2>/dev/null || echo 0)
+n=$((n + 1))
+echo $n > %[1]q
+if [ $n -le %[2]d ]; then
+	echo "attempt $n"
+	echo %[3]q >&2
+	exit 1
+fi
+echo "ok on attempt $n"
+`, counter, failures, stderr))
+}
+
+func TestAdapter_RunWithRetry(t *testing.T) {
+	tests := []struct {
+		name         string
+		failures     int
+		stderr       string
+		attempts     int
+		wantOutput   string
+		wantAttempts int // 0 means success
+	}{
+		{"succeeds after transient failures", 2, "error: rate limit exceeded", 3, "ok on attempt 3", 0},
+		{"gives up after attempts", 5, "connection reset by peer", 3, "attempt 3", 3},
+		{"non-transient fails immediately", 5, "error: unknown flag --bogus", 3, "attempt 1", 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			installFlakyAgent(t, tt.failures, tt.stderr)
+
+			a := &Adapter{WorkDir: t.TempDir(), RetryBaseDelay: time.Millisecond}
+			output, err := a.RunWithRetry("hello", tt.attempts)
+			if strings.TrimSpace(output) != tt.wantOutput {
+				t.Errorf("output = %q, want %q", output, tt.wantOutput)
+			}
+
+			if tt.wantAttempts == 0 {
+				if err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+				return
+			}
+			var retryErr *RetryError
+			if !errors.As(err, &retryErr) {
+				t.Fatalf("error = %v, want *RetryError", err)
+			}
+			if retryErr.Attempts != tt.wantAttempts {
+				t.Errorf("attempts = %d, want %d", retryErr.Attempts, tt.wantAttempts)
+			}
+			if !strings.Contains(err.Error(), tt.stderr) {
+				t.Errorf("error = %q, want the last stderr", err)
+			}
+		})
+	}
+}
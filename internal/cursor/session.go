@@ -3,14 +3,22 @@ package cursor
 
 import (
 	"bufio"
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
 	"os/exec"
 	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
+
+	"github.com/cursorworkshop/cursor-gastown/internal/util"
+	"github.com/gofrs/flock"
 )
 
 // Session represents a Cursor CLI session.
@@ -38,6 +46,17 @@ type Session struct {
 
 	// Status is the current session status (active, suspended, completed).
 	Status string `json:"status"`
+
+	// Tags are operator-assigned labels (normalized; see NormalizeTag).
+	Tags []string `json:"tags,omitempty"`
+
+	// Label is an optional human-readable name, unique within a store.
+	// Set it with SessionStore.Rename.
+	Label string `json:"label,omitempty"`
+
+	// MetricIDs are the council task metrics recorded for this session
+	// (see SessionStore.AttachMetric and CostFor).
+	MetricIDs []string `json:"metric_ids,omitempty"`
 }
 
 // SessionStatus constants.
@@ -47,13 +66,39 @@ const (
 	SessionStatusCompleted = "completed"
 )
 
+// ErrSessionNotFound is returned when a session ID is not in the store.
+var ErrSessionNotFound = errors.New("session not found")
+
+// ErrMetricNotFound is returned by CostFor when a linked task metric
+// can't be found.
+var ErrMetricNotFound = errors.New("task metric not found")
+
+// ErrLabelInUse is returned when a label is already used by another
+// session in the store.
+var ErrLabelInUse = errors.New("session label already in use")
+
 // SessionStore manages session state persistence.
+//
+// Several gt processes (the agent and its hooks) may share a sessions file,
+// so writes hold an advisory lock on a sidecar ".lock" file, merge with the
+// file's current contents, and replace the file atomically.
 type SessionStore struct {
 	mu       sync.RWMutex
 	sessions map[string]*Session
 	path     string
+
+	// SuspendAfter is how long an active session may go without activity
+	// before the reaper marks it suspended; zero uses DefaultSuspendAfter.
+	SuspendAfter time.Duration
+
+	// now returns the current time; tests replace it.
+	now func() time.Time
 }
 
+// DefaultSuspendAfter is the reaper's default idle time before an active
+// session is suspended.
+const DefaultSuspendAfter = time.Hour
+
 // sessionsFileName is the filename for session storage.
 const sessionsFileName = "cursor-sessions.json"
 
@@ -64,16 +109,64 @@ func NewSessionStore(dir string) (*SessionStore, error) {
 	store := &SessionStore{
 		sessions: make(map[string]*Session),
 		path:     path,
+		now:      time.Now,
 	}
 
 	// Load existing sessions if file exists
-	if err := store.load(); err != nil && !os.IsNotExist(err) {
-		return nil, fmt.Errorf("loading sessions: %w", err)
+	if _, err := os.Stat(path); err == nil {
+		unlock, err := store.lock(true)
+		if err != nil {
+			return nil, err
+		}
+		defer unlock()
+		if err := store.load(); err != nil && !os.IsNotExist(err) {
+			return nil, fmt.Errorf("loading sessions: %w", err)
+		}
 	}
 
 	return store, nil
 }
 
+// lock takes the sessions file's advisory lock, shared for readers and
+// exclusive for writers, and returns a function that releases it.
+func (s *SessionStore) lock(shared bool) (func(), error) {
+	if err := os.MkdirAll(filepath.Dir(s.path), 0755); err != nil {
+		return nil, fmt.Errorf("creating sessions directory: %w", err)
+	}
+	fileLock := flock.New(s.path + ".lock")
+	lock := fileLock.Lock
+	if shared {
+		lock = fileLock.RLock
+	}
+	if err := lock(); err != nil {
+		return nil, fmt.Errorf("locking sessions file: %w", err)
+	}
+	return func() { _ = fileLock.Unlock() }, nil
+}
+
+// update applies fn to the sessions while holding the exclusive lock. The
+// file is reloaded first so sessions written by other processes are kept,
+// and fn's result is saved only if it returns nil.
+func (s *SessionStore) update(fn func(sessions map[string]*Session) error) error {
+	unlock, err := s.lock(false)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	if err := s.load(); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("loading sessions: %w", err)
+	}
+
+	s.mu.Lock()
+	err = fn(s.sessions)
+	s.mu.Unlock()
+	if err != nil {
+		return err
+	}
+	return s.save()
+}
+
 // load reads sessions from disk.
 func (s *SessionStore) load() error {
 	data, err := os.ReadFile(s.path)
@@ -85,6 +178,9 @@ func (s *SessionStore) load() error {
 	if err := json.Unmarshal(data, &sessions); err != nil {
 		return fmt.Errorf("parsing sessions file: %w", err)
 	}
+	if sessions == nil {
+		sessions = make(map[string]*Session)
+	}
 
 	s.mu.Lock()
 	s.sessions = sessions
@@ -93,7 +189,8 @@ func (s *SessionStore) load() error {
 	return nil
 }
 
-// save writes sessions to disk.
+// save writes sessions to disk by replacing the file, so readers never see
+// a partial write. Callers hold the exclusive lock.
 func (s *SessionStore) save() error {
 	s.mu.RLock()
 	data, err := json.MarshalIndent(s.sessions, "", "  ")
@@ -103,12 +200,7 @@ func (s *SessionStore) save() error {
 		return fmt.Errorf("marshaling sessions: %w", err)
 	}
 
-	// Ensure directory exists
-	if err := os.MkdirAll(filepath.Dir(s.path), 0755); err != nil {
-		return fmt.Errorf("creating sessions directory: %w", err)
-	}
-
-	if err := os.WriteFile(s.path, data, 0644); err != nil {
+	if err := util.AtomicWriteFile(s.path, data, 0644); err != nil {
 		return fmt.Errorf("writing sessions file: %w", err)
 	}
 
@@ -116,13 +208,15 @@ func (s *SessionStore) save() error {
 }
 
 // Get returns a session by ID.
+// The returned session is a copy; changes must be persisted with Put.
 func (s *SessionStore) Get(id string) *Session {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
-	return s.sessions[id]
+	return s.sessions[id].Clone()
 }
 
 // GetByRole returns the most recent active session for a role.
+// The returned session is a copy; changes must be persisted with Put.
 func (s *SessionStore) GetByRole(role, rigName string) *Session {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
@@ -139,38 +233,90 @@ func (s *SessionStore) GetByRole(role, rigName string) *Session {
 			best = sess
 		}
 	}
-	return best
+	return best.Clone()
+}
+
+// GetByLabel returns the session with the given label, or nil.
+// The returned session is a copy; changes must be persisted with Put.
+func (s *SessionStore) GetByLabel(label string) *Session {
+	label = strings.TrimSpace(label)
+	if label == "" {
+		return nil
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.sessions[labelOwner(s.sessions, label, "")].Clone()
+}
+
+// labelOwner returns the ID of the session other than exceptID using
+// label, or "" if there is none.
+func labelOwner(sessions map[string]*Session, label, exceptID string) string {
+	for id, sess := range sessions {
+		if id != exceptID && sess.Label == label {
+			return id
+		}
+	}
+	return ""
 }
 
 // Put stores a session.
+// The store keeps its own copy, so later changes to sess are not persisted
+// until Put is called again. It returns ErrLabelInUse if another session
+// already has sess's label.
 func (s *SessionStore) Put(sess *Session) error {
-	s.mu.Lock()
-	s.sessions[sess.ID] = sess
-	s.mu.Unlock()
-	return s.save()
+	return s.update(func(sessions map[string]*Session) error {
+		if sess.Label != "" {
+			if owner := labelOwner(sessions, sess.Label, sess.ID); owner != "" {
+				return fmt.Errorf("%w: %q is used by session %s", ErrLabelInUse, sess.Label, owner)
+			}
+		}
+		sessions[sess.ID] = sess.Clone()
+		return nil
+	})
+}
+
+// Rename sets the label of session id; an empty label clears it. Labels
+// are trimmed and must be unique within the store.
+func (s *SessionStore) Rename(id, label string) error {
+	label = strings.TrimSpace(label)
+
+	return s.update(func(sessions map[string]*Session) error {
+		sess, ok := sessions[id]
+		if !ok {
+			return fmt.Errorf("%w: %s", ErrSessionNotFound, id)
+		}
+		if label != "" {
+			if owner := labelOwner(sessions, label, id); owner != "" {
+				return fmt.Errorf("%w: %q is used by session %s", ErrLabelInUse, label, owner)
+			}
+		}
+		sess.Label = label
+		return nil
+	})
 }
 
 // Delete removes a session.
 func (s *SessionStore) Delete(id string) error {
-	s.mu.Lock()
-	delete(s.sessions, id)
-	s.mu.Unlock()
-	return s.save()
+	return s.update(func(sessions map[string]*Session) error {
+		delete(sessions, id)
+		return nil
+	})
 }
 
-// List returns all sessions.
+// List returns copies of all sessions.
 func (s *SessionStore) List() []*Session {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
 
 	result := make([]*Session, 0, len(s.sessions))
 	for _, sess := range s.sessions {
-		result = append(result, sess)
+		result = append(result, sess.Clone())
 	}
 	return result
 }
 
-// ListActive returns all active sessions.
+// ListActive returns copies of all active sessions.
 func (s *SessionStore) ListActive() []*Session {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
@@ -178,98 +324,592 @@ func (s *SessionStore) ListActive() []*Session {
 	result := make([]*Session, 0)
 	for _, sess := range s.sessions {
 		if sess.Status == SessionStatusActive {
-			result = append(result, sess)
+			result = append(result, sess.Clone())
 		}
 	}
 	return result
 }
 
+// ByTag returns copies of the sessions carrying tag, most recently active
+// first. The tag is normalized before matching.
+func (s *SessionStore) ByTag(tag string) []*Session {
+	tag = NormalizeTag(tag)
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var result []*Session
+	for _, sess := range s.sessions {
+		if sess.HasTag(tag) {
+			result = append(result, sess.Clone())
+		}
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].LastActiveAt.After(result[j].LastActiveAt)
+	})
+	return result
+}
+
+// Session sort keys for PageOpts.Sort.
+const (
+	SessionSortID      = "id"
+	SessionSortCreated = "created"
+	SessionSortActive  = "active"
+	SessionSortRole    = "role"
+)
+
+// SessionSortKeys lists the supported PageOpts.Sort values.
+var SessionSortKeys = []string{SessionSortID, SessionSortCreated, SessionSortActive, SessionSortRole}
+
+// PageOpts controls PaginateSessions.
+type PageOpts struct {
+	// Sort is one of SessionSortKeys; empty sorts by ID. Created and
+	// active sort newest first, role sorts alphabetically.
+	Sort string
+
+	// Offset is how many sorted sessions to skip.
+	Offset int
+
+	// Limit caps the page size; <= 0 returns everything after Offset.
+	Limit int
+}
+
+// PaginateSessions sorts sessions by opts.Sort, breaking ties by ID so the
+// order is deterministic and consecutive pages never overlap or skip, and
+// returns the page selected by Offset and Limit. An offset past the end
+// returns an empty slice. The input slice is not modified.
+func PaginateSessions(sessions []*Session, opts PageOpts) []*Session {
+	sorted := make([]*Session, len(sessions))
+	copy(sorted, sessions)
+
+	sort.SliceStable(sorted, func(i, j int) bool {
+		a, b := sorted[i], sorted[j]
+		switch opts.Sort {
+		case SessionSortCreated:
+			if !a.CreatedAt.Equal(b.CreatedAt) {
+				return a.CreatedAt.After(b.CreatedAt)
+			}
+		case SessionSortActive:
+			if !a.LastActiveAt.Equal(b.LastActiveAt) {
+				return a.LastActiveAt.After(b.LastActiveAt)
+			}
+		case SessionSortRole:
+			if a.Role != b.Role {
+				return a.Role < b.Role
+			}
+		}
+		return a.ID < b.ID
+	})
+
+	offset := opts.Offset
+	if offset < 0 {
+		offset = 0
+	}
+	if offset >= len(sorted) {
+		return []*Session{}
+	}
+	sorted = sorted[offset:]
+	if opts.Limit > 0 && opts.Limit < len(sorted) {
+		sorted = sorted[:opts.Limit]
+	}
+	return sorted
+}
+
+// AttachMetric links the council task metric metricID to session
+// sessionID. Attaching the same metric twice is a no-op.
+func (s *SessionStore) AttachMetric(sessionID, metricID string) error {
+	return s.update(func(sessions map[string]*Session) error {
+		sess, ok := sessions[sessionID]
+		if !ok {
+			return fmt.Errorf("%w: %s", ErrSessionNotFound, sessionID)
+		}
+		for _, id := range sess.MetricIDs {
+			if id == metricID {
+				return nil
+			}
+		}
+		sess.MetricIDs = append(sess.MetricIDs, metricID)
+		return nil
+	})
+}
+
+// TaskCostLookup finds the cost of a recorded task metric by ID.
+// council.MetricsStore implements it; CostFor takes the interface so this
+// package need not import council, which imports it.
+type TaskCostLookup interface {
+	TaskCost(metricID string) (cost float64, ok bool)
+}
+
+// CostFor sums the cost of the task metrics linked to session sessionID.
+// If some linked metrics can't be found in metrics (e.g. they have aged out
+// of the task history), the sum of the others is returned along with an
+// error wrapping ErrMetricNotFound.
+func (s *SessionStore) CostFor(sessionID string, metrics TaskCostLookup) (float64, error) {
+	sess := s.Get(sessionID)
+	if sess == nil {
+		return 0, fmt.Errorf("%w: %s", ErrSessionNotFound, sessionID)
+	}
+
+	var total float64
+	var missing []string
+	for _, id := range sess.MetricIDs {
+		cost, ok := metrics.TaskCost(id)
+		if !ok {
+			missing = append(missing, id)
+			continue
+		}
+		total += cost
+	}
+	if len(missing) > 0 {
+		return total, fmt.Errorf("%w: %s", ErrMetricNotFound, strings.Join(missing, ", "))
+	}
+	return total, nil
+}
+
 // CleanupStale removes sessions older than the given duration.
 func (s *SessionStore) CleanupStale(maxAge time.Duration) error {
-	s.mu.Lock()
-	cutoff := time.Now().Add(-maxAge)
-	for id, sess := range s.sessions {
-		if sess.LastActiveAt.Before(cutoff) {
-			delete(s.sessions, id)
+	cutoff := s.now().Add(-maxAge)
+	return s.update(func(sessions map[string]*Session) error {
+		for id, sess := range sessions {
+			if sess.LastActiveAt.Before(cutoff) {
+				delete(sessions, id)
+			}
 		}
+		return nil
+	})
+}
+
+// SuspendIdle marks active sessions with no activity for longer than idle
+// as suspended. LastActiveAt is left alone, so suspended sessions still age
+// out through CleanupStale.
+func (s *SessionStore) SuspendIdle(idle time.Duration) error {
+	cutoff := s.now().Add(-idle)
+	return s.update(func(sessions map[string]*Session) error {
+		for _, sess := range sessions {
+			if sess.Status == SessionStatusActive && sess.LastActiveAt.Before(cutoff) {
+				sess.Status = SessionStatusSuspended
+			}
+		}
+		return nil
+	})
+}
+
+// StartReaper starts a goroutine that, every interval, suspends sessions
+// idle for longer than SuspendAfter and removes sessions older than maxAge,
+// until ctx is cancelled. Errors are ignored; the next tick retries.
+// It returns an error, and starts nothing, unless interval and maxAge are
+// positive.
+func (s *SessionStore) StartReaper(ctx context.Context, interval, maxAge time.Duration) error {
+	if interval <= 0 {
+		return fmt.Errorf("reaper interval must be positive, got %v", interval)
 	}
-	s.mu.Unlock()
-	return s.save()
+	if maxAge <= 0 {
+		return fmt.Errorf("reaper max age must be positive, got %v", maxAge)
+	}
+	suspendAfter := s.SuspendAfter
+	if suspendAfter <= 0 {
+		suspendAfter = DefaultSuspendAfter
+	}
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				_ = s.SuspendIdle(suspendAfter)
+				_ = s.CleanupStale(maxAge)
+			}
+		}
+	}()
+	return nil
 }
 
+// ansiEscape matches terminal escape sequences: CSI sequences (colors,
+// cursor movement) and OSC sequences (titles, hyperlinks).
+var ansiEscape = regexp.MustCompile(`\x1b\[[0-?]*[ -/]*[@-~]|\x1b\][^\x07\x1b]*(?:\x07|\x1b\\)`)
+
+// stripANSI removes terminal escape sequences from s.
+func stripANSI(s string) string {
+	return ansiEscape.ReplaceAllString(s, "")
+}
+
+// sessionIDPatterns match session IDs in text output, most specific
+// first. Each captures the ID in its first group.
+var sessionIDPatterns = []*regexp.Regexp{
+	// chat_id=abc123, Chat ID: abc123, "chatId": "abc123"
+	regexp.MustCompile(`(?i)\bchat[_ ]?id["']?\s*[:=]\s*["']?([\w-]+)`),
+	// session_id=abc123, Session ID: abc123
+	regexp.MustCompile(`(?i)\bsession[_ ]?id["']?\s*[:=]\s*["']?([\w-]+)`),
+	// Session: abc123, chat=abc123
+	regexp.MustCompile(`(?i)\b(?:session|chat)\s*[:=]\s*["']?(` + proseSafeID + `)`),
+	// Resuming session abc123
+	regexp.MustCompile(`(?i)\b(?:session|chat)\s+(` + proseSafeID + `)`),
+}
+
+// proseSafeID matches a session ID after a bare "session" or "chat" label.
+// The ID must contain a digit so prose like "no session found" or
+// "Session: started" doesn't match.
+const proseSafeID = `[\w-]*\d[\w-]*`
+
+// uuidPattern matches a bare UUID, the format cursor-agent uses for chat IDs.
+var uuidPattern = regexp.MustCompile(`(?i)\b[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}\b`)
+
 // CaptureSessionID attempts to capture the session ID from cursor-agent output.
 // This is called from the stop hook to record the session for potential resume.
 //
-// cursor-agent outputs session info in various ways:
-// - In JSON output mode: {"chat_id": "..."}
-// - In text mode: Look for patterns like "Session: abc123" or "Chat ID: abc123"
+// Terminal escape sequences are stripped first. Then, in order:
+//   - output that is a single JSON object: its chat_id, session_id, or id
+//   - JSON objects embedded in text (e.g. stream-json lines): the first
+//     with a chat_id or session_id
+//   - labelled text such as "chat_id=abc123", "Session ID: abc123",
+//     "Session: abc123", or "Resuming session abc123"
+//   - the first bare UUID
+//
+// It returns "" if no ID is found.
 func CaptureSessionID(output string) string {
-	// Try JSON parsing first
-	var data struct {
-		ChatID string `json:"chat_id"`
-		ID     string `json:"id"`
-	}
-	if err := json.Unmarshal([]byte(output), &data); err == nil {
-		if data.ChatID != "" {
-			return data.ChatID
-		}
-		if data.ID != "" {
-			return data.ID
+	output = strings.TrimSpace(stripANSI(output))
+
+	var whole map[string]any
+	if err := json.Unmarshal([]byte(output), &whole); err == nil {
+		if id := jsonString(whole, "chat_id", "chatId", "session_id", "sessionId", "id"); id != "" {
+			return id
 		}
 	}
 
-	// Look for common patterns in text output
-	lines := strings.Split(output, "\n")
-	for _, line := range lines {
-		line = strings.TrimSpace(line)
+	if id := embeddedJSONSessionID(output); id != "" {
+		return id
+	}
 
-		// Pattern: "Session: abc123" or "Chat ID: abc123"
-		for _, prefix := range []string{"Session:", "Chat ID:", "ChatID:", "session:", "chat_id:"} {
-			if strings.HasPrefix(line, prefix) {
-				id := strings.TrimSpace(strings.TrimPrefix(line, prefix))
-				if id != "" {
-					return id
-				}
-			}
+	for _, re := range sessionIDPatterns {
+		if m := re.FindStringSubmatch(output); m != nil {
+			return m[1]
 		}
+	}
+
+	return uuidPattern.FindString(output)
+}
 
-		// Pattern: "Resuming session abc123"
-		if strings.Contains(line, "session") {
-			parts := strings.Fields(line)
-			for i, part := range parts {
-				if part == "session" && i+1 < len(parts) {
-					return parts[i+1]
-				}
+// embeddedJSONSessionID decodes each JSON object starting at a "{" in
+// output and returns the first chat or session ID found. Nested objects
+// are reached through their own "{".
+func embeddedJSONSessionID(output string) string {
+	for i := strings.IndexByte(output, '{'); i >= 0; {
+		var obj map[string]any
+		if err := json.NewDecoder(strings.NewReader(output[i:])).Decode(&obj); err == nil {
+			if id := jsonString(obj, "chat_id", "chatId", "session_id", "sessionId"); id != "" {
+				return id
 			}
 		}
+		next := strings.IndexByte(output[i+1:], '{')
+		if next < 0 {
+			break
+		}
+		i += next + 1
 	}
-
 	return ""
 }
 
-// ListCursorSessions runs 'cursor-agent ls' to list available sessions.
-// Note: This may not work in non-TTY environments.
+// CursorSessionInfo is one session reported by 'cursor-agent ls'. Model and
+// LastActive are zero when the listing doesn't include them.
+type CursorSessionInfo struct {
+	ID         string    `json:"id"`
+	Model      string    `json:"model,omitempty"`
+	LastActive time.Time `json:"last_active,omitempty"`
+}
+
+// ListCursorSessions runs 'cursor-agent ls' and returns the session IDs,
+// most recent first as listed. See ListCursorSessionInfo.
 func ListCursorSessions() ([]string, error) {
-	cmd := exec.Command("cursor-agent", "ls")
-	output, err := cmd.Output()
+	infos, err := ListCursorSessionInfo()
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]string, len(infos))
+	for i, info := range infos {
+		ids[i] = info.ID
+	}
+	return ids, nil
+}
+
+// ListCursorSessionInfo lists sessions with whatever metadata cursor-agent
+// reports. It prefers 'cursor-agent ls --json' and falls back to parsing
+// the columnar 'cursor-agent ls' output.
+// Note: This may not work in non-TTY environments.
+func ListCursorSessionInfo() ([]CursorSessionInfo, error) {
+	if output, err := exec.Command("cursor-agent", "ls", "--json").Output(); err == nil {
+		if infos, ok := parseCursorSessionJSON(output); ok {
+			return infos, nil
+		}
+	}
+
+	output, err := exec.Command("cursor-agent", "ls").Output()
 	if err != nil {
 		return nil, fmt.Errorf("listing cursor sessions: %w", err)
 	}
+	return ParseCursorSessionList(string(output)), nil
+}
+
+// ParseCursorSessionList parses columnar 'cursor-agent ls' output. With a
+// header row, columns are located by the header's positions and matched by
+// name (ID, MODEL, LAST ACTIVE, ...), so extra or reordered columns are
+// tolerated. Without one, the first field is the ID and the remaining
+// fields are recognized as a model or timestamp by shape.
+func ParseCursorSessionList(output string) []CursorSessionInfo {
+	return parseCursorSessionList(output, time.Now())
+}
+
+// columnSplit separates columns: two or more spaces, or a tab.
+var columnSplit = regexp.MustCompile(`\s{2,}|\t`)
 
-	var sessions []string
-	scanner := bufio.NewScanner(strings.NewReader(string(output)))
+// sessionColumn is a header column's name and starting offset.
+type sessionColumn struct {
+	name  string
+	start int
+}
+
+func parseCursorSessionList(output string, now time.Time) []CursorSessionInfo {
+	var infos []CursorSessionInfo
+	var columns []sessionColumn
+	indent := 0
+
+	scanner := bufio.NewScanner(strings.NewReader(output))
 	for scanner.Scan() {
-		line := strings.TrimSpace(scanner.Text())
-		if line != "" && !strings.HasPrefix(line, "ID") { // Skip header
-			// Extract first field (session ID)
-			fields := strings.Fields(line)
-			if len(fields) > 0 {
-				sessions = append(sessions, fields[0])
+		line := strings.TrimRight(scanner.Text(), " \t\r")
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" || strings.Trim(trimmed, "-=─ ") == "" {
+			continue
+		}
+		if columns == nil && len(infos) == 0 && isSessionHeader(trimmed) {
+			indent = len(line) - len(strings.TrimLeft(line, " \t"))
+			columns = headerColumns(line[indent:])
+			continue
+		}
+
+		var info CursorSessionInfo
+		if columns != nil {
+			info = sessionFromColumns(trimIndent(line, indent), columns, now)
+		} else {
+			info = sessionFromFields(trimmed, now)
+		}
+		if info.ID != "" {
+			infos = append(infos, info)
+		}
+	}
+	return infos
+}
+
+// isSessionHeader reports whether line is a header row: its first column
+// names the session ID.
+func isSessionHeader(line string) bool {
+	first := strings.ToUpper(columnSplit.Split(line, 2)[0])
+	switch first {
+	case "ID", "CHAT ID", "SESSION", "SESSION ID", "CHAT":
+		return true
+	}
+	return false
+}
+
+// trimIndent removes up to n leading blanks from line.
+func trimIndent(line string, n int) string {
+	i := 0
+	for i < n && i < len(line) && (line[i] == ' ' || line[i] == '\t') {
+		i++
+	}
+	return line[i:]
+}
+
+// headerColumns returns the header's column names and offsets.
+func headerColumns(line string) []sessionColumn {
+	columns := []sessionColumn{{start: 0}}
+	for _, loc := range columnSplit.FindAllStringIndex(line, -1) {
+		columns = append(columns, sessionColumn{start: loc[1]})
+	}
+	for i := range columns {
+		end := len(line)
+		if i+1 < len(columns) {
+			end = columns[i+1].start
+		}
+		columns[i].name = strings.ToUpper(strings.TrimSpace(line[columns[i].start:end]))
+	}
+	return columns
+}
+
+// sessionFromColumns extracts a row by header column. Rows that split into
+// exactly one field per column are matched by position in the split, which
+// tolerates values overflowing their column; others are sliced at the
+// header's offsets.
+func sessionFromColumns(line string, columns []sessionColumn, now time.Time) CursorSessionInfo {
+	values := columnSplit.Split(strings.TrimSpace(line), -1)
+	if len(values) != len(columns) {
+		values = make([]string, len(columns))
+		for i, col := range columns {
+			if col.start >= len(line) {
+				break
 			}
+			end := len(line)
+			if i+1 < len(columns) && columns[i+1].start < end {
+				end = columns[i+1].start
+			}
+			values[i] = line[col.start:end]
 		}
 	}
 
-	return sessions, nil
+	var info CursorSessionInfo
+	for i, col := range columns {
+		value := strings.TrimSpace(values[i])
+		switch {
+		case i == 0:
+			if fields := strings.Fields(value); len(fields) > 0 {
+				info.ID = fields[0]
+			}
+		case col.name == "MODEL":
+			info.Model = value
+		case strings.Contains(col.name, "ACTIVE") || strings.Contains(col.name, "UPDATED") ||
+			strings.Contains(col.name, "LAST") || col.name == "CREATED" || col.name == "TIME" || col.name == "DATE":
+			if t, ok := parseSessionTime(value, now); ok {
+				info.LastActive = t
+			}
+		}
+	}
+	return info
+}
+
+func sessionFromFields(line string, now time.Time) CursorSessionInfo {
+	fields := columnSplit.Split(line, -1)
+	if len(fields) == 1 {
+		fields = strings.Fields(line)
+	}
+	info := CursorSessionInfo{ID: fields[0]}
+	for _, field := range fields[1:] {
+		field = strings.TrimSpace(field)
+		if info.Model == "" && (ModelProvider(field) != "unknown" || field == "auto") {
+			info.Model = field
+			continue
+		}
+		if info.LastActive.IsZero() {
+			if t, ok := parseSessionTime(field, now); ok {
+				info.LastActive = t
+			}
+		}
+	}
+	return info
+}
+
+// sessionTimeLayouts are the absolute timestamp formats accepted in
+// session listings.
+var sessionTimeLayouts = []string{
+	time.RFC3339,
+	"2006-01-02 15:04:05",
+	"2006-01-02 15:04",
+	"2006-01-02T15:04:05",
+	"2006-01-02",
+}
+
+// relativeTime matches "5 minutes ago", "1h ago", and similar.
+var relativeTime = regexp.MustCompile(`^(\d+)\s*([a-z]+)\s+ago$`)
+
+// parseSessionTime parses an absolute timestamp, "just now", or a relative
+// "N units ago" against now.
+func parseSessionTime(value string, now time.Time) (time.Time, bool) {
+	value = strings.TrimSpace(value)
+	for _, layout := range sessionTimeLayouts {
+		if t, err := time.ParseInLocation(layout, value, time.Local); err == nil {
+			return t, true
+		}
+	}
+
+	lower := strings.ToLower(value)
+	if lower == "just now" || lower == "now" {
+		return now, true
+	}
+	m := relativeTime.FindStringSubmatch(lower)
+	if m == nil {
+		return time.Time{}, false
+	}
+	n, err := strconv.Atoi(m[1])
+	if err != nil {
+		return time.Time{}, false
+	}
+	unit, ok := relativeUnit(m[2])
+	if !ok {
+		// Try the singular of a plural unit ("mins", "hours").
+		unit, ok = relativeUnit(strings.TrimSuffix(m[2], "s"))
+	}
+	if !ok {
+		return time.Time{}, false
+	}
+	return now.Add(-time.Duration(n) * unit), true
+}
+
+// relativeUnit returns the duration of a relative time unit, matched
+// whole so a bare "s" means seconds rather than a stripped plural.
+func relativeUnit(unit string) (time.Duration, bool) {
+	switch unit {
+	case "s", "sec", "second":
+		return time.Second, true
+	case "m", "min", "minute":
+		return time.Minute, true
+	case "h", "hr", "hour":
+		return time.Hour, true
+	case "d", "day":
+		return 24 * time.Hour, true
+	case "w", "wk", "week":
+		return 7 * 24 * time.Hour, true
+	default:
+		return 0, false
+	}
+}
+
+// parseCursorSessionJSON decodes 'cursor-agent ls --json' output: an array
+// of sessions, or an object holding one under "sessions" or "chats". Key
+// names vary between releases, so common spellings are accepted. It reports
+// false if the output isn't JSON in either shape.
+func parseCursorSessionJSON(data []byte) ([]CursorSessionInfo, bool) {
+	var raw []map[string]any
+	if err := json.Unmarshal(data, &raw); err != nil {
+		var wrapped map[string][]map[string]any
+		if err := json.Unmarshal(data, &wrapped); err != nil {
+			return nil, false
+		}
+		raw = wrapped["sessions"]
+		if raw == nil {
+			raw = wrapped["chats"]
+		}
+		if raw == nil {
+			return nil, false
+		}
+	}
+
+	infos := make([]CursorSessionInfo, 0, len(raw))
+	for _, entry := range raw {
+		info := CursorSessionInfo{
+			ID:    jsonString(entry, "id", "chatId", "chat_id", "sessionId", "session_id"),
+			Model: jsonString(entry, "model"),
+		}
+		if ts := jsonString(entry, "lastActive", "last_active", "updatedAt", "updated_at", "timestamp"); ts != "" {
+			if t, err := time.Parse(time.RFC3339, ts); err == nil {
+				info.LastActive = t
+			}
+		}
+		if info.ID != "" {
+			infos = append(infos, info)
+		}
+	}
+	return infos, true
+}
+
+// jsonString returns the first of keys holding a string in entry.
+func jsonString(entry map[string]any, keys ...string) string {
+	for _, key := range keys {
+		if s, ok := entry[key].(string); ok && s != "" {
+			return s
+		}
+	}
+	return ""
 }
 
 // ResumeSession builds a command to resume a cursor-agent session.
@@ -330,18 +970,74 @@ func SessionFromEnv(workDir, role, rigName string) *Session {
 	}
 }
 
+// Clone returns a copy of the session.
+// Sessions returned by a SessionStore are clones, so mutating them does not
+// affect stored state until they are passed back to Put.
+func (s *Session) Clone() *Session {
+	if s == nil {
+		return nil
+	}
+	c := *s
+	c.Tags = append([]string(nil), s.Tags...)
+	c.MetricIDs = append([]string(nil), s.MetricIDs...)
+	return &c
+}
+
+// NormalizeTag trims and lowercases a tag so "Auth " and "auth" match.
+func NormalizeTag(tag string) string {
+	return strings.ToLower(strings.TrimSpace(tag))
+}
+
+// AddTag adds tags to the session, normalized and without duplicates.
+// Empty tags are ignored. Call SessionStore.Put afterwards to persist.
+func (s *Session) AddTag(tags ...string) {
+	for _, tag := range tags {
+		tag = NormalizeTag(tag)
+		if tag != "" && !s.HasTag(tag) {
+			s.Tags = append(s.Tags, tag)
+		}
+	}
+}
+
+// RemoveTag removes a tag from the session and reports whether it was
+// present. Call SessionStore.Put afterwards to persist.
+func (s *Session) RemoveTag(tag string) bool {
+	tag = NormalizeTag(tag)
+	for i, t := range s.Tags {
+		if t == tag {
+			s.Tags = append(s.Tags[:i], s.Tags[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+// HasTag reports whether the session carries tag.
+func (s *Session) HasTag(tag string) bool {
+	tag = NormalizeTag(tag)
+	for _, t := range s.Tags {
+		if t == tag {
+			return true
+		}
+	}
+	return false
+}
+
 // Touch updates the LastActiveAt timestamp.
+// Call SessionStore.Put afterwards to persist the change.
 func (s *Session) Touch() {
 	s.LastActiveAt = time.Now()
 }
 
 // MarkCompleted marks the session as completed.
+// Call SessionStore.Put afterwards to persist the change.
 func (s *Session) MarkCompleted() {
 	s.Status = SessionStatusCompleted
 	s.LastActiveAt = time.Now()
 }
 
 // MarkSuspended marks the session as suspended.
+// Call SessionStore.Put afterwards to persist the change.
 func (s *Session) MarkSuspended() {
 	s.Status = SessionStatusSuspended
 	s.LastActiveAt = time.Now()
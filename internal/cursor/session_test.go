@@ -0,0 +1,466 @@
+package cursor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestSessionStore_ReadsReturnCopies(t *testing.T) {
+	store, err := NewSessionStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewSessionStore failed: %v", err)
+	}
+
+	sess := &Session{
+		ID:           "chat-1",
+		Role:         "polecat",
+		RigName:      "gastown",
+		Status:       SessionStatusActive,
+		LastActiveAt: time.Now(),
+	}
+	if err := store.Put(sess); err != nil {
+		t.Fatalf("Put failed: %v", err)
+	}
+
+	// Mutating the original after Put must not leak into the store.
+	sess.Model = "leaked"
+	if got := store.Get("chat-1"); got.Model != "" {
+		t.Errorf("stored Model = %q after mutating Put argument, want empty", got.Model)
+	}
+
+	got := store.Get("chat-1")
+	got.MarkCompleted()
+	if stored := store.Get("chat-1"); stored.Status != SessionStatusActive {
+		t.Errorf("stored Status = %q before Put, want %q", stored.Status, SessionStatusActive)
+	}
+
+	byRole := store.GetByRole("polecat", "gastown")
+	byRole.Role = "mayor"
+	for _, s := range store.List() {
+		s.RigName = "other"
+	}
+	stored := store.Get("chat-1")
+	if stored.Role != "polecat" || stored.RigName != "gastown" {
+		t.Errorf("stored session mutated via reads: role=%q rig=%q", stored.Role, stored.RigName)
+	}
+
+	if err := store.Put(got); err != nil {
+		t.Fatalf("Put failed: %v", err)
+	}
+	if stored := store.Get("chat-1"); stored.Status != SessionStatusCompleted {
+		t.Errorf("stored Status = %q after Put, want %q", stored.Status, SessionStatusCompleted)
+	}
+}
+
+func TestSessionClone_Nil(t *testing.T) {
+	var sess *Session
+	if sess.Clone() != nil {
+		t.Error("Clone of nil session should be nil")
+	}
+}
+
+func TestSession_Tags(t *testing.T) {
+	store, err := NewSessionStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewSessionStore failed: %v", err)
+	}
+
+	now := time.Now()
+	auth := &Session{ID: "chat-1", Status: SessionStatusActive, LastActiveAt: now.Add(-time.Hour)}
+	auth.AddTag(" Refactor-Auth ", "spike", "refactor-auth", "")
+	if len(auth.Tags) != 2 || auth.Tags[0] != "refactor-auth" || auth.Tags[1] != "spike" {
+		t.Fatalf("Tags = %v, want [refactor-auth spike]", auth.Tags)
+	}
+
+	other := &Session{ID: "chat-2", Status: SessionStatusActive, LastActiveAt: now}
+	other.AddTag("SPIKE")
+	for _, sess := range []*Session{auth, other} {
+		if err := store.Put(sess); err != nil {
+			t.Fatalf("Put failed: %v", err)
+		}
+	}
+
+	// Tags persist through a reload.
+	reloaded, err := NewSessionStore(filepath.Dir(store.path))
+	if err != nil {
+		t.Fatalf("reloading store: %v", err)
+	}
+	spikes := reloaded.ByTag("Spike")
+	if len(spikes) != 2 || spikes[0].ID != "chat-2" {
+		t.Errorf("ByTag(Spike) = %v, want chat-2 then chat-1", spikes)
+	}
+	if got := reloaded.ByTag("REFACTOR-AUTH"); len(got) != 1 || got[0].ID != "chat-1" {
+		t.Errorf("ByTag(REFACTOR-AUTH) = %v, want chat-1", got)
+	}
+
+	// Returned sessions are copies, including their tags.
+	spikes[0].Tags[0] = "mutated"
+	if len(reloaded.ByTag("spike")) != 2 {
+		t.Error("mutating a returned session's tags changed the store")
+	}
+
+	if !auth.RemoveTag("SPIKE") || auth.HasTag("spike") || auth.RemoveTag("spike") {
+		t.Errorf("RemoveTag misbehaved, tags now %v", auth.Tags)
+	}
+}
+
+func TestParseCursorSessionList(t *testing.T) {
+	now := time.Date(2025, 11, 25, 12, 0, 0, 0, time.Local)
+
+	tests := []struct {
+		name   string
+		output string
+		want   []CursorSessionInfo
+	}{
+		{
+			name: "header",
+			output: `ID                                    MODEL        LAST ACTIVE
+a1b2c3d4-0000-0000-0000-000000000001  sonnet-4.5   5 minutes ago
+a1b2c3d4-0000-0000-0000-000000000002  gpt-5.2      2025-11-24 09:30:00
+`,
+			want: []CursorSessionInfo{
+				{ID: "a1b2c3d4-0000-0000-0000-000000000001", Model: "sonnet-4.5", LastActive: now.Add(-5 * time.Minute)},
+				{ID: "a1b2c3d4-0000-0000-0000-000000000002", Model: "gpt-5.2", LastActive: time.Date(2025, 11, 24, 9, 30, 0, 0, time.Local)},
+			},
+		},
+		{
+			name: "no header",
+			output: `chat-1  opus-4.5  1h ago
+chat-2
+chat-3  sonnet-4.5  5s ago
+chat-4  gpt-5.2  30 secs ago
+`,
+			want: []CursorSessionInfo{
+				{ID: "chat-1", Model: "opus-4.5", LastActive: now.Add(-time.Hour)},
+				{ID: "chat-2"},
+				{ID: "chat-3", Model: "sonnet-4.5", LastActive: now.Add(-5 * time.Second)},
+				{ID: "chat-4", Model: "gpt-5.2", LastActive: now.Add(-30 * time.Second)},
+			},
+		},
+		{
+			name: "extra and reordered columns",
+			output: `  CHAT ID   TITLE              LAST ACTIVE   MODEL       MESSAGES
+  ---------------------------------------------------------------
+  chat-9    Fix the auth bug   just now      gemini-3-pro  12
+  chat-10   Refactor           3 days ago    auto          4
+`,
+			want: []CursorSessionInfo{
+				{ID: "chat-9", Model: "gemini-3-pro", LastActive: now},
+				{ID: "chat-10", Model: "auto", LastActive: now.Add(-72 * time.Hour)},
+			},
+		},
+		{
+			name:   "empty",
+			output: "ID  MODEL\n",
+			want:   nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := parseCursorSessionList(tt.output, now)
+			if len(got) != len(tt.want) {
+				t.Fatalf("got %d sessions %+v, want %d", len(got), got, len(tt.want))
+			}
+			for i := range got {
+				if got[i].ID != tt.want[i].ID || got[i].Model != tt.want[i].Model || !got[i].LastActive.Equal(tt.want[i].LastActive) {
+					t.Errorf("session %d = %+v, want %+v", i, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestParseCursorSessionJSON(t *testing.T) {
+	infos, ok := parseCursorSessionJSON([]byte(`{"chats":[{"chatId":"chat-1","model":"sonnet-4.5","updatedAt":"2025-11-25T12:00:00Z"}]}`))
+	if !ok || len(infos) != 1 {
+		t.Fatalf("parseCursorSessionJSON = %+v, %v; want one session", infos, ok)
+	}
+	if infos[0].ID != "chat-1" || infos[0].Model != "sonnet-4.5" || infos[0].LastActive.IsZero() {
+		t.Errorf("session = %+v, want chat-1 on sonnet-4.5 with a timestamp", infos[0])
+	}
+
+	if _, ok := parseCursorSessionJSON([]byte("ID  MODEL\nchat-1  sonnet-4.5\n")); ok {
+		t.Error("text output should not parse as JSON")
+	}
+}
+
+func TestPaginateSessions(t *testing.T) {
+	base := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	var sessions []*Session
+	for i, id := range []string{"e", "c", "a", "d", "b"} {
+		sessions = append(sessions, &Session{
+			ID:        id,
+			Role:      []string{"polecat", "mayor"}[i%2],
+			CreatedAt: base.Add(time.Duration(i/2) * time.Hour), // pairs share a timestamp
+		})
+	}
+	ids := func(page []*Session) string {
+		var out []string
+		for _, s := range page {
+			out = append(out, s.ID)
+		}
+		return strings.Join(out, ",")
+	}
+
+	// Walking pages of two reproduces the full order with no overlap.
+	for _, sortKey := range SessionSortKeys {
+		full := ids(PaginateSessions(sessions, PageOpts{Sort: sortKey}))
+		var paged []string
+		for offset := 0; offset < len(sessions); offset += 2 {
+			paged = append(paged, ids(PaginateSessions(sessions, PageOpts{Sort: sortKey, Offset: offset, Limit: 2})))
+		}
+		if got := strings.Join(paged, ","); got != full {
+			t.Errorf("sort %s: pages = %s, want %s", sortKey, got, full)
+		}
+	}
+
+	if got := ids(PaginateSessions(sessions, PageOpts{Sort: SessionSortCreated})); got != "b,a,d,c,e" {
+		t.Errorf("created order = %s, want newest first with ID ties: b,a,d,c,e", got)
+	}
+	if got := ids(PaginateSessions(sessions, PageOpts{Sort: SessionSortRole})); got != "c,d,a,b,e" {
+		t.Errorf("role order = %s, want c,d,a,b,e", got)
+	}
+	if got := ids(PaginateSessions(sessions, PageOpts{Offset: 4, Limit: 10})); got != "e" {
+		t.Errorf("last page = %s, want e", got)
+	}
+	if page := PaginateSessions(sessions, PageOpts{Offset: 9}); page == nil || len(page) != 0 {
+		t.Errorf("offset past end = %v, want empty", page)
+	}
+	if sessions[0].ID != "e" {
+		t.Error("PaginateSessions reordered its input")
+	}
+}
+
+func TestSessionStore_Rename(t *testing.T) {
+	store, err := NewSessionStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewSessionStore failed: %v", err)
+	}
+	for _, id := range []string{"chat-1", "chat-2"} {
+		if err := store.Put(&Session{ID: id, Status: SessionStatusActive}); err != nil {
+			t.Fatalf("Put failed: %v", err)
+		}
+	}
+
+	if err := store.Rename("chat-1", " auth-refactor "); err != nil {
+		t.Fatalf("Rename failed: %v", err)
+	}
+	if got := store.GetByLabel("auth-refactor"); got == nil || got.ID != "chat-1" {
+		t.Errorf("GetByLabel(auth-refactor) = %v, want chat-1", got)
+	}
+	if err := store.Rename("chat-1", "auth-refactor"); err != nil {
+		t.Errorf("renaming a session to its own label: %v", err)
+	}
+
+	if err := store.Rename("chat-2", "auth-refactor"); !errors.Is(err, ErrLabelInUse) {
+		t.Errorf("duplicate Rename error = %v, want ErrLabelInUse", err)
+	}
+	if err := store.Put(&Session{ID: "chat-3", Label: "auth-refactor"}); !errors.Is(err, ErrLabelInUse) {
+		t.Errorf("duplicate Put error = %v, want ErrLabelInUse", err)
+	}
+	if err := store.Rename("missing", "x"); !errors.Is(err, ErrSessionNotFound) {
+		t.Errorf("Rename of unknown session error = %v, want ErrSessionNotFound", err)
+	}
+
+	// Labels persist through a reload.
+	reloaded, err := NewSessionStore(filepath.Dir(store.path))
+	if err != nil {
+		t.Fatalf("reloading store: %v", err)
+	}
+	if got := reloaded.GetByLabel("auth-refactor"); got == nil || got.ID != "chat-1" {
+		t.Errorf("after reload GetByLabel(auth-refactor) = %v, want chat-1", got)
+	}
+
+	// Clearing a label frees it for another session.
+	if err := reloaded.Rename("chat-1", ""); err != nil {
+		t.Fatalf("clearing label: %v", err)
+	}
+	if err := reloaded.Rename("chat-2", "auth-refactor"); err != nil {
+		t.Errorf("reusing a cleared label: %v", err)
+	}
+	if got := reloaded.GetByLabel(""); got != nil {
+		t.Errorf("GetByLabel(\"\") = %v, want nil", got)
+	}
+}
+
+func TestSessionStore_ConcurrentPuts(t *testing.T) {
+	dir := t.TempDir()
+
+	// Each writer gets its own store, as separate gt processes would.
+	const writers, perWriter = 8, 10
+	var wg sync.WaitGroup
+	errs := make(chan error, writers*perWriter)
+	for w := 0; w < writers; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			store, err := NewSessionStore(dir)
+			if err != nil {
+				errs <- err
+				return
+			}
+			for i := 0; i < perWriter; i++ {
+				if err := store.Put(&Session{ID: fmt.Sprintf("chat-%d-%d", w, i), Status: SessionStatusActive}); err != nil {
+					errs <- err
+				}
+			}
+		}(w)
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		t.Errorf("writer failed: %v", err)
+	}
+
+	reloaded, err := NewSessionStore(dir)
+	if err != nil {
+		t.Fatalf("reloading store: %v", err)
+	}
+	if got := len(reloaded.List()); got != writers*perWriter {
+		t.Errorf("reloaded %d sessions, want %d", got, writers*perWriter)
+	}
+	if _, err := os.Stat(filepath.Join(dir, sessionsFileName+".tmp")); !os.IsNotExist(err) {
+		t.Errorf("temp file left behind: %v", err)
+	}
+}
+
+func TestCaptureSessionID(t *testing.T) {
+	tests := []struct {
+		name   string
+		output string
+		want   string
+	}{
+		{"json chat_id", `{"chat_id": "abc123", "result": "done"}`, "abc123"},
+		{"json id", `{"id": "chat-42"}`, "chat-42"},
+		{"json result", `{"type":"result","subtype":"success","session_id":"7d1c2a9e-3b4f-4c5d-8e6f-0a1b2c3d4e5f","result":"ok"}`, "7d1c2a9e-3b4f-4c5d-8e6f-0a1b2c3d4e5f"},
+		{"stream-json lines", "{\"type\":\"system\",\"subtype\":\"init\",\"model\":\"sonnet-4.5\"}\n{\"type\":\"assistant\",\"message\":{\"content\":\"hi\"},\"chat_id\":\"s-99\"}\n", "s-99"},
+		{"json embedded in text", "Working...\nDone. {\"chat_id\": \"xyz789\", \"usage\": {\"tokens\": 12}}\n", "xyz789"},
+		{"chat_id equals", "info: chat_id=abc123 model=gpt-5.2", "abc123"},
+		{"chat id label", "Chat ID: 0f3e-77", "0f3e-77"},
+		{"session id label", "session_id: sess_01HX", "sess_01HX"},
+		{"session label", "Session: abc123", "abc123"},
+		{"resuming session", "Resuming session abc123...", "abc123"},
+		{"colored label", "\x1b[1;32mSession:\x1b[0m \x1b[36mabc123\x1b[0m\n", "abc123"},
+		{"colored json", "\x1b[90m{\"chat_id\":\"c0l0r\"}\x1b[0m", "c0l0r"},
+		{"hyperlinked", "\x1b]8;;https://cursor.com/chat/1\x07open\x1b]8;;\x07 chat_id=link-1", "link-1"},
+		{"bare uuid", "\x1b[2KSaved conversation 5f0e8c1a-2b3d-4e5f-9a0b-1c2d3e4f5a6b to history", "5f0e8c1a-2b3d-4e5f-9a0b-1c2d3e4f5a6b"},
+		{"prose without id", "no session found, starting fresh", ""},
+		{"session label prose", "Session: started in /tmp/work", ""},
+		{"chat label prose", "chat: waiting for model response", ""},
+		{"empty", "", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := CaptureSessionID(tt.output); got != tt.want {
+				t.Errorf("CaptureSessionID(%q) = %q, want %q", tt.output, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSessionStore_StartReaper(t *testing.T) {
+	store, err := NewSessionStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewSessionStore failed: %v", err)
+	}
+	now := time.Date(2026, 1, 2, 12, 0, 0, 0, time.UTC)
+	store.now = func() time.Time { return now }
+	store.SuspendAfter = time.Hour
+
+	for _, sess := range []*Session{
+		{ID: "old", Status: SessionStatusCompleted, LastActiveAt: now.Add(-48 * time.Hour)},
+		{ID: "idle", Status: SessionStatusActive, LastActiveAt: now.Add(-2 * time.Hour)},
+		{ID: "fresh", Status: SessionStatusActive, LastActiveAt: now.Add(-time.Minute)},
+	} {
+		if err := store.Put(sess); err != nil {
+			t.Fatalf("Put failed: %v", err)
+		}
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	for _, bad := range [][2]time.Duration{{0, 24 * time.Hour}, {-time.Second, 24 * time.Hour}, {time.Second, 0}} {
+		if err := store.StartReaper(ctx, bad[0], bad[1]); err == nil {
+			t.Errorf("StartReaper(%v, %v) should fail", bad[0], bad[1])
+		}
+	}
+	if err := store.StartReaper(ctx, 10*time.Millisecond, 24*time.Hour); err != nil {
+		t.Fatalf("StartReaper failed: %v", err)
+	}
+
+	deadline := time.Now().Add(2 * time.Second)
+	for store.Get("old") != nil || store.Get("idle").Status != SessionStatusSuspended {
+		if time.Now().After(deadline) {
+			t.Fatalf("reaper did not run; sessions: %v", store.List())
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	if got := store.Get("idle"); !got.LastActiveAt.Equal(now.Add(-2 * time.Hour)) {
+		t.Errorf("suspending changed LastActiveAt to %v", got.LastActiveAt)
+	}
+	if got := store.Get("fresh"); got.Status != SessionStatusActive {
+		t.Errorf("fresh session status = %q, want active", got.Status)
+	}
+
+	// Once cancelled, the reaper leaves new stale sessions alone.
+	cancel()
+	time.Sleep(20 * time.Millisecond)
+	if err := store.Put(&Session{ID: "late", Status: SessionStatusActive, LastActiveAt: now.Add(-48 * time.Hour)}); err != nil {
+		t.Fatalf("Put failed: %v", err)
+	}
+	time.Sleep(50 * time.Millisecond)
+	if got := store.Get("late"); got == nil || got.Status != SessionStatusActive {
+		t.Errorf("reaper ran after cancel: late = %v", got)
+	}
+}
+
+// fakeCosts is a TaskCostLookup backed by a map.
+type fakeCosts map[string]float64
+
+func (f fakeCosts) TaskCost(id string) (float64, bool) {
+	cost, ok := f[id]
+	return cost, ok
+}
+
+func TestSessionStore_AttachMetric(t *testing.T) {
+	store, err := NewSessionStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewSessionStore failed: %v", err)
+	}
+	if err := store.Put(&Session{ID: "chat-1", Status: SessionStatusActive}); err != nil {
+		t.Fatalf("Put failed: %v", err)
+	}
+
+	for _, id := range []string{"task-1", "task-2", "task-1"} {
+		if err := store.AttachMetric("chat-1", id); err != nil {
+			t.Fatalf("AttachMetric(%s) failed: %v", id, err)
+		}
+	}
+	if err := store.AttachMetric("missing", "task-1"); !errors.Is(err, ErrSessionNotFound) {
+		t.Errorf("AttachMetric to unknown session error = %v, want ErrSessionNotFound", err)
+	}
+
+	reloaded, err := NewSessionStore(filepath.Dir(store.path))
+	if err != nil {
+		t.Fatalf("reloading store: %v", err)
+	}
+	if got := reloaded.Get("chat-1").MetricIDs; len(got) != 2 || got[0] != "task-1" || got[1] != "task-2" {
+		t.Errorf("MetricIDs after reload = %v, want [task-1 task-2]", got)
+	}
+
+	cost, err := reloaded.CostFor("chat-1", fakeCosts{"task-1": 0.5, "task-2": 1.25})
+	if err != nil || cost != 1.75 {
+		t.Errorf("CostFor = %v, %v; want 1.75", cost, err)
+	}
+	if _, err := reloaded.CostFor("missing", fakeCosts{}); !errors.Is(err, ErrSessionNotFound) {
+		t.Errorf("CostFor unknown session error = %v, want ErrSessionNotFound", err)
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"sync"
 )
 
 //go:embed config/*.mdc
@@ -23,8 +24,36 @@ const (
 	Interactive RoleType = "interactive"
 )
 
-// RoleTypeFor returns the RoleType for a given role name.
+// customRoleTypes holds operator-declared role types (see SetRoleTypes).
+var (
+	customRoleTypesMu sync.RWMutex
+	customRoleTypes   map[string]RoleType
+)
+
+// SetRoleTypes replaces the operator-declared role types consulted by
+// RoleTypeFor before the built-in defaults. Passing nil clears them.
+func SetRoleTypes(types map[string]RoleType) {
+	custom := make(map[string]RoleType, len(types))
+	for role, rt := range types {
+		custom[role] = rt
+	}
+
+	customRoleTypesMu.Lock()
+	defer customRoleTypesMu.Unlock()
+	customRoleTypes = custom
+}
+
+// RoleTypeFor returns the RoleType for a given role name. Roles declared via
+// SetRoleTypes take precedence; otherwise the built-in roles apply and
+// unknown roles are interactive.
 func RoleTypeFor(role string) RoleType {
+	customRoleTypesMu.RLock()
+	rt, ok := customRoleTypes[role]
+	customRoleTypesMu.RUnlock()
+	if ok {
+		return rt
+	}
+
 	switch role {
 	case "polecat", "witness", "refinery", "deacon":
 		return Autonomous
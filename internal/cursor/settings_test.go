@@ -154,3 +154,35 @@ func TestEnsureSettingsForRole(t *testing.T) {
 		})
 	}
 }
+
+func TestEnsureSettingsForRole_CustomRoleType(t *testing.T) {
+	SetRoleTypes(map[string]RoleType{"janitor": Autonomous, "polecat": Interactive})
+	t.Cleanup(func() { SetRoleTypes(nil) })
+
+	if got := RoleTypeFor("janitor"); got != Autonomous {
+		t.Errorf("RoleTypeFor(janitor) = %v, want autonomous", got)
+	}
+	if got := RoleTypeFor("polecat"); got != Interactive {
+		t.Errorf("RoleTypeFor(polecat) = %v, want the configured override", got)
+	}
+	if got := RoleTypeFor("mayor"); got != Interactive {
+		t.Errorf("RoleTypeFor(mayor) = %v, want built-in default", got)
+	}
+
+	tmpDir := t.TempDir()
+	if err := EnsureSettingsForRole(tmpDir, "janitor"); err != nil {
+		t.Fatalf("EnsureSettingsForRole failed: %v", err)
+	}
+	content, err := os.ReadFile(filepath.Join(tmpDir, ".cursor", "rules", "gastown.mdc"))
+	if err != nil {
+		t.Fatalf("reading rules: %v", err)
+	}
+	if !strings.Contains(string(content), "autonomous agent") {
+		t.Error("custom autonomous role should get the autonomous rules")
+	}
+
+	SetRoleTypes(nil)
+	if got := RoleTypeFor("janitor"); got != Interactive {
+		t.Errorf("after clearing, RoleTypeFor(janitor) = %v, want interactive", got)
+	}
+}
@@ -4,12 +4,14 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"log"
 	"os"
 	"os/exec"
 	"os/signal"
 	"path/filepath"
 	"strconv"
+	"strings"
 	"syscall"
 	"time"
 
@@ -18,6 +20,8 @@ import (
 	"github.com/cursorworkshop/cursor-gastown/internal/boot"
 	"github.com/cursorworkshop/cursor-gastown/internal/config"
 	"github.com/cursorworkshop/cursor-gastown/internal/constants"
+	"github.com/cursorworkshop/cursor-gastown/internal/council"
+	"github.com/cursorworkshop/cursor-gastown/internal/cursor"
 	"github.com/cursorworkshop/cursor-gastown/internal/deacon"
 	"github.com/cursorworkshop/cursor-gastown/internal/feed"
 	"github.com/cursorworkshop/cursor-gastown/internal/polecat"
@@ -59,13 +63,124 @@ func New(config *Config) (*Daemon, error) {
 	logger := log.New(logFile, "", log.LstdFlags)
 	ctx, cancel := context.WithCancel(context.Background())
 
-	return &Daemon{
+	d := &Daemon{
 		config: config,
 		tmux:   tmux.NewTmux(),
 		logger: logger,
 		ctx:    ctx,
 		cancel: cancel,
-	}, nil
+	}
+
+	if err := d.Validate(); err != nil {
+		logger.Printf("Startup validation failed: %v", err)
+		cancel()
+		_ = logFile.Close()
+		return nil, err
+	}
+
+	return d, nil
+}
+
+// reconcilePendingTasks marks council tasks that were in flight when a
+// previous daemon died as abandoned, so they show up as failures in metrics.
+func (d *Daemon) reconcilePendingTasks() {
+	store, err := council.NewMetricsStore(d.config.TownRoot)
+	if err != nil {
+		d.logger.Printf("Warning: failed to load council metrics: %v", err)
+		return
+	}
+	reconciled, err := store.ReconcilePending(council.DefaultPendingTimeout, time.Now())
+	if err != nil {
+		d.logger.Printf("Warning: failed to reconcile pending tasks: %v", err)
+	}
+	for _, task := range reconciled {
+		d.logger.Printf("Marked abandoned council task %s (%s on %s, started %s)",
+			task.ID, task.Role, task.Model, task.StartedAt.Format(time.RFC3339))
+	}
+}
+
+// startSessionReaper runs the reaper on the town's cursor-agent session
+// store until the daemon stops.
+func (d *Daemon) startSessionReaper() {
+	store, err := cursor.NewSessionStore(constants.TownRuntimePath(d.config.TownRoot))
+	if err != nil {
+		d.logger.Printf("Warning: failed to load cursor sessions: %v", err)
+		return
+	}
+	if err := store.StartReaper(d.ctx, sessionReapInterval, sessionMaxAge); err != nil {
+		d.logger.Printf("Warning: failed to start session reaper: %v", err)
+	}
+}
+
+// sessionReapInterval is how often the daemon reaps cursor-agent sessions;
+// sessionMaxAge is how long it keeps a session after its last activity.
+const (
+	sessionReapInterval = 10 * time.Minute
+	sessionMaxAge       = 7 * 24 * time.Hour
+)
+
+// pruneCouncilMetrics clears council task history once the metrics file
+// exceeds MetricsPruneBytes. Aggregates are kept.
+func (d *Daemon) pruneCouncilMetrics() {
+	if d.config.MetricsPruneBytes <= 0 {
+		return
+	}
+	store, err := council.NewMetricsStore(d.config.TownRoot)
+	if err != nil {
+		d.logger.Printf("Warning: failed to load council metrics: %v", err)
+		return
+	}
+	pruned, err := store.PruneHistoryIfLarger(d.config.MetricsPruneBytes)
+	if err != nil {
+		d.logger.Printf("Warning: failed to prune council metrics: %v", err)
+		return
+	}
+	if pruned {
+		d.logger.Printf("Pruned council task history (metrics file exceeded %d bytes)", d.config.MetricsPruneBytes)
+	}
+}
+
+// Validate checks the town's council config and beads installation before
+// the daemon starts. An unparseable council config or one with
+// ValidateConfig errors always aborts startup; LintConfig findings and
+// beads issues are logged as warnings unless config.Strict is set.
+func (d *Daemon) Validate() error {
+	cfg, err := council.Load(d.config.TownRoot)
+	if err != nil {
+		return fmt.Errorf("invalid council config: %w", err)
+	}
+
+	if issues := council.ValidateConfig(cfg); len(issues) > 0 {
+		return fmt.Errorf("council config has %d error(s): %s (run 'gt council validate' for details)", len(issues), strings.Join(issues, "; "))
+	}
+
+	if warnings := council.LintConfig(cfg); len(warnings) > 0 {
+		for _, warning := range warnings {
+			d.logger.Printf("Warning: council config: %s", warning)
+		}
+		if d.config.Strict {
+			return fmt.Errorf("council config has %d warning(s): %s", len(warnings), strings.Join(warnings, "; "))
+		}
+	}
+
+	if d.config.BeadsCheck != nil {
+		if err := d.config.BeadsCheck(); err != nil {
+			d.logger.Printf("Warning: beads check: %v", err)
+			if d.config.Strict {
+				return fmt.Errorf("beads check failed: %w", err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// Preflight runs the daemon's startup validation without starting it,
+// writing warnings to w. gt daemon start uses it to refuse, with the
+// reason, a daemon that would abort in the background.
+func Preflight(config *Config, w io.Writer) error {
+	d := &Daemon{config: config, logger: log.New(w, "", 0)}
+	return d.Validate()
 }
 
 // Run starts the daemon main loop.
@@ -105,6 +220,12 @@ func (d *Daemon) Run() error {
 		d.logger.Printf("Warning: failed to save state: %v", err)
 	}
 
+	// Reconcile council tasks left pending by a previous crash
+	d.reconcilePendingTasks()
+
+	// Suspend idle cursor-agent sessions and drop stale ones in the background
+	d.startSessionReaper()
+
 	// Handle signals
 	sigChan := make(chan os.Signal, 1)
 	baseSignals := []os.Signal{syscall.SIGINT, syscall.SIGTERM}
@@ -205,6 +326,9 @@ func (d *Daemon) heartbeat(state *State) {
 	// This validates tmux sessions are still alive for polecats with work-on-hook
 	d.checkPolecatSessionHealth()
 
+	// 9. Keep council metrics from growing without bound
+	d.pruneCouncilMetrics()
+
 	// Update state
 	state.LastHeartbeat = time.Now()
 	state.HeartbeatCount++
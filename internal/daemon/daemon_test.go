@@ -2,8 +2,10 @@ package daemon
 
 import (
 	"encoding/json"
+	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 )
@@ -246,3 +248,89 @@ func TestLifecycleRequest_Serialization(t *testing.T) {
 		t.Errorf("Action mismatch: got %q, want %q", loaded.Action, request.Action)
 	}
 }
+
+func writeCouncilConfig(t *testing.T, townRoot, content string) {
+	t.Helper()
+	beadsDir := filepath.Join(townRoot, ".beads")
+	if err := os.MkdirAll(beadsDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(beadsDir, "council.toml"), []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// lintWarningCouncil is usable but has a fallback on the primary's own
+// provider, which LintConfig warns about.
+const lintWarningCouncil = "version = 1\n\n[roles.mayor]\nmodel = \"opus-4.5\"\nfallback = [\"sonnet-4.5\"]\n"
+
+func TestNew_StartupValidation(t *testing.T) {
+	tests := []struct {
+		name    string
+		council string
+		strict  bool
+		wantErr bool
+	}{
+		{"no council config", "", false, false},
+		{"valid config", "version = 1\n\n[roles.mayor]\nmodel = \"claude-opus-4.5\"\n", false, false},
+		{"unparseable config", "version = [\n", false, true},
+		{"config error aborts", "version = 1\n\n[roles.mayor]\nmodel = \"\"\n", false, true},
+		{"lint warning warns", lintWarningCouncil, false, false},
+		{"lint warning strict", lintWarningCouncil, true, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			townRoot := t.TempDir()
+			if tt.council != "" {
+				writeCouncilConfig(t, townRoot, tt.council)
+			}
+
+			config := DefaultConfig(townRoot)
+			config.Strict = tt.strict
+			d, err := New(config)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("expected New to abort startup")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("New failed: %v", err)
+			}
+			if d == nil {
+				t.Fatal("expected non-nil daemon")
+			}
+		})
+	}
+}
+
+func TestNew_StrictBeadsCheck(t *testing.T) {
+	config := DefaultConfig(t.TempDir())
+	config.BeadsCheck = func() error { return fmt.Errorf("beads too old") }
+
+	if _, err := New(config); err != nil {
+		t.Fatalf("non-strict New should only warn on beads check: %v", err)
+	}
+
+	config.Strict = true
+	if _, err := New(config); err == nil {
+		t.Fatal("strict New should abort on beads check failure")
+	}
+}
+
+func TestPreflight_ReportsWarnings(t *testing.T) {
+	townRoot := t.TempDir()
+	writeCouncilConfig(t, townRoot, lintWarningCouncil)
+
+	var out strings.Builder
+	if err := Preflight(DefaultConfig(townRoot), &out); err != nil {
+		t.Fatalf("Preflight failed: %v", err)
+	}
+	if !strings.Contains(out.String(), "Warning: council config:") {
+		t.Errorf("output = %q, want the lint warning", out.String())
+	}
+	if _, err := os.Stat(DefaultConfig(townRoot).LogFile); err == nil {
+		t.Error("Preflight should not create the daemon log")
+	}
+}
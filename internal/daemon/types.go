@@ -14,6 +14,7 @@ import (
 	"path/filepath"
 	"time"
 
+	"github.com/cursorworkshop/cursor-gastown/internal/council"
 	"github.com/cursorworkshop/cursor-gastown/internal/util"
 )
 
@@ -30,6 +31,18 @@ type Config struct {
 
 	// PidFile is the path to the PID file.
 	PidFile string `json:"pid_file"`
+
+	// Strict makes startup validation treat advisory issues (council config
+	// problems, beads version mismatch) as fatal instead of logging them.
+	Strict bool `json:"strict,omitempty"`
+
+	// MetricsPruneBytes prunes council task history during heartbeats once
+	// the metrics file grows past this size. Zero disables auto-prune.
+	MetricsPruneBytes int64 `json:"metrics_prune_bytes,omitempty"`
+
+	// BeadsCheck verifies the installed beads version during startup
+	// validation. Nil skips the check.
+	BeadsCheck func() error `json:"-"`
 }
 
 // DefaultConfig returns the default daemon configuration.
@@ -40,6 +53,7 @@ func DefaultConfig(townRoot string) *Config {
 		TownRoot:          townRoot,
 		LogFile:           filepath.Join(daemonDir, "daemon.log"),
 		PidFile:           filepath.Join(daemonDir, "daemon.pid"),
+		MetricsPruneBytes: council.DefaultHistoryPruneBytes,
 	}
 }
 
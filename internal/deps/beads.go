@@ -3,7 +3,9 @@ package deps
 
 import (
 	"fmt"
+	"os"
 	"os/exec"
+	"path/filepath"
 	"regexp"
 	"strconv"
 	"strings"
@@ -144,3 +146,44 @@ func parseVersion(v string) [3]int {
 	}
 	return parts
 }
+
+// BeadsVersionCacheFile stores gt's cached bd version check result, so gt
+// doesn't run bd --version on every command.
+const BeadsVersionCacheFile = ".gt-beads-version-cache"
+
+// BeadsVersionCachePath returns the path to the bd version cache, under
+// XDG_CACHE_HOME (or ~/.cache). Returns "" if no cache directory is known.
+func BeadsVersionCachePath() string {
+	cacheDir := os.Getenv("XDG_CACHE_HOME")
+	if cacheDir == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return ""
+		}
+		cacheDir = filepath.Join(home, ".cache")
+	}
+	return filepath.Join(cacheDir, "gastown", BeadsVersionCacheFile)
+}
+
+// BeadsVersionCacheStale reports whether the cached bd version is older than
+// the installed bd, returning both versions. It is false when there is no
+// cache or bd's version can't be determined.
+func BeadsVersionCacheStale() (cached, installed string, stale bool) {
+	path := BeadsVersionCachePath()
+	if path == "" {
+		return "", "", false
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", "", false
+	}
+	cached = strings.TrimSpace(string(data))
+
+	status, installed := CheckBeads()
+	if status == BeadsNotFound || status == BeadsUnknown {
+		return cached, "", false
+	}
+
+	release, _, _ := strings.Cut(cached, "-")
+	return cached, installed, compareVersions(release, installed) < 0
+}
@@ -0,0 +1,253 @@
+package doctor
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/cursorworkshop/cursor-gastown/internal/council"
+	"github.com/cursorworkshop/cursor-gastown/internal/cursor"
+	"github.com/cursorworkshop/cursor-gastown/internal/deps"
+)
+
+// CouncilDefaultProfile is the profile a missing council config is created from.
+const CouncilDefaultProfile = "balanced"
+
+// CouncilChecks returns the checks run by 'gt council doctor'. workDir and
+// role identify the workspace whose hooks and rules are checked.
+func CouncilChecks(workDir, role string) []Check {
+	return []Check{
+		NewCouncilConfigExistsCheck(),
+		NewCouncilProvidersCheck(),
+		NewBeadsVersionCacheCheck(),
+		NewWorkspaceHooksCheck(workDir, role),
+	}
+}
+
+// CouncilConfigExistsCheck verifies the town has a council config file.
+type CouncilConfigExistsCheck struct {
+	FixableCheck
+}
+
+// NewCouncilConfigExistsCheck creates a new council config exists check.
+func NewCouncilConfigExistsCheck() *CouncilConfigExistsCheck {
+	return &CouncilConfigExistsCheck{
+		FixableCheck: FixableCheck{
+			BaseCheck: BaseCheck{
+				CheckName:        "council-config-exists",
+				CheckDescription: "Check that the council config file exists",
+			},
+		},
+	}
+}
+
+// Run checks if the council config file exists.
+func (c *CouncilConfigExistsCheck) Run(ctx *CheckContext) *CheckResult {
+	path := council.ResolveConfigPath(ctx.TownRoot)
+	if _, err := os.Stat(path); os.IsNotExist(err) {
+		return &CheckResult{
+			Name:    c.Name(),
+			Status:  StatusWarning,
+			Message: fmt.Sprintf("%s not found (using built-in defaults)", path),
+			FixHint: fmt.Sprintf("Run 'gt council doctor --fix' to create it from the %s profile", CouncilDefaultProfile),
+		}
+	}
+
+	return &CheckResult{
+		Name:    c.Name(),
+		Status:  StatusOK,
+		Message: fmt.Sprintf("%s exists", path),
+	}
+}
+
+// Fix creates the council config from the default profile.
+func (c *CouncilConfigExistsCheck) Fix(ctx *CheckContext) error {
+	profile, ok := council.GetProfile(CouncilDefaultProfile)
+	if !ok {
+		return fmt.Errorf("profile %q not found", CouncilDefaultProfile)
+	}
+	cfg, err := profile.Config.Clone()
+	if err != nil {
+		return err
+	}
+	return council.SaveConfig(council.ConfigPath(ctx.TownRoot), cfg)
+}
+
+// CouncilProvidersCheck verifies every provider whose models the council
+// config references has a provider entry.
+type CouncilProvidersCheck struct {
+	FixableCheck
+}
+
+// NewCouncilProvidersCheck creates a new council providers check.
+func NewCouncilProvidersCheck() *CouncilProvidersCheck {
+	return &CouncilProvidersCheck{
+		FixableCheck: FixableCheck{
+			BaseCheck: BaseCheck{
+				CheckName:        "council-providers",
+				CheckDescription: "Check that referenced models have provider entries",
+			},
+		},
+	}
+}
+
+// Run checks the council config for referenced providers without entries.
+func (c *CouncilProvidersCheck) Run(ctx *CheckContext) *CheckResult {
+	cfg, err := council.Load(ctx.TownRoot)
+	if err != nil {
+		return &CheckResult{
+			Name:    c.Name(),
+			Status:  StatusError,
+			Message: fmt.Sprintf("Could not load council config: %v", err),
+		}
+	}
+
+	// Dry-run the fix on a copy to see which entries it would add.
+	dryRun, err := cfg.Clone()
+	if err != nil {
+		return &CheckResult{
+			Name:    c.Name(),
+			Status:  StatusError,
+			Message: fmt.Sprintf("Could not check council config: %v", err),
+		}
+	}
+	if missing := dryRun.EnsureProvidersForModels(); len(missing) > 0 {
+		return &CheckResult{
+			Name:    c.Name(),
+			Status:  StatusWarning,
+			Message: fmt.Sprintf("%d referenced provider(s) not configured: %s", len(missing), strings.Join(missing, ", ")),
+			FixHint: "Run 'gt council doctor --fix' to add provider entries",
+		}
+	}
+
+	return &CheckResult{
+		Name:    c.Name(),
+		Status:  StatusOK,
+		Message: "All referenced providers are configured",
+	}
+}
+
+// Fix adds the missing provider entries to the council config.
+func (c *CouncilProvidersCheck) Fix(ctx *CheckContext) error {
+	path := council.ResolveConfigPath(ctx.TownRoot)
+	cfg, err := council.LoadConfig(path)
+	if err != nil {
+		return err
+	}
+	if len(cfg.EnsureProvidersForModels()) == 0 {
+		return nil
+	}
+	return council.SaveConfig(path, cfg)
+}
+
+// BeadsVersionCacheCheck detects a cached bd version older than the
+// installed bd, which would keep gt enforcing the old version for up to
+// the cache TTL after an upgrade.
+type BeadsVersionCacheCheck struct {
+	FixableCheck
+}
+
+// NewBeadsVersionCacheCheck creates a new beads version cache check.
+func NewBeadsVersionCacheCheck() *BeadsVersionCacheCheck {
+	return &BeadsVersionCacheCheck{
+		FixableCheck: FixableCheck{
+			BaseCheck: BaseCheck{
+				CheckName:        "beads-version-cache",
+				CheckDescription: "Check the cached bd version matches the installed bd",
+			},
+		},
+	}
+}
+
+// Run compares the cached bd version with the installed one.
+func (c *BeadsVersionCacheCheck) Run(ctx *CheckContext) *CheckResult {
+	cached, installed, stale := deps.BeadsVersionCacheStale()
+	if stale {
+		return &CheckResult{
+			Name:    c.Name(),
+			Status:  StatusWarning,
+			Message: fmt.Sprintf("Cached bd version %s is older than installed %s", cached, installed),
+			FixHint: "Run 'gt council doctor --fix' to invalidate the cache",
+		}
+	}
+
+	return &CheckResult{
+		Name:    c.Name(),
+		Status:  StatusOK,
+		Message: "bd version cache is current",
+	}
+}
+
+// Fix removes the stale version cache.
+func (c *BeadsVersionCacheCheck) Fix(ctx *CheckContext) error {
+	path := deps.BeadsVersionCachePath()
+	if path == "" {
+		return nil
+	}
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
+// WorkspaceHooksCheck verifies a workspace has the Gas Town Cursor hooks and
+// rules installed.
+type WorkspaceHooksCheck struct {
+	FixableCheck
+	workDir string
+	role    string
+}
+
+// NewWorkspaceHooksCheck creates a new workspace hooks check for workDir,
+// which is set up for role when fixed.
+func NewWorkspaceHooksCheck(workDir, role string) *WorkspaceHooksCheck {
+	return &WorkspaceHooksCheck{
+		FixableCheck: FixableCheck{
+			BaseCheck: BaseCheck{
+				CheckName:        "workspace-hooks",
+				CheckDescription: "Check the workspace has Gas Town hooks and rules",
+			},
+		},
+		workDir: workDir,
+		role:    role,
+	}
+}
+
+// workspaceHookFiles are the files EnsureSettings installs, relative to the
+// workspace.
+var workspaceHookFiles = []string{
+	filepath.Join(".cursor", "hooks.json"),
+	filepath.Join(".cursor", "rules", "gastown.mdc"),
+}
+
+// Run checks that the workspace's hooks and rules files exist.
+func (c *WorkspaceHooksCheck) Run(ctx *CheckContext) *CheckResult {
+	var missing []string
+	for _, rel := range workspaceHookFiles {
+		if _, err := os.Stat(filepath.Join(c.workDir, rel)); os.IsNotExist(err) {
+			missing = append(missing, rel)
+		}
+	}
+
+	if len(missing) > 0 {
+		return &CheckResult{
+			Name:    c.Name(),
+			Status:  StatusWarning,
+			Message: fmt.Sprintf("%d hook/rule file(s) missing in %s", len(missing), c.workDir),
+			Details: missing,
+			FixHint: "Run 'gt council doctor --fix' to install them",
+		}
+	}
+
+	return &CheckResult{
+		Name:    c.Name(),
+		Status:  StatusOK,
+		Message: "Workspace hooks and rules installed",
+	}
+}
+
+// Fix installs the hooks and rules for the workspace's role.
+func (c *WorkspaceHooksCheck) Fix(ctx *CheckContext) error {
+	return cursor.EnsureSettingsForRole(c.workDir, c.role)
+}
@@ -0,0 +1,92 @@
+package doctor
+
+import (
+	"bytes"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/cursorworkshop/cursor-gastown/internal/council"
+)
+
+func TestCouncilConfigExistsCheck_FixCreatesConfig(t *testing.T) {
+	townRoot := t.TempDir()
+	ctx := &CheckContext{TownRoot: townRoot}
+
+	check := NewCouncilConfigExistsCheck()
+	if result := check.Run(ctx); result.Status != StatusWarning {
+		t.Fatalf("expected StatusWarning for missing config, got %v: %s", result.Status, result.Message)
+	}
+
+	d := NewDoctor()
+	d.Register(check)
+	var log bytes.Buffer
+	ctx.FixLog = &log
+	report := d.Fix(ctx)
+
+	if report.Checks[0].Status != StatusOK {
+		t.Fatalf("expected StatusOK after fix, got %v: %s", report.Checks[0].Status, report.Checks[0].Message)
+	}
+	if _, err := os.Stat(council.ConfigPath(townRoot)); err != nil {
+		t.Fatalf("config not created: %v", err)
+	}
+	if !strings.Contains(log.String(), "fixed council-config-exists") {
+		t.Errorf("fix not logged: %q", log.String())
+	}
+
+	cfg, err := council.Load(townRoot)
+	if err != nil {
+		t.Fatalf("loading created config: %v", err)
+	}
+	balanced, _ := council.GetProfile(CouncilDefaultProfile)
+	if got, want := cfg.GetModelForRole("refinery"), balanced.Config.GetModelForRole("refinery"); got != want {
+		t.Errorf("refinery model = %q, want %q from the %s profile", got, want, CouncilDefaultProfile)
+	}
+}
+
+func TestCouncilProvidersCheck_FixAddsMissingProviders(t *testing.T) {
+	townRoot := t.TempDir()
+	cfg := &council.Config{
+		Version: council.CurrentConfigVersion,
+		Roles: map[string]*council.RoleConfig{
+			"mayor": {Model: "opus-4.5", Fallback: []string{"gpt-5.2"}},
+		},
+		Providers: map[string]*council.ProviderConfig{
+			"anthropic": {Enabled: true, Priority: 50},
+		},
+	}
+	if err := council.SaveConfig(council.ConfigPath(townRoot), cfg); err != nil {
+		t.Fatal(err)
+	}
+	ctx := &CheckContext{TownRoot: townRoot}
+
+	check := NewCouncilProvidersCheck()
+	result := check.Run(ctx)
+	if result.Status != StatusWarning || !strings.Contains(result.Message, "openai") {
+		t.Fatalf("expected warning naming openai, got %v: %s", result.Status, result.Message)
+	}
+
+	// Declining the confirmation leaves the config alone.
+	d := NewDoctor()
+	d.Register(check)
+	ctx.Confirm = func(Check, *CheckResult) bool { return false }
+	if report := d.Fix(ctx); report.Checks[0].Status != StatusWarning {
+		t.Fatalf("declined fix was applied: %s", report.Checks[0].Message)
+	}
+
+	ctx.Confirm = func(Check, *CheckResult) bool { return true }
+	if report := d.Fix(ctx); report.Checks[0].Status != StatusOK {
+		t.Fatalf("expected StatusOK after fix, got %s", report.Checks[0].Message)
+	}
+
+	fixed, err := council.Load(townRoot)
+	if err != nil {
+		t.Fatalf("loading fixed config: %v", err)
+	}
+	if pc := fixed.Providers["openai"]; pc == nil || !pc.Enabled {
+		t.Errorf("openai provider = %+v, want an enabled entry", pc)
+	}
+	if pc := fixed.Providers["anthropic"]; pc == nil || pc.Priority != 50 {
+		t.Errorf("existing anthropic entry changed: %+v", pc)
+	}
+}
@@ -56,8 +56,17 @@ func (d *Doctor) Fix(ctx *CheckContext) *Report {
 
 		// Attempt fix if check failed and is fixable
 		if result.Status != StatusOK && check.CanFix() {
+			if ctx.Confirm != nil && !ctx.Confirm(check, result) {
+				result.Details = append(result.Details, "Fix skipped")
+				ctx.logFix("skipped fix for %s", result.Name)
+				report.Add(result)
+				continue
+			}
+
+			problem := result.Message
 			err := check.Fix(ctx)
 			if err == nil {
+				ctx.logFix("fixed %s: %s", result.Name, problem)
 				// Re-run check to verify fix worked
 				result = check.Run(ctx)
 				if result.Name == "" {
@@ -70,6 +79,7 @@ func (d *Doctor) Fix(ctx *CheckContext) *Report {
 			} else {
 				// Fix failed, add error to details
 				result.Details = append(result.Details, "Fix failed: "+err.Error())
+				ctx.logFix("fix failed for %s: %v", result.Name, err)
 			}
 		}
 
@@ -0,0 +1,93 @@
+package doctor
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/cursorworkshop/cursor-gastown/internal/council"
+)
+
+// ProviderAuthCheck probes each enabled council provider with its configured
+// API key, so wrong credentials show up before real requests start failing.
+// It makes network calls and only runs with `gt doctor --network`.
+type ProviderAuthCheck struct {
+	BaseCheck
+}
+
+// NewProviderAuthCheck creates a new provider credential check.
+func NewProviderAuthCheck() *ProviderAuthCheck {
+	return &ProviderAuthCheck{
+		BaseCheck: BaseCheck{
+			CheckName:        "provider-auth",
+			CheckDescription: "Check council provider credentials are accepted",
+		},
+	}
+}
+
+// Run probes every enabled provider and reports its auth state.
+func (c *ProviderAuthCheck) Run(ctx *CheckContext) *CheckResult {
+	cfg, err := council.Load(ctx.TownRoot)
+	if err != nil {
+		return &CheckResult{
+			Name:    c.Name(),
+			Status:  StatusWarning,
+			Message: fmt.Sprintf("Could not load council config: %v", err),
+		}
+	}
+
+	var providers []string
+	for name, pc := range cfg.Providers {
+		if pc != nil && pc.Enabled {
+			providers = append(providers, name)
+		}
+	}
+	sort.Strings(providers)
+
+	status := StatusOK
+	var details []string
+	bad, unreachable := 0, 0
+	for _, provider := range providers {
+		probeCtx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+		auth, err := cfg.CheckAuth(probeCtx, provider)
+		cancel()
+		if err != nil {
+			details = append(details, fmt.Sprintf("%s: skipped (%v)", provider, err))
+			continue
+		}
+
+		detail := fmt.Sprintf("%s: %s", provider, auth.State)
+		if auth.Detail != "" {
+			detail += " - " + auth.Detail
+		}
+		details = append(details, detail)
+
+		switch auth.State {
+		case council.AuthBadCredentials:
+			bad++
+			status = StatusError
+		case council.AuthUnreachable:
+			unreachable++
+			if status == StatusOK {
+				status = StatusWarning
+			}
+		}
+	}
+
+	result := &CheckResult{
+		Name:    c.Name(),
+		Status:  status,
+		Details: details,
+	}
+	switch {
+	case bad > 0:
+		result.Message = fmt.Sprintf("%d provider(s) rejected their credentials", bad)
+		result.FixHint = "Update the API key (see api_key_env in the council config)"
+	case unreachable > 0:
+		result.Message = fmt.Sprintf("%d provider(s) unreachable", unreachable)
+	default:
+		result.Message = fmt.Sprintf("%d provider(s) checked", len(providers))
+	}
+	return result
+}
@@ -42,6 +42,19 @@ type CheckContext struct {
 	RigName         string // Rig name (empty for town-level checks)
 	Verbose         bool   // Enable verbose output
 	RestartSessions bool   // Restart patrol sessions when fixing (requires explicit --restart-sessions flag)
+
+	// Confirm, if set, is asked before each fix; returning false skips it.
+	Confirm func(check Check, result *CheckResult) bool
+
+	// FixLog, if set, receives a line for each fix applied or skipped.
+	FixLog io.Writer
+}
+
+// logFix writes a line to FixLog, if set (output errors non-actionable).
+func (ctx *CheckContext) logFix(format string, args ...any) {
+	if ctx.FixLog != nil {
+		_, _ = fmt.Fprintf(ctx.FixLog, format+"\n", args...)
+	}
 }
 
 // RigPath returns the full path to the rig directory.
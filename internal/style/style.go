@@ -3,8 +3,11 @@ package style
 
 import (
 	"fmt"
+	"os"
 
 	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
+	"golang.org/x/term"
 )
 
 var (
@@ -48,6 +51,49 @@ var (
 	ArrowPrefix = Info.Render("→")
 )
 
+// colorEnabled records the last SetColorEnabled setting.
+var colorEnabled = true
+
+func init() {
+	SetColorEnabled(shouldEnableColor())
+}
+
+// shouldEnableColor reports whether stdout is a terminal and NO_COLOR
+// (https://no-color.org) is unset.
+func shouldEnableColor() bool {
+	if _, ok := os.LookupEnv("NO_COLOR"); ok {
+		return false
+	}
+	return term.IsTerminal(int(os.Stdout.Fd()))
+}
+
+// SetColorEnabled turns ANSI styling on or off for every style in this
+// package. It is called at startup based on TTY detection and NO_COLOR;
+// call it again to override (e.g., for a --color flag or in tests).
+func SetColorEnabled(enabled bool) {
+	colorEnabled = enabled
+	if enabled {
+		profile := termenv.EnvColorProfile()
+		if profile == termenv.Ascii {
+			profile = termenv.ANSI256
+		}
+		lipgloss.SetColorProfile(profile)
+	} else {
+		lipgloss.SetColorProfile(termenv.Ascii)
+	}
+
+	// Prefixes are pre-rendered, so refresh them under the new profile.
+	SuccessPrefix = Success.Render("[OK]")
+	WarningPrefix = Warning.Render("[!]")
+	ErrorPrefix = Error.Render("[X]")
+	ArrowPrefix = Info.Render("→")
+}
+
+// ColorEnabled reports whether ANSI styling is currently enabled.
+func ColorEnabled() bool {
+	return colorEnabled
+}
+
 // PrintWarning prints a warning message with consistent formatting.
 // The format and args work like fmt.Printf.
 func PrintWarning(format string, args ...interface{}) {
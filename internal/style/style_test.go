@@ -0,0 +1,27 @@
+package style
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestSetColorEnabled(t *testing.T) {
+	defer SetColorEnabled(ColorEnabled())
+
+	SetColorEnabled(false)
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "%s %s %s %s\n", Bold.Render("title"), Dim.Render("detail"), Success.Render("ok"), SuccessPrefix)
+	if strings.Contains(buf.String(), "\x1b[") {
+		t.Errorf("output contains ANSI escapes with color disabled: %q", buf.String())
+	}
+	if !strings.Contains(buf.String(), "title detail ok [OK]") {
+		t.Errorf("plain text lost: %q", buf.String())
+	}
+
+	SetColorEnabled(true)
+	if !strings.Contains(Success.Render("ok"), "\x1b[") {
+		t.Error("expected ANSI escapes with color enabled")
+	}
+}
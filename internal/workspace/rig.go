@@ -0,0 +1,55 @@
+package workspace
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// DefaultRigEnv names the environment variable that sets the rig used by
+// session commands when none is given explicitly.
+const DefaultRigEnv = "GASTOWN_DEFAULT_RIG"
+
+// townDirs are town-level directories that are never rigs.
+var townDirs = map[string]bool{
+	"mayor":  true,
+	"deacon": true,
+	"daemon": true,
+	"docs":   true,
+}
+
+// InferRigFromPath returns the rig containing cwd, taken as the first path
+// component below townRoot. It returns "" when cwd is outside the town, at
+// the town root, or in a town-level directory such as mayor/.
+func InferRigFromPath(townRoot, cwd string) string {
+	if townRoot == "" || cwd == "" {
+		return ""
+	}
+	rel, err := filepath.Rel(townRoot, cwd)
+	if err != nil {
+		return ""
+	}
+	rel = filepath.ToSlash(rel)
+	if rel == "." || rel == ".." || strings.HasPrefix(rel, "../") {
+		return ""
+	}
+
+	name := strings.SplitN(rel, "/", 2)[0]
+	if townDirs[name] || strings.HasPrefix(name, ".") {
+		return ""
+	}
+	return name
+}
+
+// ResolveRig picks the rig for a command: explicit (typically a --rig flag)
+// wins, then DefaultRigEnv, then the rig inferred from cwd. It returns ""
+// when none applies.
+func ResolveRig(explicit, townRoot, cwd string) string {
+	if explicit != "" {
+		return explicit
+	}
+	if env := strings.TrimSpace(os.Getenv(DefaultRigEnv)); env != "" {
+		return env
+	}
+	return InferRigFromPath(townRoot, cwd)
+}
@@ -0,0 +1,50 @@
+package workspace
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestInferRigFromPath(t *testing.T) {
+	town := filepath.Join(t.TempDir(), "town")
+	tests := []struct {
+		cwd  string
+		want string
+	}{
+		{filepath.Join(town, "greenplace"), "greenplace"},
+		{filepath.Join(town, "greenplace", "polecats", "toast"), "greenplace"},
+		{town, ""},
+		{filepath.Join(town, "mayor"), ""},
+		{filepath.Join(town, ".beads"), ""},
+		{filepath.Dir(town), ""},
+		{filepath.Join(filepath.Dir(town), "elsewhere"), ""},
+	}
+	for _, tt := range tests {
+		if got := InferRigFromPath(town, tt.cwd); got != tt.want {
+			t.Errorf("InferRigFromPath(%q) = %q, want %q", tt.cwd, got, tt.want)
+		}
+	}
+}
+
+func TestResolveRig(t *testing.T) {
+	town := filepath.Join(t.TempDir(), "town")
+	inRig := filepath.Join(town, "greenplace", "crew", "max")
+
+	t.Setenv(DefaultRigEnv, "")
+	if got := ResolveRig("", town, inRig); got != "greenplace" {
+		t.Errorf("path inference: got %q, want greenplace", got)
+	}
+
+	t.Setenv(DefaultRigEnv, "bluefield")
+	if got := ResolveRig("", town, inRig); got != "bluefield" {
+		t.Errorf("env over path: got %q, want bluefield", got)
+	}
+	if got := ResolveRig("redhill", town, inRig); got != "redhill" {
+		t.Errorf("flag over env: got %q, want redhill", got)
+	}
+
+	t.Setenv(DefaultRigEnv, "")
+	if got := ResolveRig("", town, town); got != "" {
+		t.Errorf("at town root with nothing set: got %q, want empty", got)
+	}
+}